@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// frameworkModule is the import path of the framework used by the generated code
+const frameworkModule = "github.com/nchursin/serenity-go"
+
+// abilitySpec describes the ability package to generate
+type abilitySpec struct {
+	// Name is the ability name, e.g. "FileSystem"
+	Name string
+	// Package is the package name, derived from Name if empty
+	Package string
+	// Dir is the directory in which the package directory is created
+	Dir string
+	// Module is the module path of the project, read from go.mod if empty
+	Module string
+}
+
+// names holds the identifiers used by the templates
+type names struct {
+	Package     string
+	ImportPath  string
+	Framework   string
+	Name        string
+	Interface   string
+	Impl        string
+	Constructor string
+	Phrase      string
+	Description string
+	AnswerType  string
+}
+
+// generateAbility creates a package with an ability, an interaction, a question and a test
+func generateAbility(spec abilitySpec) ([]string, error) {
+	name, err := exportedName(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSuffix(name, "Ability")
+	if name == "" {
+		return nil, fmt.Errorf("invalid name %q", spec.Name)
+	}
+
+	pkg, err := packageName(spec.Package, name)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(spec.Dir, pkg)
+	module := spec.Module
+	if module == "" {
+		module, err = importPathOf(dir)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		module = strings.TrimSuffix(module, "/") + "/" + pkg
+	}
+
+	n := names{
+		Package:     pkg,
+		ImportPath:  module,
+		Framework:   frameworkModule,
+		Name:        name,
+		Interface:   name + "Ability",
+		Impl:        lowerFirst(name) + "Ability",
+		Constructor: "New" + name,
+		Phrase:      phrase(name),
+	}
+
+	files := []struct {
+		name     string
+		template *template.Template
+	}{
+		{"ability.go", abilityTemplate},
+		{"interactions.go", interactionsTemplate},
+		{"questions.go", questionsTemplate},
+		{pkg + "_test.go", abilityTestTemplate},
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(dir, file.name)); err == nil {
+			return nil, fmt.Errorf("file %s already exists", filepath.Join(dir, file.name))
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	created := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := render(path, file.template, n); err != nil {
+			return created, err
+		}
+		created = append(created, path)
+	}
+	return created, nil
+}
+
+// generateTask creates a file with a task skeleton
+func generateTask(name, pkg, dir string) ([]string, error) {
+	name, err := exportedName(name)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err = packageName(pkg, filepath.Base(absolute(dir)))
+	if err != nil {
+		return nil, err
+	}
+
+	n := names{
+		Package:     pkg,
+		Framework:   frameworkModule,
+		Name:        name,
+		Phrase:      phrase(name),
+		Description: "#actor " + thirdPerson(phrase(name)),
+	}
+	return renderSingle(filepath.Join(dir, snakeCase(name)+".go"), taskTemplate, n)
+}
+
+// generateQuestion creates a file with a question skeleton
+func generateQuestion(name, answerType, pkg, dir string) ([]string, error) {
+	name, err := exportedName(name)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err = packageName(pkg, filepath.Base(absolute(dir)))
+	if err != nil {
+		return nil, err
+	}
+	if answerType == "" {
+		answerType = "string"
+	}
+
+	n := names{
+		Package:     pkg,
+		Framework:   frameworkModule,
+		Name:        name,
+		Description: "the " + phrase(name),
+		AnswerType:  answerType,
+	}
+	return renderSingle(filepath.Join(dir, snakeCase(name)+".go"), questionTemplate, n)
+}
+
+// generateMatcher creates a domain matcher registered with the library of its package
+func generateMatcher(name, answerType, pkg, dir string) ([]string, error) {
+	name, err := exportedName(name)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err = packageName(pkg, filepath.Base(absolute(dir)))
+	if err != nil {
+		return nil, err
+	}
+	if answerType == "" {
+		answerType = "string"
+	}
+
+	library := pkg
+	if importPath, err := importPathOf(dir); err == nil {
+		library = importPath
+	}
+
+	description := strings.TrimPrefix(phrase(name), "is ")
+	n := names{
+		Package:     pkg,
+		ImportPath:  library,
+		Framework:   frameworkModule,
+		Name:        name,
+		Description: description,
+		AnswerType:  answerType,
+	}
+	return renderSingle(filepath.Join(dir, snakeCase(name)+".go"), matcherTemplate, n)
+}
+
+// renderSingle renders a template into a new file
+func renderSingle(path string, tmpl *template.Template, n names) ([]string, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("file %s already exists", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := render(path, tmpl, n); err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
+// render executes the template, formats the result and writes it to path
+func render(path string, tmpl *template.Template, n names) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, n); err != nil {
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+
+	return writeSource(path, buf.Bytes())
+}
+
+// writeSource formats and writes Go source to path
+func writeSource(path string, source []byte) error {
+	formatted, err := format.Source(source)
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, formatted, 0644); err != nil { // #nosec G306 -- generated source files are meant to be shared
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// importPathOf derives the import path of dir from the nearest go.mod
+func importPathOf(dir string) (string, error) {
+	dir = absolute(dir)
+
+	for current := dir; ; current = filepath.Dir(current) {
+		module, err := moduleOf(filepath.Join(current, "go.mod"))
+		if err == nil {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve import path of %s: %w", dir, err)
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("no go.mod found above %s, use -module to set the module path", dir)
+		}
+	}
+}
+
+// moduleOf reads the module path from a go.mod file
+func moduleOf(path string) (string, error) {
+	file, err := os.Open(path) // #nosec G304 -- reading the project's go.mod
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if module, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return "", fmt.Errorf("no module directive in %s", path)
+}
+
+// absolute returns the absolute form of dir, or dir itself if it cannot be resolved
+func absolute(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
+
+// exportedName validates name and upper-cases its first letter
+func exportedName(name string) (string, error) {
+	if !token.IsIdentifier(name) {
+		return "", fmt.Errorf("invalid name %q: must be a Go identifier", name)
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes), nil
+}
+
+// packageName validates pkg or, if empty, derives a package name from fallback
+func packageName(pkg, fallback string) (string, error) {
+	if pkg == "" {
+		pkg = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, fallback)
+	}
+	if !token.IsIdentifier(pkg) || token.IsKeyword(pkg) || pkg != strings.ToLower(pkg) {
+		return "", fmt.Errorf("invalid package name %q", pkg)
+	}
+	return pkg, nil
+}
+
+// lowerFirst lower-cases the first letter of name
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// words splits a camel-case name into words, keeping acronyms together
+func words(name string) []string {
+	runes := []rune(name)
+	var result []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		upper := unicode.IsUpper(runes[i])
+		boundary := upper && (!unicode.IsUpper(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+		if boundary || (unicode.IsDigit(runes[i]) != unicode.IsDigit(runes[i-1])) {
+			result = append(result, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(result, string(runes[start:]))
+}
+
+// phrase turns a camel-case name into lower-case words, e.g. "PlaceOrder" into "place order"
+func phrase(name string) string {
+	parts := words(name)
+	for i, part := range parts {
+		if strings.ToUpper(part) != part {
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// snakeCase turns a camel-case name into a file name, e.g. "PlaceOrder" into "place_order"
+func snakeCase(name string) string {
+	return strings.ToLower(strings.Join(words(name), "_"))
+}
+
+// thirdPerson conjugates the leading verb of a phrase, e.g. "place order" into "places order"
+func thirdPerson(phrase string) string {
+	verb, rest, _ := strings.Cut(phrase, " ")
+	switch {
+	case verb == "":
+		return phrase
+	case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "sh"), strings.HasSuffix(verb, "ch"),
+		strings.HasSuffix(verb, "x"), strings.HasSuffix(verb, "z"), strings.HasSuffix(verb, "o"):
+		verb += "es"
+	case strings.HasSuffix(verb, "y") && len(verb) > 1 && !strings.ContainsRune("aeiou", rune(verb[len(verb)-2])):
+		verb = verb[:len(verb)-1] + "ies"
+	default:
+		verb += "s"
+	}
+	if rest == "" {
+		return verb
+	}
+	return verb + " " + rest
+}
@@ -0,0 +1,142 @@
+// Command serenity-gen scaffolds Screenplay code that follows the framework conventions.
+//
+// Usage:
+//
+//	serenity-gen ability [-package name] [-dir path] [-module path] Name
+//	serenity-gen task [-package name] [-dir path] Name
+//	serenity-gen question [-package name] [-dir path] [-type T] Name
+//	serenity-gen matcher [-package name] [-dir path] [-type T] Name
+//	serenity-gen openapi [-package name] [-dir path] spec.yaml
+//	serenity-gen proto [-package name] [-dir path] [-go-package path] service.proto
+//
+// The ability command creates a package with the ability interface and its
+// implementation, an interaction, a question and a test. The task and question
+// commands add a single skeleton file to an existing package, and the matcher
+// command a domain expectation registered as part of the package's expectation
+// library. The openapi command generates response structs and, for every operation
+// of an OpenAPI 3 spec, an activity sending the request, a question decoding the
+// response body and an expectation of the documented success status. The proto command generates, for
+// every unary method of the services in a .proto file, an activity invoking it with
+// the gRPC ability, a question about its response and typed builders for the request
+// messages.
+//
+// Examples:
+//
+//	serenity-gen ability -package files ManageFiles
+//	serenity-gen task -package checkout PlaceOrder
+//	serenity-gen question -package checkout -type float64 OrderTotal
+//	serenity-gen matcher -dir matchers IsValidIBAN
+//	serenity-gen openapi -dir petstore petstore.yaml
+//	serenity-gen proto -dir greeter greeter.proto
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "serenity-gen: %v\n", err)
+		os.Exit(2)
+	}
+}
+
+// usage describes the command line
+const usage = `usage:
+  serenity-gen ability [-package name] [-dir path] [-module path] Name
+  serenity-gen task [-package name] [-dir path] Name
+  serenity-gen question [-package name] [-dir path] [-type T] Name
+  serenity-gen matcher [-package name] [-dir path] [-type T] Name
+  serenity-gen openapi [-package name] [-dir path] spec.yaml
+  serenity-gen proto [-package name] [-dir path] [-go-package path] service.proto`
+
+// run executes the command with the given arguments and reports created files to out
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	command, args := args[0], args[1:]
+	flags := flag.NewFlagSet("serenity-gen "+command, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	packageName := flags.String("package", "", "package name (default: derived from the name)")
+	dir := flags.String("dir", ".", "directory to write to")
+
+	var files []string
+	var err error
+
+	switch command {
+	case "ability":
+		module := flags.String("module", "", "module path (default: read from go.mod)")
+		name, parseErr := parse(flags, args)
+		if parseErr != nil {
+			return parseErr
+		}
+		files, err = generateAbility(abilitySpec{
+			Name:    name,
+			Package: *packageName,
+			Dir:     *dir,
+			Module:  *module,
+		})
+	case "task":
+		name, parseErr := parse(flags, args)
+		if parseErr != nil {
+			return parseErr
+		}
+		files, err = generateTask(name, *packageName, *dir)
+	case "question":
+		answerType := flags.String("type", "string", "type of the answer")
+		name, parseErr := parse(flags, args)
+		if parseErr != nil {
+			return parseErr
+		}
+		files, err = generateQuestion(name, *answerType, *packageName, *dir)
+	case "matcher":
+		answerType := flags.String("type", "string", "type of the values the matcher evaluates")
+		name, parseErr := parse(flags, args)
+		if parseErr != nil {
+			return parseErr
+		}
+		files, err = generateMatcher(name, *answerType, *packageName, *dir)
+	case "openapi":
+		spec, parseErr := parse(flags, args)
+		if parseErr != nil {
+			return parseErr
+		}
+		files, err = generateOpenAPI(spec, *packageName, *dir)
+	case "proto":
+		goPackage := flags.String("go-package", "", "import path of the generated messages (default: the go_package option)")
+		proto, parseErr := parse(flags, args)
+		if parseErr != nil {
+			return parseErr
+		}
+		files, err = generateProto(proto, *goPackage, *packageName, *dir)
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		_, _ = fmt.Fprintf(out, "created %s\n", file)
+	}
+	return nil
+}
+
+// parse parses the flags and returns the single positional argument
+func parse(flags *flag.FlagSet, args []string) (string, error) {
+	if err := flags.Parse(args); err != nil {
+		return "", fmt.Errorf("%w\n%s", err, usage)
+	}
+	if flags.NArg() != 1 {
+		return "", fmt.Errorf("expected exactly one argument\n%s", usage)
+	}
+	return flags.Arg(0), nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFile parses a generated file and returns its source
+func parseFile(t *testing.T, path string) string {
+	t.Helper()
+
+	source, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), path, source, parser.AllErrors)
+	require.NoError(t, err, "generated file %s does not parse", path)
+	return string(source)
+}
+
+func TestAbilityScaffoldsPackage(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/shop\n\ngo 1.23\n"), 0644))
+
+	var out bytes.Buffer
+	err := run([]string{"ability", "-dir", dir, "-package", "files", "ManageFiles"}, &out)
+	require.NoError(t, err)
+
+	pkg := filepath.Join(dir, "files")
+	assert.Contains(t, out.String(), "created "+filepath.Join(pkg, "ability.go"))
+
+	ability := parseFile(t, filepath.Join(pkg, "ability.go"))
+	assert.Contains(t, ability, "type ManageFilesAbility interface")
+	assert.Contains(t, ability, "abilities.Ability")
+	assert.Contains(t, ability, "type manageFilesAbility struct")
+	assert.Contains(t, ability, "func NewManageFiles() ManageFilesAbility")
+
+	interactions := parseFile(t, filepath.Join(pkg, "interactions.go"))
+	assert.Contains(t, interactions, "core.AbilityOf[ManageFilesAbility](actor)")
+	assert.Contains(t, interactions, "actor does not have the ability to manage files: %w")
+
+	parseFile(t, filepath.Join(pkg, "questions.go"))
+
+	test := parseFile(t, filepath.Join(pkg, "files_test.go"))
+	assert.Contains(t, test, `"example.com/shop/files"`)
+	assert.Contains(t, test, "files.NewManageFiles()")
+}
+
+func TestAbilityRefusesToOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	args := []string{"ability", "-dir", dir, "-module", "example.com/shop", "Inventory"}
+
+	require.NoError(t, run(args, &bytes.Buffer{}))
+
+	err := run(args, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestTaskAndQuestionSkeletons(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "checkout")
+
+	require.NoError(t, run([]string{"task", "-dir", dir, "PlaceOrder"}, &bytes.Buffer{}))
+	require.NoError(t, run([]string{"question", "-dir", dir, "-type", "float64", "OrderTotal"}, &bytes.Buffer{}))
+
+	task := parseFile(t, filepath.Join(dir, "place_order.go"))
+	assert.Contains(t, task, "package checkout")
+	assert.Contains(t, task, `core.TaskWhere("#actor places order")`)
+
+	question := parseFile(t, filepath.Join(dir, "order_total.go"))
+	assert.Contains(t, question, "func OrderTotal() core.Question[float64]")
+	assert.Contains(t, question, `core.NewQuestion("the order total",`)
+}
+
+func TestRejectsInvalidInput(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no command", nil},
+		{"unknown command", []string{"widget", "Name"}},
+		{"missing name", []string{"task", "-dir", dir}},
+		{"invalid name", []string{"task", "-dir", dir, "place-order"}},
+		{"invalid package", []string{"task", "-dir", dir, "-package", "Checkout", "PlaceOrder"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, run(tt.args, &bytes.Buffer{}))
+		})
+	}
+}
+
+func TestThirdPerson(t *testing.T) {
+	assert.Equal(t, "places order", thirdPerson("place order"))
+	assert.Equal(t, "pushes changes", thirdPerson("push changes"))
+	assert.Equal(t, "verifies API", thirdPerson("verify API"))
+	assert.Equal(t, "pays", thirdPerson("pay"))
+	assert.Equal(t, "verify API", phrase("VerifyAPI"))
+}
+
+func TestMatcherSkeleton(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/bank\n\ngo 1.23\n"), 0644))
+
+	matchers := filepath.Join(dir, "matchers")
+	require.NoError(t, run([]string{"matcher", "-dir", matchers, "IsValidIBAN"}, &bytes.Buffer{}))
+
+	matcher := parseFile(t, filepath.Join(matchers, "is_valid_iban.go"))
+	assert.Contains(t, matcher, "package matchers")
+	assert.Contains(t, matcher, `Library:     "example.com/bank/matchers"`)
+	assert.Contains(t, matcher, "func IsValidIBAN() ensure.Expectation[string]")
+	assert.Contains(t, matcher, `expectations.NewMatcher("valid IBAN",`)
+}
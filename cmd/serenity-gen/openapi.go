@@ -0,0 +1,705 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// generatedHeader marks files written by the openapi command. Such files are
+// regenerated in place; other files are never overwritten.
+const generatedHeader = "// Code generated by serenity-gen"
+
+// openAPISpec is the subset of an OpenAPI 3 document used by the generator
+type openAPISpec struct {
+	Paths      map[string]pathItem `yaml:"paths"`
+	Components struct {
+		Schemas       map[string]*schema     `yaml:"schemas"`
+		Parameters    map[string]parameter   `yaml:"parameters"`
+		RequestBodies map[string]requestBody `yaml:"requestBodies"`
+		Responses     map[string]response    `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+// pathItem holds the operations available on a path
+type pathItem struct {
+	Parameters []parameter `yaml:"parameters"`
+	Get        *operation  `yaml:"get"`
+	Put        *operation  `yaml:"put"`
+	Post       *operation  `yaml:"post"`
+	Delete     *operation  `yaml:"delete"`
+	Options    *operation  `yaml:"options"`
+	Head       *operation  `yaml:"head"`
+	Patch      *operation  `yaml:"patch"`
+}
+
+// operations returns the operations of the path item by HTTP method, in a stable order
+func (p pathItem) operations() []methodOperation {
+	var result []methodOperation
+	for _, candidate := range []methodOperation{
+		{"GET", p.Get}, {"POST", p.Post}, {"PUT", p.Put}, {"PATCH", p.Patch},
+		{"DELETE", p.Delete}, {"HEAD", p.Head}, {"OPTIONS", p.Options},
+	} {
+		if candidate.operation != nil {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// methodOperation pairs an operation with its HTTP method
+type methodOperation struct {
+	method    string
+	operation *operation
+}
+
+// operation is a single API operation
+type operation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Parameters  []parameter         `yaml:"parameters"`
+	RequestBody *requestBody        `yaml:"requestBody"`
+	Responses   map[string]response `yaml:"responses"`
+}
+
+// parameter is a path, query, header or cookie parameter
+type parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *schema `yaml:"schema"`
+}
+
+// requestBody describes the body of a request
+type requestBody struct {
+	Ref     string               `yaml:"$ref"`
+	Content map[string]mediaType `yaml:"content"`
+}
+
+// response describes a response of an operation
+type response struct {
+	Ref     string               `yaml:"$ref"`
+	Content map[string]mediaType `yaml:"content"`
+}
+
+// mediaType holds the schema of a request or response content type
+type mediaType struct {
+	Schema *schema `yaml:"schema"`
+}
+
+// schema is the subset of a JSON schema needed to derive Go types
+type schema struct {
+	Ref        string             `yaml:"$ref"`
+	Type       schemaType         `yaml:"type"`
+	Format     string             `yaml:"format"`
+	Items      *schema            `yaml:"items"`
+	Properties map[string]*schema `yaml:"properties"`
+	Required   []string           `yaml:"required"`
+}
+
+// schemaType is the type of a schema. OpenAPI 3.1 allows a list of types, of which
+// the first one other than "null" is used.
+type schemaType string
+
+// UnmarshalYAML accepts a single type or a list of types
+func (t *schemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var types []string
+		if err := node.Decode(&types); err != nil {
+			return err
+		}
+		for _, candidate := range types {
+			if candidate != "null" {
+				*t = schemaType(candidate)
+				return nil
+			}
+		}
+		return nil
+	}
+
+	var single string
+	if err := node.Decode(&single); err != nil {
+		return err
+	}
+	*t = schemaType(single)
+	return nil
+}
+
+// openAPIGenerator turns an OpenAPI spec into models and screenplay activities
+type openAPIGenerator struct {
+	spec     *openAPISpec
+	declared map[string]bool
+	inline   []namedSchema
+	imports  map[string]bool
+}
+
+// namedSchema is a schema that is emitted as a named Go type
+type namedSchema struct {
+	name   string
+	origin string
+	schema *schema
+}
+
+// generateOpenAPI generates models.go and operations.go in dir from the spec file
+func generateOpenAPI(specPath, pkg, dir string) ([]string, error) {
+	data, err := os.ReadFile(specPath) // #nosec G304 -- the spec path is given on the command line
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec %s: %w", specPath, err)
+	}
+
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec %s: %w", specPath, err)
+	}
+	if len(spec.Paths) == 0 {
+		return nil, fmt.Errorf("spec %s defines no paths", specPath)
+	}
+
+	pkg, err = packageName(pkg, filepath.Base(absolute(dir)))
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("%s from %s. DO NOT EDIT.\n\n", generatedHeader, filepath.Base(specPath))
+	g := &openAPIGenerator{spec: &spec, declared: map[string]bool{}}
+
+	operations, err := g.operations(pkg)
+	if err != nil {
+		return nil, err
+	}
+	models, err := g.models(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name   string
+		source []byte
+	}{
+		{"models.go", models},
+		{"operations.go", operations},
+	}
+
+	for _, file := range files {
+		if err := checkRegenerable(filepath.Join(dir, file.name)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	created := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := writeSource(path, append([]byte(header), file.source...)); err != nil {
+			return created, err
+		}
+		created = append(created, path)
+	}
+	return created, nil
+}
+
+// checkRegenerable fails if path exists and was not generated by serenity-gen
+func checkRegenerable(path string) error {
+	existing, err := os.ReadFile(path) // #nosec G304 -- path is inside the output directory
+	if err != nil {
+		return nil
+	}
+	if !bytes.HasPrefix(existing, []byte(generatedHeader)) {
+		return fmt.Errorf("file %s already exists and was not generated by serenity-gen", path)
+	}
+	return nil
+}
+
+// declare reserves a top-level identifier and fails on collisions
+func (g *openAPIGenerator) declare(name string) error {
+	if g.declared[name] {
+		return fmt.Errorf("identifier %s is generated twice, set distinct operationIds or schema names", name)
+	}
+	g.declared[name] = true
+	return nil
+}
+
+// operations renders one activity per operation, plus response questions and status expectations
+func (g *openAPIGenerator) operations(pkg string) ([]byte, error) {
+	g.imports = map[string]bool{}
+	var body bytes.Buffer
+
+	paths := make([]string, 0, len(g.spec.Paths))
+	for path := range g.spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := g.spec.Paths[path]
+		for _, op := range item.operations() {
+			if err := g.operation(&body, path, item, op); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g.file(pkg, body.Bytes()), nil
+}
+
+// operation renders the activity, question and status expectation of one operation
+func (g *openAPIGenerator) operation(w *bytes.Buffer, path string, item pathItem, op methodOperation) error {
+	name := operationName(op.method, path, op.operation.OperationID)
+	if err := g.declare(name); err != nil {
+		return err
+	}
+
+	var params []goParam
+	taken := map[string]bool{}
+	for _, p := range append(append([]parameter{}, item.Parameters...), op.operation.Parameters...) {
+		p = g.resolveParameter(p)
+		if p.In != "path" && (p.In != "query" || !p.Required) {
+			continue
+		}
+		ident := paramName(p.Name, taken)
+		params = append(params, goParam{spec: p, ident: ident, goType: g.goType(p.Schema, name+goName(p.Name))})
+	}
+
+	bodyType := ""
+	if op.operation.RequestBody != nil {
+		if s := jsonSchema(g.resolveRequestBody(*op.operation.RequestBody).Content); s != nil {
+			bodyType = g.goType(s, name+"RequestBody")
+		}
+	}
+
+	var signature []string
+	for _, p := range params {
+		signature = append(signature, p.ident+" "+p.goType)
+	}
+	bodyParam := ""
+	if bodyType != "" {
+		bodyParam = paramName("body", taken)
+		signature = append(signature, bodyParam+" "+bodyType)
+	}
+
+	fmt.Fprintf(w, "// %s sends %s %s\n", name, op.method, path)
+	if summary := strings.TrimSpace(op.operation.Summary); summary != "" {
+		fmt.Fprintf(w, "//\n// %s\n", strings.ReplaceAll(summary, "\n", "\n// "))
+	}
+
+	helper := map[string]string{"GET": "SendGetRequest", "POST": "SendPostRequest", "PUT": "SendPutRequest", "DELETE": "SendDeleteRequest"}[op.method]
+	g.imports["github.com/nchursin/serenity-go/serenity/abilities/api"] = true
+
+	if helper != "" {
+		fmt.Fprintf(w, "func %s(%s) *api.RequestActivity {\n", name, strings.Join(signature, ", "))
+		g.writeURL(w, path, params)
+		fmt.Fprintf(w, "\treturn api.%s(target)", helper)
+		if bodyType != "" {
+			fmt.Fprintf(w, ".WithBody(%s)", bodyParam)
+		}
+		fmt.Fprintf(w, "\n}\n\n")
+	} else {
+		g.imports["context"] = true
+		g.imports["fmt"] = true
+		g.imports["github.com/nchursin/serenity-go/serenity/core"] = true
+
+		fmt.Fprintf(w, "func %s(%s) core.Activity {\n", name, strings.Join(signature, ", "))
+		g.writeURL(w, path, params)
+		fmt.Fprintf(w, "\treturn core.Do(fmt.Sprintf(\"#actor sends %s request to %%s\", target), func(actor core.Actor, ctx context.Context) error {\n", op.method)
+		fmt.Fprintf(w, "\t\treq, err := api.NewRequestBuilder(%q, target)", op.method)
+		if bodyType != "" {
+			fmt.Fprintf(w, ".With(%s)", bodyParam)
+		}
+		fmt.Fprintf(w, ".BuildWithContext(ctx)\n")
+		fmt.Fprintf(w, "\t\tif err != nil {\n\t\t\treturn fmt.Errorf(\"failed to build request: %%w\", err)\n\t\t}\n")
+		fmt.Fprintf(w, "\t\treturn api.SendRequest(req).PerformAs(actor, ctx)\n\t})\n}\n\n")
+	}
+
+	status, content := g.successResponse(op.operation)
+	if s := jsonSchema(content); s != nil {
+		answerType := g.goType(s, name+"ResponseBody")
+		question := name + "Response"
+		if err := g.declare(question); err != nil {
+			return err
+		}
+		g.imports["github.com/nchursin/serenity-go/serenity/core"] = true
+
+		fmt.Fprintf(w, "// %s is a question about the body of the last response, decoded as a %s response\n", question, name)
+		fmt.Fprintf(w, "func %s() core.Question[%s] {\n", question, answerType)
+		fmt.Fprintf(w, "\treturn core.Of(%q, api.NewResponseBodyAsJSON[%s]().AnsweredBy)\n}\n\n", "the "+name+" response body", answerType)
+	}
+
+	if status != 0 {
+		expectation := name + "Succeeded"
+		if err := g.declare(expectation); err != nil {
+			return err
+		}
+		g.imports["github.com/nchursin/serenity-go/serenity/core"] = true
+		g.imports["github.com/nchursin/serenity-go/serenity/expectations"] = true
+		g.imports["github.com/nchursin/serenity-go/serenity/expectations/ensure"] = true
+
+		fmt.Fprintf(w, "// %s ensures that the last response has the status documented for a successful %s\n", expectation, name)
+		fmt.Fprintf(w, "func %s() core.Activity {\n", expectation)
+		fmt.Fprintf(w, "\treturn ensure.That(api.LastResponseStatus{}, expectations.Equals(%d))\n}\n\n", status)
+	}
+
+	return nil
+}
+
+// operationName derives the activity name from the operationId, or from the method and path
+// if the operation has none, e.g. "GetPetsPetID" for GET /pets/{petId}
+func operationName(method, path, operationID string) string {
+	if operationID != "" {
+		return goName(operationID)
+	}
+	return goName(strings.ToLower(method) + " " + path)
+}
+
+// goParam is a parameter of a generated activity constructor
+type goParam struct {
+	spec   parameter
+	ident  string
+	goType string
+}
+
+// writeURL renders the statements that build the target URL into a variable named target
+func (g *openAPIGenerator) writeURL(w *bytes.Buffer, path string, params []goParam) {
+	format := path
+	var args []string
+	var query []goParam
+
+	for _, p := range params {
+		switch p.spec.In {
+		case "path":
+			placeholder := "{" + p.spec.Name + "}"
+			if strings.Contains(format, placeholder) {
+				g.imports["fmt"] = true
+				g.imports["net/url"] = true
+				format = strings.Replace(format, placeholder, "%s", 1)
+				args = append(args, fmt.Sprintf("url.PathEscape(fmt.Sprint(%s))", p.ident))
+			}
+		case "query":
+			query = append(query, p)
+		}
+	}
+
+	format = strings.ReplaceAll(format, "%", "%%")
+	format = strings.ReplaceAll(format, "%%s", "%s")
+
+	if len(args) > 0 {
+		fmt.Fprintf(w, "\ttarget := fmt.Sprintf(%q, %s)\n", format, strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(w, "\ttarget := %q\n", path)
+	}
+
+	if len(query) == 0 {
+		return
+	}
+
+	g.imports["fmt"] = true
+	g.imports["net/url"] = true
+	fmt.Fprintf(w, "\n\tquery := url.Values{}\n")
+	for _, p := range query {
+		if strings.HasPrefix(p.goType, "[]") {
+			fmt.Fprintf(w, "\tfor _, value := range %s {\n\t\tquery.Add(%q, fmt.Sprint(value))\n\t}\n", p.ident, p.spec.Name)
+		} else {
+			fmt.Fprintf(w, "\tquery.Set(%q, fmt.Sprint(%s))\n", p.spec.Name, p.ident)
+		}
+	}
+	fmt.Fprintf(w, "\ttarget += \"?\" + query.Encode()\n\n")
+}
+
+// successResponse returns the lowest documented 2xx status and its content
+func (g *openAPIGenerator) successResponse(op *operation) (int, map[string]mediaType) {
+	best := 0
+	var content map[string]mediaType
+
+	for code, resp := range op.Responses {
+		status := 0
+		switch {
+		case strings.EqualFold(code, "2XX"):
+			status = 200
+		default:
+			parsed, err := strconv.Atoi(code)
+			if err != nil || parsed < 200 || parsed > 299 {
+				continue
+			}
+			status = parsed
+		}
+		if best == 0 || status < best {
+			best = status
+			content = g.resolveResponse(resp).Content
+		}
+	}
+	return best, content
+}
+
+// jsonSchema returns the schema of the JSON content type, if any
+func jsonSchema(content map[string]mediaType) *schema {
+	if media, ok := content["application/json"]; ok && media.Schema != nil {
+		return media.Schema
+	}
+
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	for _, contentType := range types {
+		if strings.HasSuffix(contentType, "+json") && content[contentType].Schema != nil {
+			return content[contentType].Schema
+		}
+	}
+	return nil
+}
+
+// models renders the component schemas and the inline objects discovered while rendering operations
+func (g *openAPIGenerator) models(pkg string) ([]byte, error) {
+	g.imports = map[string]bool{}
+	var body bytes.Buffer
+
+	names := make([]string, 0, len(g.spec.Components.Schemas))
+	for name := range g.spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pending := make([]namedSchema, 0, len(names)+len(g.inline))
+	for _, name := range names {
+		pending = append(pending, namedSchema{
+			name:   goName(name),
+			origin: fmt.Sprintf("the %s schema", name),
+			schema: g.spec.Components.Schemas[name],
+		})
+	}
+	pending = append(pending, g.inline...)
+	g.inline = nil
+
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+
+		if err := g.declare(next.name); err != nil {
+			return nil, err
+		}
+		g.model(&body, next)
+
+		pending = append(pending, g.inline...)
+		g.inline = nil
+	}
+
+	return g.file(pkg, body.Bytes()), nil
+}
+
+// model renders a named type for a schema
+func (g *openAPIGenerator) model(w *bytes.Buffer, named namedSchema) {
+	s := named.schema
+	if s == nil || s.Ref != "" || len(s.Properties) == 0 {
+		fmt.Fprintf(w, "// %s is generated from %s\n", named.name, named.origin)
+		fmt.Fprintf(w, "type %s %s\n\n", named.name, g.goType(s, named.name+"Value"))
+		return
+	}
+
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	properties := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		properties = append(properties, name)
+	}
+	sort.Strings(properties)
+
+	fmt.Fprintf(w, "// %s is generated from %s\n", named.name, named.origin)
+	fmt.Fprintf(w, "type %s struct {\n", named.name)
+	for _, property := range properties {
+		field := goName(property)
+		fieldType := g.goType(s.Properties[property], named.name+field)
+		tag := property
+		if !required[property] {
+			tag += ",omitempty"
+			// omitempty has no effect on structs, so optional named types are pointers
+			if isNamedType(fieldType) {
+				fieldType = "*" + fieldType
+			}
+		}
+		fmt.Fprintf(w, "\t%s %s `json:%q`\n", field, fieldType, tag)
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// goType maps a schema to a Go type. Inline objects become named types called hint.
+func (g *openAPIGenerator) goType(s *schema, hint string) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		if name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/"); ok {
+			return goName(name)
+		}
+		return "any"
+	}
+
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(s.Items, hint+"Item")
+	case "object", "":
+		if len(s.Properties) == 0 {
+			if s.Type == "object" {
+				return "map[string]any"
+			}
+			return "any"
+		}
+		g.inline = append(g.inline, namedSchema{name: hint, origin: "an inline schema", schema: s})
+		return hint
+	default:
+		return "any"
+	}
+}
+
+// isNamedType reports whether a Go type produced by goType is a generated named type
+func isNamedType(goType string) bool {
+	switch goType {
+	case "string", "int32", "int64", "float64", "bool", "any":
+		return false
+	}
+	return !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[")
+}
+
+// file wraps the declarations in a package clause and the imports they use
+func (g *openAPIGenerator) file(pkg string, declarations []byte) []byte {
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "package %s\n\n", pkg)
+
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+
+	if len(imports) > 0 {
+		fmt.Fprintf(&w, "import (\n")
+		for _, path := range imports {
+			if !strings.Contains(path, ".") {
+				fmt.Fprintf(&w, "\t%q\n", path)
+			}
+		}
+		fmt.Fprintf(&w, "\n")
+		for _, path := range imports {
+			if strings.Contains(path, ".") {
+				fmt.Fprintf(&w, "\t%q\n", path)
+			}
+		}
+		fmt.Fprintf(&w, ")\n\n")
+	}
+
+	w.Write(declarations)
+	return w.Bytes()
+}
+
+// resolveParameter follows a reference to a component parameter
+func (g *openAPIGenerator) resolveParameter(p parameter) parameter {
+	if name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/"); ok {
+		return g.spec.Components.Parameters[name]
+	}
+	return p
+}
+
+// resolveRequestBody follows a reference to a component request body
+func (g *openAPIGenerator) resolveRequestBody(body requestBody) requestBody {
+	if name, ok := strings.CutPrefix(body.Ref, "#/components/requestBodies/"); ok {
+		return g.spec.Components.RequestBodies[name]
+	}
+	return body
+}
+
+// resolveResponse follows a reference to a component response
+func (g *openAPIGenerator) resolveResponse(resp response) response {
+	if name, ok := strings.CutPrefix(resp.Ref, "#/components/responses/"); ok {
+		return g.spec.Components.Responses[name]
+	}
+	return resp
+}
+
+// initialisms are written in upper case in generated identifiers
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// identifierWords splits a spec name such as "pet_id", "petId" or "x-request-id" into words
+func identifierWords(name string) []string {
+	var result []string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		result = append(result, words(part)...)
+	}
+	return result
+}
+
+// goName turns a spec name into an exported Go identifier, e.g. "pet_id" into "PetID"
+func goName(name string) string {
+	result := camelCase(identifierWords(name))
+	if result == "" {
+		return "Value"
+	}
+	if result[0] >= '0' && result[0] <= '9' {
+		return "N" + result
+	}
+	return result
+}
+
+// camelCase joins words into an upper camel-case identifier, keeping initialisms in upper case
+func camelCase(parts []string) string {
+	var b strings.Builder
+	for _, word := range parts {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// reservedParams are identifiers that generated parameters must not shadow
+var reservedParams = map[string]bool{
+	"actor": true, "api": true, "context": true, "core": true, "ctx": true, "err": true,
+	"fmt": true, "query": true, "req": true, "target": true, "url": true, "value": true,
+}
+
+// paramName turns a spec name into an unexported Go identifier that is not yet taken,
+// e.g. "pet_id" into "petID", and records it in taken
+func paramName(name string, taken map[string]bool) string {
+	ident := "param"
+	if parts := identifierWords(name); len(parts) > 0 {
+		ident = strings.ToLower(parts[0]) + camelCase(parts[1:])
+	}
+	if ident[0] >= '0' && ident[0] <= '9' || token.IsKeyword(ident) || reservedParams[ident] {
+		ident += "Param"
+	}
+	for base, i := ident, 2; taken[ident]; i++ {
+		ident = fmt.Sprintf("%s%d", base, i)
+	}
+	taken[ident] = true
+	return ident
+}
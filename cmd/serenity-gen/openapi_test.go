@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIGeneratesModelsAndOperations(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "petstore")
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"openapi", "-dir", dir, "testdata/petstore.yaml"}, &out))
+	assert.Contains(t, out.String(), "created "+filepath.Join(dir, "operations.go"))
+
+	models := parseFile(t, filepath.Join(dir, "models.go"))
+	assert.Contains(t, models, "// Code generated by serenity-gen from petstore.yaml. DO NOT EDIT.")
+	assert.Contains(t, models, "package petstore")
+	assert.Contains(t, models, "ID    int64     `json:\"id\"`")
+	assert.Contains(t, models, "Owner *PetOwner `json:\"owner,omitempty\"`")
+	assert.Contains(t, models, "type PatchPetsPetIDRequestBody struct")
+	assert.Contains(t, models, "RenamedAt string `json:\"renamed_at,omitempty\"`")
+
+	operations := parseFile(t, filepath.Join(dir, "operations.go"))
+	assert.Contains(t, operations, "func ListPets(limit int32, tags []string) *api.RequestActivity")
+	assert.Contains(t, operations, "func ListPetsResponse() core.Question[[]Pet]")
+	assert.Contains(t, operations, "func CreatePet(body NewPet) *api.RequestActivity")
+	assert.Contains(t, operations, "expectations.Equals(201)")
+	assert.Contains(t, operations, "func GetPetByID(petID int64) *api.RequestActivity")
+	assert.Contains(t, operations, "func PatchPetsPetID(petID int64, body PatchPetsPetIDRequestBody) core.Activity")
+	assert.Contains(t, operations, "func DeletePetSucceeded() core.Activity")
+	assert.NotContains(t, operations, "DeletePetResponse")
+	assert.NotContains(t, operations, "offset")
+}
+
+func TestOpenAPIRegeneratesOnlyGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	args := []string{"openapi", "-dir", dir, "-package", "petstore", "testdata/petstore.yaml"}
+
+	require.NoError(t, run(args, &bytes.Buffer{}))
+	require.NoError(t, run(args, &bytes.Buffer{}), "generated files are regenerated in place")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte("package petstore\n"), 0644))
+	err := run(args, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was not generated by serenity-gen")
+}
+
+func TestGoNames(t *testing.T) {
+	assert.Equal(t, "PetID", goName("pet_id"))
+	assert.Equal(t, "XRequestID", goName("x-request-id"))
+	assert.Equal(t, "GetPetByID", goName("getPetById"))
+	assert.Equal(t, "N2FaCode", goName("2fa_code"))
+
+	taken := map[string]bool{}
+	assert.Equal(t, "petID", paramName("petId", taken))
+	assert.Equal(t, "petID2", paramName("pet_id", taken))
+	assert.Equal(t, "typeParam", paramName("type", taken))
+	assert.Equal(t, "urlParam", paramName("url", taken))
+}
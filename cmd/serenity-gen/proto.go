@@ -0,0 +1,442 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// protoFile is the subset of a .proto file used by the generator
+type protoFile struct {
+	syntax    string
+	pkg       string
+	goPackage string
+	messages  map[string]*protoMessage
+	enums     map[string]string
+	services  []protoService
+}
+
+// protoMessage is a message definition. Name is fully qualified, e.g. "shop.Order.Item".
+type protoMessage struct {
+	name   string
+	goName string
+	fields []protoField
+}
+
+// protoField is a field of a message
+type protoField struct {
+	name     string
+	typ      string
+	scope    string
+	repeated bool
+	optional bool
+	mapKey   string
+	oneof    bool
+}
+
+// protoService is a service definition
+type protoService struct {
+	name    string
+	methods []protoMethod
+}
+
+// protoMethod is an rpc of a service
+type protoMethod struct {
+	name      string
+	input     string
+	output    string
+	scope     string
+	streaming bool
+}
+
+// wellKnownTypes maps the well-known protobuf types to their Go packages
+var wellKnownTypes = map[string]string{
+	"google.protobuf.Any":         "google.golang.org/protobuf/types/known/anypb",
+	"google.protobuf.Duration":    "google.golang.org/protobuf/types/known/durationpb",
+	"google.protobuf.Empty":       "google.golang.org/protobuf/types/known/emptypb",
+	"google.protobuf.FieldMask":   "google.golang.org/protobuf/types/known/fieldmaskpb",
+	"google.protobuf.Struct":      "google.golang.org/protobuf/types/known/structpb",
+	"google.protobuf.Timestamp":   "google.golang.org/protobuf/types/known/timestamppb",
+	"google.protobuf.BoolValue":   "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.BytesValue":  "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.DoubleValue": "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.FloatValue":  "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.Int32Value":  "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.Int64Value":  "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.StringValue": "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.UInt32Value": "google.golang.org/protobuf/types/known/wrapperspb",
+	"google.protobuf.UInt64Value": "google.golang.org/protobuf/types/known/wrapperspb",
+}
+
+// protoScalars maps the protobuf scalar types to Go types
+var protoScalars = map[string]string{
+	"double": "float64", "float": "float32",
+	"int32": "int32", "sint32": "int32", "sfixed32": "int32",
+	"int64": "int64", "sint64": "int64", "sfixed64": "int64",
+	"uint32": "uint32", "fixed32": "uint32",
+	"uint64": "uint64", "fixed64": "uint64",
+	"bool": "bool", "string": "string", "bytes": "[]byte",
+}
+
+// generateProto generates interactions, questions and request builders for the unary
+// methods of the services in a .proto file
+func generateProto(protoPath, goPackage, pkg, dir string) ([]string, error) {
+	data, err := os.ReadFile(protoPath) // #nosec G304 -- the proto path is given on the command line
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", protoPath, err)
+	}
+
+	file, err := parseProto(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", protoPath, err)
+	}
+	if len(file.services) == 0 {
+		return nil, fmt.Errorf("%s defines no services", protoPath)
+	}
+
+	if goPackage == "" {
+		goPackage = file.goPackage
+	}
+	if goPackage == "" {
+		return nil, fmt.Errorf("%s has no go_package option, use -go-package to set the import path of the generated messages", protoPath)
+	}
+
+	pkg, err = packageName(pkg, filepath.Base(absolute(dir)))
+	if err != nil {
+		return nil, err
+	}
+
+	g := &protoGenerator{file: file, imports: map[string]string{}, declared: map[string]bool{}}
+	g.messagesPackage = g.importAs(goPackage)
+
+	source, err := g.render(pkg, filepath.Base(protoPath))
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, strings.TrimSuffix(filepath.Base(protoPath), ".proto")+"_screenplay.go")
+	if err := checkRegenerable(path); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	if err := writeSource(path, source); err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
+// protoGenerator renders the screenplay code for a parsed .proto file
+type protoGenerator struct {
+	file            *protoFile
+	messagesPackage string
+	imports         map[string]string
+	declared        map[string]bool
+}
+
+// importAs registers an import and returns the name it is referred to by.
+// A go_package of the form "path;name" sets the name explicitly.
+func (g *protoGenerator) importAs(goPackage string) string {
+	path, name, explicit := strings.Cut(goPackage, ";")
+	if !explicit {
+		name = path[strings.LastIndex(path, "/")+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+
+	if existing, ok := g.imports[path]; ok {
+		return existing
+	}
+	g.imports[path] = name
+	return name
+}
+
+// declare reserves a top-level identifier and fails on collisions
+func (g *protoGenerator) declare(name string) error {
+	if g.declared[name] {
+		return fmt.Errorf("identifier %s is generated twice", name)
+	}
+	g.declared[name] = true
+	return nil
+}
+
+// render generates the source of the screenplay file
+func (g *protoGenerator) render(pkg, protoName string) ([]byte, error) {
+	var body bytes.Buffer
+	var requests []string
+	seen := map[string]bool{}
+
+	for _, service := range g.file.services {
+		for _, method := range service.methods {
+			if method.streaming {
+				continue
+			}
+
+			input, ok := g.messageType(method.input, method.scope)
+			if !ok {
+				return nil, fmt.Errorf("unknown input type %s of %s.%s", method.input, service.name, method.name)
+			}
+			output, ok := g.messageType(method.output, method.scope)
+			if !ok {
+				return nil, fmt.Errorf("unknown output type %s of %s.%s", method.output, service.name, method.name)
+			}
+
+			name := protoGoName(method.name)
+			if len(g.file.services) > 1 {
+				name = protoGoName(service.name) + name
+			}
+			if err := g.methodCode(&body, service, method, name, input, output); err != nil {
+				return nil, err
+			}
+
+			if resolved := g.resolve(method.input, method.scope); g.file.messages[resolved] != nil && !seen[resolved] {
+				seen[resolved] = true
+				requests = append(requests, resolved)
+			}
+		}
+	}
+
+	if body.Len() == 0 {
+		return nil, fmt.Errorf("%s defines no unary methods", protoName)
+	}
+
+	sort.Strings(requests)
+	for _, request := range requests {
+		if err := g.builderCode(&body, g.file.messages[request]); err != nil {
+			return nil, err
+		}
+	}
+
+	g.imports["github.com/nchursin/serenity-go/serenity/abilities/grpc"] = "grpc"
+	g.imports["github.com/nchursin/serenity-go/serenity/core"] = "core"
+	g.imports["github.com/nchursin/serenity-go/serenity/expectations"] = "expectations"
+	g.imports["github.com/nchursin/serenity-go/serenity/expectations/ensure"] = "ensure"
+	g.imports["google.golang.org/grpc/codes"] = "codes"
+
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "%s from %s. DO NOT EDIT.\n\n", generatedHeader, protoName)
+	fmt.Fprintf(&w, "package %s\n\nimport (\n", pkg)
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// framework imports go into a group of their own, after the others
+	for _, framework := range []bool{false, true} {
+		if framework {
+			fmt.Fprintf(&w, "\n")
+		}
+		for _, path := range paths {
+			if strings.HasPrefix(path, frameworkModule+"/") != framework {
+				continue
+			}
+			name := g.imports[path]
+			if name == path[strings.LastIndex(path, "/")+1:] {
+				fmt.Fprintf(&w, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&w, "\t%s %q\n", name, path)
+			}
+		}
+	}
+	fmt.Fprintf(&w, ")\n\n")
+
+	w.Write(body.Bytes())
+	return w.Bytes(), nil
+}
+
+// methodCode renders the interaction, response question and status expectation of a method
+func (g *protoGenerator) methodCode(w *bytes.Buffer, service protoService, method protoMethod, name, input, output string) error {
+	for _, identifier := range []string{name, name + "Response", name + "Succeeded"} {
+		if err := g.declare(identifier); err != nil {
+			return err
+		}
+	}
+
+	fullMethod := fmt.Sprintf("/%s/%s", qualify(g.file.pkg, service.name), method.name)
+
+	fmt.Fprintf(w, "// %s invokes %s\n", name, strings.TrimPrefix(fullMethod, "/"))
+	fmt.Fprintf(w, "func %s(request %s) core.Activity {\n", name, input)
+	fmt.Fprintf(w, "\treturn grpc.Invoke[%s](%q, request)\n}\n\n", output, fullMethod)
+
+	fmt.Fprintf(w, "// %sResponse is a question about the response of the last %s call\n", name, name)
+	fmt.Fprintf(w, "func %sResponse() core.Question[%s] {\n", name, output)
+	fmt.Fprintf(w, "\treturn grpc.NewResponseMessage[%s]()\n}\n\n", output)
+
+	fmt.Fprintf(w, "// %sSucceeded ensures that the last call completed with status OK\n", name)
+	fmt.Fprintf(w, "func %sSucceeded() core.Activity {\n", name)
+	fmt.Fprintf(w, "\treturn ensure.That(grpc.LastStatusCode{}, expectations.Equals(codes.OK))\n}\n\n")
+	return nil
+}
+
+// builderCode renders a fluent builder for a request message
+func (g *protoGenerator) builderCode(w *bytes.Buffer, message *protoMessage) error {
+	builder := message.goName + "Builder"
+	constructor := "New" + message.goName
+	if err := g.declare(builder); err != nil {
+		return err
+	}
+	if err := g.declare(constructor); err != nil {
+		return err
+	}
+
+	messageType := g.messagesPackage + "." + message.goName
+
+	fmt.Fprintf(w, "// %s builds %s messages\n", builder, message.name)
+	fmt.Fprintf(w, "type %s struct {\n\tmessage *%s\n}\n\n", builder, messageType)
+
+	fmt.Fprintf(w, "// %s starts building a %s message\n", constructor, message.name)
+	fmt.Fprintf(w, "func %s() *%s {\n\treturn &%s{message: &%s{}}\n}\n\n", constructor, builder, builder, messageType)
+
+	for _, field := range message.fields {
+		fieldType, pointer, ok := g.fieldType(field)
+		if !ok || field.oneof {
+			continue
+		}
+
+		goField := protoGoName(field.name)
+		assignment := "value"
+		if pointer {
+			assignment = "&value"
+		}
+
+		fmt.Fprintf(w, "// With%s sets the %s field\n", goField, field.name)
+		fmt.Fprintf(w, "func (b *%s) With%s(value %s) *%s {\n", builder, goField, fieldType, builder)
+		fmt.Fprintf(w, "\tb.message.%s = %s\n\treturn b\n}\n\n", goField, assignment)
+	}
+
+	fmt.Fprintf(w, "// Build returns the message\n")
+	fmt.Fprintf(w, "func (b *%s) Build() *%s {\n\treturn b.message\n}\n\n", builder, messageType)
+	return nil
+}
+
+// fieldType returns the Go type of a field's setter argument and whether the struct field is a
+// pointer to it. Fields of types defined in other files are not supported.
+func (g *protoGenerator) fieldType(field protoField) (string, bool, bool) {
+	if field.mapKey != "" {
+		key, ok := protoScalars[field.mapKey]
+		if !ok {
+			return "", false, false
+		}
+		value, ok := g.valueType(field.typ, field.scope)
+		if !ok {
+			return "", false, false
+		}
+		return fmt.Sprintf("map[%s]%s", key, value), false, true
+	}
+
+	value, ok := g.valueType(field.typ, field.scope)
+	if !ok {
+		return "", false, false
+	}
+	if field.repeated {
+		return "[]" + value, false, true
+	}
+
+	explicitPresence := g.file.syntax != "proto3" || field.optional
+	scalarOrEnum := !strings.HasPrefix(value, "*") && value != "[]byte"
+	return value, explicitPresence && scalarOrEnum, true
+}
+
+// valueType returns the Go type of a single value of a protobuf type
+func (g *protoGenerator) valueType(typ, scope string) (string, bool) {
+	if scalar, ok := protoScalars[typ]; ok {
+		return scalar, true
+	}
+	if message, ok := g.messageType(typ, scope); ok {
+		return message, true
+	}
+	if enum, ok := g.file.enums[g.resolve(typ, scope)]; ok {
+		return g.messagesPackage + "." + enum, true
+	}
+	return "", false
+}
+
+// messageType returns the Go type of a pointer to a message
+func (g *protoGenerator) messageType(typ, scope string) (string, bool) {
+	resolved := g.resolve(typ, scope)
+	if message, ok := g.file.messages[resolved]; ok {
+		return "*" + g.messagesPackage + "." + message.goName, true
+	}
+	if path, ok := wellKnownTypes[resolved]; ok {
+		return "*" + g.importAs(path) + "." + resolved[strings.LastIndex(resolved, ".")+1:], true
+	}
+	return "", false
+}
+
+// resolve finds the fully qualified name of a type referenced from scope, following the
+// protobuf rule of searching from the innermost scope outwards
+func (g *protoGenerator) resolve(typ, scope string) string {
+	if strings.HasPrefix(typ, ".") {
+		return typ[1:]
+	}
+
+	for current := scope; ; {
+		candidate := qualify(current, typ)
+		if _, ok := g.file.messages[candidate]; ok {
+			return candidate
+		}
+		if _, ok := g.file.enums[candidate]; ok {
+			return candidate
+		}
+		if current == "" {
+			return typ
+		}
+		if i := strings.LastIndex(current, "."); i >= 0 {
+			current = current[:i]
+		} else {
+			current = ""
+		}
+	}
+}
+
+// qualify joins a scope and a name
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// protoGoName converts a protobuf name to the Go name protoc-gen-go generates for it,
+// e.g. "user_id" into "UserId"
+func protoGoName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '.' && i+1 < len(name) && isLowerASCII(name[i+1]):
+		case c == '.':
+			b.WriteByte('_')
+		case c == '_' && (i == 0 || name[i-1] == '.'):
+			b.WriteByte('X')
+		case c == '_' && i+1 < len(name) && isLowerASCII(name[i+1]):
+		case c >= '0' && c <= '9':
+			b.WriteByte(c)
+		default:
+			if isLowerASCII(c) {
+				c -= 'a' - 'A'
+			}
+			b.WriteByte(c)
+			for ; i+1 < len(name) && isLowerASCII(name[i+1]); i++ {
+				b.WriteByte(name[i+1])
+			}
+		}
+	}
+	return b.String()
+}
+
+// isLowerASCII reports whether c is a lower-case ASCII letter
+func isLowerASCII(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
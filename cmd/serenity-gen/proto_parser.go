@@ -0,0 +1,366 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// protoParser reads the declarations of a .proto file that the generator needs and
+// skips everything else, such as options, extensions and reserved ranges
+type protoParser struct {
+	tokens []string
+	pos    int
+	file   *protoFile
+}
+
+// parseProto parses the source of a .proto file
+func parseProto(source string) (*protoFile, error) {
+	tokens, err := tokenizeProto(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &protoParser{
+		tokens: tokens,
+		file:   &protoFile{syntax: "proto2", messages: map[string]*protoMessage{}, enums: map[string]string{}},
+	}
+	if err := p.parseFile(); err != nil {
+		return nil, err
+	}
+	return p.file, nil
+}
+
+// parseFile parses the top-level statements
+func (p *protoParser) parseFile() error {
+	for !p.done() {
+		switch token := p.next(); token {
+		case "syntax", "edition":
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			p.file.syntax = unquote(p.next())
+			if token == "edition" {
+				p.file.syntax = "editions"
+			}
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "package":
+			p.file.pkg = p.next()
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "option":
+			if p.peek() == "go_package" {
+				p.next()
+				if err := p.expect("="); err != nil {
+					return err
+				}
+				p.file.goPackage = unquote(p.next())
+			}
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage(p.file.pkg, ""); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(p.file.pkg, ""); err != nil {
+				return err
+			}
+		case "service":
+			if err := p.parseService(); err != nil {
+				return err
+			}
+		case ";":
+		default:
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseMessage parses a message body. scope is the fully qualified name of the enclosing
+// package or message, goPrefix the Go name of the enclosing message.
+func (p *protoParser) parseMessage(scope, goPrefix string) error {
+	name := p.next()
+	message := &protoMessage{name: qualify(scope, name), goName: goPrefix + protoGoName(name)}
+	p.file.messages[message.name] = message
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.parseMessageBody(message, false)
+}
+
+// parseMessageBody parses fields and nested declarations up to the closing brace
+func (p *protoParser) parseMessageBody(message *protoMessage, inOneof bool) error {
+	for {
+		if p.done() {
+			return fmt.Errorf("unexpected end of file in message %s", message.name)
+		}
+
+		switch token := p.next(); token {
+		case "}":
+			return nil
+		case ";":
+		case "message":
+			if err := p.parseMessage(message.name, message.goName+"_"); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(message.name, message.goName+"_"); err != nil {
+				return err
+			}
+		case "oneof":
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+			if err := p.parseMessageBody(message, true); err != nil {
+				return err
+			}
+		case "option", "reserved", "extensions", "extend":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			field := protoField{scope: message.name, oneof: inOneof}
+			switch token {
+			case "repeated":
+				field.repeated = true
+				token = p.next()
+			case "optional", "required":
+				field.optional = true
+				token = p.next()
+			}
+
+			if token == "map" {
+				if err := p.expect("<"); err != nil {
+					return err
+				}
+				field.mapKey = p.next()
+				if err := p.expect(","); err != nil {
+					return err
+				}
+				token = p.next()
+				if err := p.expect(">"); err != nil {
+					return err
+				}
+			}
+
+			field.typ = token
+			field.name = p.next()
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+			message.fields = append(message.fields, field)
+		}
+	}
+}
+
+// parseEnum registers an enum and skips its values
+func (p *protoParser) parseEnum(scope, goPrefix string) error {
+	name := p.next()
+	p.file.enums[qualify(scope, name)] = goPrefix + protoGoName(name)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.skipBlock()
+}
+
+// parseService parses the rpc declarations of a service
+func (p *protoParser) parseService() error {
+	service := protoService{name: p.next()}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for {
+		if p.done() {
+			return fmt.Errorf("unexpected end of file in service %s", service.name)
+		}
+
+		switch p.next() {
+		case "}":
+			p.file.services = append(p.file.services, service)
+			return nil
+		case ";":
+		case "rpc":
+			method := protoMethod{name: p.next(), scope: p.file.pkg}
+
+			var err error
+			var streaming bool
+			if method.input, streaming, err = p.parseRPCType(); err != nil {
+				return err
+			}
+			method.streaming = streaming
+			if err := p.expect("returns"); err != nil {
+				return err
+			}
+			if method.output, streaming, err = p.parseRPCType(); err != nil {
+				return err
+			}
+			method.streaming = method.streaming || streaming
+
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+			service.methods = append(service.methods, method)
+		default:
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseRPCType parses "(stream Type)" and reports whether the type is streamed
+func (p *protoParser) parseRPCType() (string, bool, error) {
+	if err := p.expect("("); err != nil {
+		return "", false, err
+	}
+	typ := p.next()
+	streaming := false
+	if typ == "stream" && p.peek() != ")" {
+		streaming = true
+		typ = p.next()
+	}
+	return typ, streaming, p.expect(")")
+}
+
+// skipStatement skips tokens up to and including the next ";" or balanced block at this level
+func (p *protoParser) skipStatement() error {
+	for !p.done() {
+		switch p.next() {
+		case ";":
+			return nil
+		case "{":
+			return p.skipBlock()
+		case "[":
+			if err := p.skipUntil("]"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skipBlock skips tokens up to and including the brace closing the current block
+func (p *protoParser) skipBlock() error {
+	return p.skipUntil("}")
+}
+
+// skipUntil skips tokens up to and including the closing token, honouring nesting
+func (p *protoParser) skipUntil(closing string) error {
+	opening := map[string]string{"}": "{", "]": "["}[closing]
+	depth := 1
+	for !p.done() {
+		switch p.next() {
+		case opening:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("missing %q", closing)
+}
+
+// next returns the next token, or an empty string at the end of the file
+func (p *protoParser) next() string {
+	if p.done() {
+		return ""
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	return token
+}
+
+// peek returns the next token without consuming it
+func (p *protoParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// expect consumes the next token and fails if it is not the expected one
+func (p *protoParser) expect(expected string) error {
+	if token := p.next(); token != expected {
+		return fmt.Errorf("expected %q, found %q", expected, token)
+	}
+	return nil
+}
+
+// done reports whether all tokens were consumed
+func (p *protoParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// tokenizeProto splits a .proto source into identifiers, numbers, strings and symbols,
+// dropping comments
+func tokenizeProto(source string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(source[i:], "//"):
+			end := strings.IndexByte(source[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(source) && source[j] != c {
+				if source[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(source) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, source[i:j+1])
+			i = j + 1
+		case isProtoWordByte(c):
+			j := i
+			for j < len(source) && isProtoWordByte(source[j]) {
+				j++
+			}
+			tokens = append(tokens, source[i:j])
+			i = j
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// isProtoWordByte reports whether c can be part of an identifier, a qualified name or a number
+func isProtoWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-' || c == '+'
+}
+
+// unquote removes the quotes around a string token
+func unquote(token string) string {
+	if len(token) >= 2 && (token[0] == '"' || token[0] == '\'') {
+		return token[1 : len(token)-1]
+	}
+	return token
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoGeneratesInteractionsAndBuilders(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "greeter")
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"proto", "-dir", dir, "testdata/greeter.proto"}, &out))
+	assert.Contains(t, out.String(), "created "+filepath.Join(dir, "greeter_screenplay.go"))
+
+	source := parseFile(t, filepath.Join(dir, "greeter_screenplay.go"))
+	assert.Contains(t, source, "// Code generated by serenity-gen from greeter.proto. DO NOT EDIT.")
+	assert.Contains(t, source, "package greeter")
+	assert.Contains(t, source, `"example.com/shop/gen/greeterpb"`)
+
+	assert.Contains(t, source, "func SayHello(request *greeterpb.HelloRequest) core.Activity")
+	assert.Contains(t, source, `grpc.Invoke[*greeterpb.HelloReply]("/shop.greeter.v1.Greeter/SayHello", request)`)
+	assert.Contains(t, source, "func SayHelloResponse() core.Question[*greeterpb.HelloReply]")
+	assert.Contains(t, source, "func SayHelloSucceeded() core.Activity")
+	assert.Contains(t, source, "func Ping(request *emptypb.Empty) core.Activity")
+	assert.NotContains(t, source, "ListCustomers", "streaming methods are not generated")
+
+	assert.Contains(t, source, "func NewHelloRequest() *HelloRequestBuilder")
+	assert.Contains(t, source, "func (b *HelloRequestBuilder) WithName(value string) *HelloRequestBuilder")
+	assert.Contains(t, source, "b.message.Times = &value")
+	assert.Contains(t, source, "WithCounters(value map[string]int64)")
+	assert.Contains(t, source, "WithLanguage(value greeterpb.Language)")
+	assert.Contains(t, source, "WithAddress(value *greeterpb.Customer_Address)")
+	assert.Contains(t, source, "WithSentAt(value *timestamppb.Timestamp)")
+	assert.NotContains(t, source, "WithText", "oneof members are set on the message directly")
+}
+
+func TestProtoGoPackageOverride(t *testing.T) {
+	dir := t.TempDir()
+	proto := filepath.Join(dir, "status.proto")
+	require.NoError(t, os.WriteFile(proto, []byte(`
+syntax = "proto2";
+package status;
+message Request { optional string id = 1; required bytes token = 2; }
+message Reply {}
+service Status { rpc Get(Request) returns (Reply); }
+`), 0644))
+
+	err := run([]string{"proto", "-dir", dir, "-package", "status", proto}, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no go_package option")
+
+	require.NoError(t, run([]string{"proto", "-dir", dir, "-package", "status", "-go-package", "example.com/gen/statuspb", proto}, &bytes.Buffer{}))
+
+	source := parseFile(t, filepath.Join(dir, "status_screenplay.go"))
+	assert.Contains(t, source, "func Get(request *statuspb.Request) core.Activity")
+	assert.Contains(t, source, "b.message.Id = &value")
+	assert.Contains(t, source, "b.message.Token = value")
+}
+
+func TestProtoGoNames(t *testing.T) {
+	assert.Equal(t, "UserId", protoGoName("user_id"))
+	assert.Equal(t, "SayHello", protoGoName("SayHello"))
+	assert.Equal(t, "XPrivate", protoGoName("_private"))
+	assert.Equal(t, "Field_2", protoGoName("field_2"))
+}
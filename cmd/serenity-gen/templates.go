@@ -0,0 +1,184 @@
+package main
+
+import "text/template"
+
+// abilityTemplate renders the ability interface, its implementation and constructor
+var abilityTemplate = template.Must(template.New("ability").Parse(`// Package {{.Package}} provides the ability to {{.Phrase}} together with its interactions and questions.
+package {{.Package}}
+
+import (
+	"fmt"
+	"sync"
+
+	"{{.Framework}}/serenity/abilities"
+)
+
+// {{.Interface}} enables an actor to {{.Phrase}}
+type {{.Interface}} interface {
+	abilities.Ability
+	// Record stores a value, replace with the operations of the ability
+	Record(value string) error
+	// LastValue returns the most recently recorded value
+	LastValue() string
+}
+
+// {{.Impl}} implements {{.Interface}}
+type {{.Impl}} struct {
+	lastValue string
+	mutex     sync.RWMutex
+}
+
+// {{.Constructor}} creates a new {{.Interface}}
+func {{.Constructor}}() {{.Interface}} {
+	return &{{.Impl}}{}
+}
+
+// Record stores a value
+func (a *{{.Impl}}) Record(value string) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if value == "" {
+		return fmt.Errorf("failed to record value: value is empty")
+	}
+
+	a.lastValue = value
+	return nil
+}
+
+// LastValue returns the most recently recorded value
+func (a *{{.Impl}}) LastValue() string {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.lastValue
+}
+`))
+
+// interactionsTemplate renders an interaction using the ability
+var interactionsTemplate = template.Must(template.New("interactions").Parse(`package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+
+	"{{.Framework}}/serenity/core"
+)
+
+// Record creates an interaction that records a value using {{.Interface}}
+func Record(value string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor records %q", value), func(actor core.Actor, ctx context.Context) error {
+		ability, err := core.AbilityOf[{{.Interface}}](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to {{.Phrase}}: %w", err)
+		}
+
+		return ability.Record(value)
+	})
+}
+`))
+
+// questionsTemplate renders a question answered using the ability
+var questionsTemplate = template.Must(template.New("questions").Parse(`package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+
+	"{{.Framework}}/serenity/core"
+)
+
+// LastValue is a question about the most recently recorded value
+func LastValue() core.Question[string] {
+	return core.NewQuestion("the last recorded value", func(actor core.Actor, ctx context.Context) (string, error) {
+		ability, err := core.AbilityOf[{{.Interface}}](actor)
+		if err != nil {
+			return "", fmt.Errorf("actor does not have the ability to {{.Phrase}}: %w", err)
+		}
+
+		return ability.LastValue(), nil
+	})
+}
+`))
+
+// abilityTestTemplate renders a test exercising the generated package
+var abilityTestTemplate = template.Must(template.New("test").Parse(`package {{.Package}}_test
+
+import (
+	"testing"
+
+	"{{.ImportPath}}"
+	"{{.Framework}}/serenity/expectations"
+	"{{.Framework}}/serenity/expectations/ensure"
+	serenity "{{.Framework}}/serenity/testing"
+)
+
+func Test{{.Name}}Ability(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Tester").WhoCan({{.Package}}.{{.Constructor}}())
+
+	actor.AttemptsTo(
+		{{.Package}}.Record("hello"),
+		ensure.That({{.Package}}.LastValue(), expectations.Equals("hello")),
+	)
+}
+`))
+
+// taskTemplate renders a task skeleton
+var taskTemplate = template.Must(template.New("task").Parse(`package {{.Package}}
+
+import "{{.Framework}}/serenity/core"
+
+// {{.Name}} creates a task to {{.Phrase}}
+func {{.Name}}() core.Activity {
+	// TODO: add the activities that make up the task
+	return core.TaskWhere("{{.Description}}")
+}
+`))
+
+// questionTemplate renders a question skeleton
+var questionTemplate = template.Must(template.New("question").Parse(`package {{.Package}}
+
+import (
+	"context"
+
+	"{{.Framework}}/serenity/core"
+)
+
+// {{.Name}} is a question about {{.Description}}
+func {{.Name}}() core.Question[{{.AnswerType}}] {
+	return core.NewQuestion("{{.Description}}", func(actor core.Actor, ctx context.Context) ({{.AnswerType}}, error) {
+		var answer {{.AnswerType}}
+		// TODO: answer the question using the actor's abilities
+		return answer, nil
+	})
+}
+`))
+
+// matcherTemplate renders a domain matcher registered with its expectation library
+var matcherTemplate = template.Must(template.New("matcher").Parse(`package {{.Package}}
+
+import (
+	"{{.Framework}}/serenity/expectations"
+	"{{.Framework}}/serenity/expectations/ensure"
+)
+
+func init() {
+	expectations.RegisterMatcher(expectations.MatcherInfo{
+		Library:     "{{.ImportPath}}",
+		Name:        "{{.Name}}",
+		Description: "{{.Description}}",
+		AnswerType:  "{{.AnswerType}}",
+	})
+}
+
+// {{.Name}} expects {{.Description}}
+func {{.Name}}() ensure.Expectation[{{.AnswerType}}] {
+	return expectations.NewMatcher("{{.Description}}", func(actual {{.AnswerType}}) string {
+		// TODO: return why the actual value is not {{.Description}}, or "" if it is
+		return ""
+	})
+}
+`))
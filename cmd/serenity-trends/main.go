@@ -0,0 +1,82 @@
+// Command serenity-trends renders the trends of the runs recorded by the history reporter:
+// the pass rate of each run, the duration of each scenario across the runs, and the
+// failures of the latest run that are new since a baseline run.
+//
+// Usage:
+//
+//	serenity-trends [-runs N] [-baseline run] [history]
+//
+// The history is a JSON lines file or, for the .db, .sqlite and .sqlite3 extensions, a
+// SQLite database; it defaults to target/serenity/history.jsonl. The baseline defaults to
+// the run before the latest one.
+//
+// Examples:
+//
+//	serenity-trends
+//	serenity-trends -runs 10 -baseline release-1.4 build/history.db
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/history"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "serenity-trends: %v\n", err)
+		os.Exit(2)
+	}
+}
+
+// usage describes the command line
+const usage = `usage:
+  serenity-trends [-runs N] [-baseline run] [history]`
+
+// run renders the trends of the history named by the arguments to out
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("serenity-trends", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	runs := flags.Int("runs", 10, "number of latest runs shown, 0 for all of them")
+	baseline := flags.String("baseline", "", "run the latest one is compared with (default: the run before it)")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, usage)
+	}
+
+	path := filepath.Join(reporting.DefaultOutputRoot, "history.jsonl")
+	switch flags.NArg() {
+	case 0:
+	case 1:
+		path = flags.Arg(0)
+	default:
+		return errors.New(usage)
+	}
+
+	store, err := history.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	recorded, err := store.Runs(context.Background())
+	if err != nil {
+		return err
+	}
+	trends, err := history.Compute(recorded, *runs, *baseline)
+	if err != nil {
+		return err
+	}
+	return trends.Render(out)
+}
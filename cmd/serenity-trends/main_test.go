@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/reporting/history"
+)
+
+// recordRuns appends three nightly runs of a checkout suite to the store at path
+func recordRuns(t *testing.T, path string) {
+	t.Helper()
+
+	store, err := history.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	started := time.Date(2026, 10, 14, 21, 0, 0, 0, time.UTC)
+	runs := []history.Run{
+		{ID: "nightly-1", Started: started, Tests: []history.Test{
+			{Name: "TestCatalog", Status: history.Passed, Duration: 200 * time.Millisecond},
+			{Name: "TestCheckout", Status: history.Passed, Duration: time.Second},
+			{Name: "TestRefunds", Status: history.Failed, Duration: 3 * time.Second, Error: "order 42 was not refunded"},
+		}},
+		{ID: "nightly-2", Started: started.Add(24 * time.Hour), Tests: []history.Test{
+			{Name: "TestCatalog", Status: history.Passed, Duration: 250 * time.Millisecond},
+			{Name: "TestCheckout", Status: history.Passed, Duration: 1500 * time.Millisecond},
+			{Name: "TestRefunds", Status: history.Failed, Duration: 3 * time.Second, Error: "order 42 was not refunded"},
+		}},
+		// The packages of the last run append separately and are merged by the run ID
+		{ID: "nightly-3", Started: started.Add(48 * time.Hour), Tests: []history.Test{
+			{Name: "TestCatalog", Status: history.Skipped},
+			{Name: "TestCheckout", Status: history.Failed, Duration: 2 * time.Second, Error: "expected status 201, but got 402\nbody: {}"},
+		}},
+		{ID: "nightly-3", Started: started.Add(48*time.Hour + time.Minute), Tests: []history.Test{
+			{Name: "TestRefunds", Status: history.Passed, Duration: 2 * time.Second},
+		}},
+	}
+	for _, run := range runs {
+		require.NoError(t, store.Append(context.Background(), run))
+	}
+}
+
+func TestTrendsOfJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	recordRuns(t, path)
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{path}, &out))
+
+	assert.Equal(t, `Pass rate
+  nightly-1  66.7%  (2/3, 0 skipped)
+  nightly-2  66.7%  (2/3, 0 skipped)
+  nightly-3  50.0%  (1/2, 1 skipped)
+
+Duration per scenario
+  TestCatalog   200.0ms  250.0ms  -
+  TestCheckout  1.00s    1.50s    2.00s
+  TestRefunds   3.00s    3.00s    2.00s
+
+New failures since nightly-2
+  TestCheckout: expected status 201, but got 402
+
+Fixed since nightly-2
+  TestRefunds
+`, out.String())
+}
+
+func TestTrendsOfSQLiteWithBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	recordRuns(t, path)
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"-runs", "1", "-baseline", "nightly-1", path}, &out))
+
+	assert.Contains(t, out.String(), "nightly-3  50.0%")
+	assert.NotContains(t, out.String(), "nightly-2")
+	assert.Contains(t, out.String(), "New failures since nightly-1\n  TestCheckout: expected status 201, but got 402\n")
+}
+
+func TestTrendsRejectsUnknownBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	recordRuns(t, path)
+
+	err := run([]string{"-baseline", "nightly-0", path}, &bytes.Buffer{})
+
+	assert.EqualError(t, err, "unknown baseline run 'nightly-0'")
+}
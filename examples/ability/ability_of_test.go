@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestAbilityOf_ReturnsTypedAbility demonstrates retrieving an ability without type assertions
+func TestAbilityOf_ReturnsTypedAbility(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+
+	tempDir := t.TempDir()
+	actor := test.ActorCalled("FileTester").WhoCan(
+		api.CallAnApiAt("https://api.example.com"),
+		ManageFilesIn(tempDir),
+	)
+
+	// Ask by interface
+	fileManager, err := core.AbilityOf[FileSystemAbility](actor)
+	require.NoError(t, err)
+	require.Equal(t, tempDir, fileManager.WorkingDirectory())
+
+	// Ask by concrete type
+	concrete, err := core.AbilityOf[*fileSystemAbility](actor)
+	require.NoError(t, err)
+	require.Same(t, fileManager, concrete)
+
+	callAbility := core.MustAbilityOf[api.CallAnAPI](actor)
+	require.Equal(t, "https://api.example.com", callAbility.GetBaseURL())
+}
+
+// TestAbilityOf_ListsAvailableAbilities demonstrates the error returned for a missing ability
+func TestAbilityOf_ListsAvailableAbilities(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+
+	actor := test.ActorCalled("Bob").WhoCan(api.CallAnApiAt("https://api.example.com"))
+
+	_, err := core.AbilityOf[FileSystemAbility](actor)
+	require.Error(t, err)
+	require.Equal(t, "actor 'Bob' lacks examples.FileSystemAbility; has: api.callAnAPI", err.Error())
+
+	require.PanicsWithValue(t,
+		"MustAbilityOf: actor 'Bob' lacks examples.FileSystemAbility; has: api.callAnAPI",
+		func() { core.MustAbilityOf[FileSystemAbility](actor) },
+	)
+}
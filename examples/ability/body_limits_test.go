@@ -0,0 +1,68 @@
+package examples
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestLargeResponseBodies demonstrates checking a large export without loading it into memory
+func TestLargeResponseBodies(t *testing.T) {
+	var export strings.Builder
+	export.WriteString("id,status\n")
+	for i := 1; i <= 10000; i++ {
+		status := "shipped"
+		if i%1000 == 0 {
+			status = "failed"
+		}
+		fmt.Fprintf(&export, "%d,%s\n", i, status)
+	}
+	digest := sha256.Sum256([]byte(export.String()))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+			return
+		}
+		_, _ = w.Write([]byte(export.String()))
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Auditor").WhoCan(api.CallAnApiAt(server.URL, api.WithMaxBufferedBody(1024)))
+
+	actor.AttemptsTo(
+		api.SendGetRequest("/health"),
+		ensure.That(api.NewJSONPath("status"), expectations.Equals[any]("ok")),
+		ensure.That(api.BodySize{}, expectations.Equals(int64(15))),
+		ensure.That(api.LastResponseBody{}, expectations.Equals(`{"status":"ok"}`)),
+
+		api.SendGetRequest("/orders.csv"),
+		ensure.That(api.BodyLinesMatching(`,failed$`), expectations.Equals([]string{
+			"1000,failed", "2000,failed", "3000,failed", "4000,failed", "5000,failed",
+			"6000,failed", "7000,failed", "8000,failed", "9000,failed", "10000,failed",
+		})),
+
+		api.SendGetRequest("/orders.csv"),
+		ensure.That(api.BodySHA256{}, expectations.Equals(hex.EncodeToString(digest[:]))),
+	)
+
+	actor.AttemptsTo(api.SendGetRequest("/orders.csv"))
+	_, err := core.Answer(actor, api.LastResponseBody{})
+	require.ErrorIs(t, err, api.ErrBodyTooLarge)
+	size, err := core.Answer(actor, api.BodySize{})
+	require.NoError(t, err, "the body is still available for streaming after the limit was hit")
+	require.Equal(t, int64(export.Len()), size)
+}
@@ -0,0 +1,62 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestClientOptions demonstrates tuning the HTTP client of the CallAnAPI ability
+func TestClientOptions(t *testing.T) {
+	t.Run("self-signed certificates of test environments", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		test := serenity.NewSerenityTest(t)
+		actor := test.ActorCalled("Tester").WhoCan(api.CallAnApiAt(server.URL,
+			api.WithInsecureSkipVerify(),
+			api.WithMaxIdleConnsPerHost(10),
+		))
+
+		actor.AttemptsTo(
+			api.SendGetRequest("/health"),
+			ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusNoContent)),
+		)
+	})
+
+	t.Run("timeouts", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		ability := api.CallAnApiAt(server.URL, api.WithTimeout(50*time.Millisecond))
+		req, err := http.NewRequest(http.MethodGet, "/slow", nil)
+		require.NoError(t, err)
+
+		_, err = ability.SendRequest(req, context.Background())
+		require.ErrorContains(t, err, "Client.Timeout exceeded")
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		ability := api.CallAnApiAt("http://localhost", api.WithProxy("://proxy"))
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+
+		_, err = ability.SendRequest(req, context.Background())
+		require.ErrorContains(t, err, "invalid client configuration: invalid proxy URL")
+	})
+}
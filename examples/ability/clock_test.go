@@ -0,0 +1,67 @@
+package examples
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/clock"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// waitForInvoiceDue is a time-dependent task that polls through the actor's clock
+func waitForInvoiceDue(due time.Time) core.Activity {
+	return core.Do("#actor waits until the invoice is due", func(actor core.Actor, ctx context.Context) error {
+		c := clock.Of(actor)
+		for c.Now().Before(due) {
+			if err := c.Sleep(ctx, time.Hour); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TestManipulateTime demonstrates running time-dependent scenarios instantly on a virtual clock
+func TestManipulateTime(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	start := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	actor := test.ActorCalled("Accountant").WhoCan(clock.UseVirtualTime(start))
+
+	began := time.Now()
+	actor.AttemptsTo(
+		clock.WaitFor(30*time.Minute),
+		ensure.That(clock.CurrentTime{}, expectations.Equals(start.Add(30*time.Minute))),
+
+		waitForInvoiceDue(start.AddDate(0, 0, 30)),
+		ensure.That(clock.CurrentTime{}, expectations.Equals(start.AddDate(0, 0, 30).Add(30*time.Minute))),
+
+		clock.SetTimeTo(start),
+		ensure.That(clock.CurrentTime{}, expectations.Equals(start)),
+	)
+	assert.Less(t, time.Since(began), time.Second, "a virtual month should pass instantly")
+}
+
+// TestRealTimeClock shows the same vocabulary running against the wall clock
+func TestRealTimeClock(t *testing.T) {
+	t.Setenv(clock.ModeEnvVar, "real")
+
+	wallClock := clock.UseTimeFromEnvironment()
+	require.False(t, wallClock.IsVirtual())
+	require.Error(t, wallClock.Set(time.Now()), "the real clock cannot be set")
+
+	began := time.Now()
+	require.NoError(t, wallClock.Sleep(context.Background(), 20*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(began), 20*time.Millisecond)
+
+	t.Setenv(clock.ModeEnvVar, "virtual")
+	assert.True(t, clock.UseTimeFromEnvironment().IsVirtual())
+}
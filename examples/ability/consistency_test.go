@@ -0,0 +1,80 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/db"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/tasks/consistency"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// orderState is an order as served by the API and stored in the database
+type orderState struct {
+	ID    int    `json:"id"`
+	State string `json:"state"`
+}
+
+// TestVerifyApiAndDatabaseAgree demonstrates confirming in the database what the API reports
+func TestVerifyApiAndDatabaseAgree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1, "state": "paid"}`))
+	}))
+	defer server.Close()
+
+	shared, path := openOrdersDatabase(t)
+	_, err := shared.Exec("INSERT INTO orders (id, state) VALUES (1, 'paid')")
+	require.NoError(t, err)
+
+	verification := consistency.VerifyApiAndDatabaseAgree(
+		api.NewResponseBodyAsJSON[orderState](),
+		db.QueryOneAs[orderState]("SELECT id, state FROM orders WHERE id = ?", 1),
+		consistency.Equal[orderState],
+	)
+
+	t.Run("sources agree", func(t *testing.T) {
+		test := serenity.NewSerenityTest(t)
+		defer test.Shutdown()
+
+		var steps []string
+		test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+			if finished, ok := event.(events.ActivityFinished); ok {
+				steps = append(steps, finished.Activity)
+			}
+		}))
+
+		actor := test.ActorCalled("Auditor").WhoCan(api.CallAnApiAt(server.URL), db.UseADatabaseAt("sqlite3", path))
+		actor.AttemptsTo(
+			api.SendGetRequest("/orders/1"),
+			verification,
+		)
+
+		require.Equal(t, []string{
+			"#actor sends GET request to /orders/1",
+			"#actor reads the last response body as JSON from the API",
+			"#actor reads asks the orderState returned by SELECT id, state FROM orders WHERE id = ? from the database",
+			"#actor compares the answers of the API and the database",
+			"#actor verifies that the API and the database agree",
+		}, steps)
+	})
+
+	t.Run("sources disagree", func(t *testing.T) {
+		_, err := shared.Exec("UPDATE orders SET state = 'refunded' WHERE id = 1")
+		require.NoError(t, err)
+
+		test := serenity.NewSerenityTest(t)
+		defer test.Shutdown()
+
+		actor := test.ActorCalled("Auditor").WhoCan(api.CallAnApiAt(server.URL), db.UseADatabaseAt("sqlite3", path))
+		actor.AttemptsTo(api.SendGetRequest("/orders/1"))
+
+		err = verification.PerformAs(actor, test.Context())
+		require.ErrorContains(t, err, "the API and the database disagree: expected {1 paid}, but got {1 refunded}")
+	})
+}
@@ -0,0 +1,54 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestCorrelationIDs demonstrates tagging requests with IDs to find them in server logs
+func TestCorrelationIDs(t *testing.T) {
+	var (
+		mutex  sync.Mutex
+		logged []http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		logged = append(logged, r.Header.Clone())
+		mutex.Unlock()
+		w.Header().Set("X-Request-ID", r.Header.Get("X-Request-ID"))
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL,
+		api.WithCorrelationIDs("X-Request-ID"),
+		api.WithIdempotencyKeys(""),
+	))
+
+	actor.AttemptsTo(api.SendPostRequest("/orders"))
+	correlationID := serenity.MustAnswer(actor, api.LastCorrelationID{})
+
+	actor.AttemptsTo(
+		ensure.That(api.NewResponseHeader("X-Request-ID"), expectations.Equals(correlationID)),
+		ensure.That(notes.Recall[string](api.CorrelationIDNote), expectations.Equals(correlationID)),
+
+		api.SendGetRequest("/orders").WithHeader("X-Request-ID", "support-ticket-42"),
+		ensure.That(api.LastCorrelationID{}, expectations.Equals("support-ticket-42")),
+	)
+
+	require.Len(t, logged, 2)
+	require.NotEmpty(t, logged[0].Get("Idempotency-Key"))
+	require.Empty(t, logged[1].Get("Idempotency-Key"), "GET requests are idempotent")
+	require.Equal(t, logged[0].Get("Idempotency-Key"), serenity.MustAnswer(actor, notes.Recall[string](api.IdempotencyKeyNote)))
+	require.NotEqual(t, logged[0].Get("X-Request-ID"), logged[1].Get("X-Request-ID"))
+}
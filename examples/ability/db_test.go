@@ -0,0 +1,169 @@
+package examples
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/db"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// openOrdersDatabase creates a file-backed SQLite database with an empty orders table
+func openOrdersDatabase(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "shop.db")
+	database, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	_, err = database.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY, state TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	return database, path
+}
+
+// countOrders counts the orders committed to the shared database
+func countOrders(t *testing.T, database *sql.DB) int {
+	t.Helper()
+
+	var count int
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM orders").Scan(&count))
+	return count
+}
+
+// TestUseADatabaseWithinTransaction demonstrates that writes made in transactional mode
+// are visible to the actor but rolled back on Shutdown
+func TestUseADatabaseWithinTransaction(t *testing.T) {
+	shared, path := openOrdersDatabase(t)
+
+	t.Run("places orders", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithContext(context.Background(), t)
+		defer test.Shutdown()
+
+		actor := test.ActorCalled("Clerk").WhoCan(db.UseADatabaseAt("sqlite3", path).WithinTransaction())
+
+		actor.AttemptsTo(
+			db.Execute("INSERT INTO orders (id, state) VALUES (?, ?)", 1, "new"),
+			db.Execute("INSERT INTO orders (id, state) VALUES (?, ?)", 2, "paid"),
+			ensure.That(db.NewNumberOfRowsIn("orders"), expectations.Equals(2)),
+			ensure.That(db.NewNumberOfRowsIn("orders").Where("state = ?", "paid"), expectations.Equals(1)),
+		)
+	})
+
+	assert.Equal(t, 0, countOrders(t, shared), "transaction should be rolled back on Shutdown")
+}
+
+// TestUseADatabaseCleaningTables demonstrates deleting the rows a test wrote on Shutdown
+func TestUseADatabaseCleaningTables(t *testing.T) {
+	shared, _ := openOrdersDatabase(t)
+
+	t.Run("places orders", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithContext(context.Background(), t)
+		defer test.Shutdown()
+
+		actor := test.ActorCalled("Clerk").WhoCan(db.Using(shared).CleaningTables("orders"))
+
+		actor.AttemptsTo(
+			db.Execute("INSERT INTO orders (id, state) VALUES (?, ?)", 1, "new"),
+			ensure.That(db.NewNumberOfRowsIn("orders"), expectations.Equals(1)),
+		)
+
+		assert.Equal(t, 1, countOrders(t, shared), "rows are committed while the test runs")
+	})
+
+	assert.Equal(t, 0, countOrders(t, shared), "tables should be cleaned on Shutdown")
+	require.NoError(t, shared.Ping(), "a provided database should stay open")
+}
+
+// TestApplyMigrationsAndSeed demonstrates making database preconditions explicit steps
+func TestApplyMigrationsAndSeed(t *testing.T) {
+	dir := t.TempDir()
+	migrations := filepath.Join(dir, "migrations")
+	require.NoError(t, os.Mkdir(migrations, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(migrations, "001_create_customers.sql"),
+		[]byte("CREATE TABLE customers (id INTEGER PRIMARY KEY, name TEXT NOT NULL);"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(migrations, "002_create_orders.sql"),
+		[]byte("CREATE TABLE orders (id INTEGER PRIMARY KEY, customer_id INTEGER REFERENCES customers (id), state TEXT);"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(migrations, "002_create_orders.down.sql"),
+		[]byte("DROP TABLE orders;"), 0600))
+
+	seed := filepath.Join(dir, "seed.yaml")
+	require.NoError(t, os.WriteFile(seed, []byte(`
+customers:
+  - id: 1
+    name: Ada
+orders:
+  - id: 10
+    customer_id: 1
+    state: new
+  - id: 11
+    customer_id: 1
+    state: ~
+`), 0600))
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Clerk").WhoCan(db.UseADatabaseAt("sqlite3", filepath.Join(dir, "shop.db")))
+
+	actor.AttemptsTo(
+		ensure.That(db.SchemaVersion{}, expectations.Equals(0)),
+		db.ApplyMigrations(migrations),
+		db.ApplyMigrations(migrations),
+		ensure.That(db.SchemaVersion{}, expectations.Equals(2)),
+
+		db.SeedFromFile(seed),
+		ensure.That(db.NewNumberOfRowsIn("customers"), expectations.Equals(1)),
+		ensure.That(db.NewNumberOfRowsIn("orders").Where("state IS NULL"), expectations.Equals(1)),
+	)
+}
+
+// order is scanned from the orders table by the typed query questions
+type order struct {
+	ID       int
+	State    string
+	Customer *string `db:"customer_name"`
+}
+
+// TestQueryAs demonstrates scanning query results into typed values for expectations
+func TestQueryAs(t *testing.T) {
+	shared, _ := openOrdersDatabase(t)
+	_, err := shared.Exec("ALTER TABLE orders ADD COLUMN customer_name TEXT")
+	require.NoError(t, err)
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Clerk").WhoCan(db.Using(shared).WithinTransaction())
+
+	ada := "Ada"
+	actor.AttemptsTo(
+		db.Execute("INSERT INTO orders (id, state, customer_name) VALUES (?, ?, ?)", 1, "new", ada),
+		db.Execute("INSERT INTO orders (id, state) VALUES (?, ?)", 2, "paid"),
+
+		ensure.That(db.QueryOneAs[order]("SELECT id, state, customer_name FROM orders WHERE id = ?", 1),
+			expectations.Equals(order{ID: 1, State: "new", Customer: &ada})),
+		ensure.That(db.QueryOneAs[string]("SELECT state FROM orders WHERE id = ?", 2), expectations.Equals("paid")),
+		ensure.That(db.QueryAllAs[order]("SELECT * FROM orders ORDER BY id"),
+			expectations.Equals([]order{{ID: 1, State: "new", Customer: &ada}, {ID: 2, State: "paid"}})),
+		ensure.That(db.QueryAllAs[int]("SELECT id FROM orders WHERE state = ?", "shipped"), expectations.Equals([]int{})),
+	)
+
+	_, err = db.QueryOneAs[order]("SELECT * FROM orders").AnsweredBy(actor, context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected SELECT * FROM orders to return one row, got 2")
+
+	_, err = db.QueryAllAs[struct{ ID int }]("SELECT id, state FROM orders").AnsweredBy(actor, context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "column state has no matching field")
+}
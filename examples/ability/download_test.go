@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestDownloads demonstrates saving response bodies to files and checking what was saved
+func TestDownloads(t *testing.T) {
+	report := []byte("%PDF-1.7 quarterly report")
+	digest := sha256.Sum256(report)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(report)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Accountant").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.DownloadTo(filepath.Join(dir, "reports", "q1.pdf")).From(api.SendGetRequest("/reports/q1.pdf")),
+		ensure.That(api.DownloadedSize{}, expectations.Equals(int64(len(report)))),
+		ensure.That(api.DownloadedSHA256{}, expectations.Equals(hex.EncodeToString(digest[:]))),
+
+		api.SendGetRequest("/reports/q2.pdf"),
+		ensure.That(api.ContentType(), api.HasMediaType("application/pdf")),
+		api.DownloadTo(filepath.Join(dir, "q2.pdf")),
+	)
+
+	for _, name := range []string{"reports/q1.pdf", "q2.pdf"} {
+		saved, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		require.Equal(t, report, saved)
+	}
+}
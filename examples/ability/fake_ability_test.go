@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// fakeAPI is an in-memory CallAnAPI implementation that answers every request with a canned response
+type fakeAPI struct {
+	status       int
+	body         string
+	requests     []*http.Request
+	lastResponse *http.Response
+}
+
+func (f *fakeAPI) SendRequest(req *http.Request, ctx context.Context) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+	f.lastResponse = &http.Response{
+		StatusCode: f.status,
+		Body:       io.NopCloser(strings.NewReader(f.body)),
+		Header:     make(http.Header),
+		Request:    req,
+	}
+	return f.lastResponse, nil
+}
+
+func (f *fakeAPI) LastResponse() *http.Response    { return f.lastResponse }
+func (f *fakeAPI) SetBaseURL(baseURL string) error { return nil }
+func (f *fakeAPI) GetBaseURL() string              { return "" }
+func (f *fakeAPI) RequestCount() int               { return len(f.requests) }
+func (f *fakeAPI) LastRequestPath() string         { return f.requests[len(f.requests)-1].URL.Path }
+
+func (f *fakeAPI) withResponse(status int, body string) *fakeAPI {
+	f.status = status
+	f.body = body
+	return f
+}
+
+// TestFakeAbilityImplementation demonstrates swapping the real API ability for a fake one:
+// the built-in api interactions and questions look the ability up by its interface
+func TestFakeAbilityImplementation(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+
+	fake := (&fakeAPI{}).withResponse(201, `{"id": 1}`)
+	actor := test.ActorCalled("OfflineTester").WhoCan(fake)
+
+	actor.AttemptsTo(
+		api.SendPostRequest("/users").WithBody(map[string]string{"name": "John"}),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(201)),
+		ensure.That(api.LastResponseBody{}, expectations.Contains(`"id": 1`)),
+	)
+
+	require.Equal(t, 1, fake.RequestCount())
+	require.Equal(t, "/users", fake.LastRequestPath())
+
+	// The ability can also be retrieved by interface with AbilityTo
+	ability, err := actor.AbilityTo((*api.CallAnAPI)(nil))
+	require.NoError(t, err)
+	require.Same(t, fake, ability)
+}
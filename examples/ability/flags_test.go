@@ -0,0 +1,69 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/flags"
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestToggleFeatures demonstrates verifying behavior under both states of a flag, with the
+// original flag values restored after every test
+func TestToggleFeatures(t *testing.T) {
+	provider := flags.InMemory()
+	_ = provider.SetFlag(context.Background(), "checkout-variant", "classic")
+
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("new-checkout=%t", enabled), func(t *testing.T) {
+			test := serenity.NewSerenityTestWithContext(context.Background(), t)
+			defer test.Shutdown()
+
+			actor := test.ActorCalled("Shopper").WhoCan(flags.ToggleFeaturesWith(provider))
+
+			toggle := flags.DisableFlag("new-checkout")
+			if enabled {
+				toggle = flags.EnableFlag("new-checkout")
+			}
+
+			actor.AttemptsTo(
+				toggle,
+				flags.SetFlag("checkout-variant", "express"),
+				ensure.That(flags.NewFlagValue[bool]("new-checkout"), expectations.Equals(enabled)),
+				ensure.That(flags.NewFlagValue[string]("checkout-variant"), expectations.Equals("express")),
+			)
+			assert.Equal(t, enabled, provider.Enabled("new-checkout"), "the system under test sees the flag")
+		})
+
+		_, found, _ := provider.Flag(context.Background(), "new-checkout")
+		assert.False(t, found, "flags set by a test are removed on Shutdown")
+		variant, _, _ := provider.Flag(context.Background(), "checkout-variant")
+		assert.Equal(t, "classic", variant, "changed flags are restored on Shutdown")
+	}
+}
+
+// TestToggleConfigFeatures demonstrates toggling the feature flags read by config.Feature
+func TestToggleConfigFeatures(t *testing.T) {
+	config.Use(config.New().WithFeature("newCheckout", false))
+
+	t.Run("enabled", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithContext(context.Background(), t)
+		defer test.Shutdown()
+
+		actor := test.ActorCalled("Shopper").WhoCan(flags.ToggleFeaturesWith(flags.ConfigFeatures()))
+		actor.AttemptsTo(flags.EnableFlag("newCheckout"))
+
+		assert.True(t, config.Feature("newCheckout"))
+	})
+
+	assert.False(t, config.Feature("newCheckout"))
+	_, set := os.LookupEnv(config.FeatureEnvVar("newCheckout"))
+	assert.False(t, set)
+}
@@ -0,0 +1,100 @@
+package examples
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/grpc"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// startHealthServer serves the standard gRPC health and reflection services on a random local port
+func startHealthServer(t *testing.T) (string, *health.Server) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpcgo.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String(), healthServer
+}
+
+// TestCallAService demonstrates invoking unary gRPC methods and asking about the responses
+func TestCallAService(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	target, healthServer := startHealthServer(t)
+	healthServer.SetServingStatus("payments", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	actor := test.ActorCalled("Operator").WhoCan(grpc.CallAServiceAt(target))
+
+	actor.AttemptsTo(
+		grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{}),
+		ensure.That(grpc.LastStatusCode{}, expectations.Equals(codes.OK)),
+
+		grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{Service: "payments"}),
+		ensure.That(grpc.LastStatusCode{}, expectations.Equals(codes.OK)),
+
+		grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{Service: "unknown"}),
+		ensure.That(grpc.LastStatusCode{}, expectations.Equals(codes.NotFound)),
+		ensure.That(grpc.LastStatusMessage{}, expectations.Equals("unknown service")),
+	)
+
+	actor.AttemptsTo(
+		grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{Service: "payments"}),
+	)
+	response, err := grpc.NewResponseMessage[*healthpb.HealthCheckResponse]().AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.GetStatus())
+}
+
+// TestServerSmokeChecks demonstrates smoke-checking a server with the health-checking and
+// reflection protocols
+func TestServerSmokeChecks(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	target, healthServer := startHealthServer(t)
+	healthServer.SetServingStatus("payments", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	actor := test.ActorCalled("Operator").WhoCan(grpc.CallAServiceAt(target))
+
+	actor.AttemptsTo(
+		ensure.That(grpc.ServiceIsServing(""), expectations.Equals(true)),
+		ensure.That(grpc.ServiceIsServing("payments"), expectations.Equals(false)),
+		ensure.That(grpc.ServiceIsServing("unknown"), expectations.Equals(false)),
+
+		ensure.That(grpc.AvailableServices(), expectations.Equals([]string{
+			"grpc.health.v1.Health",
+			"grpc.reflection.v1.ServerReflection",
+			"grpc.reflection.v1alpha.ServerReflection",
+		})),
+		ensure.That(grpc.MethodExists("/grpc.health.v1.Health/Check"), expectations.Equals(true)),
+		ensure.That(grpc.MethodExists("grpc.health.v1.Health.Watch"), expectations.Equals(true)),
+		ensure.That(grpc.MethodExists("/grpc.health.v1.Health/Reset"), expectations.Equals(false)),
+		ensure.That(grpc.MethodExists("/orders.v1.OrderService/PlaceOrder"), expectations.Equals(false)),
+	)
+
+	_, err := grpc.LastStatusCode{}.AnsweredBy(actor, context.Background())
+	require.Error(t, err, "smoke checks are not recorded as calls")
+}
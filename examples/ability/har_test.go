@@ -0,0 +1,94 @@
+package examples
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestTrafficCapture demonstrates attaching the traffic of a test to the report as a HAR file
+func TestTrafficCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 7}`))
+	}))
+	defer server.Close()
+
+	var (
+		mutex       sync.Mutex
+		attachments []events.AttachmentAdded
+	)
+
+	t.Run("scenario", func(t *testing.T) {
+		test := serenity.NewSerenityTest(t)
+		test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+			if attachment, ok := event.(events.AttachmentAdded); ok {
+				mutex.Lock()
+				attachments = append(attachments, attachment)
+				mutex.Unlock()
+			}
+		}))
+
+		secrets.Register("s3cr3t-token")
+		actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL, api.WithTrafficCapture()))
+		actor.AttemptsTo(
+			api.SendPostRequest("/orders?express=true").
+				WithHeader("Authorization", "Bearer s3cr3t-token").
+				WithBody(map[string]string{"item": "book"}),
+			ensure.That(api.LastResponseBody{}, expectations.Equals(`{"id": 7}`)),
+		)
+	})
+
+	require.Len(t, attachments, 1)
+	require.Equal(t, "Buyer", attachments[0].Actor)
+	require.Equal(t, api.HARAttachment, attachments[0].Name)
+	require.NotContains(t, string(attachments[0].Content), "s3cr3t-token")
+
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					Method      string `json:"method"`
+					URL         string `json:"url"`
+					QueryString []struct {
+						Name  string `json:"name"`
+						Value string `json:"value"`
+					} `json:"queryString"`
+					PostData struct {
+						Text string `json:"text"`
+					} `json:"postData"`
+				} `json:"request"`
+				Response struct {
+					Status     int    `json:"status"`
+					StatusText string `json:"statusText"`
+					Content    struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	require.NoError(t, json.Unmarshal(attachments[0].Content, &har))
+	require.Len(t, har.Log.Entries, 1)
+
+	entry := har.Log.Entries[0]
+	require.Equal(t, http.MethodPost, entry.Request.Method)
+	require.Equal(t, server.URL+"/orders?express=true", entry.Request.URL)
+	require.Equal(t, "express", entry.Request.QueryString[0].Name)
+	require.JSONEq(t, `{"item": "book"}`, entry.Request.PostData.Text)
+	require.Equal(t, http.StatusCreated, entry.Response.Status)
+	require.Equal(t, "Created", entry.Response.StatusText)
+	require.Equal(t, `{"id": 7}`, entry.Response.Content.Text)
+}
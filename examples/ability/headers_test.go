@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestResponseHeaders demonstrates asserting on typed response headers
+func TestResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Header().Set("Cache-Control", `private, max-age=60, no-transform`)
+		w.Header().Set("Location", "/orders/42")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", HttpOnly: true, Secure: true})
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.SendPostRequest("/orders"),
+		ensure.That(api.ContentType(), api.HasMediaType("application/json")),
+		ensure.That(api.CacheControl(), api.HasCacheDirective("private")),
+		ensure.That(api.LocationHeader(), api.HasPath("/orders/42")),
+		ensure.That(api.SetCookies(), api.HasCookie("session", func(c *http.Cookie) bool { return c.HttpOnly && c.Secure })),
+		ensure.That(api.AllHeaders(), api.HasHeader("set-cookie")),
+	)
+
+	err := api.HasHeader("X-Powered-By").Evaluate(serenity.MustAnswer(actor, api.AllHeaders()))
+	require.Error(t, err)
+
+	mediaType := serenity.MustAnswer(actor, api.ContentType())
+	require.Equal(t, "UTF-8", mediaType.Parameters["charset"])
+	maxAge, ok := serenity.MustAnswer(actor, api.CacheControl()).MaxAge()
+	require.True(t, ok)
+	require.Equal(t, time.Minute, maxAge)
+	require.Equal(t, server.URL+"/orders/42", serenity.MustAnswer(actor, api.LocationHeader()).String())
+}
@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestHTTPMethods demonstrates sending requests with methods beyond GET, POST, PUT and DELETE
+func TestHTTPMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Allow", "GET, PATCH, HEAD, OPTIONS, PURGE")
+		w.Header().Set("X-Method", r.Method)
+		_, _ = w.Write([]byte(r.Method + " " + string(body)))
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Editor").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.SendPatchRequest("/posts/1").
+			WithHeader("Content-Type", "application/merge-patch+json").
+			WithBody(map[string]string{"title": "Updated"}),
+		ensure.That(api.LastResponseBody{}, expectations.Equals(`PATCH {"title":"Updated"}`)),
+
+		api.SendHeadRequest("/posts/1"),
+		ensure.That(api.NewResponseHeader("X-Method"), expectations.Equals("HEAD")),
+		ensure.That(api.LastResponseBody{}, expectations.Equals("")),
+
+		api.SendOptionsRequest("/posts"),
+		ensure.That(api.NewResponseHeader("Allow"), expectations.Contains("PATCH")),
+
+		api.SendRequestWithMethod("PURGE", "/cache/posts").WithHeaders(map[string]string{"X-Purge-Key": "all"}),
+		ensure.That(api.LastResponseBody{}, expectations.Equals("PURGE ")),
+	)
+}
@@ -0,0 +1,66 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/logs"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestTailLogs demonstrates asserting on what the system under test logged during a scenario
+func TestTailLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.log")
+	require.NoError(t, os.WriteFile(path, []byte(`{"level":"error","msg":"before the test"}`+"\n"), 0o600))
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	// log stands in for the system under test writing to its log file
+	log := func(lines string) core.Activity {
+		return core.Do("the system logs", func(actor core.Actor, ctx context.Context) error {
+			_, err := file.WriteString(lines)
+			return err
+		})
+	}
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Operator").WhoCan(logs.TailFile(path))
+
+	actor.AttemptsTo(
+		ensure.That(logs.NoErrorLogsSince(""), expectations.Equals(true)),
+
+		log(`{"level":"info","event":"cart_created"}`+"\n"+`{"level":"info","event":"order_pl`),
+		logs.MarkLogPosition("checkout"),
+		log(`aced","order":1}`+"\n"+`{"level":"info","event":"email_sent"}`+"\n"),
+
+		ensure.That(logs.LinesMatching(`"event":"order_placed"`, "checkout"), expectations.Equals([]string{`{"level":"info","event":"order_placed","order":1}`})),
+		ensure.That(logs.LinesMatching(`"event":"cart_created"`, "checkout"), expectations.Equals([]string{})),
+		ensure.That(logs.LinesMatching(`"event":"\w+"`, ""), expectations.Equals([]string{
+			`{"level":"info","event":"cart_created"}`,
+			`{"level":"info","event":"order_placed","order":1}`,
+			`{"level":"info","event":"email_sent"}`,
+		})),
+		ensure.That(logs.NoErrorLogsSince("checkout"), expectations.Equals(true)),
+
+		logs.MarkLogPosition("refund"),
+		log("level=error msg=\"refund failed\"\n"),
+		ensure.That(logs.NoErrorLogsSince("refund"), expectations.Equals(false)),
+		ensure.That(logs.NoErrorLogsSince("checkout"), expectations.Equals(false)),
+	)
+
+	_, err = logs.LinesMatching("order", "payment").AnsweredBy(actor, test.Context())
+	require.EqualError(t, err, "no log position marked as payment")
+}
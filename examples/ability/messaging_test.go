@@ -0,0 +1,184 @@
+package examples
+
+import (
+	"context"
+	"crypto/md5" // #nosec G501 -- SQS checksums message bodies with MD5
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/pubsub"
+	"github.com/nchursin/serenity-go/serenity/abilities/sqs"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// orderEvent is the message exchanged in the messaging examples
+type orderEvent struct {
+	Order int    `json:"order"`
+	State string `json:"state"`
+}
+
+// fakeAWS emulates the parts of SQS and SNS the sqs ability uses, in the way LocalStack
+// would: SNS topics deliver to the SQS queues subscribed to them
+type fakeAWS struct {
+	server        *httptest.Server
+	queues        map[string][]string
+	subscriptions map[string]string
+	mutex         sync.Mutex
+}
+
+func startFakeAWS(t *testing.T, subscriptions map[string]string) *fakeAWS {
+	t.Helper()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	fake := &fakeAWS{queues: make(map[string][]string), subscriptions: subscriptions}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+func (f *fakeAWS) handle(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	target := r.Header.Get("X-Amz-Target")
+	if target == "" {
+		// SNS uses the query protocol
+		_ = r.ParseForm()
+		queue := f.subscriptions[r.PostForm.Get("TopicArn")]
+		f.queues[queue] = append(f.queues[queue], r.PostForm.Get("Message"))
+		_, _ = fmt.Fprint(w, `<PublishResponse><PublishResult><MessageId>sns-1</MessageId></PublishResult></PublishResponse>`)
+		return
+	}
+
+	var input map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&input)
+	queue, _ := input["QueueUrl"].(string)
+	queue = strings.TrimPrefix(queue, f.server.URL+"/")
+
+	var output any
+	switch strings.TrimPrefix(target, "AmazonSQS.") {
+	case "GetQueueUrl":
+		output = map[string]string{"QueueUrl": f.server.URL + "/" + fmt.Sprint(input["QueueName"])}
+	case "SendMessage":
+		body := fmt.Sprint(input["MessageBody"])
+		f.queues[queue] = append(f.queues[queue], body)
+		output = map[string]string{"MessageId": "sqs-1", "MD5OfMessageBody": md5Hex(body)}
+	case "ReceiveMessage":
+		var messages []map[string]string
+		if len(f.queues[queue]) > 0 {
+			body := f.queues[queue][0]
+			f.queues[queue] = f.queues[queue][1:]
+			messages = append(messages, map[string]string{"MessageId": "m-1", "ReceiptHandle": "r-1", "Body": body, "MD5OfBody": md5Hex(body)})
+		}
+		output = map[string]any{"Messages": messages}
+	case "DeleteMessage":
+		output = map[string]string{}
+	case "GetQueueAttributes":
+		output = map[string]any{"Attributes": map[string]string{"ApproximateNumberOfMessages": fmt.Sprint(len(f.queues[queue]))}}
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	_ = json.NewEncoder(w).Encode(output)
+}
+
+func md5Hex(body string) string {
+	sum := md5.Sum([]byte(body)) // #nosec G401 -- SQS checksums message bodies with MD5
+	return hex.EncodeToString(sum[:])
+}
+
+// TestUseSQS demonstrates verifying messages sent to queues and published to topics
+func TestUseSQS(t *testing.T) {
+	fake := startFakeAWS(t, map[string]string{"arn:aws:sns:us-east-1:000000000000:orders": "order-events"})
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Shipper").WhoCan(sqs.UseSQSAt(fake.server.URL, "us-east-1"))
+
+	actor.AttemptsTo(
+		sqs.SendToQueue("shipments", `{"order":1,"state":"packed"}`),
+		sqs.SendToQueue("shipments", `{"order":2,"state":"packed"}`),
+		ensure.That(sqs.NewQueueDepth("shipments"), expectations.Equals(2)),
+
+		sqs.ReceiveFrom("shipments"),
+		ensure.That(sqs.LastReceivedMessageAs[orderEvent](), expectations.Equals(orderEvent{Order: 1, State: "packed"})),
+		ensure.That(sqs.NewQueueDepth("shipments"), expectations.Equals(1)),
+
+		sqs.PublishToTopic("arn:aws:sns:us-east-1:000000000000:orders", `{"order":3,"state":"paid"}`),
+		sqs.ReceiveFrom("order-events"),
+		ensure.That(sqs.LastReceivedMessageBody{}, expectations.Equals(`{"order":3,"state":"paid"}`)),
+	)
+}
+
+// startFakePubSub emulates the REST methods of the Pub/Sub emulator used by the pubsub
+// ability, delivering every topic to the subscription with the same name plus "-sub"
+func startFakePubSub(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var mutex sync.Mutex
+	subscriptions := make(map[string][]pubsub.Message)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		resource, method, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/"), ":")
+		var request struct {
+			Messages []pubsub.Message `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+
+		switch method {
+		case "publish":
+			subscription := strings.Replace(resource, "/topics/", "/subscriptions/", 1) + "-sub"
+			subscriptions[subscription] = append(subscriptions[subscription], request.Messages[0])
+			_, _ = fmt.Fprint(w, `{"messageIds":["ps-1"]}`)
+		case "pull":
+			received := []map[string]any{}
+			if pending := subscriptions[resource]; len(pending) > 0 {
+				subscriptions[resource] = pending[1:]
+				received = append(received, map[string]any{"ackId": "a-1", "message": pending[0]})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"receivedMessages": received})
+		case "acknowledge":
+			_, _ = fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestUsePubSub demonstrates publishing to and pulling from the Pub/Sub emulator
+func TestUsePubSub(t *testing.T) {
+	server := startFakePubSub(t)
+	t.Setenv("PUBSUB_EMULATOR_HOST", strings.TrimPrefix(server.URL, "http://"))
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Shipper").WhoCan(pubsub.UsePubSubIn("shop"))
+
+	actor.AttemptsTo(
+		pubsub.PublishTo("orders", `{"order":4,"state":"paid"}`),
+		pubsub.PullFrom("orders-sub"),
+		ensure.That(pubsub.LastReceivedMessageAs[orderEvent](), expectations.Equals(orderEvent{Order: 4, State: "paid"})),
+		ensure.That(pubsub.LastReceivedMessageData{}, expectations.Equals(`{"order":4,"state":"paid"}`)),
+	)
+
+	_, err := pubsub.LastReceivedMessageData{}.AnsweredBy(test.ActorCalled("Auditor").WhoCan(pubsub.UsePubSubIn("shop")), context.Background())
+	require.EqualError(t, err, "no message has been received")
+}
@@ -0,0 +1,180 @@
+package examples
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/grpc"
+	"github.com/nchursin/serenity-go/serenity/abilities/tlsconfig"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// internalPKI is a certificate authority with a server certificate for 127.0.0.1 and a
+// client certificate written to PEM files
+type internalPKI struct {
+	caPEM    []byte
+	cas      *x509.CertPool
+	server   tls.Certificate
+	certFile string
+	keyFile  string
+}
+
+// serverConfig requires clients to present a certificate issued by the authority
+func (pki internalPKI) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{pki.server},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pki.cas,
+	}
+}
+
+// newInternalPKI issues the certificates of an internal service and its client
+func newInternalPKI(t *testing.T) internalPKI {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Internal CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	issue := func(serial int64, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	serverCert, serverKey := issue(2, "payments.internal", x509.ExtKeyUsageServerAuth)
+	server, err := tls.X509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+
+	clientCert, clientKey := issue(3, "checkout", x509.ExtKeyUsageClientAuth)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	require.NoError(t, os.WriteFile(certFile, clientCert, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, clientKey, 0o600))
+
+	cas := x509.NewCertPool()
+	cas.AddCert(ca)
+
+	return internalPKI{
+		caPEM:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		cas:      cas,
+		server:   server,
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+}
+
+// TestMutualTLS demonstrates calling internal services that require client certificates
+func TestMutualTLS(t *testing.T) {
+	pki := newInternalPKI(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = pki.serverConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	t.Run("client certificate of the ability", func(t *testing.T) {
+		test := serenity.NewSerenityTest(t)
+		actor := test.ActorCalled("Checkout").WhoCan(api.CallAnApiAt(server.URL,
+			api.WithClientCertificate(pki.certFile, pki.keyFile),
+			api.WithRootCAs(pki.caPEM),
+		))
+
+		actor.AttemptsTo(
+			api.SendGetRequest("/payments"),
+			ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+			ensure.That(api.LastResponseBody{}, expectations.Equals("checkout")),
+		)
+	})
+
+	t.Run("client certificate of a single request", func(t *testing.T) {
+		test := serenity.NewSerenityTest(t)
+		ability := api.CallAnApiAt(server.URL, api.WithRootCAs(pki.caPEM))
+		actor := test.ActorCalled("Checkout").WhoCan(ability)
+
+		actor.AttemptsTo(
+			api.SendGetRequest("/payments").WithTLS(tlsconfig.ClientCertificate(pki.certFile, pki.keyFile)),
+			ensure.That(api.LastResponseBody{}, expectations.Equals("checkout")),
+		)
+
+		req, err := http.NewRequest(http.MethodGet, "/payments", nil)
+		require.NoError(t, err)
+		_, err = ability.SendRequest(req, context.Background())
+		require.ErrorContains(t, err, "HTTP request failed")
+	})
+
+	t.Run("gRPC", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		grpcServer := grpcgo.NewServer(grpcgo.Creds(credentials.NewTLS(pki.serverConfig())))
+		healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+		go func() {
+			_ = grpcServer.Serve(listener)
+		}()
+		defer grpcServer.Stop()
+
+		test := serenity.NewSerenityTest(t)
+		actor := test.ActorCalled("Checkout").WhoCan(grpc.CallAServiceAt(listener.Addr().String(), grpc.WithTLS(
+			tlsconfig.ClientCertificate(pki.certFile, pki.keyFile),
+			tlsconfig.RootCAs(pki.caPEM),
+		)))
+
+		actor.AttemptsTo(
+			grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{}),
+			ensure.That(grpc.LastStatusCode{}, expectations.Equals(codes.OK)),
+		)
+	})
+}
@@ -0,0 +1,65 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/core"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// browser stands for an expensive ability, such as a real browser session
+type browser struct {
+	id        int64
+	discarded atomic.Bool
+}
+
+// Discard closes the browser
+func (b *browser) Discard() error {
+	b.discarded.Store(true)
+	return nil
+}
+
+// TestAbilityPool demonstrates parallel scenarios borrowing abilities from a pool instead of
+// creating one per actor
+func TestAbilityPool(t *testing.T) {
+	var launched atomic.Int64
+	browsers := abilities.NewPool(2, func(ctx context.Context) (*browser, error) {
+		return &browser{id: launched.Add(1)}, nil
+	})
+
+	t.Run("scenarios", func(t *testing.T) {
+		for i := range 6 {
+			t.Run(fmt.Sprintf("shopper %d", i), func(t *testing.T) {
+				t.Parallel()
+				test := serenity.NewSerenityTest(t)
+				actor := test.ActorCalled("Shopper").WhoCan(serenity.Borrow(test, browsers))
+
+				actor.AttemptsTo(core.Do("#actor browses the catalog", func(actor core.Actor, ctx context.Context) error {
+					_, err := core.AbilityOf[*browser](actor)
+					return err
+				}))
+			})
+		}
+	})
+
+	// Never more browsers than the size of the pool, and none closed by the scenarios
+	require.LessOrEqual(t, launched.Load(), int64(2))
+	require.Zero(t, browsers.Lent())
+	require.Equal(t, int(launched.Load()), browsers.Idle())
+
+	idle, err := browsers.Acquire(context.Background())
+	require.NoError(t, err)
+	require.False(t, idle.discarded.Load())
+	require.NoError(t, browsers.Release(idle))
+
+	require.NoError(t, browsers.Close())
+	require.True(t, idle.discarded.Load())
+	_, err = browsers.Acquire(context.Background())
+	require.ErrorIs(t, err, abilities.ErrPoolClosed)
+}
@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestRedirects demonstrates asserting on redirects, followed or not
+func TestRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/old-blog/post", http.RedirectHandler("/blog/post", http.StatusMovedPermanently))
+	mux.Handle("/blog/post", http.RedirectHandler("/blog/post/", http.StatusFound))
+	mux.HandleFunc("/blog/post/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("post"))
+	})
+	mux.Handle("/login", http.RedirectHandler("/sso/authorize?client_id=shop", http.StatusSeeOther))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	reader := test.ActorCalled("Reader").WhoCan(api.CallAnApiAt(server.URL))
+
+	reader.AttemptsTo(
+		api.SendGetRequest("/old-blog/post"),
+		ensure.That(api.LastResponseBody{}, expectations.Equals("post")),
+		ensure.That(api.RedirectChain{}, expectations.Equals([]api.Redirect{
+			{StatusCode: http.StatusMovedPermanently, URL: server.URL + "/blog/post"},
+			{StatusCode: http.StatusFound, URL: server.URL + "/blog/post/"},
+		})),
+
+		api.SendGetRequest("/login").WithoutRedirects(),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusSeeOther)),
+		ensure.That(api.LocationHeader(), api.HasPath("/sso/authorize")),
+		ensure.That(api.RedirectChain{}, expectations.Equals([]api.Redirect{})),
+	)
+
+	crawler := test.ActorCalled("Crawler").WhoCan(api.CallAnApiAt(server.URL, api.WithoutRedirects()))
+
+	crawler.AttemptsTo(
+		api.SendGetRequest("/old-blog/post"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusMovedPermanently)),
+		ensure.That(api.NewResponseHeader("Location"), expectations.Equals("/blog/post")),
+	)
+}
@@ -0,0 +1,79 @@
+package examples
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestHMACSignedRequests demonstrates calling an API that verifies HMAC request signatures
+func TestHMACSignedRequests(t *testing.T) {
+	const key = "webhook-signing-key"
+	t.Setenv("PARTNER_SIGNING_KEY", key)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodyHash := sha256.Sum256(body)
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(strings.Join([]string{r.Method, r.URL.RequestURI(), r.Header.Get("X-Timestamp"), hex.EncodeToString(bodyHash[:])}, "\n")))
+
+		if !hmac.Equal([]byte(r.Header.Get("X-Signature")), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	signingKey := secrets.Ref(secrets.FromEnv("PARTNER_"), "signing-key")
+	actor := test.ActorCalled("Partner").WhoCan(api.CallAnApiAt(server.URL, api.WithRequestSigner(api.HMACSHA256(signingKey))))
+
+	actor.AttemptsTo(
+		api.SendPostRequest("/events?source=partner").WithBody(map[string]string{"type": "order.paid"}),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusAccepted)),
+	)
+}
+
+// TestSigV4SignedRequests demonstrates signing requests to AWS APIs with Signature Version 4
+func TestSigV4SignedRequests(t *testing.T) {
+	var authorization, date string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization, date = r.Header.Get("Authorization"), r.Header.Get("X-Amz-Date")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	credentials := aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG"}, nil
+	})
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Operator").WhoCan(api.CallAnApiAt(server.URL,
+		api.WithRequestSigner(api.SigV4(credentials, "execute-api", "eu-west-1")),
+	))
+
+	actor.AttemptsTo(
+		api.SendGetRequest("/prod/orders"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+	)
+
+	require.NotEmpty(t, date)
+	require.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"+date[:8]+"/eu-west-1/execute-api/aws4_request"), authorization)
+	require.Equal(t, secrets.Masked, secrets.Mask("wJalrXUtnFEMI/K7MDENG"))
+}
@@ -0,0 +1,45 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestStatusClasses demonstrates accepting any status code of a class or from a list
+func TestStatusClasses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/status/"))
+		w.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Client").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.SendDeleteRequest("/status/204"),
+		ensure.That(api.LastResponseStatus{}, expectations.IsSuccessStatus()),
+		ensure.That(api.LastResponseStatus{}, expectations.IsOneOf(200, 202, 204)),
+
+		api.SendGetRequest("/status/409"),
+		ensure.That(api.LastResponseStatus{}, expectations.IsClientError()),
+
+		api.SendGetRequest("/status/503"),
+		ensure.That(api.LastResponseStatus{}, expectations.IsServerError()),
+	)
+
+	require.NoError(t, expectations.IsRedirect().Evaluate(http.StatusSeeOther))
+	err := expectations.IsSuccessStatus().Evaluate(http.StatusNotFound)
+	require.EqualError(t, err, "expected success status (2xx), but got 404 Not Found")
+	require.Error(t, expectations.IsOneOf(200, 201).Evaluate(204))
+}
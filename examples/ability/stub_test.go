@@ -0,0 +1,106 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/stub"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestInProcessStubs demonstrates stubbing a downstream service and verifying the requests it received
+func TestInProcessStubs(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+
+	payments := stub.InProcess()
+	actor := test.ActorCalled("Integrator").WhoCan(
+		payments,
+		api.CallAnApiAt(payments.URL()), // stands in for the system under test calling the stub
+	)
+
+	actor.AttemptsTo(
+		stub.ResetStubs(),
+		stub.StubFor(
+			stub.Post("/payments").WillReturn(201).WithJSONBody(map[string]string{"state": "accepted"}),
+			stub.For(stub.PostRequestsTo("/payments").WithBodyContaining(`"amount":0`)).WillReturn(422),
+		),
+
+		api.SendPostRequest("/payments").WithBody(map[string]int{"amount": 100}),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(201)),
+		ensure.That(api.NewJSONPath("state"), expectations.Equals[interface{}]("accepted")),
+
+		api.SendPostRequest("/payments").WithBody(map[string]int{"amount": 0}),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(422)),
+
+		api.SendGetRequest("/refunds"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(404)),
+
+		ensure.That(stub.NewNumberOfRequestsMatching(stub.PostRequestsTo("/payments")), expectations.Equals(2)),
+		ensure.That(stub.NewNumberOfRequestsMatching(
+			stub.RequestsTo("/payments").WithHeader("Content-Type", "application/json").WithBodyContaining(`"amount":100`),
+		), expectations.Equals(1)),
+	)
+
+	requests, err := stub.NewRequestsReceivedMatching(stub.GetRequestsTo("/refunds")).AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, "GET", requests[0].Method)
+}
+
+// TestWireMockStubs demonstrates the WireMock admin API calls made by the ability
+func TestWireMockStubs(t *testing.T) {
+	var mappings []map[string]interface{}
+	resets := 0
+
+	wiremock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/__admin/mappings":
+			var mapping map[string]interface{}
+			_ = json.Unmarshal(body, &mapping)
+			mappings = append(mappings, mapping)
+			w.WriteHeader(http.StatusCreated)
+		case "/__admin/reset":
+			resets++
+		case "/__admin/requests/find":
+			_, _ = w.Write([]byte(`{"requests": [{"url": "/orders/42?expand=items", "method": "GET",
+				"headers": {"Accept": "application/json"}, "body": ""}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer wiremock.Close()
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	actor := test.ActorCalled("Integrator").WhoCan(stub.UsingWireMock(wiremock.URL))
+
+	actor.AttemptsTo(
+		stub.ResetStubs(),
+		stub.StubFor(stub.Get("/orders/42").WithBody("order 42")),
+		ensure.That(stub.NewNumberOfRequestsMatching(stub.GetRequestsTo("/orders/42")), expectations.Equals(1)),
+	)
+
+	require.Equal(t, 1, resets)
+	require.Equal(t, []map[string]interface{}{{
+		"request":  map[string]interface{}{"method": "GET", "urlPath": "/orders/42"},
+		"response": map[string]interface{}{"status": float64(200), "body": "order 42"},
+	}}, mappings)
+
+	requests, err := stub.NewRequestsReceivedMatching(stub.GetRequestsTo("/orders/42")).AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []stub.ReceivedRequest{{
+		Method:  "GET",
+		Path:    "/orders/42",
+		Query:   "expand=items",
+		Headers: map[string][]string{"Accept": {"application/json"}},
+	}}, requests)
+}
@@ -0,0 +1,63 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestPartialPayloads demonstrates asserting only the fields of a payload a scenario cares about
+func TestPartialPayloads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "ord-42",
+			"status": "paid",
+			"createdAt": "2024-05-01T12:00:00Z",
+			"customer": {"id": 7, "name": "Ada", "email": "ada@example.com"},
+			"items": [{"sku": "tea", "quantity": 2}, {"sku": "mug", "quantity": 1}]
+		}`))
+	}))
+	defer server.Close()
+
+	type customer struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type order struct {
+		Status   string   `json:"status"`
+		Customer customer `json:"customer"`
+		Total    float64  `json:"total"` // zero, so not checked
+	}
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.SendGetRequest("/orders/ord-42"),
+		ensure.That(api.NewResponseBodyAsJSON[any](), expectations.ContainsSubset(map[string]any{
+			"status":   "paid",
+			"customer": map[string]any{"name": "Ada"},
+			"items":    []map[string]any{{"sku": "tea"}, {"sku": "mug"}},
+		})),
+		ensure.That(api.NewResponseBodyAsJSON[any](), expectations.ContainsSubset(order{
+			Status:   "paid",
+			Customer: customer{ID: 7, Name: "Ada"},
+		})),
+	)
+
+	payload := serenity.MustAnswer(actor, api.NewResponseBodyAsJSON[any]())
+	err := expectations.ContainsSubset(map[string]any{
+		"status":   "shipped",
+		"customer": map[string]any{"phone": "555"},
+		"items":    []any{map[string]any{"quantity": 3}, map[string]any{}},
+	}).Evaluate(payload)
+	require.EqualError(t, err, `expected to contain the subset, but customer.phone is missing; items[0].quantity is 2 instead of 3; status is "paid" instead of "shipped"`)
+}
@@ -0,0 +1,102 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/data"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// TestGenerateTestData_IsDeterministic demonstrates that the same seed reproduces the same data
+func TestGenerateTestData_IsDeterministic(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	ctx := context.Background()
+
+	first := test.ActorCalled("First").WhoCan(data.WithSeed(42))
+	second := test.ActorCalled("Second").WhoCan(data.WithSeed(42))
+
+	firstPerson, err := data.APerson().AnsweredBy(first, ctx)
+	require.NoError(t, err)
+	secondPerson, err := data.APerson().AnsweredBy(second, ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, firstPerson, secondPerson)
+	require.Regexp(t, uuidPattern, firstPerson.ID)
+	require.Contains(t, firstPerson.Email, "@"+data.DefaultEmailDomain)
+}
+
+// TestGenerateTestData_ProducesUniqueValues demonstrates values that don't collide between calls
+func TestGenerateTestData_ProducesUniqueValues(t *testing.T) {
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	actor := test.ActorCalled("Generator").WhoCan(data.WithRandomSeed())
+
+	shopEmail, err := data.AnEmailAt("shop.test").AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+	require.Contains(t, shopEmail, "@shop.test")
+
+	emails := make(map[string]bool)
+	ids := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		email, err := data.AnEmail().AnsweredBy(actor, context.Background())
+		require.NoError(t, err)
+		id, err := data.AUUID().AnsweredBy(actor, context.Background())
+		require.NoError(t, err)
+
+		emails[email] = true
+		ids[id] = true
+	}
+
+	require.Len(t, emails, 100)
+	require.Len(t, ids, 100)
+
+	actor.AttemptsTo(
+		ensure.That(data.ANumberBetween(1, 6), expectations.Satisfies("is a dice roll", func(roll int) error {
+			if roll < 1 || roll > 6 {
+				return fmt.Errorf("%d is not between 1 and 6", roll)
+			}
+			return nil
+		})),
+	)
+}
+
+// TestGenerateTestData_WithAPI demonstrates sending a generated person to an API
+func TestGenerateTestData_WithAPI(t *testing.T) {
+	var received data.Person
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	actor := test.ActorCalled("Registrar").WhoCan(
+		api.CallAnApiAt(server.URL),
+		data.WithSeed(7),
+	)
+
+	person, err := data.APerson().WithFirstName("Ada").WithEmailDomain("users.test").AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+
+	actor.AttemptsTo(
+		api.SendPostRequest("/users").WithBody(person),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(201)),
+	)
+
+	require.Equal(t, person, received)
+	require.Equal(t, "Ada", received.FirstName)
+	require.Contains(t, received.Email, "ada.")
+	require.Contains(t, received.Email, "@users.test")
+}
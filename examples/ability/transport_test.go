@@ -0,0 +1,132 @@
+package examples
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/grpc"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestHTTP2 demonstrates forcing HTTP/2 and asserting the protocol actually negotiated
+func TestHTTP2(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("over TLS", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(ok)
+		server.EnableHTTP2 = true
+		server.StartTLS()
+		defer server.Close()
+
+		test := serenity.NewSerenityTest(t)
+		actor := test.ActorCalled("Gateway").WhoCan(api.CallAnApiAt(server.URL, api.WithHTTP2(), api.WithInsecureSkipVerify()))
+
+		actor.AttemptsTo(
+			api.SendGetRequest("/"),
+			ensure.That(api.NegotiatedProtocol{}, expectations.Equals("HTTP/2.0")),
+		)
+	})
+
+	t.Run("cleartext", func(t *testing.T) {
+		server := httptest.NewServer(h2c.NewHandler(ok, &http2.Server{}))
+		defer server.Close()
+
+		test := serenity.NewSerenityTest(t)
+		actor := test.ActorCalled("Gateway").WhoCan(api.CallAnApiAt(server.URL, api.WithH2C()))
+
+		actor.AttemptsTo(
+			api.SendGetRequest("/"),
+			ensure.That(api.NegotiatedProtocol{}, expectations.Equals("HTTP/2.0")),
+		)
+	})
+
+	t.Run("servers without HTTP/2", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(ok)
+		server.Config.ErrorLog = log.New(io.Discard, "", 0) // the rejected handshake is expected
+		server.StartTLS()
+		defer server.Close()
+
+		ability := api.CallAnApiAt(server.URL, api.WithHTTP2(), api.WithInsecureSkipVerify())
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+
+		_, err = ability.SendRequest(req, context.Background())
+		require.Error(t, err)
+	})
+}
+
+// grpcWebHealth stands in for a gRPC-web bridge in front of a health service reporting
+// every service but "payments" as serving
+func grpcWebHealth(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) < 5 || r.Header.Get("Content-Type") != "application/grpc-web+proto" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request healthpb.HealthCheckRequest
+	if err := proto.Unmarshal(body[5:], &request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc-web+proto")
+	if request.GetService() == "payments" {
+		w.Header().Set("Grpc-Status", "5")
+		w.Header().Set("Grpc-Message", "unknown%20service")
+		return
+	}
+
+	message, _ := proto.Marshal(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+	trailers := []byte("grpc-status: 0\r\ngrpc-message: \r\n")
+	for _, frame := range []struct {
+		flag    byte
+		payload []byte
+	}{{0x00, message}, {0x80, trailers}} {
+		header := make([]byte, 5)
+		header[0] = frame.flag
+		binary.BigEndian.PutUint32(header[1:], uint32(len(frame.payload)))
+		_, _ = w.Write(append(header, frame.payload...))
+	}
+}
+
+// TestGRPCWeb demonstrates calling a gRPC service through a gRPC-web bridge
+func TestGRPCWeb(t *testing.T) {
+	bridge := httptest.NewServer(http.HandlerFunc(grpcWebHealth))
+	defer bridge.Close()
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Browser").WhoCan(grpc.CallAServiceViaGRPCWeb(bridge.URL, nil))
+
+	actor.AttemptsTo(
+		grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{}),
+		ensure.That(grpc.LastStatusCode{}, expectations.Equals(codes.OK)),
+
+		grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{Service: "payments"}),
+		ensure.That(grpc.LastStatusCode{}, expectations.Equals(codes.NotFound)),
+		ensure.That(grpc.LastStatusMessage{}, expectations.Equals("unknown service")),
+	)
+
+	actor.AttemptsTo(
+		grpc.Invoke[*healthpb.HealthCheckResponse]("/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{}),
+	)
+	response, err := grpc.NewResponseMessage[*healthpb.HealthCheckResponse]().AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+}
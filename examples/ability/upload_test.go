@@ -0,0 +1,77 @@
+package examples
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestFileUploads demonstrates streaming large files to an upload endpoint
+func TestFileUploads(t *testing.T) {
+	video := bytes.Repeat([]byte("frame"), 1<<20)
+	path := filepath.Join(t.TempDir(), "launch.mp4")
+	require.NoError(t, os.WriteFile(path, video, 0o600))
+
+	var contentLength int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		reader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			digest := sha256.New()
+			size, _ := io.Copy(digest, part)
+			_, _ = fmt.Fprintf(w, "%s=%s:%d:%s\n", part.FormName(), part.FileName(), size, hex.EncodeToString(digest.Sum(nil))[:8])
+		}
+	}))
+	defer server.Close()
+
+	var sent, total int64
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Publisher").WhoCan(api.CallAnApiAt(server.URL))
+
+	digest := sha256.Sum256(video)
+	actor.AttemptsTo(
+		notes.Record("album", "launch"),
+		api.SendPostRequest("/videos").
+			WithFormField("album", "{{album}}").
+			WithFile("video", path).
+			WithUploadProgress(func(s, t int64) { sent, total = s, t }).
+			Templated(),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+		ensure.That(api.LastResponseBody{}, expectations.Contains("album=:6:")),
+		ensure.That(api.BodyLinesMatching(`^video=`), expectations.Equals([]string{
+			fmt.Sprintf("video=launch.mp4:%d:%s", len(video), hex.EncodeToString(digest[:])[:8]),
+		})),
+	)
+
+	require.Equal(t, contentLength, total, "the form size is known before it is streamed")
+	require.Equal(t, total, sent)
+	require.Equal(t, total, serenity.MustAnswer(actor, api.UploadedBytes{}))
+	require.Greater(t, total, int64(len(video)))
+}
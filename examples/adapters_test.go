@@ -0,0 +1,29 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestExpectationAdapters demonstrates using typed and untyped expectations interchangeably
+func TestExpectationAdapters(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Adapter")
+
+	var untyped any = "ord-42"
+	actor.AttemptsTo(
+		ensure.That(answerable.ValueOf(untyped), expectations.ForAny(expectations.Contains("ord-"))),
+		ensure.That(answerable.ValueOf(201), expectations.AsType[int](expectations.IsLessThan(300))),
+		ensure.That(answerable.ValueOf(19.99), expectations.AsType[float64](expectations.EqualsDecimal("19.99"))),
+	)
+
+	err := expectations.ForAny(expectations.Contains("ord-")).Evaluate(42)
+	require.EqualError(t, err, "expected a value of type string, but got int (42)")
+	require.NoError(t, expectations.ForAny(expectations.Equals[[]string](nil)).Evaluate(nil))
+}
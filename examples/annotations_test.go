@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/annotations"
+)
+
+// TestGitHubAnnotations demonstrates surfacing failures inline on pull requests with
+// GitHub Actions workflow commands
+func TestGitHubAnnotations(t *testing.T) {
+	var output bytes.Buffer
+	annotator := annotations.NewAnnotator()
+	annotator.SetOutput(&output)
+	annotator.SetEnabled(true)
+	annotator.SetWorkspace("/home/runner/work/shop")
+
+	// A failed test, whose task failed because of an assertion written in checkout_test.go
+	annotator.OnTestFinish(reporting.NewResult("TestCheckout/pay_by_card", reporting.StatusFailed).
+		WithError(errors.New("test failed")).
+		WithChildren(
+			reporting.NewResult("Buyer browses the catalog", reporting.StatusPassed),
+			reporting.NewResult("Buyer pays by card", reporting.StatusFailed).
+				WithError(errors.New("task 'pays by card' failed")).
+				WithLocation("/home/runner/work/shop/checkout/checkout_test.go:42").
+				WithChildren(reporting.NewResult("Buyer ensures that the status equals 201", reporting.StatusFailed).
+					WithError(errors.New("expected 201, got 402")).
+					WithLocation("/home/runner/work/shop/checkout/checkout_test.go:42"))))
+	// A failed test without a located step is annotated without a file
+	annotator.OnTestFinish(reporting.NewResult("TestRefunds", reporting.StatusFailed).
+		WithError(errors.New("test failed")))
+	// Passed tests are not annotated
+	annotator.OnTestFinish(reporting.NewResult("TestCatalog", reporting.StatusPassed))
+
+	require.Equal(t,
+		"::error file=checkout/checkout_test.go,line=42,title=TestCheckout/pay_by_card::Buyer ensures that the status equals 201%0Aexpected 201, got 402\n"+
+			"::error title=TestRefunds::test failed\n",
+		output.String())
+
+	t.Run("outside GitHub Actions", func(t *testing.T) {
+		output.Reset()
+		annotator.SetEnabled(false)
+		annotator.OnTestFinish(reporting.NewResult("TestRefunds", reporting.StatusFailed))
+
+		require.Empty(t, output.String())
+	})
+}
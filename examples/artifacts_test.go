@@ -0,0 +1,197 @@
+package examples
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/artifacts"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// invoicePDF renders a minimal PDF document with one line of Helvetica text per page
+func invoicePDF(pages ...string) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // the page tree, once the page objects are numbered
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	kids := make([]string, len(pages))
+	for i, text := range pages {
+		content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", len(objects)))
+		kids[i] = fmt.Sprintf("%d 0 R", len(objects))
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var document bytes.Buffer
+	document.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = document.Len()
+		fmt.Fprintf(&document, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := document.Len()
+	fmt.Fprintf(&document, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&document, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&document, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return document.Bytes()
+}
+
+// TestArtifactQuestions demonstrates verifying generated documents, archives and images
+func TestArtifactQuestions(t *testing.T) {
+	var logo bytes.Buffer
+	require.NoError(t, png.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 120, 40))))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/invoices/42.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write(invoicePDF("Invoice 42", "Total: 42.00"))
+		case "/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(logo.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	export := filepath.Join(t.TempDir(), "export.zip")
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for _, name := range []string{"orders.csv", "customers.csv"} {
+		_, err := writer.Create(name)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, os.WriteFile(export, archive.Bytes(), 0o600))
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Accountant").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.SendGetRequest("/invoices/42.pdf"),
+		ensure.That(artifacts.PDFPageCount(artifacts.LastResponseBody()), expectations.Equals(2)),
+		ensure.That(artifacts.PDFTextContent(artifacts.LastResponseBody()), expectations.Equals("Invoice 42\nTotal: 42.00")),
+
+		api.SendGetRequest("/logo.png"),
+		ensure.That(artifacts.ImageDimensions(artifacts.LastResponseBody()), expectations.Equals(artifacts.Dimensions{Width: 120, Height: 40})),
+
+		ensure.That(artifacts.ZipEntries(artifacts.File(export)), expectations.Equals([]string{"orders.csv", "customers.csv"})),
+	)
+
+	_, err := artifacts.PDFPageCount(artifacts.Bytes("a text file", []byte("not a PDF"))).AnsweredBy(actor, test.Context())
+	require.ErrorContains(t, err, "failed to parse PDF")
+}
+
+// exportedOrder is a row of the order exports in the table examples
+type exportedOrder struct {
+	ID       int     `table:"Order ID"`
+	Customer string  `table:"Customer"`
+	Total    float64 `table:"Total"`
+	Paid     bool
+}
+
+// TestTableQuestions demonstrates verifying CSV and Excel exports
+func TestTableQuestions(t *testing.T) {
+	csvExport := artifacts.Bytes("orders.csv", []byte("Order ID,Customer,Total,Paid\n1,Ada,42.50,true\n2,Grace,7,false\n"))
+
+	workbook := excelize.NewFile()
+	require.NoError(t, workbook.SetSheetName("Sheet1", "Orders"))
+	for i, row := range [][]any{{"Order ID", "Customer", "Total", "Paid"}, {1, "Ada", 42.5, true}, {2, "Grace", 7, false}} {
+		require.NoError(t, workbook.SetSheetRow("Orders", fmt.Sprintf("A%d", i+1), &row))
+	}
+	content, err := workbook.WriteToBuffer()
+	require.NoError(t, err)
+	excelExport := artifacts.Bytes("orders.xlsx", content.Bytes())
+
+	expected := []exportedOrder{
+		{ID: 1, Customer: "Ada", Total: 42.5, Paid: true},
+		{ID: 2, Customer: "Grace", Total: 7},
+	}
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Accountant")
+
+	actor.AttemptsTo(
+		ensure.That(artifacts.CSVRecords(csvExport), expectations.HasHeader("Order ID", "Customer", "Total", "Paid")),
+		ensure.That(artifacts.CSVRecords(csvExport), expectations.RowWhere("Customer", "Grace")),
+		ensure.That(artifacts.CSVRecordsAs[exportedOrder](csvExport), expectations.Equals(expected)),
+
+		ensure.That(artifacts.ExcelRows(excelExport, "Orders"), expectations.RowWhere("Order ID", "2")),
+		ensure.That(artifacts.ExcelSheetAs[exportedOrder](excelExport, ""), expectations.Equals(expected)),
+	)
+
+	err = expectations.RowWhere("Customer", "Linus").Evaluate([][]string{{"Customer"}, {"Ada"}, {"Grace"}})
+	require.EqualError(t, err, "expected a row where Customer is 'Linus', but got [Ada, Grace]")
+}
+
+// TestMarkupQuestions demonstrates verifying server-rendered pages and feeds
+func TestMarkupQuestions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = fmt.Fprint(w, `<html><body><h1>Your   orders</h1><ul class="orders">
+				<li><a href="/orders/1">Order <b>1</b></a></li>
+				<li><a href="/orders/2">Order <b>2</b></a></li>
+			</ul></body></html>`)
+		case "/feed.xml":
+			w.Header().Set("Content-Type", "application/rss+xml")
+			_, _ = fmt.Fprint(w, `<?xml version="1.0"?><rss version="2.0"><channel><title>Shop news</title>
+				<item><title>Spring sale</title><link>https://shop.example.com/sale</link></item>
+				<item><title>New arrivals</title><link>https://shop.example.com/new</link></item>
+			</channel></rss>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Shopper").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.SendGetRequest("/orders"),
+		ensure.That(artifacts.HTMLSelect("h1"), expectations.Equals([]string{"Your orders"})),
+		ensure.That(artifacts.HTMLSelect("ul.orders > li"), expectations.Equals([]string{"Order 1", "Order 2"})),
+		ensure.That(artifacts.HTMLSelect("ul.orders a").Attribute("href"), expectations.Equals([]string{"/orders/1", "/orders/2"})),
+		ensure.That(artifacts.HTMLSelect("p.empty"), expectations.Equals([]string{})),
+
+		api.SendGetRequest("/feed.xml"),
+		ensure.That(artifacts.XMLXPath("//item/title"), expectations.Equals([]string{"Spring sale", "New arrivals"})),
+		ensure.That(artifacts.XMLXPath("/rss/@version"), expectations.Equals([]string{"2.0"})),
+		ensure.That(
+			artifacts.XMLXPath("//feed/entry/title").In(artifacts.Bytes("atom.xml", []byte(`<feed><entry><title>Hello</title></entry></feed>`))),
+			expectations.Equals([]string{"Hello"}),
+		),
+	)
+
+	_, err := artifacts.HTMLSelect("ul >").AnsweredBy(actor, test.Context())
+	require.ErrorContains(t, err, `invalid CSS selector "ul >"`)
+}
@@ -0,0 +1,268 @@
+package examples
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+	"github.com/nchursin/serenity-go/serenity/tasks/auth"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// startShop serves a login form, an OAuth2 token endpoint, a SAML assertion consumer
+// service and an /account resource that accepts any of the resulting credentials
+func startShop(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("grant_type") != "password" || r.PostFormValue("password") != "wonderland" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token-` + r.PostFormValue("username") + `","token_type":"bearer"}`))
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("email") != "alice@example.com" || r.PostFormValue("password") != "wonderland" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "form-session"})
+		http.Redirect(w, r, "/account", http.StatusSeeOther)
+	})
+	mux.HandleFunc("/saml/acs", func(w http.ResponseWriter, r *http.Request) {
+		assertion, _ := base64.StdEncoding.DecodeString(r.PostFormValue("SAMLResponse"))
+		if !strings.Contains(string(assertion), "<saml:NameID>alice</saml:NameID>") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "saml-session"})
+		http.Redirect(w, r, r.PostFormValue("RelayState"), http.StatusFound)
+	})
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") == "Bearer token-alice",
+			r.Header.Get("X-API-Key") == "key-123",
+			strings.HasPrefix(r.Header.Get("Cookie"), "session="):
+			_, _ = w.Write([]byte("alice"))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestAuthenticationTasks demonstrates logging in with the reusable auth tasks: each one
+// notes the credential and configures the API ability to send it
+func TestAuthenticationTasks(t *testing.T) {
+	t.Setenv("SHOP_ALICE_PASSWORD", "wonderland")
+	t.Setenv("SHOP_API_KEY", "key-123")
+	password := secrets.Ref(secrets.FromEnv("SHOP_"), "alice-password")
+
+	server := startShop(t)
+
+	passwordGrant := auth.LogInWithPasswordGrant(auth.PasswordGrant{
+		TokenURL: "/oauth/token",
+		ClientID: "shop-tests",
+		Username: "alice",
+		Password: password,
+	})
+	formLogin := auth.LogInWithSessionCookie(auth.FormLogin{
+		URL:           "/login",
+		UsernameField: "email",
+		Username:      "alice@example.com",
+		Password:      password,
+	})
+	samlLogin := auth.LogInWithSAMLStub(auth.SAMLStubLogin{
+		ACSURL:     server.URL + "/saml/acs",
+		NameID:     "alice",
+		Attributes: map[string]string{"role": "customer"},
+		RelayState: "/account",
+	})
+
+	logins := []struct {
+		name    string
+		login   core.Activity
+		subject string
+		noted   string
+	}{
+		{"OAuth2 password grant", passwordGrant, auth.AccessTokenNote, "token-alice"},
+		{"API key", auth.UseAPIKey("X-API-Key", secrets.Ref(secrets.FromEnv("SHOP_"), "api-key")), auth.APIKeyNote, "key-123"},
+		{"session cookie", formLogin, auth.SessionCookieNote, "session=form-session"},
+		{"SAML stub", samlLogin, auth.SessionCookieNote, "session=saml-session"},
+	}
+
+	for _, tc := range logins {
+		t.Run(tc.name, func(t *testing.T) {
+			test := serenity.NewSerenityTestWithContext(context.Background(), t)
+			defer test.Shutdown()
+
+			actor := test.ActorCalled("Alice").WhoCan(api.CallAnApiAt(server.URL))
+
+			actor.AttemptsTo(
+				api.SendGetRequest("/account"),
+				ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusUnauthorized)),
+
+				tc.login,
+				ensure.That(notes.Recall[string](tc.subject), expectations.Equals(tc.noted)),
+
+				api.SendGetRequest("/account"),
+				ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+			)
+		})
+	}
+}
+
+// TestAuthenticationFailure demonstrates that a rejected login fails the task without
+// revealing the password
+func TestAuthenticationFailure(t *testing.T) {
+	t.Setenv("SHOP_ALICE_PASSWORD", "looking-glass")
+	server := startShop(t)
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Alice").WhoCan(api.CallAnApiAt(server.URL))
+
+	err := auth.LogInWithSessionCookie(auth.FormLogin{
+		URL:           "/login",
+		UsernameField: "email",
+		Username:      "alice@example.com",
+		Password:      secrets.Ref(secrets.FromEnv("SHOP_"), "alice-password"),
+	}).PerformAs(actor, test.Context())
+
+	require.EqualError(t, err, "login failed with status 401")
+	assert.NotContains(t, err.Error(), "looking-glass")
+}
+
+// TestSwitchingRoles demonstrates switching credentials mid-scenario, as in permission
+// matrix tests: each role logs in once and its credentials are swapped back in later
+func TestSwitchingRoles(t *testing.T) {
+	t.Setenv("SHOP_ALICE_PASSWORD", "wonderland")
+	t.Setenv("SHOP_API_KEY", "key-123")
+	server := startShop(t)
+
+	logins := 0
+	counted := func(login core.Activity) core.Activity {
+		return core.Do(login.Description(), func(actor core.Actor, ctx context.Context) error {
+			logins++
+			return login.PerformAs(actor, ctx)
+		})
+	}
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Tester").WhoCan(
+		api.CallAnApiAt(server.URL),
+		auth.SwitchBetween(
+			auth.Role{Name: "customer", LogIn: counted(auth.LogInWithPasswordGrant(auth.PasswordGrant{
+				TokenURL: "/oauth/token",
+				ClientID: "shop-tests",
+				Username: "alice",
+				Password: secrets.Ref(secrets.FromEnv("SHOP_"), "alice-password"),
+			}))},
+			auth.Role{Name: "integration", LogIn: counted(auth.UseAPIKey("X-API-Key", secrets.Ref(secrets.FromEnv("SHOP_"), "api-key")))},
+			auth.Role{Name: "anonymous"},
+		),
+	)
+
+	actor.AttemptsTo(
+		auth.SwitchToRole("customer"),
+		ensure.That(auth.CurrentRole{}, expectations.Equals("customer")),
+		api.SendGetRequest("/account"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+
+		auth.SwitchToRole("anonymous"),
+		api.SendGetRequest("/account"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusUnauthorized)),
+
+		auth.SwitchToRole("integration"),
+		api.SendGetRequest("/account"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+
+		auth.SwitchToRole("customer"),
+		ensure.That(notes.Recall[string](auth.RoleNote), expectations.Equals("customer")),
+		api.SendGetRequest("/account"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+	)
+
+	headers, err := core.AbilityOf[api.DefaultHeaders](actor)
+	require.NoError(t, err)
+	assert.Equal(t, http.Header{"Authorization": {"Bearer token-alice"}}, headers.DefaultHeaderValues())
+
+	role, _ := core.AttributeOf(actor, auth.RoleAttribute)
+	assert.Equal(t, "customer", role)
+	assert.Equal(t, 2, logins, "switching back to a role should not log in again")
+
+	err = auth.SwitchToRole("owner").PerformAs(actor, test.Context())
+	require.EqualError(t, err, "unknown role owner, the actor can switch to anonymous, customer, integration")
+}
+
+// TestPermissionMatrix demonstrates expanding roles, endpoints and expected statuses into
+// one reported scenario per combination
+func TestPermissionMatrix(t *testing.T) {
+	t.Setenv("SHOP_ALICE_PASSWORD", "wonderland")
+	t.Setenv("SHOP_API_KEY", "key-123")
+	shop := startShop(t)
+
+	orders := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		customer := r.Header.Get("Authorization") == "Bearer token-alice"
+		integration := r.Header.Get("X-API-Key") == "key-123"
+		switch {
+		case !customer && !integration:
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodDelete && !customer:
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer orders.Close()
+
+	logins := 0
+	counted := func(login core.Activity) core.Activity {
+		return core.Do(login.Description(), func(actor core.Actor, ctx context.Context) error {
+			logins++
+			return login.PerformAs(actor, ctx)
+		})
+	}
+
+	auth.PermissionMatrix{
+		Endpoints: []auth.Endpoint{
+			{Name: "list orders", Request: api.SendGetRequest("/orders")},
+			{Request: api.SendDeleteRequest("/orders/42")},
+		},
+		Permissions: []auth.Permissions{
+			{Role: auth.Role{Name: "customer", LogIn: counted(auth.LogInWithPasswordGrant(auth.PasswordGrant{
+				TokenURL: shop.URL + "/oauth/token",
+				ClientID: "shop-tests",
+				Username: "alice",
+				Password: secrets.Ref(secrets.FromEnv("SHOP_"), "alice-password"),
+			}))}, Statuses: []int{http.StatusOK, http.StatusNoContent}},
+			{Role: auth.Role{Name: "integration", LogIn: counted(auth.UseAPIKey("X-API-Key", secrets.Ref(secrets.FromEnv("SHOP_"), "api-key")))},
+				Statuses: []int{http.StatusOK, http.StatusForbidden}},
+			{Role: auth.Role{Name: "anonymous"}, Statuses: []int{http.StatusUnauthorized, http.StatusUnauthorized}},
+		},
+	}.Run(t, func(test serenity.SerenityTest) core.Actor {
+		return test.ActorCalled("Tester").WhoCan(api.CallAnApiAt(orders.URL))
+	})
+
+	assert.Equal(t, 2, logins, "each role should log in once per matrix")
+}
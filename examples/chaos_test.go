@@ -0,0 +1,109 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/chaos"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// fetchOrderWithRetries is a task with a simple retry policy, the kind of code chaos testing verifies
+func fetchOrderWithRetries(attempts int) core.Activity {
+	return core.Do("#actor fetches the order, retrying on failure", func(actor core.Actor, ctx context.Context) error {
+		var err error
+		for attempt := 0; attempt < attempts; attempt++ {
+			if err = api.SendGetRequest("/orders/1").PerformAs(actor, ctx); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("order not fetched after %d attempts: %w", attempts, err)
+	})
+}
+
+// TestRetryPolicySurvivesInjectedFailures demonstrates verifying a retry policy against an unreliable network
+func TestRetryPolicySurvivesInjectedFailures(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"id": 1, "status": "shipped"}`))
+	}))
+	defer server.Close()
+
+	monkey := chaos.NewMonkey(7).Failing(30).DroppingResponses(20)
+
+	test := serenity.NewSerenityTest(t)
+	callAnAPI := api.Using(&http.Client{Transport: monkey.Transport(nil)})
+	require.NoError(t, callAnAPI.SetBaseURL(server.URL))
+	actor := test.ActorCalled("Customer").WhoCan(callAnAPI)
+
+	for i := 0; i < 10; i++ {
+		actor.AttemptsTo(
+			fetchOrderWithRetries(10),
+			ensure.That(api.LastResponseStatus{}, expectations.Equals(200)),
+		)
+	}
+
+	injections := monkey.Injections()
+	require.Positive(t, injections.Failed+injections.Dropped)
+	require.Equal(t, int64(injections.Intercepted-injections.Failed), requests.Load())
+}
+
+// TestChaosAroundActivities demonstrates injecting faults into arbitrary activities
+func TestChaosAroundActivities(t *testing.T) {
+	var performed int
+	placeOrder := core.Do("#actor places an order", func(actor core.Actor, ctx context.Context) error {
+		performed++
+		return nil
+	})
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Customer")
+
+	t.Run("dropped responses still reach the system", func(t *testing.T) {
+		dropping := chaos.NewMonkey(1).DroppingResponses(100).Around(placeOrder)
+
+		err := dropping.PerformAs(actor, test.Context())
+
+		require.ErrorIs(t, err, chaos.ErrDroppedResponse)
+		require.Equal(t, "#actor places an order", dropping.Description())
+		require.Equal(t, 1, performed)
+	})
+
+	t.Run("injected failures prevent the activity", func(t *testing.T) {
+		err := chaos.NewMonkey(1).Failing(100).Around(placeOrder).PerformAs(actor, test.Context())
+
+		require.ErrorIs(t, err, chaos.ErrInjectedFailure)
+		require.Equal(t, 1, performed)
+	})
+
+	t.Run("latency delays the activity", func(t *testing.T) {
+		slow := chaos.NewMonkey(1).AddingLatency(20*time.Millisecond, 30*time.Millisecond, 100).Around(placeOrder)
+
+		start := time.Now()
+		actor.AttemptsTo(slow)
+
+		require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		require.Equal(t, 2, performed)
+	})
+
+	t.Run("cancelled context interrupts the latency", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := chaos.NewMonkey(1).AddingLatency(time.Hour, time.Hour, 100).Around(placeOrder).PerformAs(actor, ctx)
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
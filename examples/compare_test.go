@@ -0,0 +1,66 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/compare"
+	"github.com/nchursin/serenity-go/serenity/core"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// productService serves the product as the given version of the service renders it
+func productService(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+// TestComparingEnvironments demonstrates validating a migration by comparing the responses
+// of the legacy service and its rewrite
+func TestComparingEnvironments(t *testing.T) {
+	legacy := productService(`{"id": "a1", "name": "Book", "price": 10, "generatedAt": "monday"}`)
+	defer legacy.Close()
+	rewrite := productService(`{"price": 10, "name": "Book", "id": "b2", "generatedAt": "tuesday"}`)
+	defer rewrite.Close()
+
+	t.Run("equivalent responses", func(t *testing.T) {
+		test := serenity.NewSerenityTest(t)
+		actor := test.ActorCalled("MigrationEngineer").WhoCan(api.CallAnApiAt(legacy.URL))
+
+		actor.AttemptsTo(
+			compare.BaseURLs(legacy.URL, rewrite.URL).
+				Named("legacy", "rewrite").
+				Performing(api.SendGetRequest("/products/42")).
+				Ignoring("body.id", "body.generatedAt"),
+		)
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		test := serenity.NewSerenityTest(t)
+		actor := test.ActorCalled("MigrationEngineer").WhoCan(api.CallAnApiAt(legacy.URL))
+
+		comparison := compare.BaseURLs(legacy.URL, rewrite.URL).
+			Named("legacy", "rewrite").
+			Performing(api.SendGetRequest("/products/42")).
+			Ignoring("body.generatedAt")
+		err := comparison.PerformAs(actor, context.Background())
+
+		var assertion *core.AssertionError
+		require.True(t, errors.As(err, &assertion))
+		require.Contains(t, err.Error(), `body.id: "a1" != "b2"`)
+		require.Equal(t, []compare.Difference{{Path: "body.id", Baseline: "a1", Candidate: "b2"}}, comparison.Differences())
+
+		// The actor keeps calling the environment it was set up with
+		ability, err := core.AbilityOf[api.CallAnAPI](actor)
+		require.NoError(t, err)
+		require.Equal(t, legacy.URL, ability.GetBaseURL())
+	})
+}
@@ -0,0 +1,54 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/reporting/dashboard"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestDashboard demonstrates the live summary of a run, and its plain fallback
+func TestDashboard(t *testing.T) {
+	checkout := func(t *testing.T, board *dashboard.Dashboard) {
+		t.Run("checkout", func(t *testing.T) {
+			test := serenity.NewSerenityTestWithReporter(context.Background(), t, board)
+			test.ActorCalled("Buyer").AttemptsTo(core.Do("#actor pays", func(actor core.Actor, ctx context.Context) error {
+				return nil
+			}))
+		})
+	}
+
+	t.Run("plain output when not attached to a terminal", func(t *testing.T) {
+		var output bytes.Buffer
+		board := dashboard.New()
+		board.SetOutput(&output)
+		defer board.Close()
+		require.False(t, board.Live())
+
+		checkout(t, board)
+
+		require.Contains(t, output.String(), "🚀 Starting: TestDashboard/plain_output_when_not_attached_to_a_terminal/checkout")
+		require.Contains(t, output.String(), "✅ Buyer pays")
+	})
+
+	t.Run("live summary on a terminal", func(t *testing.T) {
+		var output bytes.Buffer
+		board := dashboard.New()
+		board.SetOutput(&output)
+		board.SetLive(true)
+
+		checkout(t, board)
+		board.Close()
+
+		require.Contains(t, output.String(), "⠋ TestDashboard/live_summary_on_a_terminal/checkout")
+		require.Contains(t, output.String(), "    ⠋ Buyer pays")
+		require.Contains(t, output.String(), "✅ TestDashboard/live_summary_on_a_terminal/checkout (")
+		require.Contains(t, output.String(), "✅ 1 passed  ❌ 0 failed  ⏭️ 0 skipped")
+		require.Contains(t, output.String(), "\033[J", "the summary is redrawn in place")
+	})
+}
@@ -0,0 +1,68 @@
+package examples
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/coverage"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestEndpointCoverage demonstrates comparing the requests of the tests with the operations
+// of an OpenAPI spec to find the untested ones
+func TestEndpointCoverage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	output := t.TempDir()
+	settings, err := config.RunSettings{
+		Reporters: []string{"coverage"},
+		OutputDir: output,
+		Retries:   config.RetryPolicy{Attempts: 1},
+	}.WithReporterSettings("coverage", coverage.Settings{OpenAPI: "testdata/openapi/petstore.yaml"})
+	require.NoError(t, err)
+	runWithSettings(t, settings, func(t *testing.T) {
+		t.Run("browsing", func(t *testing.T) {
+			buyer := serenity.NewSerenityTest(t).ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL + "/api/v1/"))
+			buyer.AttemptsTo(
+				api.SendGetRequest("pets"),
+				api.SendGetRequest("pets/mine"),
+				api.SendGetRequest("pets/42"),
+				api.SendGetRequest("health"),
+			)
+		})
+		t.Run("adopting", func(t *testing.T) {
+			buyer := serenity.NewSerenityTest(t).ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL + "/api/v1/"))
+			buyer.AttemptsTo(
+				api.SendPostRequest("pets"),
+				api.SendGetRequest("pets"),
+			)
+		})
+	})
+
+	path, err := reporting.OutputDirAt(output).Path(coverage.ReportFileName)
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report coverage.Report
+	require.NoError(t, json.Unmarshal(content, &report))
+
+	calls := make(map[string]int)
+	for _, operation := range report.Operations {
+		calls[operation.OperationID] = operation.Calls
+	}
+	require.Equal(t, map[string]int{"listPets": 2, "createPet": 1, "listMyPets": 1, "showPetById": 1, "deletePet": 0}, calls)
+	require.Equal(t, 4, report.Covered())
+	require.Len(t, report.Uncovered, 1)
+	require.Equal(t, "/api/v1/health", report.Uncovered[0].Path)
+}
@@ -0,0 +1,113 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestTypedErrorsCanBeInspected demonstrates branching on failure categories with errors.Is and errors.As
+func TestTypedErrorsCanBeInspected(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Inspector")
+	ctx := context.Background()
+
+	t.Run("missing ability", func(t *testing.T) {
+		err := api.SendGetRequest("/orders").PerformAs(actor, ctx)
+
+		require.ErrorIs(t, err, core.ErrMissingAbility)
+
+		var missing *core.MissingAbilityError
+		require.ErrorAs(t, err, &missing)
+		require.Equal(t, "Inspector", missing.Actor)
+		require.Equal(t, "api.CallAnAPI", missing.Requested)
+		require.Empty(t, missing.Available)
+	})
+
+	t.Run("assertion inside a task", func(t *testing.T) {
+		checkTotal := core.TaskWhere("#actor checks the order total",
+			ensure.That(answerOf("order total", 42), expectations.Equals(40)),
+		)
+
+		err := checkTotal.PerformAs(actor, ctx)
+
+		var activityErr *core.ActivityError
+		require.ErrorAs(t, err, &activityErr)
+		require.Equal(t, "Inspector", activityErr.Actor)
+		require.Equal(t, "#actor checks the order total", activityErr.Activity)
+		require.Equal(t, "#actor ensures that asks order total equals 40", activityErr.Step)
+
+		var assertion *core.AssertionError
+		require.ErrorAs(t, err, &assertion)
+		require.Equal(t, 40, assertion.Expected)
+		require.Equal(t, 42, assertion.Actual)
+
+		require.True(t, errors.Is(err, core.ErrActivityFailed))
+		require.True(t, errors.Is(err, core.ErrAssertionFailed))
+		require.False(t, errors.Is(err, core.ErrMissingAbility))
+	})
+
+	t.Run("custom validation", func(t *testing.T) {
+		err := ensure.That(answerOf("order total", -3), expectations.Satisfies("is positive", func(actual int) error {
+			if actual <= 0 {
+				return errors.New("total must be positive")
+			}
+			return nil
+		})).PerformAs(actor, ctx)
+
+		var assertion *core.AssertionError
+		require.ErrorAs(t, err, &assertion)
+		require.Equal(t, "is positive", assertion.Expected)
+		require.Equal(t, -3, assertion.Actual)
+		require.EqualError(t, err, "assertion failed for 'asks order total': total must be positive")
+	})
+
+	t.Run("described mismatch", func(t *testing.T) {
+		err := ensure.That(answerOf("basket items", []string{"tea", "milk", "jam"}), atMostItems(2)).PerformAs(actor, ctx)
+
+		var assertion *core.AssertionError
+		require.ErrorAs(t, err, &assertion)
+		require.Equal(t, []string{"tea", "milk", "jam"}, assertion.Actual)
+		require.EqualError(t, err, "assertion failed for 'asks basket items': got 3 items, 1 more than allowed")
+	})
+}
+
+// atMostItemsExpectation limits the length of a slice and explains by how much it is exceeded
+type atMostItemsExpectation struct {
+	limit int
+}
+
+func atMostItems(limit int) atMostItemsExpectation {
+	return atMostItemsExpectation{limit: limit}
+}
+
+func (e atMostItemsExpectation) Evaluate(actual []string) error {
+	if len(actual) > e.limit {
+		return core.NewAssertionError(e.limit, len(actual), "expected at most %d items, but got %d", e.limit, len(actual))
+	}
+	return nil
+}
+
+func (e atMostItemsExpectation) Description() string {
+	return fmt.Sprintf("has at most %d items", e.limit)
+}
+
+func (e atMostItemsExpectation) DescribeMismatch(actual []string) string {
+	return fmt.Sprintf("got %d items, %d more than allowed", len(actual), len(actual)-e.limit)
+}
+
+// answerOf creates a question with a fixed answer
+func answerOf[T any](description string, answer T) core.Question[T] {
+	return core.Of(description, func(actor core.Actor, ctx context.Context) (T, error) {
+		return answer, nil
+	})
+}
@@ -0,0 +1,88 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// metricsListener is a custom listener counting events, as a metrics exporter would
+type metricsListener struct {
+	mutex    sync.Mutex
+	counts   map[string]int
+	failures []string
+}
+
+func (m *metricsListener) Notify(event events.Event) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.counts[event.Kind()]++
+	if failed, ok := event.(events.AssertionFailed); ok {
+		m.failures = append(m.failures, failed.Actor+": "+failed.Assertion)
+	}
+}
+
+// TestCustomListenerObservesActors demonstrates subscribing a custom listener to the event bus
+func TestCustomListenerObservesActors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	metrics := &metricsListener{counts: make(map[string]int)}
+
+	t.Run("scenario", func(t *testing.T) {
+		test := serenity.NewSerenityTest(t)
+		test.Events().Subscribe(metrics)
+
+		actor := test.ActorCalled("Observer").WhoCan(api.CallAnApiAt(server.URL))
+		actor.AttemptsTo(
+			api.SendPostRequest("/orders"),
+			ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusAccepted)),
+		)
+
+		// Assertions performed outside of AttemptsTo still publish their failure
+		err := ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusCreated)).
+			PerformAs(actor, context.Background())
+		require.Error(t, err)
+	})
+
+	require.Equal(t, 1, metrics.counts["ActorStarted"])
+	require.Equal(t, 2, metrics.counts["ActivityStarted"])
+	require.Equal(t, 2, metrics.counts["ActivityFinished"])
+	require.Equal(t, 2, metrics.counts["QuestionAnswered"])
+	require.Equal(t, 1, metrics.counts["TestFinished"])
+	require.Equal(t, []string{"Observer: #actor ensures that the last response status code equals 201"}, metrics.failures)
+}
+
+// TestPublishingCustomEvents demonstrates activities announcing events through core.Publish
+func TestPublishingCustomEvents(t *testing.T) {
+	var answers []events.QuestionAnswered
+
+	test := serenity.NewSerenityTest(t)
+	test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+		if answered, ok := event.(events.QuestionAnswered); ok {
+			answers = append(answers, answered)
+		}
+	}))
+
+	actor := test.ActorCalled("Auditor")
+	actor.AttemptsTo(core.Do("#actor checks the audit log", func(actor core.Actor, ctx context.Context) error {
+		core.Publish(actor, events.QuestionAnswered{Actor: actor.Name(), Question: "audit entries", Answer: 3})
+		return nil
+	}))
+
+	require.Equal(t, []events.QuestionAnswered{{Actor: "Auditor", Question: "audit entries", Answer: 3}}, answers)
+}
@@ -0,0 +1,38 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestFieldFormats demonstrates checking the format of generated field values
+func TestFieldFormats(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Validator")
+
+	actor.AttemptsTo(
+		ensure.That(answerable.ValueOf("123e4567-e89b-12d3-a456-426614174000"), expectations.IsUUID()),
+		ensure.That(answerable.ValueOf("01ARZ3NDEKTSV4RRFFQ69G5FAV"), expectations.IsULID()),
+		ensure.That(answerable.ValueOf("2024-05-01T14:30:00.123+02:00"), expectations.IsISO8601Timestamp()),
+		ensure.That(answerable.ValueOf("ada@example.com"), expectations.IsEmailAddress()),
+		ensure.That(answerable.ValueOf("https://shop.example.com/orders/42"), expectations.IsURL()),
+	)
+
+	failures := map[string]error{
+		"expected a UUID, but '123e4567e89b12d3a456426614174000' has 32 characters instead of 36":                                    expectations.IsUUID().Evaluate("123e4567e89b12d3a456426614174000"),
+		"expected a UUID, but '123e4567-e89b-12d3-a456-42661417400g' has the non-hexadecimal character 'g' at position 36":           expectations.IsUUID().Evaluate("123e4567-e89b-12d3-a456-42661417400g"),
+		"expected a ULID, but '01ARZ3NDEKTSV4RRFFQ69G5FAU' has the character 'U' at position 26, which is not in Crockford's base32": expectations.IsULID().Evaluate("01ARZ3NDEKTSV4RRFFQ69G5FAU"),
+		"expected an ISO 8601 timestamp, but '2024-05-01T14:30:00' has no time zone, such as Z or +02:00":                            expectations.IsISO8601Timestamp().Evaluate("2024-05-01T14:30:00"),
+		"expected an email address, but 'Ada <ada@example.com>' is not a bare address, which would be 'ada@example.com'":             expectations.IsEmailAddress().Evaluate("Ada <ada@example.com>"),
+		"expected a URL, but '/orders/42' has no scheme, such as https://":                                                           expectations.IsURL().Evaluate("/orders/42"),
+	}
+	for message, err := range failures {
+		require.EqualError(t, err, message)
+	}
+}
@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// isNotAServerError accepts any status a robust service may answer malformed input with
+var isNotAServerError = expectations.Satisfies("is not a server error", func(status int) error {
+	if status >= http.StatusInternalServerError {
+		return fmt.Errorf("got %d", status)
+	}
+	return nil
+})
+
+// FuzzCreateOrder demonstrates fuzzing the body of a POST request: go test runs the seed
+// corpus, go test -fuzz FuzzCreateOrder ./examples generates further bodies
+func FuzzCreateOrder(f *testing.F) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var order map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	f.Add(`{"item": "book"}`)
+	f.Add(`{"item": `)
+	f.Add(`[]`)
+
+	serenity.Fuzz(f, func(test serenity.SerenityTest, body string) {
+		actor := test.ActorCalled("Attacker").WhoCan(api.CallAnApiAt(server.URL))
+
+		actor.AttemptsTo(
+			api.SendPostRequest("/orders").WithBody(body),
+			ensure.That(api.LastResponseStatus{}, isNotAServerError),
+		)
+	})
+}
@@ -0,0 +1,82 @@
+package examples
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/clock"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/tasks/health"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestHealthChecks demonstrates synchronizing on services that take time to start
+func TestHealthChecks(t *testing.T) {
+	// The service becomes ready on the third probe
+	var probes atomic.Int32
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if probes.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer service.Close()
+
+	database, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		_ = database.Close()
+	}()
+
+	t.Run("waiting reports the time waited", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithContext(context.Background(), t)
+		defer test.Shutdown()
+
+		waited := map[string]any{}
+		test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+			if answered, ok := event.(events.QuestionAnswered); ok {
+				waited[answered.Question] = answered.Answer
+			}
+		}))
+
+		actor := test.ActorCalled("Operator").WhoCan(clock.UseVirtualTime(time.Now()))
+
+		actor.AttemptsTo(
+			health.VerifyDependenciesHealthy(time.Minute,
+				health.Dependency{Name: "database", Target: "tcp://" + database.Addr().String()},
+			),
+			health.WaitForServiceReady(service.URL, 30*time.Second),
+		)
+
+		require.Equal(t, map[string]any{
+			"the time waited for database to be ready":            time.Duration(0),
+			"the time waited for " + service.URL + " to be ready": 300 * time.Millisecond,
+		}, waited)
+	})
+
+	t.Run("unhealthy dependencies are listed", func(t *testing.T) {
+		closed, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := closed.Addr().String()
+		require.NoError(t, closed.Close())
+
+		test := serenity.NewSerenityTestWithContext(context.Background(), t)
+		defer test.Shutdown()
+
+		actor := test.ActorCalled("Operator").WhoCan(clock.UseVirtualTime(time.Now()))
+
+		err = health.VerifyDependenciesHealthy(time.Second,
+			health.Dependency{Name: "cache", Target: address},
+			health.Dependency{Name: "database", Target: "tcp://" + database.Addr().String()},
+		).PerformAs(actor, test.Context())
+
+		require.ErrorContains(t, err, "cache is not ready after 1s and 5 attempts")
+		require.NotContains(t, err.Error(), "database")
+	})
+}
@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/reporting/history"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestRecordingHistory demonstrates accumulating the results of runs to follow their trends
+func TestRecordingHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	settings, err := config.RunSettings{
+		Reporters: []string{"history"},
+		OutputDir: t.TempDir(),
+		Retries:   config.RetryPolicy{Attempts: 1},
+	}.WithReporterSettings("history", history.Settings{Store: path})
+	require.NoError(t, err)
+	runWithSettings(t, settings, func(t *testing.T) {
+		t.Run("catalog", func(t *testing.T) {
+			serenity.NewSerenityTest(t).ActorCalled("Buyer").AttemptsTo(
+				core.Do("#actor browses the catalog", func(actor core.Actor, ctx context.Context) error {
+					return nil
+				}),
+			)
+		})
+	})
+
+	store, err := history.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		_ = store.Close()
+	}()
+	runs, err := store.Runs(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, runs, 1)
+	require.Len(t, runs[0].Tests, 1)
+	require.Equal(t, "TestRecordingHistory/catalog", runs[0].Tests[0].Name)
+	require.Equal(t, history.Passed, runs[0].Tests[0].Status)
+
+	trends, err := history.Compute(runs, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, 1.0, trends.Runs[0].PassRate())
+}
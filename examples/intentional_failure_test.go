@@ -20,7 +20,7 @@ func TestIntentionalFailure(t *testing.T) {
 	// Create mock TestContext
 	mockCtx := mocks.NewMockTestContext(ctrl)
 
-	mockCtx.EXPECT().Helper().Times(2)
+	mockCtx.EXPECT().Helper().Times(3)
 	mockCtx.EXPECT().Cleanup(gomock.Any())
 
 	// Expect Name() to be called during test initialization
@@ -33,6 +33,9 @@ func TestIntentionalFailure(t *testing.T) {
 	// We expect it to be called exactly once with any format string and arguments
 	mockCtx.EXPECT().Errorf(gomock.Any(), gomock.Any()).Times(1)
 
+	// Failed assertions are critical by default, so the test is stopped with FailNow
+	mockCtx.EXPECT().FailNow().Times(1)
+
 	// Create SerenityTest with mock context
 	test := serenity.NewSerenityTest(mockCtx)
 	// Call it manually to do it before mocks
@@ -46,6 +49,6 @@ func TestIntentionalFailure(t *testing.T) {
 		ensure.That(api.LastResponseStatus{}, expectations.Equals(404)), // This will fail and call Errorf
 	)
 
-	// The test will pass because we're using a mock, but the expectation will be verified
-	// automatically when ctrl.Finish() is called, ensuring Errorf was called exactly once.
+	// The test will pass because we're using a mock, but the expectations will be verified
+	// automatically when ctrl.Finish() is called, ensuring Errorf and FailNow were called once.
 }
@@ -0,0 +1,111 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/load"
+	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// browseCatalog is a functional task reused unchanged by the load test
+func browseCatalog() core.Activity {
+	return core.TaskWhere("#actor browses the catalog",
+		api.SendGetRequest("/products"),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(200)),
+	)
+}
+
+// TestSwarmReusesFunctionalTasks demonstrates running a functional task as a load test
+func TestSwarmReusesFunctionalTasks(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every tenth request fails
+		if requests.Add(1)%10 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	reporter := console_reporter.NewConsoleReporter()
+	reporter.SetOutput(&output)
+
+	test := serenity.NewSerenityTestWithReporter(context.Background(), t, reporter)
+	actor := test.ActorCalled("PerformanceEngineer")
+
+	swarm := load.Swarm("catalog browsing", 5).
+		WhoCan(func(index int) []abilities.Ability {
+			return []abilities.Ability{api.CallAnApiAt(server.URL)}
+		}).
+		Performing(browseCatalog()).
+		Iterations(20).
+		ExpectingErrorRateBelow(0.2).
+		ExpectingPercentileBelow(95, time.Second)
+
+	actor.AttemptsTo(swarm)
+
+	result := swarm.Result()
+	require.Equal(t, 100, result.Iterations)
+	require.Equal(t, 10, result.Errors)
+	require.InDelta(t, 0.1, result.ErrorRate(), 1e-9)
+	require.Less(t, result.Percentile(95), time.Second)
+
+	capturedOutput := output.String()
+	require.Contains(t, capturedOutput, "PerformanceEngineer unleashes a swarm of 5 actors performing catalog browsing")
+	require.Contains(t, capturedOutput, "PerformanceEngineer measures 100 iterations by 5 actors")
+	require.Contains(t, capturedOutput, "errors 10.00%")
+}
+
+// TestSwarmThresholds demonstrates the failure reported when a threshold is exceeded
+func TestSwarmThresholds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	swarm := load.Swarm("failing checkout", 2).
+		WhoCan(func(index int) []abilities.Ability {
+			return []abilities.Ability{api.CallAnApiAt(server.URL)}
+		}).
+		Performing(browseCatalog()).
+		For(50 * time.Millisecond).
+		ExpectingErrorRateBelow(0.01)
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	actor := test.ActorCalled("PerformanceEngineer")
+
+	err := swarm.PerformAs(actor, context.Background())
+	require.ErrorContains(t, err, "error rate 100.00% is not below 1.00% (most frequent: ")
+	require.ErrorContains(t, err, "expected 200, but got 500")
+	require.Positive(t, swarm.Result().Iterations)
+}
+
+// BenchmarkBrowseCatalog demonstrates reusing a functional task as a benchmark:
+// go test -bench BrowseCatalog ./examples reports ns/op, allocations and latency percentiles
+func BenchmarkBrowseCatalog(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityBenchmark(b)
+	actor := test.ActorCalled("PerformanceEngineer").WhoCan(api.CallAnApiAt(server.URL))
+
+	serenity.Benchmark(b, actor, browseCatalog())
+}
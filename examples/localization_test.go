@@ -0,0 +1,31 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestLocalizedText demonstrates comparing localized strings where byte equality is wrong
+func TestLocalizedText(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Translator")
+
+	decomposed := "Cafe\u0301 Mu\u0308ller" // as returned by some macOS file systems and APIs
+	actor.AttemptsTo(
+		ensure.That(answerable.ValueOf(decomposed), expectations.EqualsText("Café Müller")),
+		ensure.That(answerable.ValueOf("Crème Brûlée"), expectations.EqualsText("creme brulee", expectations.IgnoringDiacritics(), expectations.IgnoringCase())),
+		ensure.That(answerable.ValueOf("Ærø"), expectations.EqualsText("ærø", expectations.CollatedIn("da"), expectations.IgnoringCase())),
+	)
+
+	require.Error(t, expectations.EqualsText("Café").Evaluate("Cafe"))
+	require.Error(t, expectations.EqualsText("a", expectations.CollatedIn("not a locale!")).Evaluate("a"))
+	require.Error(t, expectations.EqualsText("Ærø", expectations.CollatedIn("da")).Evaluate("ærø"))
+	require.Equal(t, "equals text (in da, ignoring case) 'ærø'",
+		expectations.EqualsText("ærø", expectations.CollatedIn("da"), expectations.IgnoringCase()).Description())
+}
@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+func init() {
+	expectations.RegisterMatcher(expectations.MatcherInfo{
+		Library:     "github.com/nchursin/serenity-go/examples",
+		Name:        "IsValidIBAN",
+		Description: "a valid IBAN",
+		AnswerType:  "string",
+	})
+}
+
+// IsValidIBAN is a domain matcher, as published by a team's expectation library
+func IsValidIBAN() ensure.Expectation[string] {
+	return expectations.NewMatcher("a valid IBAN", func(actual string) string {
+		iban := strings.ReplaceAll(actual, " ", "")
+		if len(iban) < 15 || len(iban) > 34 {
+			return fmt.Sprintf("'%s' has %d characters", actual, len(iban))
+		}
+
+		var digits strings.Builder
+		for _, c := range iban[4:] + iban[:4] {
+			switch {
+			case '0' <= c && c <= '9':
+				digits.WriteRune(c)
+			case 'A' <= c && c <= 'Z':
+				digits.WriteString(fmt.Sprint(c - 'A' + 10))
+			default:
+				return fmt.Sprintf("'%s' contains '%c'", actual, c)
+			}
+		}
+		number, _ := new(big.Int).SetString(digits.String(), 10)
+		if new(big.Int).Mod(number, big.NewInt(97)).Int64() != 1 {
+			return fmt.Sprintf("the check digits of '%s' are wrong", actual)
+		}
+		return ""
+	})
+}
+
+// TestDomainMatchers demonstrates publishing and using a custom expectation library
+func TestDomainMatchers(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Banker")
+
+	actor.AttemptsTo(
+		ensure.That(answerable.ValueOf("GB82 WEST 1234 5698 7654 32"), IsValidIBAN()),
+	)
+
+	require.EqualError(t, IsValidIBAN().Evaluate("GB82 WEST 1234 5698 7654 33"), "expected a valid IBAN, but the check digits of 'GB82 WEST 1234 5698 7654 33' are wrong")
+	require.Equal(t, "is a valid IBAN", IsValidIBAN().Description())
+	require.Contains(t, expectations.RegisteredMatchers(), expectations.MatcherInfo{
+		Library:     "github.com/nchursin/serenity-go/examples",
+		Name:        "IsValidIBAN",
+		Description: "a valid IBAN",
+		AnswerType:  "string",
+	})
+}
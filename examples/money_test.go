@@ -0,0 +1,38 @@
+package examples
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestMoneyAmounts demonstrates comparing prices without float64 equality pitfalls
+func TestMoneyAmounts(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Cashier")
+
+	var order map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"total": 19.990, "tax": {"amount": 3.19, "currency": "eur"}}`), &order))
+
+	subtotal, shipping := 0.1, 0.2
+	sum := subtotal + shipping // 0.30000000000000004
+
+	actor.AttemptsTo(
+		ensure.That(answerable.ValueOf[any](order["total"]), expectations.EqualsDecimal("19.99")),
+		ensure.That(answerable.ValueOf[any](sum), expectations.WithinTolerance("0.3", "0.000001")),
+		ensure.That(answerable.ValueOf[any](order["tax"]), expectations.EqualsMoney("3.19", "EUR")),
+		ensure.That(answerable.ValueOf[any]("€1,299.00"), expectations.EqualsMoney("1299", "EUR")),
+		ensure.That(answerable.ValueOf[any]("JPY 1500"), expectations.EqualsMoney("1500", "JPY")),
+	)
+
+	require.Error(t, expectations.EqualsDecimal("0.3").Evaluate(sum))
+	require.EqualError(t, expectations.EqualsMoney("19.99", "EUR").Evaluate("19.99 USD"), "expected 19.99 EUR, but got 19.99 USD")
+	require.EqualError(t, expectations.EqualsMoney("1500", "JPY").Evaluate("1500.5"), "expected 1500 JPY, but got 1500.5, more precise than the 0 decimal places of JPY")
+	require.EqualError(t, expectations.WithinTolerance(10, 0.5).Evaluate("10.75"), "expected 10 ± 0.5, but got 10.75 (off by 0.75)")
+}
@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestOrderingGuarantees demonstrates asserting the order and uniqueness of listed items
+func TestOrderingGuarantees(t *testing.T) {
+	type product struct {
+		ID    string
+		Price int
+	}
+	page := []product{{"p1", 500}, {"p7", 750}, {"p3", 750}, {"p2", 1200}}
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Shopper")
+
+	actor.AttemptsTo(
+		ensure.That(answerable.ValueOf(page), expectations.IsSortedBy(func(a, b product) bool { return a.Price < b.Price })),
+		ensure.That(answerable.ValueOf(page), expectations.HasNoDuplicates(func(p product) string { return p.ID })),
+		ensure.That(answerable.ValueOf([]string{"apple", "banana", "cherry"}), expectations.IsSortedAscending[string]()),
+		ensure.That(answerable.ValueOf([]int{3, 2, 2, 1}), expectations.IsSortedDescending[int]()),
+	)
+
+	require.EqualError(t, expectations.IsSortedAscending[int]().Evaluate([]int{1, 3, 2}),
+		"expected sorted in ascending order, but element 2 (2) comes after element 1 (3)")
+	require.EqualError(t, expectations.HasNoDuplicates(func(id string) string { return id }).Evaluate([]string{"p1", "p2", "p1"}),
+		"expected no duplicates, but elements 0 and 2 share the key p1")
+}
@@ -0,0 +1,53 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestOutputDirectory demonstrates saving report files to a folder per run
+func TestOutputDirectory(t *testing.T) {
+	root := t.TempDir()
+
+	// Folders of earlier runs, the oldest of which are removed when the run starts writing
+	for _, run := range []string{"20260101-090000", "20260102-090000", "20260103-090000"} {
+		require.NoError(t, os.Mkdir(filepath.Join(root, run), 0o755))
+		old := time.Now().Add(-48 * time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(root, run), old, old))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(root, "baseline"), 0o755))
+
+	t.Setenv(reporting.RunIDEnvVar, "nightly-42")
+	output := reporting.NewOutputDir(root).KeepRuns(2)
+
+	t.Run("checkout", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithReporter(context.Background(), t, reporting.NewAttachmentWriter(output))
+		test.ActorCalled("Buyer").AttemptsTo(core.Do("#actor pays", func(actor core.Actor, ctx context.Context) error {
+			core.Publish(actor, events.AttachmentAdded{Actor: actor.Name(), Name: "receipt.txt", MediaType: "text/plain", Content: []byte("paid")})
+			return nil
+		}))
+	})
+
+	receipt, err := os.ReadFile(filepath.Join(root, "nightly-42", "attachments", "TestOutputDirectory", "checkout", "receipt.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "paid", string(receipt))
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Equal(t, []string{"20260103-090000", "baseline", "nightly-42"}, names,
+		"the current run and the latest other one are kept, folders that are not runs are left alone")
+}
@@ -0,0 +1,76 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/pact"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestPactContractRecording demonstrates an acceptance test that doubles as a consumer contract:
+// the pact file is written when the test shuts down
+func TestPactContractRecording(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": 42, "state": "paid"}`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 43}`))
+		}
+	}))
+	defer server.Close()
+
+	contract := pact.NewContract("web-shop", "orders-service").WritingTo(t.TempDir())
+
+	t.Run("orders", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithContext(context.Background(), t)
+		actor := test.ActorCalled("Buyer").WhoCan(contract.Recording(api.CallAnApiAt(server.URL)))
+
+		actor.AttemptsTo(
+			pact.Given("order 42 exists").UponReceiving("a request for order 42"),
+			api.SendGetRequest("/orders/42").WithHeader("Authorization", "Bearer not-in-the-contract"),
+			ensure.That(api.NewJSONPath("state"), expectations.Equals[interface{}]("paid")),
+			api.SendPostRequest("/orders?notify=true").WithBody(map[string]string{"item": "book"}),
+			ensure.That(api.LastResponseStatus{}, expectations.Equals(201)),
+		)
+	})
+
+	data, err := os.ReadFile(contract.Path())
+	require.NoError(t, err)
+
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &document))
+	require.Equal(t, map[string]interface{}{"name": "web-shop"}, document["consumer"])
+	require.Equal(t, map[string]interface{}{"name": "orders-service"}, document["provider"])
+
+	interactions := contract.Interactions()
+	require.Len(t, interactions, 2)
+
+	require.Equal(t, pact.Interaction{
+		Description:   "a request for order 42",
+		ProviderState: "order 42 exists",
+		Request:       pact.Request{Method: "GET", Path: "/orders/42"},
+		Response: pact.Response{
+			Status:  200,
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    map[string]interface{}{"id": float64(42), "state": "paid"},
+		},
+	}, interactions[0])
+
+	require.Equal(t, "a POST request to /orders", interactions[1].Description)
+	require.Equal(t, "notify=true", interactions[1].Request.Query)
+	require.Equal(t, map[string]interface{}{"item": "book"}, interactions[1].Request.Body)
+	require.Equal(t, 201, interactions[1].Response.Status)
+}
@@ -0,0 +1,94 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestRepeat demonstrates performing the same activity several times
+func TestRepeat(t *testing.T) {
+	var output bytes.Buffer
+	reporter := console_reporter.NewConsoleReporter()
+	reporter.SetOutput(&output)
+
+	test := serenity.NewSerenityTestWithReporter(context.Background(), t, reporter)
+	actor := test.ActorCalled("BulkCreator")
+
+	var created []string
+	actor.AttemptsTo(
+		core.Repeat(3, func(i int) core.Activity {
+			name := fmt.Sprintf("user-%d", i)
+			return core.Do(fmt.Sprintf("#actor creates %s", name), func(actor core.Actor, ctx context.Context) error {
+				created = append(created, name)
+				return nil
+			})
+		}),
+	)
+
+	require.Equal(t, []string{"user-0", "user-1", "user-2"}, created)
+
+	// Each iteration is reported as a nested sub-step
+	capturedOutput := output.String()
+	require.Contains(t, capturedOutput, "BulkCreator repeats an activity 3 times")
+	require.Contains(t, capturedOutput, "    ✅ BulkCreator creates user-0")
+	require.Contains(t, capturedOutput, "    ✅ BulkCreator creates user-2")
+}
+
+// TestForEach demonstrates performing an activity for each item answered by a question
+func TestForEach(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("OrderVerifier")
+
+	type OrderLine struct {
+		SKU      string
+		Quantity int
+	}
+
+	orderLines := answerable.ResultOf("order lines", func(actor core.Actor, ctx context.Context) ([]OrderLine, error) {
+		return []OrderLine{{SKU: "A-1", Quantity: 2}, {SKU: "B-2", Quantity: 1}}, nil
+	})
+
+	var verified []string
+	actor.AttemptsTo(
+		core.ForEach(orderLines, func(line OrderLine) core.Activity {
+			verified = append(verified, line.SKU)
+			return ensure.That(answerable.ValueOf[any](line.Quantity), expectations.IsGreaterThan(0))
+		}),
+	)
+
+	require.Equal(t, []string{"A-1", "B-2"}, verified)
+}
+
+// TestRepeatStopsOnFirstFailure demonstrates that a failing iteration stops the remaining ones
+func TestRepeatStopsOnFirstFailure(t *testing.T) {
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("BulkCreator")
+
+	attempts := 0
+	repeat := core.Repeat(5, func(i int) core.Activity {
+		return core.Do("#actor creates a user", func(actor core.Actor, ctx context.Context) error {
+			attempts++
+			if i == 1 {
+				return fmt.Errorf("user already exists")
+			}
+			return nil
+		})
+	})
+
+	err := repeat.PerformAs(actor, context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "iteration 2 of 5 failed")
+	require.Contains(t, err.Error(), "user already exists")
+	require.Equal(t, 2, attempts)
+}
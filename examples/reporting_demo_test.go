@@ -1,16 +1,24 @@
 package examples
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
 	"github.com/nchursin/serenity-go/serenity/expectations"
 	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/reporting"
 	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
+	"github.com/nchursin/serenity-go/serenity/reporting/transcript"
 	serenity "github.com/nchursin/serenity-go/serenity/testing"
 )
 
@@ -38,10 +46,11 @@ func TestConsoleReportingDemo(t *testing.T) {
 	)
 }
 
-// TestReportingToFile demonstrates outputting report to file
+// TestReportingToFile demonstrates outputting report to a file in the output directory
 func TestReportingToFile(t *testing.T) {
-	// Create file for output in current directory
-	file, err := os.Create("test_report.txt")
+	// Create file for output in the folder of this run, e.g. target/serenity/20260114-093012
+	output := reporting.OutputDirAt(reporting.DefaultOutputRoot)
+	file, err := output.Create("test_report.txt")
 	require.NoError(t, err)
 
 	// Create reporter that writes to file
@@ -61,8 +70,155 @@ func TestReportingToFile(t *testing.T) {
 	file.Close()
 
 	// Verify file was created and contains content
-	content, err := os.ReadFile("test_report.txt")
+	content, err := os.ReadFile(file.Name())
 	require.NoError(t, err)
 	require.Contains(t, string(content), "Starting: TestReportingToFile")
 	require.Contains(t, string(content), "FileReporter sends GET request")
 }
+
+// TestReportedNamesAndMetadata demonstrates reporting steps in business language with metadata
+func TestReportedNamesAndMetadata(t *testing.T) {
+	var output bytes.Buffer
+	reporter := console_reporter.NewConsoleReporter()
+	reporter.SetOutput(&output)
+
+	test := serenity.NewSerenityTestWithReporter(context.Background(), t, reporter)
+
+	var finished []events.ActivityFinished
+	test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+		if e, ok := event.(events.ActivityFinished); ok {
+			finished = append(finished, e)
+		}
+	}))
+
+	actor := test.ActorCalled("Clerk")
+	actor.AttemptsTo(
+		core.WithReportedName(core.Do("#actor inserts a row into customers", func(actor core.Actor, ctx context.Context) error {
+			return nil
+		}), "Given an existing customer").
+			WithMetadata("ticket", "SHOP-42").
+			WithMetadata("requirement", "https://example.com/req/7"),
+	)
+
+	require.Len(t, finished, 1)
+	require.Equal(t, "Given an existing customer", finished[0].Activity)
+	require.Equal(t, map[string]string{"ticket": "SHOP-42", "requirement": "https://example.com/req/7"}, finished[0].Metadata)
+
+	require.Contains(t, output.String(), "Given an existing customer")
+	require.Contains(t, output.String(), "     requirement: https://example.com/req/7\n     ticket: SHOP-42\n")
+}
+
+// TestScenarioOutlineReporting demonstrates reporting the examples of a data-driven scenario
+// within their feature and scenario outline
+func TestScenarioOutlineReporting(t *testing.T) {
+	var output bytes.Buffer
+	console := console_reporter.NewConsoleReporter()
+	console.SetOutput(&output)
+	recorder := transcript.NewRecorder("")
+	reporter := reporting.NewMultiReporter(console, recorder)
+
+	for _, card := range []string{"visa", "amex"} {
+		t.Run(card, func(t *testing.T) {
+			ctx := serenity.ReportedIn(context.Background(),
+				events.Feature("Checkout"), events.Scenario("Pay by card"), events.Example(card))
+			test := serenity.NewSerenityTestWithReporter(ctx, t, reporter)
+			test.ActorCalled("Buyer").AttemptsTo(core.Do("#actor pays with "+card, func(actor core.Actor, ctx context.Context) error {
+				return nil
+			}))
+		})
+	}
+
+	require.Contains(t, output.String(), "Feature: Checkout › Scenario: Pay by card › Example: visa")
+	require.Contains(t, output.String(), "Feature: Checkout › Scenario: Pay by card › Example: amex")
+
+	tests := recorder.Transcript().Tests
+	require.Len(t, tests, 2)
+	require.Equal(t, []events.Context{events.Feature("Checkout"), events.Scenario("Pay by card"), events.Example("amex")}, tests[1].Contexts)
+}
+
+// resultCollector is a custom reporter keeping the result of the last test
+type resultCollector struct {
+	result reporting.TestResult
+}
+
+func (rc *resultCollector) OnTestStart(testName string)                  {}
+func (rc *resultCollector) OnTestFinish(result reporting.TestResult)     { rc.result = result }
+func (rc *resultCollector) OnStepStart(stepDescription string)           {}
+func (rc *resultCollector) OnStepFinish(stepResult reporting.TestResult) {}
+func (rc *resultCollector) SetOutput(w io.Writer)                        {}
+
+// TestResultsFormATree demonstrates the result model: test results carry the results of their
+// steps, nested as they were performed, with the files attached to them
+func TestResultsFormATree(t *testing.T) {
+	collector := &resultCollector{}
+
+	t.Run("checkout", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithReporter(context.Background(), t, collector)
+		actor := test.ActorCalled("Buyer")
+
+		pay := core.Do("#actor pays", func(actor core.Actor, ctx context.Context) error {
+			core.Publish(actor, events.AttachmentAdded{Actor: actor.Name(), Name: "receipt.txt", MediaType: "text/plain", Content: []byte("paid")})
+			return nil
+		})
+		actor.AttemptsTo(core.Do("#actor checks out", func(actor core.Actor, ctx context.Context) error {
+			return core.PerformAsSubStep(actor, ctx, pay)
+		}))
+	})
+
+	result := collector.result
+	require.Equal(t, "TestResultsFormATree/checkout", result.Name())
+	require.False(t, result.StartTime().IsZero())
+	require.Len(t, result.Children(), 1)
+
+	task := result.Children()[0]
+	require.Equal(t, "Buyer checks out", task.Name())
+	require.Len(t, task.Children(), 1)
+
+	payment := task.Children()[0]
+	require.Equal(t, "Buyer pays", payment.Name())
+	require.Equal(t, []reporting.Attachment{{Name: "receipt.txt", MediaType: "text/plain", Content: []byte("paid")}}, payment.Attachments())
+}
+
+// TestDurationRendering demonstrates how durations are rendered in reports: the unit adapts
+// to the magnitude, so sub-millisecond steps are not all shown as zero
+func TestDurationRendering(t *testing.T) {
+	require.Equal(t, "850ns", reporting.FormatDuration(850*time.Nanosecond))
+	require.Equal(t, "48.2µs", reporting.FormatDuration(48200*time.Nanosecond))
+	require.Equal(t, "212.4ms", reporting.FormatDuration(212400*time.Microsecond))
+	require.Equal(t, "1.25s", reporting.FormatDuration(1250*time.Millisecond))
+	require.Equal(t, "2m5s", reporting.FormatDuration(125*time.Second))
+
+	result := reporting.NewResult("step", reporting.StatusPassed).WithTimings(time.Now(), -time.Millisecond)
+	require.Zero(t, result.Duration())
+}
+
+// TestEnvironmentGatedScenario demonstrates skipping a scenario that does not apply to the
+// environment: the remaining steps and the test are reported as skipped
+func TestEnvironmentGatedScenario(t *testing.T) {
+	collector := &resultCollector{}
+
+	t.Run("pay by card", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithReporter(context.Background(), t, collector)
+		actor := test.ActorCalled("Shopper")
+
+		sandboxUnavailable := core.Of("the payment sandbox is unavailable", func(actor core.Actor, ctx context.Context) (bool, error) {
+			return os.Getenv("PAYMENTS_SANDBOX_URL") == "", nil
+		})
+		t.Setenv("PAYMENTS_SANDBOX_URL", "")
+
+		actor.AttemptsTo(
+			core.SkipIf(sandboxUnavailable, "payments run only against the sandbox"),
+			core.Do("#actor pays by card", func(actor core.Actor, ctx context.Context) error {
+				return errors.New("the sandbox should not be called")
+			}),
+		)
+	})
+
+	result := collector.result
+	require.Equal(t, reporting.StatusSkipped, result.Status())
+	require.Len(t, result.Children(), 2)
+	for _, step := range result.Children() {
+		require.Equal(t, reporting.StatusSkipped, step.Status())
+		require.EqualError(t, step.Error(), "payments run only against the sandbox")
+	}
+}
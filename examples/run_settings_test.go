@@ -0,0 +1,20 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// runWithSettings runs the tests of an example with the run settings, then finishes the run
+// as serenity.Main does after all the tests of a package, so that the reporters summarizing
+// the run have reported when it returns
+func runWithSettings(t *testing.T, settings config.RunSettings, tests func(t *testing.T)) {
+	t.Helper()
+	config.UseRunSettings(settings)
+	defer config.UseRunSettings(config.DefaultRunSettings())
+
+	tests(t)
+	serenity.FinishRun()
+}
@@ -0,0 +1,60 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestSecretsAreMaskedInReports demonstrates reading credentials from a secrets provider:
+// the values reach the API but never appear in the report
+func TestSecretsAreMaskedInReports(t *testing.T) {
+	t.Setenv("ORDERS_API_TOKEN", "s3cr3t-orders-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t-orders-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	reporter := console_reporter.NewConsoleReporter()
+	reporter.SetOutput(&output)
+
+	test := serenity.NewSerenityTestWithReporter(context.Background(), t, reporter)
+	actor := test.ActorCalled("OrdersClient").WhoCan(api.CallAnApiAt(server.URL))
+
+	token := secrets.Ref(secrets.FromEnv("ORDERS_"), "api-token")
+
+	// Reading the value registers it for masking
+	value, err := token.Value(context.Background())
+	require.NoError(t, err)
+
+	actor.AttemptsTo(
+		api.SendGetRequest("/orders").WithBearerToken(token),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(200)),
+		core.Do(fmt.Sprintf("#actor logs the token %s", value), func(actor core.Actor, ctx context.Context) error {
+			return nil
+		}),
+	)
+
+	capturedOutput := output.String()
+	require.Contains(t, capturedOutput, "OrdersClient logs the token ******")
+	require.NotContains(t, capturedOutput, "s3cr3t-orders-token")
+}
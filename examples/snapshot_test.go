@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestMatchesSnapshot demonstrates comparing an API response with a stored golden file.
+// Key order and formatting of the response don't matter, the JSON is normalized first.
+func TestMatchesSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"Homer Simpson","id":1,"roles":["admin","user"],` +
+			`"address":{"street":"742 Evergreen Terrace","city":"Springfield"}}`))
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	actor := test.ActorCalled("SnapshotTester").WhoCan(api.CallAnApiAt(server.URL))
+
+	actor.AttemptsTo(
+		api.SendGetRequest("/users/1"),
+		ensure.That(api.LastResponseBody{}, expectations.MatchesSnapshot[string]("user-profile")),
+	)
+}
+
+// TestMatchesSnapshot_UpdateMode demonstrates regenerating snapshots with UPDATE_SNAPSHOTS=1
+func TestMatchesSnapshot_UpdateMode(t *testing.T) {
+	dir := t.TempDir()
+	order := map[string]interface{}{"id": 42, "items": []string{"book"}}
+
+	t.Setenv(expectations.UpdateSnapshotsEnvVar, "1")
+	require.NoError(t, expectations.MatchesSnapshotIn[map[string]interface{}](dir, "orders/42").Evaluate(order))
+
+	stored, err := os.ReadFile(filepath.Join(dir, "orders", "42.json"))
+	require.NoError(t, err)
+	require.Equal(t, "{\n  \"id\": 42,\n  \"items\": [\n    \"book\"\n  ]\n}\n", string(stored))
+
+	t.Setenv(expectations.UpdateSnapshotsEnvVar, "")
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	actor := test.ActorCalled("SnapshotTester")
+
+	actor.AttemptsTo(
+		ensure.That(answerable.ValueOf(order), expectations.MatchesSnapshotIn[map[string]interface{}](dir, "orders/42")),
+	)
+}
+
+// TestMatchesSnapshot_ReportsDiff demonstrates the diff printed when a value changes
+func TestMatchesSnapshot_ReportsDiff(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "greeting.json"), []byte(`{"greeting": "hello", "count": 1}`), 0o644))
+
+	snapshot := expectations.MatchesSnapshotIn[string](dir, "greeting")
+	require.Equal(t, "matches snapshot 'greeting'", snapshot.Description())
+
+	err := snapshot.Evaluate(`{"count": 2, "greeting": "hello"}`)
+	require.EqualError(t, err, "value does not match snapshot 'greeting' (- snapshot, + actual; run with UPDATE_SNAPSHOTS=1 to update):\n"+
+		"  {\n"+
+		"-   \"count\": 1,\n"+
+		"+   \"count\": 2,\n"+
+		"    \"greeting\": \"hello\"\n"+
+		"  }")
+
+	err = expectations.MatchesSnapshotIn[string](dir, "missing").Evaluate("text")
+	require.ErrorContains(t, err, "snapshot 'missing' does not exist")
+
+	err = expectations.MatchesSnapshotIn[string](dir, "../escape").Evaluate("text")
+	require.EqualError(t, err, "invalid snapshot name '../escape'")
+}
@@ -0,0 +1,113 @@
+package examples
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// cancelOrder is a reusable task template: the order to cancel is the one the actor
+// noted as orderId, and the reason is noted by each scenario
+func cancelOrder() core.Activity {
+	return api.SendPostRequest("/orders/{{orderId}}/cancel").
+		WithHeader("X-Reason", "{{reason}}").
+		WithBody(map[string]string{"order": "{{orderId}}", "reason": "{{reason}}"}).
+		Templated()
+}
+
+// TestInteractionTemplates demonstrates placeholders resolved from the actor's notes
+func TestInteractionTemplates(t *testing.T) {
+	var cancelled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"ord-7"}`))
+		default:
+			body, _ := io.ReadAll(r.Body)
+			cancelled = append(cancelled, r.URL.Path+" "+r.Header.Get("X-Reason")+" "+string(body))
+		}
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	var activities []string
+	test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+		if started, ok := event.(events.ActivityStarted); ok {
+			activities = append(activities, started.Activity)
+		}
+	}))
+
+	actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL), notes.UsingAnEmptyNotepad())
+
+	actor.AttemptsTo(
+		api.SendPostRequest("/orders").WithBody(map[string]int{"item": 1}),
+		notes.RecordAnswer("orderId", api.NewJSONPath("id")),
+		notes.Record("reason", `changed "mind"`),
+		cancelOrder(),
+		ensure.That(api.LastResponseStatus{}, expectations.Equals(http.StatusOK)),
+		// Requests that aren't templated are sent as written
+		api.SendPostRequest("/orders/ord-7/notes").WithBody("{{not a placeholder}}"),
+	)
+
+	require.Equal(t, []string{
+		`/orders/ord-7/cancel changed "mind" {"order":"ord-7","reason":"changed \"mind\""}`,
+		"/orders/ord-7/notes  {{not a placeholder}}",
+	}, cancelled)
+	require.Contains(t, activities, "#actor sends POST request to /orders/ord-7/cancel")
+
+	err := cancelOrder().PerformAs(test.ActorCalled("Stranger").WhoCan(api.CallAnApiAt(server.URL)), test.Context())
+	require.EqualError(t, err, `failed to build request: no notes on orderId for the placeholders in "/orders/{{orderId}}/cancel"`)
+}
+
+// TestQuestionBackedParameters demonstrates interactions whose parameters are answered
+// when they are performed
+func TestQuestionBackedParameters(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"ord-9","total":42}`))
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTestWithContext(context.Background(), t)
+	defer test.Shutdown()
+
+	actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL))
+
+	// Defined before the order exists
+	showOrder := api.SendGetRequestTo(answerable.Format("/orders/%s", api.NewJSONPath("id")))
+	payOrder := api.SendPostRequest("/payments").WithBody(api.ResponseBodyAsJSON[map[string]any]{})
+
+	require.Equal(t, "#actor sends GET request to /orders/<JSON path 'id'>", showOrder.Description())
+
+	actor.AttemptsTo(
+		api.SendPostRequest("/orders").WithBody(map[string]int{"item": 1}),
+		showOrder,
+		payOrder,
+	)
+
+	require.Equal(t, []string{
+		`POST /orders {"item":1}`,
+		"GET /orders/ord-9 ",
+		`POST /payments {"id":"ord-9","total":42}`,
+	}, requests)
+}
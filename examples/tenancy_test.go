@@ -0,0 +1,86 @@
+package examples
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/tenancy"
+	"github.com/nchursin/serenity-go/serenity/answerable"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestTenancy demonstrates namespacing the data of a run in a shared environment and
+// deleting it afterwards without touching the data of other runs
+func TestTenancy(t *testing.T) {
+	var mutex sync.Mutex
+	users := []string{"someone.else@example.test"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			var user struct {
+				Email string `json:"email"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&user)
+			users = append(users, user.Email)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			tag := r.URL.Query().Get("tag")
+			kept := users[:0]
+			for _, email := range users {
+				if !strings.Contains(email, tag) {
+					kept = append(kept, email)
+				}
+			}
+			users = kept
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Registrar").WhoCan(
+		api.CallAnApiAt(server.URL),
+		tenancy.WithToken("run-abc123"),
+	)
+
+	actor.AttemptsTo(
+		ensure.That(tenancy.Token(), expectations.Equals("run-abc123")),
+		ensure.That(tenancy.Namespaced("orders"), expectations.Equals("run-abc123-orders")),
+
+		api.SendPostRequest("/users").WithBody(answerable.Format(`{"email": "%s"}`, tenancy.AnEmail())),
+		api.SendPostRequest("/users").WithBody(answerable.Format(`{"email": "%s"}`, tenancy.AnEmail())),
+	)
+
+	require.Regexp(t, `^run-abc123-[a-z0-9]{4}\d+$`, serenity.MustAnswer(actor, tenancy.AnID()))
+
+	mutex.Lock()
+	require.Len(t, users, 3)
+	require.NotEqual(t, users[1], users[2], "generated emails should be unique")
+	require.True(t, strings.HasPrefix(users[1], "run-abc123."))
+	mutex.Unlock()
+
+	actor.AttemptsTo(
+		tenancy.CleanUp(func(token string) core.Activity {
+			return api.SendDeleteRequest("/users?tag=" + token)
+		}),
+	)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Equal(t, []string{"someone.else@example.test"}, users)
+	require.Regexp(t, `^run-[a-z0-9]{8}$`, tenancy.ForThisRun().Token())
+}
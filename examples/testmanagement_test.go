@@ -0,0 +1,105 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/testmanagement"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestPublishingToTestManagement demonstrates uploading the statuses of the cases covered by
+// the tests to a test-management tool when the run finishes
+func TestPublishingToTestManagement(t *testing.T) {
+	requests := make(map[string][]byte)
+	tool := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests[r.URL.RequestURI()] = body
+		if r.URL.Path == "/api/v2/authenticate" {
+			_, _ = w.Write([]byte(`"xray-token"`))
+		}
+	}))
+	defer tool.Close()
+
+	browse := core.Do("#actor browses the catalog", func(actor core.Actor, ctx context.Context) error {
+		return nil
+	})
+
+	t.Run("a publisher uploading to TestRail", func(t *testing.T) {
+		publisher := testmanagement.NewPublisher(testmanagement.NewTestRail(tool.URL, "qa@example.com", "api-key", 42))
+		t.Run("catalog", func(t *testing.T) {
+			serenity.NewSerenityTestWithReporter(context.Background(), t, publisher).
+				CoversCase("C1234", "C1235").
+				ActorCalled("Buyer").AttemptsTo(browse)
+		})
+		// A failed test of the run covering one of the cases, with the step that failed it
+		publisher.OnTestFinish(reporting.NewResult("TestCheckout", reporting.StatusFailed).
+			WithCases("C1235").
+			WithError(errors.New("test failed")).
+			WithChildren(reporting.NewResult("Buyer pays by card", reporting.StatusFailed).
+				WithError(errors.New("expected status 201, but got 402"))))
+
+		outcomes := publisher.Outcomes()
+		require.Len(t, outcomes, 2)
+		require.Equal(t, reporting.StatusPassed, outcomes[0].Status)
+		require.Equal(t, reporting.StatusFailed, outcomes[1].Status)
+		require.Equal(t, []string{"TestPublishingToTestManagement/a_publisher_uploading_to_TestRail/catalog", "TestCheckout"}, outcomes[1].Tests)
+		require.Contains(t, outcomes[1].Comment, "TestCheckout: failed")
+		require.Contains(t, outcomes[1].Comment, "expected status 201, but got 402")
+
+		publisher.OnRunFinish()
+
+		var uploaded struct {
+			Results []struct {
+				CaseID   int `json:"case_id"`
+				StatusID int `json:"status_id"`
+			} `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(requests["/index.php?/api/v2/add_results_for_cases/42"], &uploaded))
+		require.Len(t, uploaded.Results, 2)
+		require.Equal(t, 1234, uploaded.Results[0].CaseID)
+		require.Equal(t, 1, uploaded.Results[0].StatusID)
+		require.Equal(t, 1235, uploaded.Results[1].CaseID)
+		require.Equal(t, 5, uploaded.Results[1].StatusID)
+	})
+
+	t.Run("the testmanagement reporter of the run settings", func(t *testing.T) {
+		clear(requests)
+		settings, err := config.RunSettings{
+			Reporters: []string{"testmanagement"},
+			Retries:   config.RetryPolicy{Attempts: 1},
+		}.WithReporterSettings("testmanagement", testmanagement.Settings{
+			Tool: "xray", URL: tool.URL, User: "client-id", Token: "client-secret", Run: "SHOP-512",
+		})
+		require.NoError(t, err)
+		runWithSettings(t, settings, func(t *testing.T) {
+			t.Run("catalog", func(t *testing.T) {
+				serenity.NewSerenityTest(t).CoversCase("SHOP-12").ActorCalled("Buyer").AttemptsTo(browse)
+			})
+		})
+
+		var execution struct {
+			TestExecutionKey string `json:"testExecutionKey"`
+			Tests            []struct {
+				TestKey string `json:"testKey"`
+				Status  string `json:"status"`
+			} `json:"tests"`
+		}
+		require.Contains(t, requests, "/api/v2/authenticate")
+		require.NoError(t, json.Unmarshal(requests["/api/v2/import/execution"], &execution))
+		require.Equal(t, "SHOP-512", execution.TestExecutionKey)
+		require.Len(t, execution.Tests, 1)
+		require.Equal(t, "SHOP-12", execution.Tests[0].TestKey)
+		require.Equal(t, "PASSED", execution.Tests[0].Status)
+	})
+}
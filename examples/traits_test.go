@@ -0,0 +1,54 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// ListOrders is a reusable task that adapts to the actor performing it: administrators
+// use the admin endpoint, and every actor sends the tenant it acts for
+func ListOrders() core.Activity {
+	return core.Do("#actor lists the orders", func(actor core.Actor, ctx context.Context) error {
+		path := "/orders"
+		if core.HasTrait(actor, "admin") {
+			path = "/admin/orders"
+		}
+
+		request := api.SendGetRequest(path)
+		if tenant, ok := core.AttributeOf(actor, "tenant"); ok {
+			request = request.WithHeader("X-Tenant", tenant)
+		}
+		return request.PerformAs(actor, ctx)
+	})
+}
+
+// TestActorTraits demonstrates reusable tasks adapting to who performs them
+func TestActorTraits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path + " for " + r.Header.Get("X-Tenant")))
+	}))
+	defer server.Close()
+
+	test := serenity.NewSerenityTest(t)
+
+	admin := core.Is(test.ActorCalled("Ada").WhoCan(api.CallAnApiAt(server.URL)), "admin")
+	core.With(admin, "tenant", "acme")
+	customer := core.With(test.ActorCalled("Carl").WhoCan(api.CallAnApiAt(server.URL)), "tenant", "globex")
+
+	admin.AttemptsTo(
+		ListOrders(),
+		ensure.That(api.LastResponseBody{}, expectations.Equals("/admin/orders for acme")),
+	)
+	customer.AttemptsTo(
+		ListOrders(),
+		ensure.That(api.LastResponseBody{}, expectations.Equals("/orders for globex")),
+	)
+}
@@ -0,0 +1,144 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/reporting/transcript"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// checkoutScenario performs the same scenario against a given release of the service
+func checkoutScenario(t *testing.T, recorder *transcript.Recorder, serverURL string) {
+	test := serenity.NewSerenityTestWithReporter(context.Background(), t, recorder)
+	actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(serverURL))
+
+	actor.AttemptsTo(
+		api.SendPostRequest("/checkout"),
+		ensure.That(api.LastResponseStatus{}, expectations.Satisfies("is a success", func(status int) error {
+			if status >= 300 {
+				return fmt.Errorf("unexpected status %d", status)
+			}
+			return nil
+		})),
+		ensure.That(api.NewJSONPath("state"), expectations.Satisfies("is known", func(state interface{}) error {
+			if state == nil {
+				return fmt.Errorf("state is missing")
+			}
+			return nil
+		})),
+	)
+}
+
+// releaseServer simulates a release of the checkout service
+func releaseServer(status int, state string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"state": %q}`, state)
+	}))
+}
+
+// TestTranscriptHighlightsBehavioralDrift demonstrates recording a transcript for one release
+// and replaying it as a diff against a later run
+func TestTranscriptHighlightsBehavioralDrift(t *testing.T) {
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "v1.json")
+
+	v1 := releaseServer(http.StatusOK, "paid")
+	defer v1.Close()
+
+	baselineRecorder := transcript.NewRecorder(baselinePath)
+	t.Run("v1", func(t *testing.T) {
+		checkoutScenario(t, baselineRecorder, v1.URL)
+	})
+
+	baseline, err := transcript.Load(baselinePath)
+	require.NoError(t, err)
+	require.Len(t, baseline.Tests, 1)
+	require.Equal(t, "passed", baseline.Tests[0].Status)
+	require.Equal(t, []transcript.Answer{{Question: "the last response status code", Value: "200"}},
+		baseline.Tests[0].Steps[1].Answers)
+
+	// Pretend the baseline was recorded by the same test in an earlier release
+	baseline.Tests[0].Name = strings.Replace(baseline.Tests[0].Name, "/v1", "/v2", 1)
+	require.NoError(t, baseline.Save(baselinePath))
+
+	v2 := releaseServer(http.StatusAccepted, "pending")
+	defer v2.Close()
+
+	var output bytes.Buffer
+	recorder := transcript.NewRecorder(filepath.Join(dir, "v2.json"))
+	recorder.SetOutput(&output)
+	require.NoError(t, recorder.CompareWith(baselinePath))
+
+	t.Run("v2", func(t *testing.T) {
+		checkoutScenario(t, recorder, v2.URL)
+	})
+
+	drifts := recorder.Drifts()
+	require.Len(t, drifts, 1)
+	require.Equal(t, "TestTranscriptHighlightsBehavioralDrift/v2", drifts[0].Test)
+	require.Contains(t, drifts[0].Diff, `-     ? the last response status code = 200`)
+	require.Contains(t, drifts[0].Diff, `+     ? the last response status code = 202`)
+	require.Contains(t, drifts[0].Diff, `-     ? JSON path 'state' = "paid"`)
+	require.Contains(t, drifts[0].Diff, `+     ? JSON path 'state' = "pending"`)
+	require.Contains(t, output.String(), "Behavioral drift in TestTranscriptHighlightsBehavioralDrift/v2")
+}
+
+// TestRequirementCoverage demonstrates tracing scenarios and steps back to requirements
+func TestRequirementCoverage(t *testing.T) {
+	server := releaseServer(http.StatusOK, "paid")
+	defer server.Close()
+
+	recorder := transcript.NewRecorder("")
+	var covered []string
+
+	t.Run("checkout", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithReporter(context.Background(), t, recorder).
+			CoversRequirement("SHOP-1", "SHOP-2")
+		test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+			if e, ok := event.(events.RequirementCovered); ok {
+				covered = append(covered, e.Requirement)
+			}
+		}))
+		test.CoversRequirement("SHOP-2", "SHOP-3")
+
+		actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL))
+		actor.AttemptsTo(
+			api.SendPostRequest("/checkout"),
+			core.LinkedToIssues(ensure.That(api.NewJSONPath("state"), expectations.Equals[interface{}]("paid")), "SHOP-4"),
+		)
+	})
+
+	t.Run("refund", func(t *testing.T) {
+		test := serenity.NewSerenityTestWithReporter(context.Background(), t, recorder).CoversRequirement("SHOP-2")
+		test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL)).AttemptsTo(api.SendPostRequest("/refund"))
+	})
+
+	require.Equal(t, []string{"SHOP-3"}, covered)
+
+	tests := recorder.Transcript().Tests
+	require.Equal(t, []string{"SHOP-1", "SHOP-2", "SHOP-3"}, tests[0].Requirements)
+	require.Equal(t, map[string]string{core.IssuesKey: "SHOP-4"}, tests[0].Steps[1].Metadata)
+
+	require.Equal(t, map[string][]string{
+		"SHOP-1": {"TestRequirementCoverage/checkout"},
+		"SHOP-2": {"TestRequirementCoverage/checkout", "TestRequirementCoverage/refund"},
+		"SHOP-3": {"TestRequirementCoverage/checkout"},
+		"SHOP-4": {"TestRequirementCoverage/checkout"},
+	}, recorder.Transcript().Coverage())
+}
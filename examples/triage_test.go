@@ -0,0 +1,166 @@
+package examples
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/reporting/triage"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+	"github.com/nchursin/serenity-go/serenity/testing/mocks"
+)
+
+// TestTriageBundle demonstrates attaching the context of a failure to the failed test, so
+// that a bug can be filed with it
+func TestTriageBundle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orders" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"error":"card declined"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	settings, err := config.RunSettings{
+		OutputDir: t.TempDir(),
+		Retries:   config.RetryPolicy{Attempts: 1},
+	}.WithReporterSettings("triage", triage.Settings{Enabled: true, Exchanges: 2})
+	require.NoError(t, err)
+	config.UseRunSettings(settings)
+	defer config.UseRunSettings(config.DefaultRunSettings())
+
+	// A mock test context lets the scenario fail without failing this test
+	ctrl := gomock.NewController(t)
+	mockCtx := mocks.NewMockTestContext(ctrl)
+	mockCtx.EXPECT().Helper().AnyTimes()
+	mockCtx.EXPECT().Cleanup(gomock.Any())
+	mockCtx.EXPECT().Name().Return("TestCheckout")
+	mockCtx.EXPECT().Errorf(gomock.Any(), gomock.Any())
+	mockCtx.EXPECT().FailNow()
+	mockCtx.EXPECT().Failed().Return(true).AnyTimes()
+
+	test := serenity.NewSerenityTest(mockCtx)
+	var bundles []events.AttachmentAdded
+	test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+		if attachment, ok := event.(events.AttachmentAdded); ok && attachment.Name == triage.AttachmentName {
+			bundles = append(bundles, attachment)
+		}
+	}))
+
+	buyer := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(server.URL), notes.UsingAnEmptyNotepad())
+	buyer.AttemptsTo(
+		notes.Record("cart id", "c-7"),
+		api.SendGetRequest("/catalog"),
+		api.SendGetRequest("/cart"),
+		core.TaskWhere("#actor places an order",
+			api.SendPostRequest("/orders").
+				WithHeader("Authorization", "Bearer s3cr3t").
+				WithBody(map[string]string{"cart": "c-7"}),
+			ensure.That(api.LastResponseStatus{}, expectations.Equals(201)),
+		),
+	)
+	test.Shutdown()
+
+	require.Len(t, bundles, 1)
+	var bundle triage.Bundle
+	require.NoError(t, json.Unmarshal(bundles[0].Content, &bundle))
+
+	require.Equal(t, "TestCheckout", bundle.Test)
+	require.NotNil(t, bundle.Failure)
+	require.Equal(t, "Buyer", bundle.Failure.Actor)
+	require.Equal(t, "201", bundle.Failure.Expected)
+	require.Equal(t, "402", bundle.Failure.Actual)
+	require.Equal(t, "Buyer places an order", bundle.Failure.Steps[0])
+	require.Equal(t, bundle.Failure.Step, bundle.Failure.Steps[len(bundle.Failure.Steps)-1])
+
+	// The bundle keeps the last two exchanges, with the credentials redacted
+	require.Len(t, bundle.Exchanges, 2)
+	require.Equal(t, server.URL+"/cart", bundle.Exchanges[0].URL)
+	order := bundle.Exchanges[1]
+	require.Equal(t, http.MethodPost, order.Method)
+	require.Equal(t, http.StatusPaymentRequired, order.Status)
+	require.Equal(t, []string{"[redacted]"}, order.RequestHeaders["Authorization"])
+	require.JSONEq(t, `{"cart":"c-7"}`, order.RequestBody)
+	require.JSONEq(t, `{"error":"card declined"}`, order.ResponseBody)
+
+	require.Equal(t, map[string]map[string]string{"Buyer": {"cart id": "c-7"}}, bundle.Notes)
+	require.NotEmpty(t, bundle.Environment.GoVersion)
+	require.NotEmpty(t, bundle.Environment.RunID)
+}
+
+// TestTriageBundleOfToleratedFailures demonstrates that the bundle describes the failure that
+// failed the test, rather than failures the scenario tolerated, such as ignored steps and
+// retried attempts
+func TestTriageBundleOfToleratedFailures(t *testing.T) {
+	started := func(activity string) events.Event {
+		return events.ActivityStarted{Actor: "Buyer", Activity: activity}
+	}
+	finished := func(activity string, err error) events.Event {
+		if err == nil {
+			return events.ActivityFinished{Actor: "Buyer", Activity: activity, Outcome: events.Passed}
+		}
+		return events.ActivityFinished{Actor: "Buyer", Activity: activity, Outcome: events.Failed, Err: err}
+	}
+	timeout := errors.New("request timed out")
+	declined := errors.New("expected 201, but got 402")
+
+	collect := func(scenario ...events.Event) *triage.Failure {
+		collector := triage.NewCollector(0)
+		for _, event := range scenario {
+			collector.Notify(event)
+		}
+		return collector.Bundle("TestCheckout", nil, triage.Environment{}).Failure
+	}
+
+	t.Run("the failure of the last failed activity", func(t *testing.T) {
+		failure := collect(
+			// An ignored step
+			started("#actor dismisses the banner"),
+			finished("#actor dismisses the banner", errors.New("no banner")),
+			started("#actor places an order"),
+			// An attempt retried by the enclosing activity
+			started("#actor sends the order"),
+			finished("#actor sends the order", timeout),
+			started("#actor sends the order"),
+			finished("#actor sends the order", nil),
+			started("#actor checks the status"),
+			finished("#actor checks the status", declined),
+			finished("#actor places an order", fmt.Errorf("task '#actor places an order' failed: %w", declined)),
+		)
+
+		require.NotNil(t, failure)
+		require.Equal(t, "Buyer checks the status", failure.Step)
+		require.Equal(t, []string{"Buyer places an order", "Buyer checks the status"}, failure.Steps)
+		require.Equal(t, declined.Error(), failure.Error)
+	})
+
+	t.Run("failures tolerated by a passing activity", func(t *testing.T) {
+		failure := collect(
+			started("#actor checks the status"),
+			finished("#actor checks the status", declined),
+			started("#actor places an order"),
+			started("#actor sends the order"),
+			finished("#actor sends the order", timeout),
+			finished("#actor places an order", nil),
+		)
+
+		require.NotNil(t, failure)
+		require.Equal(t, "Buyer checks the status", failure.Step)
+	})
+}
@@ -0,0 +1,80 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/webhook"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// TestWebhookNotifications demonstrates posting the summary of a run to a chat channel
+func TestWebhookNotifications(t *testing.T) {
+	var posted []string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		posted = append(posted, string(body))
+	}))
+	defer hook.Close()
+
+	run := func(t *testing.T, newTest func(t *testing.T) serenity.SerenityTest) {
+		t.Run("catalog", func(t *testing.T) {
+			newTest(t).ActorCalled("Buyer").AttemptsTo(core.Do("#actor browses the catalog", func(actor core.Actor, ctx context.Context) error {
+				return nil
+			}))
+		})
+	}
+
+	t.Run("a notifier reporting to Slack", func(t *testing.T) {
+		notifier := webhook.NewNotifier(hook.URL).
+			WithTitle("Nightly acceptance").
+			WithReportURL("https://ci.example.com/nightly/index.html")
+		run(t, func(t *testing.T) serenity.SerenityTest {
+			return serenity.NewSerenityTestWithReporter(context.Background(), t, notifier)
+		})
+		// A failed test of the run, with the step that failed it
+		notifier.OnTestFinish(reporting.NewResult("TestCheckout", reporting.StatusFailed).
+			WithError(errors.New("test failed")).
+			WithChildren(reporting.NewResult("Buyer pays by card", reporting.StatusFailed).
+				WithError(errors.New("expected status 201, but got 402"))))
+		notifier.OnRunFinish()
+
+		require.Len(t, posted, 1)
+		var message map[string]string
+		require.NoError(t, json.Unmarshal([]byte(posted[0]), &message))
+		require.Regexp(t, `^❌ Nightly acceptance: 1 passed, 1 failed, 0 skipped in \S+\n`+
+			`• <https://ci.example.com/nightly/index.html\|TestCheckout>: expected status 201, but got 402\n`+
+			`<https://ci.example.com/nightly/index.html\|Open the report>$`, message["text"])
+	})
+
+	t.Run("the webhook reporter of the run settings", func(t *testing.T) {
+		posted = nil
+		settings, err := config.RunSettings{
+			Reporters: []string{"webhook"},
+			Retries:   config.RetryPolicy{Attempts: 1},
+		}.WithReporterSettings("webhook", webhook.Settings{URL: hook.URL, Format: "json", Title: "Release candidate"})
+		require.NoError(t, err)
+		runWithSettings(t, settings, func(t *testing.T) {
+			run(t, func(t *testing.T) serenity.SerenityTest {
+				return serenity.NewSerenityTest(t)
+			})
+		})
+
+		require.Len(t, posted, 1)
+		var summary webhook.Summary
+		require.NoError(t, json.Unmarshal([]byte(posted[0]), &summary))
+		require.Equal(t, "Release candidate", summary.Title)
+		require.Equal(t, 1, summary.Passed)
+		require.Zero(t, summary.Failed)
+	})
+}
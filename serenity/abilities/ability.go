@@ -4,3 +4,36 @@ package abilities
 type Ability interface {
 	// Base interface for all abilities
 }
+
+// Discardable is implemented by abilities that hold resources or buffered results which must be
+// released or flushed when the test finishes. SerenityTest discards the abilities of all its
+// actors on Shutdown and reports any returned error as a test failure.
+type Discardable interface {
+	Ability
+	// Discard releases the resources held by the ability
+	Discard() error
+}
+
+// Inspectable is implemented by abilities that can describe their current state, such as the
+// last response received. The debug mode prints it before each activity.
+type Inspectable interface {
+	Ability
+	// Inspect returns a one-line description of the ability's current state
+	Inspect() string
+}
+
+// Attachable is implemented by abilities that collect material for the report while the test
+// runs, such as captured traffic. SerenityTest attaches it to the test on Shutdown, before
+// discarding the ability.
+type Attachable interface {
+	Ability
+	// Attachments returns the files to attach to the report
+	Attachments() []Attachment
+}
+
+// Attachment is a file attached to the report
+type Attachment struct {
+	Name      string
+	MediaType string
+	Content   []byte
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// ErrBodyTooLarge is returned by questions buffering a response body larger than the limit
+// set with WithMaxBufferedBody
+var ErrBodyTooLarge = errors.New("response body exceeds the buffering limit")
+
+// errBodyConsumed is read from a response body already streamed by a question
+var errBodyConsumed = errors.New("the response body was consumed by a streaming question")
+
+// WithMaxBufferedBody limits the size of the response bodies questions such as
+// LastResponseBody and NewJSONPath load into memory. Larger bodies fail those questions;
+// streaming questions such as BodySize, BodySHA256 and BodyLinesMatching still process
+// them. Traffic capture buffers every body regardless of the limit.
+func WithMaxBufferedBody(bytes int64) ClientOption {
+	return func(o *clientOptions) {
+		o.maxBufferedBody = bytes
+	}
+}
+
+// bufferedBody is a response body held in memory, read again from the start by every question
+type bufferedBody struct {
+	*bytes.Reader
+}
+
+// Close does nothing, as the body holds no connection
+func (bufferedBody) Close() error {
+	return nil
+}
+
+// consumedBody is a response body already streamed by a question
+type consumedBody struct{}
+
+// Read fails, as the body can no longer be read
+func (consumedBody) Read([]byte) (int, error) {
+	return 0, errBodyConsumed
+}
+
+// Close does nothing
+func (consumedBody) Close() error {
+	return nil
+}
+
+// bufferedBodyOf reads the response body into memory, up to the limit of the ability, and
+// keeps it in the response for the next questions
+func bufferedBodyOf(callAbility CallAnAPI, resp *http.Response) ([]byte, error) {
+	if buffered, ok := resp.Body.(bufferedBody); ok {
+		return contentOf(buffered)
+	}
+
+	reader := io.Reader(resp.Body)
+	limit := int64(-1)
+	if limited, ok := callAbility.(interface{ maxBodySize() int64 }); ok && limited.maxBodySize() > 0 {
+		limit = limited.maxBodySize()
+		reader = io.LimitReader(resp.Body, limit+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if limit >= 0 && int64(len(body)) > limit {
+		// Keep the rest of the stream for streaming questions
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return nil, fmt.Errorf("%w of %d bytes; ask streaming questions such as BodySize or BodySHA256 instead", ErrBodyTooLarge, limit)
+	}
+
+	_ = resp.Body.Close()
+	resp.Body = bufferedBody{bytes.NewReader(body)}
+	return body, nil
+}
+
+// contentOf returns the content of a buffered body, rewinding it for the next reader
+func contentOf(body bufferedBody) ([]byte, error) {
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	_, err = body.Seek(0, io.SeekStart)
+	return content, err
+}
+
+// streamBodyOf passes the last response body to process without loading it into memory.
+// A body buffered by an earlier question is read from memory and kept; otherwise the
+// body is consumed and later questions about it fail.
+func streamBodyOf(actor core.Actor, process func(body io.Reader) error) error {
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
+	if err != nil {
+		return fmt.Errorf("actor does not have the ability to call an API: %w", err)
+	}
+
+	resp := callAbility.LastResponse()
+	if resp == nil {
+		return fmt.Errorf("no response available")
+	}
+
+	if buffered, ok := resp.Body.(bufferedBody); ok {
+		if _, err := buffered.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		defer func() {
+			_, _ = buffered.Seek(0, io.SeekStart)
+		}()
+		return process(buffered)
+	}
+
+	body := resp.Body
+	resp.Body = consumedBody{}
+	defer func() {
+		_ = body.Close()
+	}()
+	if err := process(body); err != nil {
+		return fmt.Errorf("failed to stream response body: %w", err)
+	}
+	return nil
+}
+
+// BodySize returns the size of the last response body in bytes, streaming it
+type BodySize struct{}
+
+// AnsweredBy returns the number of bytes in the last response body
+func (bs BodySize) AnsweredBy(actor core.Actor, ctx context.Context) (int64, error) {
+	var size int64
+	err := streamBodyOf(actor, func(body io.Reader) error {
+		n, err := io.Copy(io.Discard, body)
+		size = n
+		return err
+	})
+	return size, err
+}
+
+// Description returns the question description
+func (bs BodySize) Description() string {
+	return "the size of the last response body"
+}
+
+// BodySHA256 returns the hex encoded SHA-256 digest of the last response body, streaming it
+type BodySHA256 struct{}
+
+// AnsweredBy returns the SHA-256 digest of the last response body
+func (bd BodySHA256) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	digest := sha256.New()
+	err := streamBodyOf(actor, func(body io.Reader) error {
+		_, err := io.Copy(digest, body)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// Description returns the question description
+func (bd BodySHA256) Description() string {
+	return "the SHA-256 digest of the last response body"
+}
+
+// BodyLines returns the lines of the last response body matching a pattern, streaming it
+type BodyLines struct {
+	pattern string
+}
+
+// BodyLinesMatching creates a question for the lines of the last response body matching
+// the regular expression, e.g. in a large CSV export or log download
+func BodyLinesMatching(pattern string) BodyLines {
+	return BodyLines{pattern: pattern}
+}
+
+// AnsweredBy returns the matching lines, without their line endings
+func (bl BodyLines) AnsweredBy(actor core.Actor, ctx context.Context) ([]string, error) {
+	expression, err := regexp.Compile(bl.pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	matching := []string{}
+	err = streamBodyOf(actor, func(body io.Reader) error {
+		reader := bufio.NewReader(body)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				line = strings.TrimRight(line, "\r\n")
+				if expression.MatchString(line) {
+					matching = append(matching, line)
+				}
+			}
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matching, nil
+}
+
+// Description returns the question description
+func (bl BodyLines) Description() string {
+	return fmt.Sprintf("the lines of the last response body matching '%s'", bl.pattern)
+}
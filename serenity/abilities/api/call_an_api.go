@@ -2,12 +2,15 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 
 	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/abilities/tlsconfig"
 )
 
 // CallAnAPI enables an actor to make HTTP requests to APIs
@@ -23,12 +26,39 @@ type CallAnAPI interface {
 	GetBaseURL() string
 }
 
+// DefaultHeaders is implemented by CallAnAPI abilities that add headers to every request,
+// such as the credentials set up by the auth tasks
+type DefaultHeaders interface {
+	// SetDefaultHeader adds the header to every subsequent request that does not set it
+	SetDefaultHeader(name, value string)
+	// RemoveDefaultHeader stops adding the header to subsequent requests
+	RemoveDefaultHeader(name string)
+	// DefaultHeaderValues returns a copy of the headers added to every request
+	DefaultHeaderValues() http.Header
+}
+
+// RequestSigning is implemented by CallAnAPI abilities that sign requests before sending them
+type RequestSigning interface {
+	// SetRequestSigner signs every subsequent request with the signer, or stops signing them if it is nil
+	SetRequestSigner(signer RequestSigner)
+}
+
 // callAnAPI implements the CallAnAPI interface
 type callAnAPI struct {
-	client       *http.Client
-	baseURL      string
-	lastResponse *http.Response
-	mutex        sync.RWMutex
+	client             *http.Client
+	clientErr          error
+	ownsClient         bool
+	signer             RequestSigner
+	ids                requestIDs
+	maxBufferedBody    int64
+	baseURL            string
+	headers            http.Header
+	lastResponse       *http.Response
+	lastCorrelationID  string
+	lastIdempotencyKey string
+	lastRedirects      []Redirect
+	lastUploadedBytes  atomic.Int64
+	mutex              sync.RWMutex
 }
 
 // Using creates a new CallAnAPI ability with the given HTTP client
@@ -43,18 +73,48 @@ func Using(client *http.Client) CallAnAPI {
 	}
 }
 
-// CallAnApiAt creates a new CallAnAPI ability with the given base URL
-func CallAnApiAt(baseURL string) CallAnAPI {
-	return Using(http.DefaultClient).(*callAnAPI).withBaseURL(baseURL)
+// CallAnApiAt creates a new CallAnAPI ability with the given base URL. Without options it
+// uses http.DefaultClient; options create a client with its own transport, whose idle
+// connections are closed when the ability is discarded.
+func CallAnApiAt(baseURL string, opts ...ClientOption) CallAnAPI {
+	if len(opts) == 0 {
+		return Using(http.DefaultClient).(*callAnAPI).withBaseURL(baseURL)
+	}
+
+	options := newClientOptions(opts)
+	client, err := options.newClient()
+	return (&callAnAPI{
+		client:          client,
+		clientErr:       err,
+		ownsClient:      true,
+		signer:          options.signer,
+		ids:             requestIDs{correlationHeader: options.correlationHeader, idempotencyHeader: options.idempotencyHeader},
+		maxBufferedBody: options.maxBufferedBody,
+	}).withBaseURL(baseURL)
 }
 
 // SendRequest sends an HTTP request and stores the response
 func (c *callAnAPI) SendRequest(req *http.Request, ctx context.Context) (*http.Response, error) {
+	if c.clientErr != nil {
+		return nil, fmt.Errorf("invalid client configuration: %w", c.clientErr)
+	}
+
 	// Apply base URL if request URL is relative
 	c.mutex.RLock()
 	baseURL := c.baseURL
+	headers := c.headers.Clone()
+	signer := c.signer
 	c.mutex.RUnlock()
 
+	client := c.client
+	follow := followsRedirects(req.Context())
+	if overrides := tlsOverridesOf(req.Context()); len(overrides) > 0 {
+		var err error
+		if client, err = c.clientWithTLS(overrides); err != nil {
+			return nil, err
+		}
+	}
+
 	if baseURL != "" && req.URL != nil && !req.URL.IsAbs() {
 		parsedBaseURL, err := url.Parse(baseURL)
 		if err != nil {
@@ -66,7 +126,40 @@ func (c *callAnAPI) SendRequest(req *http.Request, ctx context.Context) (*http.R
 
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	attachesIDs := c.ids != (requestIDs{})
+	if len(headers) > 0 || attachesIDs {
+		// WithContext shares the header map with the caller's request
+		req.Header = req.Header.Clone()
+		if req.Header == nil {
+			req.Header = make(http.Header)
+		}
+	}
+	for name, values := range headers {
+		if req.Header.Get(name) == "" {
+			req.Header[name] = values
+		}
+	}
+
+	if attachesIDs {
+		correlationID, idempotencyKey := c.ids.attach(req)
+		c.mutex.Lock()
+		c.lastCorrelationID, c.lastIdempotencyKey = correlationID, idempotencyKey
+		c.mutex.Unlock()
+	}
+
+	if signer != nil {
+		if err := sign(signer, req); err != nil {
+			return nil, err
+		}
+	}
+
+	c.lastUploadedBytes.Store(0)
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = countingBody{ReadCloser: req.Body, count: &c.lastUploadedBytes}
+	}
+
+	redirects := []Redirect{}
+	resp, err := recordingRedirects(client, follow, &redirects).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -74,6 +167,7 @@ func (c *callAnAPI) SendRequest(req *http.Request, ctx context.Context) (*http.R
 	// Store the response for later retrieval
 	c.mutex.Lock()
 	c.lastResponse = resp
+	c.lastRedirects = redirects
 	c.mutex.Unlock()
 
 	return resp, nil
@@ -86,6 +180,54 @@ func (c *callAnAPI) LastResponse() *http.Response {
 	return c.lastResponse
 }
 
+// LastCorrelationID returns the correlation ID of the last request
+func (c *callAnAPI) LastCorrelationID() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lastCorrelationID
+}
+
+// LastIdempotencyKey returns the idempotency key of the last request
+func (c *callAnAPI) LastIdempotencyKey() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lastIdempotencyKey
+}
+
+// LastRedirects returns the redirects followed by the last request, in order
+func (c *callAnAPI) LastRedirects() []Redirect {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lastRedirects
+}
+
+// LastUploadedBytes returns the number of body bytes sent by the last request
+func (c *callAnAPI) LastUploadedBytes() int64 {
+	return c.lastUploadedBytes.Load()
+}
+
+// maxBodySize returns the size limit of the response bodies questions load into memory,
+// or 0 if there is none
+func (c *callAnAPI) maxBodySize() int64 {
+	return c.maxBufferedBody
+}
+
+// Inspect describes the base URL and the last response
+func (c *callAnAPI) Inspect() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	state := "base URL " + c.baseURL
+	if c.baseURL == "" {
+		state = "no base URL"
+	}
+
+	if c.lastResponse == nil {
+		return state + ", no response yet"
+	}
+	return fmt.Sprintf("%s, last response %s (%s)", state, c.lastResponse.Status, c.lastResponse.Header.Get("Content-Type"))
+}
+
 // SetBaseURL sets the base URL for subsequent requests
 func (c *callAnAPI) SetBaseURL(baseURL string) error {
 	_, err := url.Parse(baseURL)
@@ -99,6 +241,60 @@ func (c *callAnAPI) SetBaseURL(baseURL string) error {
 	return nil
 }
 
+// SetDefaultHeader adds the header to every subsequent request that does not set it
+func (c *callAnAPI) SetDefaultHeader(name, value string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.headers == nil {
+		c.headers = make(http.Header)
+	}
+	c.headers.Set(name, value)
+}
+
+// RemoveDefaultHeader stops adding the header to subsequent requests
+func (c *callAnAPI) RemoveDefaultHeader(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.headers.Del(name)
+}
+
+// DefaultHeaderValues returns a copy of the headers added to every request
+func (c *callAnAPI) DefaultHeaderValues() http.Header {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.headers.Clone()
+}
+
+// SetRequestSigner signs every subsequent request with the signer, or stops signing them if it is nil
+func (c *callAnAPI) SetRequestSigner(signer RequestSigner) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.signer = signer
+}
+
+// Discard closes the idle connections of a client created from options. A client passed
+// to Using belongs to the caller and is left open.
+func (c *callAnAPI) Discard() error {
+	if c.ownsClient && c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Attachments returns the HAR file of the traffic captured with WithTrafficCapture
+func (c *callAnAPI) Attachments() []abilities.Attachment {
+	if c.client == nil {
+		return nil
+	}
+	if capture, ok := c.client.Transport.(*harCapture); ok {
+		return capture.log.attachments()
+	}
+	return nil
+}
+
 // GetBaseURL returns the current base URL
 func (c *callAnAPI) GetBaseURL() string {
 	c.mutex.RLock()
@@ -106,6 +302,58 @@ func (c *callAnAPI) GetBaseURL() string {
 	return c.baseURL
 }
 
+// tlsOverridesKey is the context key of the TLS overrides of a request
+type tlsOverridesKey struct{}
+
+// withTLSOverrides returns a context carrying TLS overrides for the request built with it
+func withTLSOverrides(ctx context.Context, opts []tlsconfig.Option) context.Context {
+	return context.WithValue(ctx, tlsOverridesKey{}, opts)
+}
+
+// tlsOverridesOf returns the TLS overrides carried by the request context
+func tlsOverridesOf(ctx context.Context) []tlsconfig.Option {
+	opts, _ := ctx.Value(tlsOverridesKey{}).([]tlsconfig.Option)
+	return opts
+}
+
+// clientWithTLS returns a copy of the client whose transport uses the TLS configuration
+// changed by the overrides. Its connection is closed after the request, so it is not
+// reused by requests without the overrides.
+func (c *callAnAPI) clientWithTLS(opts []tlsconfig.Option) (*http.Client, error) {
+	next := c.client.Transport
+	capture, capturing := next.(*harCapture)
+	if capturing {
+		next = capture.next
+	}
+
+	var transport *http.Transport
+	switch t := next.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, fmt.Errorf("TLS overrides need an *http.Transport, the client uses %T", t)
+	}
+
+	config := transport.TLSClientConfig
+	if config == nil {
+		config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if err := tlsconfig.Apply(config, opts...); err != nil {
+		return nil, fmt.Errorf("invalid TLS overrides: %w", err)
+	}
+	transport.TLSClientConfig = config
+	transport.DisableKeepAlives = true
+
+	client := *c.client
+	client.Transport = transport
+	if capturing {
+		client.Transport = &harCapture{next: transport, log: capture.log}
+	}
+	return &client, nil
+}
+
 // withBaseURL sets the base URL and returns the ability for chaining
 func (c *callAnAPI) withBaseURL(baseURL string) CallAnAPI {
 	c.mutex.Lock()
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Subjects of the notes taken on the IDs attached to the last request
+const (
+	CorrelationIDNote  = "correlation id"
+	IdempotencyKeyNote = "idempotency key"
+)
+
+// CorrelatedRequests is implemented by CallAnAPI abilities that attach IDs to every request,
+// so that it can be found in the logs of the system under test
+type CorrelatedRequests interface {
+	// LastCorrelationID returns the correlation ID of the last request, or an empty string
+	LastCorrelationID() string
+	// LastIdempotencyKey returns the idempotency key of the last request, or an empty string
+	LastIdempotencyKey() string
+}
+
+// WithCorrelationIDs attaches a new random ID in the header, "X-Correlation-ID" if empty, to
+// every request that does not set one. The ID of the last request is noted under
+// CorrelationIDNote and answers LastCorrelationID.
+func WithCorrelationIDs(header string) ClientOption {
+	if header == "" {
+		header = "X-Correlation-ID"
+	}
+	return func(o *clientOptions) {
+		o.correlationHeader = header
+	}
+}
+
+// WithIdempotencyKeys attaches a new random key in the header, "Idempotency-Key" if empty,
+// to every POST and PATCH request that does not set one, so that retried requests are not
+// applied twice. The key of the last request is noted under IdempotencyKeyNote.
+func WithIdempotencyKeys(header string) ClientOption {
+	if header == "" {
+		header = "Idempotency-Key"
+	}
+	return func(o *clientOptions) {
+		o.idempotencyHeader = header
+	}
+}
+
+// requestIDs attaches IDs to requests
+type requestIDs struct {
+	correlationHeader string
+	idempotencyHeader string
+}
+
+// attach sets the IDs missing from the request headers and returns the IDs the request carries
+func (r requestIDs) attach(req *http.Request) (correlationID, idempotencyKey string) {
+	if r.correlationHeader != "" {
+		correlationID = headerOrNewID(req, r.correlationHeader)
+	}
+	if r.idempotencyHeader != "" && (req.Method == http.MethodPost || req.Method == http.MethodPatch) {
+		idempotencyKey = headerOrNewID(req, r.idempotencyHeader)
+	}
+	return correlationID, idempotencyKey
+}
+
+// headerOrNewID returns the value of the header, setting it to a new ID if it is missing
+func headerOrNewID(req *http.Request, header string) string {
+	if value := req.Header.Get(header); value != "" {
+		return value
+	}
+	id := newRequestID()
+	req.Header.Set(header, id)
+	return id
+}
+
+// newRequestID returns a random version 4 UUID
+func newRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:])
+}
+
+// LastCorrelationID returns the correlation ID attached to the last request
+type LastCorrelationID struct{}
+
+// AnsweredBy returns the correlation ID of the last request sent with the actor's CallAnAPI ability
+func (lc LastCorrelationID) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
+	if err != nil {
+		return "", fmt.Errorf("actor does not have the ability to call an API: %w", err)
+	}
+
+	correlated, ok := callAbility.(CorrelatedRequests)
+	if !ok || correlated.LastCorrelationID() == "" {
+		return "", fmt.Errorf("no correlation ID attached; create the ability with WithCorrelationIDs")
+	}
+	return correlated.LastCorrelationID(), nil
+}
+
+// Description returns the question description
+func (lc LastCorrelationID) Description() string {
+	return "the correlation ID of the last request"
+}
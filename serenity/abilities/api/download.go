@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// DownloadNote is the subject of the note taken on the last file downloaded with DownloadTo
+const DownloadNote = "last download"
+
+// Download describes a response body saved to disk
+type Download struct {
+	// Path is the file the body was saved to
+	Path string
+	// Size is the number of bytes saved
+	Size int64
+	// SHA256 is the hex encoded SHA-256 digest of the saved bytes
+	SHA256 string
+}
+
+// DownloadActivity is an interaction that saves a response body to a file
+type DownloadActivity struct {
+	path     string
+	request  core.Activity
+	location core.Location
+}
+
+// DownloadTo creates an interaction that streams the last response body to the file,
+// creating its directory, and notes its size and digest under DownloadNote for the
+// DownloadedSize and DownloadedSHA256 questions. Like the streaming questions, it
+// consumes a body not yet read by other questions.
+//
+//	actor.AttemptsTo(
+//		api.DownloadTo("out/invoice.pdf").From(api.SendGetRequest("/invoices/42.pdf")),
+//		ensure.That(api.DownloadedSHA256{}, expectations.Equals(publishedDigest)),
+//	)
+func DownloadTo(path string) *DownloadActivity {
+	return &DownloadActivity{path: path, location: core.CallerLocation()}
+}
+
+// From sends the request first and downloads its response instead of the last one
+func (d *DownloadActivity) From(request core.Activity) *DownloadActivity {
+	d.request = request
+	return d
+}
+
+// Description returns the interaction description
+func (d *DownloadActivity) Description() string {
+	if d.request != nil {
+		return fmt.Sprintf("%s and downloads the response to %s", d.request.Description(), d.path)
+	}
+	return fmt.Sprintf("#actor downloads the last response to %s", d.path)
+}
+
+// Location returns where the interaction was constructed
+func (d *DownloadActivity) Location() core.Location {
+	return d.location
+}
+
+// FailureMode returns the failure mode for downloads (default: FailFast)
+func (d *DownloadActivity) FailureMode() core.FailureMode {
+	return core.FailFast
+}
+
+// PerformAs sends the request, if any, and saves the response body to the file
+func (d *DownloadActivity) PerformAs(actor core.Actor, ctx context.Context) error {
+	if d.request != nil {
+		if err := d.request.PerformAs(actor, ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(d.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	download := Download{Path: d.path}
+	err := streamBodyOf(actor, func(body io.Reader) error {
+		file, err := os.Create(d.path)
+		if err != nil {
+			return err
+		}
+
+		digest := sha256.New()
+		download.Size, err = io.Copy(io.MultiWriter(file, digest), body)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(d.path)
+			return err
+		}
+		download.SHA256 = hex.EncodeToString(digest.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to download to %s: %w", d.path, err)
+	}
+
+	notes.Of(actor).Record(DownloadNote, download)
+	return nil
+}
+
+// lastDownloadOf returns the last download noted by the actor
+func lastDownloadOf(actor core.Actor) (Download, error) {
+	note, ok := notes.Of(actor).Recall(DownloadNote)
+	if !ok {
+		return Download{}, fmt.Errorf("nothing downloaded yet")
+	}
+	download, ok := note.(Download)
+	if !ok {
+		return Download{}, fmt.Errorf("the %s note holds %T, not a download", DownloadNote, note)
+	}
+	return download, nil
+}
+
+// DownloadedSize returns the size in bytes of the last file downloaded with DownloadTo
+type DownloadedSize struct{}
+
+// AnsweredBy returns the number of bytes saved by the last download
+func (ds DownloadedSize) AnsweredBy(actor core.Actor, ctx context.Context) (int64, error) {
+	download, err := lastDownloadOf(actor)
+	return download.Size, err
+}
+
+// Description returns the question description
+func (ds DownloadedSize) Description() string {
+	return "the size of the last download"
+}
+
+// DownloadedSHA256 returns the hex encoded SHA-256 digest of the last file downloaded with DownloadTo
+type DownloadedSHA256 struct{}
+
+// AnsweredBy returns the SHA-256 digest of the bytes saved by the last download
+func (dd DownloadedSHA256) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	download, err := lastDownloadOf(actor)
+	return download.SHA256, err
+}
+
+// Description returns the question description
+func (dd DownloadedSHA256) Description() string {
+	return "the SHA-256 digest of the last download"
+}
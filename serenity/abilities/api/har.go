@@ -0,0 +1,263 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// HARAttachment is the name of the HAR file attached by abilities capturing their traffic
+const HARAttachment = "traffic.har"
+
+// WithTrafficCapture records every request and response, and attaches them to the report
+// as a HAR file when the test finishes. Any registered secret values are masked. Open the
+// file in the network panel of a browser's developer tools to inspect the traffic.
+func WithTrafficCapture() ClientOption {
+	return func(o *clientOptions) {
+		o.capture = true
+	}
+}
+
+// harLog holds the entries of a HAR file
+type harLog struct {
+	mutex   sync.Mutex
+	entries []harEntry
+}
+
+// add records an entry
+func (l *harLog) add(entry harEntry) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// attachments returns the HAR file of the recorded entries, or nothing if no request was sent
+func (l *harLog) attachments() []abilities.Attachment {
+	l.mutex.Lock()
+	entries := l.entries
+	l.mutex.Unlock()
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	document := map[string]any{"log": map[string]any{
+		"version": "1.2",
+		"creator": map[string]string{"name": "serenity-go", "version": "1"},
+		"entries": entries,
+	}}
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return []abilities.Attachment{{
+		Name:      HARAttachment,
+		MediaType: "application/json",
+		Content:   []byte(secrets.Mask(string(content))),
+	}}
+}
+
+// harCapture is a transport recording the traffic sent through it
+type harCapture struct {
+	next http.RoundTripper
+	log  *harLog
+}
+
+// RoundTrip sends the request and records it with the response. The response body is read
+// to record it, and replaced with a copy.
+func (h *harCapture) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := requestBodyOf(req)
+	if err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	resp, err := h.next.RoundTrip(req)
+	waited := time.Since(started)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if err != nil {
+		return nil, err
+	}
+	received := time.Since(started) - waited
+
+	h.log.add(harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            milliseconds(waited + received),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Cookies:     []harNameVal{},
+			Headers:     harHeaders(req.Header),
+			QueryString: harQuery(req),
+			PostData:    harPostDataOf(req, requestBody),
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  strings.TrimSpace(strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode))),
+			HTTPVersion: resp.Proto,
+			Cookies:     []harNameVal{},
+			Headers:     harHeaders(resp.Header),
+			Content:     harContentOf(resp.Header.Get("Content-Type"), responseBody),
+			RedirectURL: resp.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(responseBody),
+		},
+		Cache:   struct{}{},
+		Timings: harTimings{Send: 0, Wait: milliseconds(waited), Receive: milliseconds(received)},
+	})
+	return resp, nil
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport
+func (h *harCapture) CloseIdleConnections() {
+	if closer, ok := h.next.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// requestBodyOf reads the request body without consuming it
+func requestBodyOf(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// milliseconds returns the duration in milliseconds, as HAR timings are
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// HAR 1.2 structures, see http://www.softwareishard.com/blog/har-12-spec/
+type (
+	harEntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+	}
+
+	harRequest struct {
+		Method      string       `json:"method"`
+		URL         string       `json:"url"`
+		HTTPVersion string       `json:"httpVersion"`
+		Cookies     []harNameVal `json:"cookies"`
+		Headers     []harNameVal `json:"headers"`
+		QueryString []harNameVal `json:"queryString"`
+		PostData    *harPostData `json:"postData,omitempty"`
+		HeadersSize int          `json:"headersSize"`
+		BodySize    int          `json:"bodySize"`
+	}
+
+	harResponse struct {
+		Status      int          `json:"status"`
+		StatusText  string       `json:"statusText"`
+		HTTPVersion string       `json:"httpVersion"`
+		Cookies     []harNameVal `json:"cookies"`
+		Headers     []harNameVal `json:"headers"`
+		Content     harContent   `json:"content"`
+		RedirectURL string       `json:"redirectURL"`
+		HeadersSize int          `json:"headersSize"`
+		BodySize    int          `json:"bodySize"`
+	}
+
+	harNameVal struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+		Encoding string `json:"encoding,omitempty"`
+	}
+
+	harTimings struct {
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	}
+)
+
+// harHeaders lists the header fields in the order of their names
+func harHeaders(header http.Header) []harNameVal {
+	fields := []harNameVal{}
+	for _, name := range slices.Sorted(maps.Keys(header)) {
+		for _, value := range header[name] {
+			fields = append(fields, harNameVal{Name: name, Value: value})
+		}
+	}
+	return fields
+}
+
+// harQuery lists the query parameters of the request URL in the order of their names
+func harQuery(req *http.Request) []harNameVal {
+	params := []harNameVal{}
+	query := req.URL.Query()
+	for _, name := range slices.Sorted(maps.Keys(query)) {
+		for _, value := range query[name] {
+			params = append(params, harNameVal{Name: name, Value: value})
+		}
+	}
+	return params
+}
+
+// harPostDataOf describes the request body, or returns nil for requests without one
+func harPostDataOf(req *http.Request, body []byte) *harPostData {
+	if len(body) == 0 {
+		return nil
+	}
+	content := harContentOf(req.Header.Get("Content-Type"), body)
+	text := content.Text
+	if content.Encoding != "" {
+		text = "(binary, " + content.MimeType + ")"
+	}
+	return &harPostData{MimeType: content.MimeType, Text: text}
+}
+
+// harContentOf describes a body, base64-encoding it unless it is text
+func harContentOf(mediaType string, body []byte) harContent {
+	content := harContent{Size: len(body), MimeType: mediaType}
+	if utf8.Valid(body) {
+		content.Text = string(body)
+	} else {
+		content.Text = base64.StdEncoding.EncodeToString(body)
+		content.Encoding = "base64"
+	}
+	return content
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// MediaType is a parsed Content-Type header
+type MediaType struct {
+	// Type is the lowercase media type, e.g. "application/json"
+	Type string
+	// Parameters holds the parameters with lowercase names, e.g. "charset"
+	Parameters map[string]string
+}
+
+// String formats the media type as a header value
+func (m MediaType) String() string {
+	return mime.FormatMediaType(m.Type, m.Parameters)
+}
+
+// CacheDirectives are the directives of a Cache-Control header by lowercase name, with an
+// empty value for directives without one, e.g. "no-store"
+type CacheDirectives map[string]string
+
+// Has reports whether the directive is present
+func (c CacheDirectives) Has(directive string) bool {
+	_, ok := c[strings.ToLower(directive)]
+	return ok
+}
+
+// MaxAge returns the max-age directive and whether it is present and valid
+func (c CacheDirectives) MaxAge() (time.Duration, bool) {
+	seconds, err := strconv.Atoi(c["max-age"])
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// parseCacheControl parses the directives of the Cache-Control header values
+func parseCacheControl(values []string) CacheDirectives {
+	directives := CacheDirectives{}
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			name, argument, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name == "" {
+				continue
+			}
+			directives[strings.ToLower(name)] = strings.Trim(argument, `"`)
+		}
+	}
+	return directives
+}
+
+// lastResponseOf returns the last response received with the actor's CallAnAPI ability
+func lastResponseOf(actor core.Actor) (*http.Response, error) {
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to call an API: %w", err)
+	}
+
+	resp := callAbility.LastResponse()
+	if resp == nil {
+		return nil, fmt.Errorf("no response available")
+	}
+	return resp, nil
+}
+
+// ResponseContentType returns the parsed Content-Type header of the last response
+type ResponseContentType struct{}
+
+// ContentType creates a question for the media type of the last response
+func ContentType() ResponseContentType {
+	return ResponseContentType{}
+}
+
+// AnsweredBy returns the media type of the last HTTP response
+func (rc ResponseContentType) AnsweredBy(actor core.Actor, ctx context.Context) (MediaType, error) {
+	resp, err := lastResponseOf(actor)
+	if err != nil {
+		return MediaType{}, err
+	}
+
+	header := resp.Header.Get("Content-Type")
+	if header == "" {
+		return MediaType{}, fmt.Errorf("the last response has no Content-Type header")
+	}
+	mediaType, parameters, err := mime.ParseMediaType(header)
+	if err != nil {
+		return MediaType{}, fmt.Errorf("invalid Content-Type header '%s': %w", header, err)
+	}
+	return MediaType{Type: mediaType, Parameters: parameters}, nil
+}
+
+// Description returns the question description
+func (rc ResponseContentType) Description() string {
+	return "the content type of the last response"
+}
+
+// ResponseCacheControl returns the parsed Cache-Control header of the last response
+type ResponseCacheControl struct{}
+
+// CacheControl creates a question for the caching directives of the last response
+func CacheControl() ResponseCacheControl {
+	return ResponseCacheControl{}
+}
+
+// AnsweredBy returns the caching directives of the last HTTP response, empty if there are none
+func (rc ResponseCacheControl) AnsweredBy(actor core.Actor, ctx context.Context) (CacheDirectives, error) {
+	resp, err := lastResponseOf(actor)
+	if err != nil {
+		return nil, err
+	}
+	return parseCacheControl(resp.Header.Values("Cache-Control")), nil
+}
+
+// Description returns the question description
+func (rc ResponseCacheControl) Description() string {
+	return "the cache control of the last response"
+}
+
+// ResponseLocation returns the Location header of the last response
+type ResponseLocation struct{}
+
+// LocationHeader creates a question for the location the last response points to
+func LocationHeader() ResponseLocation {
+	return ResponseLocation{}
+}
+
+// AnsweredBy returns the Location header of the last HTTP response, resolved against the
+// request URL
+func (rl ResponseLocation) AnsweredBy(actor core.Actor, ctx context.Context) (*url.URL, error) {
+	resp, err := lastResponseOf(actor)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Location header: %w", err)
+	}
+	return location, nil
+}
+
+// Description returns the question description
+func (rl ResponseLocation) Description() string {
+	return "the location of the last response"
+}
+
+// ResponseCookies returns the cookies set by the last response
+type ResponseCookies struct{}
+
+// SetCookies creates a question for the cookies set by the last response
+func SetCookies() ResponseCookies {
+	return ResponseCookies{}
+}
+
+// AnsweredBy returns the cookies parsed from the Set-Cookie headers of the last HTTP response
+func (rc ResponseCookies) AnsweredBy(actor core.Actor, ctx context.Context) ([]*http.Cookie, error) {
+	resp, err := lastResponseOf(actor)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Cookies(), nil
+}
+
+// Description returns the question description
+func (rc ResponseCookies) Description() string {
+	return "the cookies set by the last response"
+}
+
+// ResponseHeaders returns all headers of the last response
+type ResponseHeaders struct{}
+
+// AllHeaders creates a question for all headers of the last response
+func AllHeaders() ResponseHeaders {
+	return ResponseHeaders{}
+}
+
+// AnsweredBy returns a copy of the headers of the last HTTP response
+func (rh ResponseHeaders) AnsweredBy(actor core.Actor, ctx context.Context) (http.Header, error) {
+	resp, err := lastResponseOf(actor)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Header.Clone(), nil
+}
+
+// Description returns the question description
+func (rh ResponseHeaders) Description() string {
+	return "the headers of the last response"
+}
+
+// headerExpectation is an expectation on a typed header value
+type headerExpectation[T any] struct {
+	description string
+	evaluate    func(actual T) error
+}
+
+// Evaluate evaluates the expectation
+func (he headerExpectation[T]) Evaluate(actual T) error {
+	return he.evaluate(actual)
+}
+
+// Description returns the expectation description
+func (he headerExpectation[T]) Description() string {
+	return he.description
+}
+
+// HasMediaType expects a media type, ignoring its parameters, e.g. "application/json"
+func HasMediaType(mediaType string) ensure.Expectation[MediaType] {
+	return headerExpectation[MediaType]{
+		description: fmt.Sprintf("has media type '%s'", mediaType),
+		evaluate: func(actual MediaType) error {
+			if !strings.EqualFold(actual.Type, mediaType) {
+				return core.NewAssertionError(mediaType, actual.Type, "expected media type '%s', but got '%s'", mediaType, actual.Type)
+			}
+			return nil
+		},
+	}
+}
+
+// HasCacheDirective expects a caching directive, e.g. "no-store"
+func HasCacheDirective(directive string) ensure.Expectation[CacheDirectives] {
+	return headerExpectation[CacheDirectives]{
+		description: fmt.Sprintf("has cache directive '%s'", directive),
+		evaluate: func(actual CacheDirectives) error {
+			if !actual.Has(directive) {
+				return core.NewAssertionError(directive, actual, "expected cache directive '%s', but got %v", directive, actual)
+			}
+			return nil
+		},
+	}
+}
+
+// HasPath expects a location with the path, e.g. "/orders/42"
+func HasPath(path string) ensure.Expectation[*url.URL] {
+	return headerExpectation[*url.URL]{
+		description: fmt.Sprintf("has path '%s'", path),
+		evaluate: func(actual *url.URL) error {
+			if actual == nil || actual.Path != path {
+				return core.NewAssertionError(path, actual, "expected location with path '%s', but got '%v'", path, actual)
+			}
+			return nil
+		},
+	}
+}
+
+// HasCookie expects a cookie with the name; the cookie must also satisfy any checks given,
+// e.g. func(c *http.Cookie) bool { return c.HttpOnly }
+func HasCookie(name string, checks ...func(cookie *http.Cookie) bool) ensure.Expectation[[]*http.Cookie] {
+	return headerExpectation[[]*http.Cookie]{
+		description: fmt.Sprintf("has cookie '%s'", name),
+		evaluate: func(actual []*http.Cookie) error {
+			for _, cookie := range actual {
+				if cookie.Name != name {
+					continue
+				}
+				for _, check := range checks {
+					if !check(cookie) {
+						return core.NewAssertionError(name, cookie.String(), "cookie '%s' does not satisfy the checks: %s", name, cookie)
+					}
+				}
+				return nil
+			}
+			return core.NewAssertionError(name, actual, "expected cookie '%s', but got %v", name, actual)
+		},
+	}
+}
+
+// HasHeader expects a header with the name, case-insensitively
+func HasHeader(name string) ensure.Expectation[http.Header] {
+	return headerExpectation[http.Header]{
+		description: fmt.Sprintf("has header '%s'", name),
+		evaluate: func(actual http.Header) error {
+			if len(actual.Values(name)) == 0 {
+				return core.NewAssertionError(name, actual, "expected header '%s', but got %v", name, actual)
+			}
+			return nil
+		},
+	}
+}
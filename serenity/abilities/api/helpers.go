@@ -14,27 +14,136 @@ func SendRequest(req *http.Request) core.Activity {
 // SendGetRequest creates GET request activity with fluent interface
 func SendGetRequest(url string) *RequestActivity {
 	return &RequestActivity{
-		builder: NewRequestBuilder("GET", url),
+		builder:  NewRequestBuilder("GET", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendGetRequestTo creates GET request activity to the URL answered by the question when
+// the request is sent
+func SendGetRequestTo(url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder("GET", url),
+		location: core.CallerLocation(),
 	}
 }
 
 // SendPostRequest creates POST request activity with fluent interface
 func SendPostRequest(url string) *RequestActivity {
 	return &RequestActivity{
-		builder: NewRequestBuilder("POST", url),
+		builder:  NewRequestBuilder("POST", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendPostRequestTo creates POST request activity to the URL answered by the question when
+// the request is sent
+func SendPostRequestTo(url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder("POST", url),
+		location: core.CallerLocation(),
 	}
 }
 
 // SendPutRequest creates PUT request activity with fluent interface
 func SendPutRequest(url string) *RequestActivity {
 	return &RequestActivity{
-		builder: NewRequestBuilder("PUT", url),
+		builder:  NewRequestBuilder("PUT", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendPutRequestTo creates PUT request activity to the URL answered by the question when
+// the request is sent
+func SendPutRequestTo(url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder("PUT", url),
+		location: core.CallerLocation(),
 	}
 }
 
 // SendDeleteRequest creates DELETE request activity with fluent interface
 func SendDeleteRequest(url string) *RequestActivity {
 	return &RequestActivity{
-		builder: NewRequestBuilder("DELETE", url),
+		builder:  NewRequestBuilder("DELETE", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendDeleteRequestTo creates DELETE request activity to the URL answered by the question when
+// the request is sent
+func SendDeleteRequestTo(url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder("DELETE", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendPatchRequest creates PATCH request activity with fluent interface
+func SendPatchRequest(url string) *RequestActivity {
+	return &RequestActivity{
+		builder:  NewRequestBuilder("PATCH", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendPatchRequestTo creates PATCH request activity to the URL answered by the question when
+// the request is sent
+func SendPatchRequestTo(url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder("PATCH", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendHeadRequest creates HEAD request activity with fluent interface
+func SendHeadRequest(url string) *RequestActivity {
+	return &RequestActivity{
+		builder:  NewRequestBuilder("HEAD", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendHeadRequestTo creates HEAD request activity to the URL answered by the question when
+// the request is sent
+func SendHeadRequestTo(url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder("HEAD", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendOptionsRequest creates OPTIONS request activity with fluent interface
+func SendOptionsRequest(url string) *RequestActivity {
+	return &RequestActivity{
+		builder:  NewRequestBuilder("OPTIONS", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendOptionsRequestTo creates OPTIONS request activity to the URL answered by the question when
+// the request is sent
+func SendOptionsRequestTo(url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder("OPTIONS", url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendRequestWithMethod creates request activity with fluent interface for any method,
+// such as a WebDAV "PROPFIND" or a custom "PURGE"
+func SendRequestWithMethod(method, url string) *RequestActivity {
+	return &RequestActivity{
+		builder:  NewRequestBuilder(method, url),
+		location: core.CallerLocation(),
+	}
+}
+
+// SendRequestWithMethodTo creates request activity for any method to the URL answered by
+// the question when the request is sent
+func SendRequestWithMethodTo(method string, url core.Question[string]) *RequestActivity {
+	return &RequestActivity{
+		builder:  newQuestionRequestBuilder(method, url),
+		location: core.CallerLocation(),
 	}
 }
@@ -3,22 +3,37 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/abilities/tlsconfig"
+	"github.com/nchursin/serenity-go/serenity/answerable"
 	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/secrets"
 )
 
 // sendRequest is an interaction that sends an HTTP request
 type sendRequest struct {
-	request *http.Request
+	request  *http.Request
+	location core.Location
 }
 
 // a creates a new SendRequest interaction
 func a(req *http.Request) core.Activity {
-	return &sendRequest{request: req}
+	return &sendRequest{request: req, location: core.CallerLocation()}
+}
+
+// Location returns where the interaction was constructed
+func (s *sendRequest) Location() core.Location {
+	return s.location
 }
 
 // Description returns the interaction description
@@ -36,14 +51,15 @@ func (s *sendRequest) PerformAs(actor core.Actor, ctx context.Context) error {
 		return fmt.Errorf("request is nil")
 	}
 
-	ability, err := actor.AbilityTo(&callAnAPI{})
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
 	if err != nil {
 		return fmt.Errorf("actor does not have the ability to call an API: %w", err)
 	}
 
-	callAbility := ability.(CallAnAPI)
-
-	_, err = callAbility.SendRequest(s.request, ctx)
+	start := time.Now()
+	resp, err := callAbility.SendRequest(s.request, ctx)
+	publishRequestSent(actor, s.request, resp, time.Since(start), err)
+	noteRequestIDs(actor, callAbility)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
@@ -51,6 +67,46 @@ func (s *sendRequest) PerformAs(actor core.Actor, ctx context.Context) error {
 	return nil
 }
 
+// publishRequestSent announces the request, e.g. for the endpoint coverage. The ability
+// resolves the URL of the request against its base URL before sending it, and the request
+// of the response carries the headers it added.
+func publishRequestSent(actor core.Actor, req *http.Request, resp *http.Response, duration time.Duration, err error) {
+	event := events.RequestSent{
+		Actor:    actor.Name(),
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Duration: duration,
+		Err:      err,
+		Request:  req,
+		Response: resp,
+	}
+	if event.Method == "" {
+		event.Method = http.MethodGet
+	}
+	if resp != nil {
+		event.Status = resp.StatusCode
+		if resp.Request != nil {
+			event.Request = resp.Request
+		}
+	}
+	core.Publish(actor, event)
+}
+
+// noteRequestIDs notes the IDs the ability attached to the last request, so they can be
+// cross-referenced with the logs of the system under test even when the request failed
+func noteRequestIDs(actor core.Actor, callAbility CallAnAPI) {
+	correlated, ok := callAbility.(CorrelatedRequests)
+	if !ok {
+		return
+	}
+	if id := correlated.LastCorrelationID(); id != "" {
+		notes.Of(actor).Record(CorrelationIDNote, id)
+	}
+	if key := correlated.LastIdempotencyKey(); key != "" {
+		notes.Of(actor).Record(IdempotencyKeyNote, key)
+	}
+}
+
 // FailureMode returns the failure mode for send requests (default: FailFast)
 func (s *sendRequest) FailureMode() core.FailureMode {
 	return core.FailFast
@@ -58,10 +114,23 @@ func (s *sendRequest) FailureMode() core.FailureMode {
 
 // RequestBuilder helps build HTTP requests with fluent interface
 type RequestBuilder struct {
-	method  string
-	url     string
-	headers map[string]string
-	body    io.Reader
+	method        string
+	url           string
+	urlQuestion   core.Question[string]
+	headers       map[string]string
+	secretHeaders map[string]secretHeader
+	body          io.Reader
+	bodyParam     any
+	tls           []tlsconfig.Option
+	noRedirects   bool
+	form          *multipartForm
+	progress      UploadProgressFunc
+}
+
+// secretHeader is a header whose value is read from a secrets provider when the request is built
+type secretHeader struct {
+	secret secrets.Secret
+	format func(value string) string
 }
 
 // NewRequestBuilder creates a new request builder
@@ -73,13 +142,24 @@ func NewRequestBuilder(method, url string) *RequestBuilder {
 	}
 }
 
+// newQuestionRequestBuilder creates a new request builder for a URL answered by the
+// question when the request is sent
+func newQuestionRequestBuilder(method string, url core.Question[string]) *RequestBuilder {
+	rb := NewRequestBuilder(method, "")
+	rb.urlQuestion = url
+	return rb
+}
+
 // Method returns the HTTP method
 func (rb *RequestBuilder) Method() string {
 	return rb.method
 }
 
-// URL returns the request URL
+// URL returns the request URL, or the description of the question answering it
 func (rb *RequestBuilder) URL() string {
+	if rb.urlQuestion != nil {
+		return answerable.Describe(rb.urlQuestion)
+	}
 	return rb.url
 }
 
@@ -97,6 +177,75 @@ func (rb *RequestBuilder) WithHeaders(headers map[string]string) *RequestBuilder
 	return rb
 }
 
+// WithSecretHeader adds a header whose value is resolved from the secret when the request is built.
+// The value is registered for masking, so it never appears in reports or error messages.
+func (rb *RequestBuilder) WithSecretHeader(key string, secret secrets.Secret) *RequestBuilder {
+	return rb.withSecretHeader(key, secret, func(value string) string { return value })
+}
+
+// WithBearerToken sets the Authorization header to a bearer token read from the secret
+func (rb *RequestBuilder) WithBearerToken(token secrets.Secret) *RequestBuilder {
+	return rb.withSecretHeader("Authorization", token, func(value string) string {
+		return "Bearer " + value
+	})
+}
+
+// WithBasicAuth sets the Authorization header to basic credentials with a password read from the secret
+func (rb *RequestBuilder) WithBasicAuth(username string, password secrets.Secret) *RequestBuilder {
+	return rb.withSecretHeader("Authorization", password, func(value string) string {
+		encoded := base64.StdEncoding.EncodeToString([]byte(username + ":" + value))
+		secrets.Register(encoded)
+		return "Basic " + encoded
+	})
+}
+
+func (rb *RequestBuilder) withSecretHeader(key string, secret secrets.Secret, format func(string) string) *RequestBuilder {
+	if rb.secretHeaders == nil {
+		rb.secretHeaders = make(map[string]secretHeader)
+	}
+	rb.secretHeaders[key] = secretHeader{secret: secret, format: format}
+	return rb
+}
+
+// WithTLS changes the TLS configuration of the ability for this request only, e.g. to
+// present a different client certificate
+func (rb *RequestBuilder) WithTLS(opts ...tlsconfig.Option) *RequestBuilder {
+	rb.tls = append(rb.tls, opts...)
+	return rb
+}
+
+// WithoutRedirects returns the redirect response of this request instead of following it
+func (rb *RequestBuilder) WithoutRedirects() *RequestBuilder {
+	rb.noRedirects = true
+	return rb
+}
+
+// WithFormField adds a field to the multipart/form-data body of the request
+func (rb *RequestBuilder) WithFormField(name, value string) *RequestBuilder {
+	if rb.form == nil {
+		rb.form = newMultipartForm()
+	}
+	rb.form.parts = append(rb.form.parts, formPart{name: name, value: value})
+	return rb
+}
+
+// WithFile adds the file to the multipart/form-data body of the request. The file is
+// streamed from disk as the request is sent, so it is never held in memory, unless the
+// request is signed or its traffic captured.
+func (rb *RequestBuilder) WithFile(field, path string) *RequestBuilder {
+	if rb.form == nil {
+		rb.form = newMultipartForm()
+	}
+	rb.form.parts = append(rb.form.parts, formPart{name: field, path: path})
+	return rb
+}
+
+// WithUploadProgress calls progress as the request body is sent
+func (rb *RequestBuilder) WithUploadProgress(progress UploadProgressFunc) *RequestBuilder {
+	rb.progress = progress
+	return rb
+}
+
 // WithBody sets the request body
 func (rb *RequestBuilder) WithBody(body io.Reader) *RequestBuilder {
 	rb.body = body
@@ -152,22 +301,151 @@ func (rb *RequestBuilder) With(data interface{}) *RequestBuilder {
 
 // Build creates the HTTP request
 func (rb *RequestBuilder) Build() (*http.Request, error) {
-	req, err := http.NewRequest(rb.method, rb.url, rb.body)
+	return rb.BuildWithContext(context.Background())
+}
+
+// BuildWithContext creates the HTTP request, resolving secret headers with the given context
+func (rb *RequestBuilder) BuildWithContext(ctx context.Context) (*http.Request, error) {
+	return rb.buildWith(ctx, nil)
+}
+
+// buildWith creates the HTTP request. With an actor, the note placeholders of the URL,
+// header values, form fields and in-memory bodies are resolved from the actor's notes.
+func (rb *RequestBuilder) buildWith(ctx context.Context, actor core.Actor) (*http.Request, error) {
+	resolve := func(text string) (string, error) { return text, nil }
+	resolveBody := resolve
+	if actor != nil {
+		resolve = func(text string) (string, error) { return notes.Resolve(actor, text) }
+		resolveBody = resolve
+		if strings.HasPrefix(rb.headers["Content-Type"], "application/json") {
+			resolveBody = func(text string) (string, error) { return notes.ResolveJSON(actor, text) }
+		}
+	}
+
+	target, err := resolve(rb.url)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := rb.resolvedBody(resolveBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rb.tls) > 0 {
+		ctx = withTLSOverrides(ctx, rb.tls)
+	}
+	if rb.noRedirects {
+		ctx = withoutRedirects(ctx)
+	}
+
+	var form *multipartForm
+	if rb.form != nil {
+		if form, err = rb.form.resolved(resolve); err != nil {
+			return nil, err
+		}
+		body = &formBody{form: form}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, rb.method, target, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	if form != nil {
+		if req.ContentLength, err = form.size(); err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return &formBody{form: form}, nil
+		}
+		req.Header.Set("Content-Type", form.contentType())
+	}
+
 	// Add headers
 	for key, value := range rb.headers {
+		value, err := resolve(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve header %s: %w", key, err)
+		}
 		req.Header.Set(key, value)
 	}
 
+	for key, header := range rb.secretHeaders {
+		value, err := header.secret.Value(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve header %s: %w", key, err)
+		}
+		req.Header.Set(key, header.format(value))
+	}
+
+	if rb.progress != nil {
+		withUploadProgress(req, rb.progress)
+	}
+
 	return req, nil
 }
 
+// resolvedBody passes a body held in memory through resolve. The unresolved body is kept,
+// so the builder can build the request again. Streamed bodies are sent unchanged.
+func (rb *RequestBuilder) resolvedBody(resolve func(text string) (string, error)) (io.Reader, error) {
+	var template []byte
+	switch body := rb.body.(type) {
+	case *bytes.Buffer:
+		template = body.Bytes()
+	case *bytes.Reader, *strings.Reader:
+		content, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		template = content
+		rb.body = bytes.NewBuffer(content)
+	default:
+		return rb.body, nil
+	}
+
+	resolved, err := resolve(string(template))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve request body: %w", err)
+	}
+	return strings.NewReader(resolved), nil
+}
+
+// answeredBy returns a copy of the builder with its question-backed URL and body
+// answered by the actor, or the builder itself if it has none
+func (rb *RequestBuilder) answeredBy(actor core.Actor, ctx context.Context) (*RequestBuilder, error) {
+	if rb.urlQuestion == nil && rb.bodyParam == nil {
+		return rb, nil
+	}
+
+	answered := *rb
+	answered.headers = maps.Clone(rb.headers)
+	answered.urlQuestion, answered.bodyParam = nil, nil
+
+	if rb.urlQuestion != nil {
+		url, err := answerable.Resolve(actor, ctx, answerable.Asked(rb.urlQuestion))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve URL: %w", err)
+		}
+		answered.url = url
+	}
+
+	if rb.bodyParam != nil {
+		body, err := answerable.Answer(actor, ctx, rb.bodyParam)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve body: %w", err)
+		}
+		answered.With(body)
+	}
+
+	return &answered, nil
+}
+
 // RequestActivity - unified HTTP request activity with fluent interface
 type RequestActivity struct {
-	builder *RequestBuilder
+	builder   *RequestBuilder
+	location  core.Location
+	templated bool
 }
 
 // Description implements core.Activity interface
@@ -184,7 +462,16 @@ func (ra *RequestActivity) PerformAs(actor core.Actor, ctx context.Context) erro
 		return fmt.Errorf("request builder is nil")
 	}
 
-	req, err := ra.builder.Build()
+	builder, err := ra.builder.answeredBy(actor, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	var templating core.Actor
+	if ra.templated {
+		templating = actor
+	}
+	req, err := builder.buildWith(ctx, templating)
 	if err != nil {
 		return fmt.Errorf("failed to build request: %w", err)
 	}
@@ -194,16 +481,29 @@ func (ra *RequestActivity) PerformAs(actor core.Actor, ctx context.Context) erro
 	return sendReq.PerformAs(actor, ctx)
 }
 
+// Location returns where the request activity was constructed
+func (ra *RequestActivity) Location() core.Location {
+	return ra.location
+}
+
 // FailureMode returns the failure mode for request activities (default: FailFast)
 func (ra *RequestActivity) FailureMode() core.FailureMode {
 	return core.FailFast
 }
 
-// WithBody adds request body (JSON marshaling for interface{})
+// WithBody adds request body (JSON marshaling for interface{}). A question is answered
+// when the request is sent, and its answer used as the body.
 func (ra *RequestActivity) WithBody(data interface{}) *RequestActivity {
-	if ra.builder != nil {
-		ra.builder.With(data) // Reuse existing logic
+	if ra.builder == nil {
+		return ra
 	}
+
+	if answerable.IsQuestion(data) {
+		ra.builder.bodyParam = data
+		return ra
+	}
+	ra.builder.bodyParam = nil
+	ra.builder.With(data) // Reuse existing logic
 	return ra
 }
 
@@ -222,3 +522,76 @@ func (ra *RequestActivity) WithHeader(key, value string) *RequestActivity {
 	}
 	return ra
 }
+
+// WithSecretHeader adds a header whose value is read from the secret when the request is sent
+func (ra *RequestActivity) WithSecretHeader(key string, secret secrets.Secret) *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithSecretHeader(key, secret)
+	}
+	return ra
+}
+
+// WithBearerToken authenticates the request with a bearer token read from the secret
+func (ra *RequestActivity) WithBearerToken(token secrets.Secret) *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithBearerToken(token)
+	}
+	return ra
+}
+
+// WithBasicAuth authenticates the request with basic credentials, reading the password from the secret
+func (ra *RequestActivity) WithBasicAuth(username string, password secrets.Secret) *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithBasicAuth(username, password)
+	}
+	return ra
+}
+
+// WithTLS changes the TLS configuration of the ability for this request only, e.g. to
+// present a different client certificate
+func (ra *RequestActivity) WithTLS(opts ...tlsconfig.Option) *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithTLS(opts...)
+	}
+	return ra
+}
+
+// Templated resolves the "{{subject}}" placeholders of the URL, header values, form fields
+// and in-memory body from the actor's notes when the request is sent, failing on a
+// placeholder without a note. Values are escaped in JSON bodies.
+func (ra *RequestActivity) Templated() *RequestActivity {
+	ra.templated = true
+	return ra
+}
+
+// WithoutRedirects returns the redirect response of this request instead of following it
+func (ra *RequestActivity) WithoutRedirects() *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithoutRedirects()
+	}
+	return ra
+}
+
+// WithFormField adds a field to the multipart/form-data body of the request
+func (ra *RequestActivity) WithFormField(name, value string) *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithFormField(name, value)
+	}
+	return ra
+}
+
+// WithFile adds the file to the multipart/form-data body of the request, streaming it from disk
+func (ra *RequestActivity) WithFile(field, path string) *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithFile(field, path)
+	}
+	return ra
+}
+
+// WithUploadProgress calls progress as the request body is sent
+func (ra *RequestActivity) WithUploadProgress(progress UploadProgressFunc) *RequestActivity {
+	if ra.builder != nil {
+		ra.builder.WithUploadProgress(progress)
+	}
+	return ra
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/tlsconfig"
+)
+
+// ClientOption configures the HTTP client created by CallAnApiAt
+//
+//	api.CallAnApiAt(shopURL,
+//		api.WithTimeout(5*time.Second),
+//		api.WithMaxIdleConnsPerHost(50),
+//		api.WithInsecureSkipVerify(), // self-signed certificates of test environments
+//	)
+type ClientOption func(*clientOptions)
+
+// clientOptions collects the configuration of the HTTP client
+type clientOptions struct {
+	timeout   time.Duration
+	transport *http.Transport
+	protocol  protocol
+	capture   bool
+	signer    RequestSigner
+	errs      []error
+
+	noRedirects bool
+
+	correlationHeader string
+	idempotencyHeader string
+	maxBufferedBody   int64
+}
+
+// protocol is the HTTP version forced by the options
+type protocol int
+
+const (
+	negotiated protocol = iota
+	http2OverTLS
+	http2Cleartext
+)
+
+// WithTimeout limits the time of every request, including reading the response body
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithoutKeepAlives opens a new connection for every request instead of reusing them
+func WithoutKeepAlives() ClientOption {
+	return func(o *clientOptions) {
+		o.transport.DisableKeepAlives = true
+	}
+}
+
+// WithMaxIdleConns limits the number of idle connections kept for reuse across all hosts
+func WithMaxIdleConns(n int) ClientOption {
+	return func(o *clientOptions) {
+		o.transport.MaxIdleConns = n
+	}
+}
+
+// WithMaxIdleConnsPerHost limits the number of idle connections kept for reuse per host.
+// The default of 2 is low for actors sending many concurrent requests to one service.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(o *clientOptions) {
+		o.transport.MaxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout closes connections that stay idle for longer than the timeout
+func WithIdleConnTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.transport.IdleConnTimeout = timeout
+	}
+}
+
+// WithProxy sends requests through the proxy, e.g. "http://proxy.internal:3128", instead
+// of the one configured by the HTTP_PROXY and HTTPS_PROXY environment variables
+func WithProxy(proxyURL string) ClientOption {
+	return func(o *clientOptions) {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			o.errs = append(o.errs, fmt.Errorf("invalid proxy URL: %w", err))
+			return
+		}
+		o.transport.Proxy = http.ProxyURL(proxy)
+	}
+}
+
+// WithTLSConfig uses the TLS configuration, e.g. with custom root CAs or client
+// certificates, for HTTPS connections
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.transport.TLSClientConfig = config.Clone()
+	}
+}
+
+// WithClientCertificate presents the certificate and key, read from PEM files, to services
+// requiring mutual TLS
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return WithTLS(tlsconfig.ClientCertificate(certFile, keyFile))
+}
+
+// WithRootCAs trusts server certificates issued by the PEM encoded certificate authorities
+// instead of those of the system
+func WithRootCAs(pem []byte) ClientOption {
+	return WithTLS(tlsconfig.RootCAs(pem))
+}
+
+// WithInsecureSkipVerify accepts any server certificate. Use it only against test
+// environments with self-signed certificates.
+func WithInsecureSkipVerify() ClientOption {
+	return WithTLS(tlsconfig.InsecureSkipVerify())
+}
+
+// WithTLS changes the TLS configuration of HTTPS connections with the options
+func WithTLS(opts ...tlsconfig.Option) ClientOption {
+	return func(o *clientOptions) {
+		if o.transport.TLSClientConfig == nil {
+			o.transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if err := tlsconfig.Apply(o.transport.TLSClientConfig, opts...); err != nil {
+			o.errs = append(o.errs, err)
+		}
+	}
+}
+
+// WithHTTP2 speaks HTTP/2 over TLS and fails requests to servers that do not support it,
+// instead of falling back to HTTP/1.1. Proxies are not supported with HTTP/2 forced.
+func WithHTTP2() ClientOption {
+	return func(o *clientOptions) {
+		o.protocol = http2OverTLS
+	}
+}
+
+// WithH2C speaks HTTP/2 without TLS, with prior knowledge, to servers such as gRPC gateways
+// accepting cleartext HTTP/2. Proxies are not supported with HTTP/2 forced.
+func WithH2C() ClientOption {
+	return func(o *clientOptions) {
+		o.protocol = http2Cleartext
+	}
+}
+
+// roundTripper returns the transport speaking the protocol forced by the options
+func (o *clientOptions) roundTripper() http.RoundTripper {
+	switch o.protocol {
+	case http2OverTLS:
+		return &http2.Transport{
+			TLSClientConfig:    o.transport.TLSClientConfig,
+			DisableCompression: o.transport.DisableCompression,
+			IdleConnTimeout:    o.transport.IdleConnTimeout,
+		}
+	case http2Cleartext:
+		return &http2.Transport{
+			AllowHTTP:          true,
+			DisableCompression: o.transport.DisableCompression,
+			IdleConnTimeout:    o.transport.IdleConnTimeout,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, addr)
+			},
+		}
+	default:
+		return o.transport
+	}
+}
+
+// newClientOptions applies the options to the defaults
+func newClientOptions(opts []ClientOption) *clientOptions {
+	options := &clientOptions{transport: http.DefaultTransport.(*http.Transport).Clone()}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// newClient creates an HTTP client with its own transport configured by the options.
+// Invalid options are returned as an error.
+func (options *clientOptions) newClient() (*http.Client, error) {
+	if err := errors.Join(options.errs...); err != nil {
+		return nil, err
+	}
+	transport := options.roundTripper()
+	if options.capture {
+		transport = &harCapture{next: transport, log: &harLog{}}
+	}
+	client := &http.Client{Transport: transport, Timeout: options.timeout}
+	if options.noRedirects {
+		client.CheckRedirect = stopRedirects
+	}
+	return client, nil
+}
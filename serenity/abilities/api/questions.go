@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
 
@@ -16,12 +15,11 @@ type LastResponseStatus struct{}
 
 // AnsweredBy returns the status code from the last HTTP response
 func (lr LastResponseStatus) AnsweredBy(actor core.Actor, ctx context.Context) (int, error) {
-	ability, err := actor.AbilityTo(&callAnAPI{})
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
 	if err != nil {
 		return 0, fmt.Errorf("actor does not have the ability to call an API: %w", err)
 	}
 
-	callAbility := ability.(CallAnAPI)
 	resp := callAbility.LastResponse()
 	if resp == nil {
 		return 0, fmt.Errorf("no response available")
@@ -40,28 +38,21 @@ type LastResponseBody struct{}
 
 // AnsweredBy returns the body from the last HTTP response
 func (lr LastResponseBody) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
-	ability, err := actor.AbilityTo(&callAnAPI{})
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
 	if err != nil {
 		return "", fmt.Errorf("actor does not have the ability to call an API: %w", err)
 	}
 
-	callAbility := ability.(CallAnAPI)
 	resp := callAbility.LastResponse()
 	if resp == nil {
 		return "", fmt.Errorf("no response available")
 	}
 
-	defer func() {
-		_ = resp.Body.Close() // Ignore cleanup error
-	}()
-	body, err := io.ReadAll(resp.Body)
+	body, err := bufferedBodyOf(callAbility, resp)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return "", err
 	}
 
-	// Restore body for potential re-reading
-	resp.Body = io.NopCloser(strings.NewReader(string(body)))
-
 	return string(body), nil
 }
 
@@ -82,12 +73,11 @@ func NewResponseHeader(key string) ResponseHeader {
 
 // AnsweredBy returns the header value from the last HTTP response
 func (rh ResponseHeader) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
-	ability, err := actor.AbilityTo(&callAnAPI{})
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
 	if err != nil {
 		return "", fmt.Errorf("actor does not have the ability to call an API: %w", err)
 	}
 
-	callAbility := ability.(CallAnAPI)
 	resp := callAbility.LastResponse()
 	if resp == nil {
 		return "", fmt.Errorf("no response available")
@@ -113,28 +103,21 @@ func NewResponseBodyAsJSON[T any]() ResponseBodyAsJSON[T] {
 func (rbaj ResponseBodyAsJSON[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
 	var result T
 
-	ability, err := actor.AbilityTo(&callAnAPI{})
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
 	if err != nil {
 		return result, fmt.Errorf("actor does not have the ability to call an API: %w", err)
 	}
 
-	callAbility := ability.(CallAnAPI)
 	resp := callAbility.LastResponse()
 	if resp == nil {
 		return result, fmt.Errorf("no response available")
 	}
 
-	defer func() {
-		_ = resp.Body.Close() // Ignore cleanup error
-	}()
-	body, err := io.ReadAll(resp.Body)
+	body, err := bufferedBodyOf(callAbility, resp)
 	if err != nil {
-		return result, fmt.Errorf("failed to read response body: %w", err)
+		return result, err
 	}
 
-	// Restore body for potential re-reading
-	resp.Body = io.NopCloser(strings.NewReader(string(body)))
-
 	if err := json.Unmarshal(body, &result); err != nil {
 		return result, fmt.Errorf("failed to parse JSON response: %w", err)
 	}
@@ -159,28 +142,21 @@ func NewJSONPath(path string) JSONPath {
 
 // AnsweredBy returns the value at the specified JSON path
 func (jp JSONPath) AnsweredBy(actor core.Actor, ctx context.Context) (any, error) {
-	ability, err := actor.AbilityTo(&callAnAPI{})
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
 	if err != nil {
 		return nil, fmt.Errorf("actor does not have the ability to call an API: %w", err)
 	}
 
-	callAbility := ability.(CallAnAPI)
 	resp := callAbility.LastResponse()
 	if resp == nil {
 		return nil, fmt.Errorf("no response available")
 	}
 
-	defer func() {
-		_ = resp.Body.Close() // Ignore cleanup error
-	}()
-	body, err := io.ReadAll(resp.Body)
+	body, err := bufferedBodyOf(callAbility, resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
-	// Restore body for potential re-reading
-	resp.Body = io.NopCloser(strings.NewReader(string(body)))
-
 	var data any
 	if err := json.Unmarshal(body, &data); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
@@ -235,6 +211,29 @@ func (jp JSONPath) extractValue(data any, path []string) (any, error) {
 	}
 }
 
+// NegotiatedProtocol returns the protocol of the last response, e.g. "HTTP/1.1" or "HTTP/2.0"
+type NegotiatedProtocol struct{}
+
+// AnsweredBy returns the protocol the last HTTP response was received with
+func (np NegotiatedProtocol) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
+	if err != nil {
+		return "", fmt.Errorf("actor does not have the ability to call an API: %w", err)
+	}
+
+	resp := callAbility.LastResponse()
+	if resp == nil {
+		return "", fmt.Errorf("no response available")
+	}
+
+	return resp.Proto, nil
+}
+
+// Description returns the question description
+func (np NegotiatedProtocol) Description() string {
+	return "the negotiated protocol of the last response"
+}
+
 // ResponseTime returns the response time of the last request
 type ResponseTime struct{}
 
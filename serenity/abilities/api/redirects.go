@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// maxRedirects is the number of redirects followed by default, as by http.Client
+const maxRedirects = 10
+
+// Redirect is a hop of a redirect chain
+type Redirect struct {
+	// StatusCode is the status of the redirect response, e.g. 301 or 302
+	StatusCode int
+	// URL is the absolute URL the response redirected to
+	URL string
+}
+
+// RedirectHistory is implemented by CallAnAPI abilities that record the redirects followed
+// by the last request
+type RedirectHistory interface {
+	// LastRedirects returns the redirects followed by the last request, in order
+	LastRedirects() []Redirect
+}
+
+// WithoutRedirects stops following redirects, so the last response is the redirect itself,
+// e.g. to check the Location of a login flow
+func WithoutRedirects() ClientOption {
+	return func(o *clientOptions) {
+		o.noRedirects = true
+	}
+}
+
+// stopRedirects is the redirect policy returning redirect responses instead of following them
+func stopRedirects(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// noRedirectsKey is the context key marking a request whose redirects are not followed
+type noRedirectsKey struct{}
+
+// withoutRedirects returns a context marking the request built with it to not follow redirects
+func withoutRedirects(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRedirectsKey{}, true)
+}
+
+// followsRedirects reports whether the request context allows following redirects
+func followsRedirects(ctx context.Context) bool {
+	noRedirects, _ := ctx.Value(noRedirectsKey{}).(bool)
+	return !noRedirects
+}
+
+// recordingRedirects returns a copy of the client appending the redirects it follows to
+// chain. The client policy applies unless the request does not follow redirects.
+func recordingRedirects(client *http.Client, follow bool, chain *[]Redirect) *http.Client {
+	policy := client.CheckRedirect
+	recording := *client
+	recording.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !follow {
+			return http.ErrUseLastResponse
+		}
+		if policy != nil {
+			if err := policy(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		redirect := Redirect{URL: req.URL.String()}
+		if req.Response != nil {
+			redirect.StatusCode = req.Response.StatusCode
+		}
+		*chain = append(*chain, redirect)
+		return nil
+	}
+	return &recording
+}
+
+// RedirectChain returns the redirects followed by the last request, empty if it was not
+// redirected
+type RedirectChain struct{}
+
+// AnsweredBy returns the redirects followed by the last request sent with the actor's CallAnAPI ability
+func (rc RedirectChain) AnsweredBy(actor core.Actor, ctx context.Context) ([]Redirect, error) {
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to call an API: %w", err)
+	}
+
+	history, ok := callAbility.(RedirectHistory)
+	if !ok {
+		return nil, fmt.Errorf("the ability to call an API does not record redirects")
+	}
+	if callAbility.LastResponse() == nil {
+		return nil, fmt.Errorf("no response available")
+	}
+	return history.LastRedirects(), nil
+}
+
+// Description returns the question description
+func (rc RedirectChain) Description() string {
+	return "the redirect chain of the last request"
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// RequestSigner signs requests right before CallAnAPI sends them, after the base URL and
+// default headers are applied, for APIs requiring signatures computed over the request
+type RequestSigner interface {
+	// Sign adds the signature to the request. The body is given separately and can still
+	// be read from the request.
+	Sign(req *http.Request, body []byte) error
+}
+
+// RequestSignerFunc adapts a function to the RequestSigner interface
+type RequestSignerFunc func(req *http.Request, body []byte) error
+
+// Sign calls the function
+func (f RequestSignerFunc) Sign(req *http.Request, body []byte) error {
+	return f(req, body)
+}
+
+// WithRequestSigner signs every request with the signer:
+//
+//	api.CallAnApiAt(ordersURL, api.WithRequestSigner(api.SigV4(cfg.Credentials, "execute-api", "eu-west-1")))
+func WithRequestSigner(signer RequestSigner) ClientOption {
+	return func(o *clientOptions) {
+		o.signer = signer
+	}
+}
+
+// sign reads the request body, keeping it readable, and signs the request. The headers are
+// copied first, so the caller's request is not changed.
+func sign(signer RequestSigner, req *http.Request) error {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		content, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read request body for signing: %w", err)
+		}
+		body = content
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.ContentLength = int64(len(body))
+	}
+
+	req.Header = req.Header.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	if err := signer.Sign(req, body); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+	return nil
+}
+
+// sigV4 signs requests with AWS Signature Version 4
+type sigV4 struct {
+	credentials aws.CredentialsProvider
+	service     string
+	region      string
+	signer      *v4.Signer
+}
+
+// SigV4 signs requests with AWS Signature Version 4 for the service and region, e.g.
+// "execute-api" and "eu-west-1", with the credentials of the provider, such as those of
+// the default AWS configuration. The secret key and session token are masked in output.
+func SigV4(credentials aws.CredentialsProvider, service, region string) RequestSigner {
+	return &sigV4{credentials: credentials, service: service, region: region, signer: v4.NewSigner()}
+}
+
+// Sign retrieves the credentials and adds the Authorization and X-Amz-Date headers
+func (s *sigV4) Sign(req *http.Request, body []byte) error {
+	credentials, err := s.credentials.Retrieve(req.Context())
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	secrets.Register(credentials.SecretAccessKey, credentials.SessionToken)
+
+	payloadHash := sha256.Sum256(body)
+	hash := hex.EncodeToString(payloadHash[:])
+	if s.service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", hash)
+	}
+	return s.signer.SignHTTP(req.Context(), credentials, req, hash, s.service, s.region, time.Now())
+}
+
+// HMACSigner signs requests with an HMAC-SHA256 of their canonical string
+type HMACSigner struct {
+	key             secrets.Secret
+	signatureHeader string
+	timestampHeader string
+	canonical       func(req *http.Request, body []byte, timestamp string) string
+}
+
+// HMACSHA256 signs requests with an HMAC-SHA256 keyed with the secret. The signing time,
+// in RFC 3339 format, is sent in the X-Timestamp header and the hex encoded signature in
+// the X-Signature header. The signed canonical string is
+//
+//	METHOD\nPATH?QUERY\nTIMESTAMP\nhex(SHA-256(BODY))
+func HMACSHA256(key secrets.Secret) *HMACSigner {
+	return &HMACSigner{
+		key:             key,
+		signatureHeader: "X-Signature",
+		timestampHeader: "X-Timestamp",
+		canonical:       canonicalString,
+	}
+}
+
+// InHeaders sends the signature and the signing time in the named headers
+func (h *HMACSigner) InHeaders(signature, timestamp string) *HMACSigner {
+	h.signatureHeader, h.timestampHeader = signature, timestamp
+	return h
+}
+
+// WithCanonicalString signs the string built by canonical instead of the default one
+func (h *HMACSigner) WithCanonicalString(canonical func(req *http.Request, body []byte, timestamp string) string) *HMACSigner {
+	h.canonical = canonical
+	return h
+}
+
+// Sign adds the signature and signing time headers
+func (h *HMACSigner) Sign(req *http.Request, body []byte) error {
+	key, err := h.key.Value(req.Context())
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(h.canonical(req, body, timestamp)))
+
+	req.Header.Set(h.timestampHeader, timestamp)
+	req.Header.Set(h.signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+// canonicalString joins the method, request URI, timestamp and body hash with newlines
+func canonicalString(req *http.Request, body []byte, timestamp string) string {
+	bodyHash := sha256.Sum256(body)
+	return strings.Join([]string{req.Method, req.URL.RequestURI(), timestamp, hex.EncodeToString(bodyHash[:])}, "\n")
+}
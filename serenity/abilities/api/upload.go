@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// UploadProgressFunc is called as a request body is sent, with the bytes sent so far and
+// the total size of the body, or -1 if it is unknown
+type UploadProgressFunc func(sent, total int64)
+
+// UploadTracking is implemented by CallAnAPI abilities that count the bytes of the request
+// bodies they send
+type UploadTracking interface {
+	// LastUploadedBytes returns the number of body bytes sent by the last request
+	LastUploadedBytes() int64
+}
+
+// formPart is a field or a file of a multipart form
+type formPart struct {
+	name  string
+	value string
+	path  string
+}
+
+// isFile reports whether the part is a file streamed from disk
+func (p formPart) isFile() bool {
+	return p.path != ""
+}
+
+// multipartForm is a multipart/form-data body whose files are streamed from disk
+type multipartForm struct {
+	parts    []formPart
+	boundary string
+}
+
+// newMultipartForm creates an empty form with a random boundary
+func newMultipartForm() *multipartForm {
+	return &multipartForm{boundary: multipart.NewWriter(io.Discard).Boundary()}
+}
+
+// contentType returns the Content-Type header of the form
+func (f *multipartForm) contentType() string {
+	return "multipart/form-data; boundary=" + f.boundary
+}
+
+// resolved returns a copy of the form with the field values passed through resolve
+func (f *multipartForm) resolved(resolve func(text string) (string, error)) (*multipartForm, error) {
+	resolved := &multipartForm{boundary: f.boundary, parts: make([]formPart, len(f.parts))}
+	for i, part := range f.parts {
+		if !part.isFile() {
+			value, err := resolve(part.value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve form field %s: %w", part.name, err)
+			}
+			part.value = value
+		}
+		resolved.parts[i] = part
+	}
+	return resolved, nil
+}
+
+// size returns the number of bytes of the encoded form, without reading the files
+func (f *multipartForm) size() (int64, error) {
+	var counter countingWriter
+	if err := f.write(&counter, func(w io.Writer, part formPart) error {
+		info, err := os.Stat(part.path)
+		if err != nil {
+			return err
+		}
+		counter.n += info.Size()
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+// formBody is the body of a request sending a form, encoded once the request is sent
+type formBody struct {
+	form   *multipartForm
+	reader io.ReadCloser
+}
+
+// Read reads the encoded form, starting to encode it on the first read
+func (b *formBody) Read(p []byte) (int, error) {
+	if b.reader == nil {
+		b.reader = b.form.open()
+	}
+	return b.reader.Read(p)
+}
+
+// Close stops encoding the form
+func (b *formBody) Close() error {
+	if b.reader == nil {
+		return nil
+	}
+	return b.reader.Close()
+}
+
+// open returns the encoded form, streaming the files from disk as it is read
+func (f *multipartForm) open() io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(f.write(writer, func(w io.Writer, part formPart) error {
+			file, err := os.Open(part.path)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = file.Close()
+			}()
+			_, err = io.Copy(w, file)
+			return err
+		}))
+	}()
+	return reader
+}
+
+// write encodes the form to out, passing the content of every file to copyFile
+func (f *multipartForm) write(out io.Writer, copyFile func(w io.Writer, part formPart) error) error {
+	writer := multipart.NewWriter(out)
+	if err := writer.SetBoundary(f.boundary); err != nil {
+		return err
+	}
+
+	for _, part := range f.parts {
+		if !part.isFile() {
+			if err := writer.WriteField(part.name, part.value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			escapeQuotes(part.name), escapeQuotes(filepath.Base(part.path))))
+		header.Set("Content-Type", "application/octet-stream")
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(w, part); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", part.path, err)
+		}
+	}
+	return writer.Close()
+}
+
+// quoteEscaper escapes the quotes in the Content-Disposition header as mime/multipart does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes escapes a field or file name for the Content-Disposition header
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// countingWriter counts the bytes written to it
+type countingWriter struct {
+	n int64
+}
+
+// Write counts the bytes
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// progressBody reports the bytes read from a request body
+type progressBody struct {
+	io.ReadCloser
+	sent     int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+// Read reads from the body and reports the progress
+func (p *progressBody) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// withUploadProgress reports the progress of sending the request body, including any
+// body the client sends again after a redirect
+func withUploadProgress(req *http.Request, progress UploadProgressFunc) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+
+	total := req.ContentLength
+	if total == 0 {
+		total = -1
+	}
+	req.Body = &progressBody{ReadCloser: req.Body, total: total, progress: progress}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &progressBody{ReadCloser: body, total: total, progress: progress}, nil
+		}
+	}
+}
+
+// countingBody counts the bytes read from a request body
+type countingBody struct {
+	io.ReadCloser
+	count *atomic.Int64
+}
+
+// Read reads from the body and counts the bytes
+func (c countingBody) Read(b []byte) (int, error) {
+	n, err := c.ReadCloser.Read(b)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+// UploadedBytes returns the number of body bytes sent by the last request
+type UploadedBytes struct{}
+
+// AnsweredBy returns the number of body bytes sent by the last request of the actor's CallAnAPI ability
+func (ub UploadedBytes) AnsweredBy(actor core.Actor, ctx context.Context) (int64, error) {
+	callAbility, err := core.AbilityOf[CallAnAPI](actor)
+	if err != nil {
+		return 0, fmt.Errorf("actor does not have the ability to call an API: %w", err)
+	}
+
+	tracking, ok := callAbility.(UploadTracking)
+	if !ok {
+		return 0, fmt.Errorf("the ability to call an API does not count uploaded bytes")
+	}
+	return tracking.LastUploadedBytes(), nil
+}
+
+// Description returns the question description
+func (ub UploadedBytes) Description() string {
+	return "the bytes uploaded by the last request"
+}
@@ -0,0 +1,28 @@
+package clock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// WaitFor creates an interaction that lets the duration pass on the actor's clock:
+// instantly with a virtual clock, in real time otherwise
+func WaitFor(d time.Duration) core.Activity {
+	return core.Do(fmt.Sprintf("#actor waits for %s", d), func(actor core.Actor, ctx context.Context) error {
+		return Of(actor).Sleep(ctx, d)
+	})
+}
+
+// SetTimeTo creates an interaction that moves the actor's virtual clock to the time
+func SetTimeTo(t time.Time) core.Activity {
+	return core.Do(fmt.Sprintf("#actor sets the time to %s", t.Format(time.RFC3339)), func(actor core.Actor, ctx context.Context) error {
+		ability, err := core.AbilityOf[ManipulateTime](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to manipulate time: %w", err)
+		}
+		return ability.Set(t)
+	})
+}
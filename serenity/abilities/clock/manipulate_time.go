@@ -0,0 +1,168 @@
+// Package clock provides the ability to manipulate time, so that time-dependent tasks and
+// polling run instantly against a virtual clock in unit tests and in real time against
+// deployed systems, without changing the scenario.
+//
+// Tasks read the time and wait through the actor's clock:
+//
+//	c := clock.Of(actor)
+//	deadline := c.Now().Add(time.Minute)
+//	for c.Now().Before(deadline) {
+//		...
+//		if err := c.Sleep(ctx, time.Second); err != nil {
+//			return err
+//		}
+//	}
+//
+// An actor without the ability uses the real clock.
+package clock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// ModeEnvVar names the environment variable selecting the clock of UseTimeFromEnvironment
+const ModeEnvVar = "SERENITY_CLOCK"
+
+// Clock tells the time and waits
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// Sleep waits for the duration or until the context is done
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// ManipulateTime enables an actor to control the clock used by time-dependent activities
+type ManipulateTime interface {
+	abilities.Ability
+	Clock
+	// Set moves a virtual clock to the time. The real clock cannot be set.
+	Set(t time.Time) error
+	// IsVirtual reports whether the clock is virtual
+	IsVirtual() bool
+}
+
+// Real is the wall clock
+var Real Clock = realClock{}
+
+// realClock implements Clock with the time package
+type realClock struct{}
+
+// Now returns the wall clock time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep waits for the duration or until the context is done
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// manipulateTime implements the ManipulateTime interface
+type manipulateTime struct {
+	virtual bool
+	now     time.Time
+	mutex   sync.RWMutex
+}
+
+// UseVirtualTime creates a new ManipulateTime ability with a virtual clock starting at
+// start. Sleeping advances the virtual clock and returns immediately.
+func UseVirtualTime(start time.Time) ManipulateTime {
+	return &manipulateTime{virtual: true, now: start}
+}
+
+// UseRealTime creates a new ManipulateTime ability backed by the wall clock
+func UseRealTime() ManipulateTime {
+	return &manipulateTime{}
+}
+
+// UseTimeFromEnvironment creates a virtual clock starting now when SERENITY_CLOCK is
+// "virtual", and a real clock otherwise, so the same suite runs instantly in unit mode
+// and in real time in integration mode
+func UseTimeFromEnvironment() ManipulateTime {
+	if os.Getenv(ModeEnvVar) == "virtual" {
+		return UseVirtualTime(time.Now())
+	}
+	return UseRealTime()
+}
+
+// Now returns the current time of the clock
+func (m *manipulateTime) Now() time.Time {
+	if !m.virtual {
+		return Real.Now()
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.now
+}
+
+// Sleep advances a virtual clock by the duration, or waits for it in real time
+func (m *manipulateTime) Sleep(ctx context.Context, d time.Duration) error {
+	if !m.virtual {
+		return Real.Sleep(ctx, d)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d > 0 {
+		m.mutex.Lock()
+		m.now = m.now.Add(d)
+		m.mutex.Unlock()
+	}
+	return nil
+}
+
+// Set moves a virtual clock to the time
+func (m *manipulateTime) Set(t time.Time) error {
+	if !m.virtual {
+		return errors.New("cannot set the real clock")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.now = t
+	return nil
+}
+
+// IsVirtual reports whether the clock is virtual
+func (m *manipulateTime) IsVirtual() bool {
+	return m.virtual
+}
+
+// Inspect describes the clock
+func (m *manipulateTime) Inspect() string {
+	if !m.virtual {
+		return "real clock"
+	}
+	return fmt.Sprintf("virtual clock at %s", m.Now().Format(time.RFC3339Nano))
+}
+
+// Of returns the clock of the actor's ManipulateTime ability, or the real clock if the
+// actor does not have one
+func Of(actor core.Actor) Clock {
+	if ability, err := core.AbilityOf[ManipulateTime](actor); err == nil {
+		return ability
+	}
+	return Real
+}
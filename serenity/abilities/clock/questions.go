@@ -0,0 +1,21 @@
+package clock
+
+import (
+	"context"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// CurrentTime returns the time on the actor's clock
+type CurrentTime struct{}
+
+// AnsweredBy returns the current time of the actor's clock
+func (ct CurrentTime) AnsweredBy(actor core.Actor, ctx context.Context) (time.Time, error) {
+	return Of(actor).Now(), nil
+}
+
+// Description returns the question description
+func (ct CurrentTime) Description() string {
+	return "the current time"
+}
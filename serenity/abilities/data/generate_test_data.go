@@ -0,0 +1,213 @@
+// Package data provides the GenerateTestData ability for producing deterministic fake test data.
+//
+// Hard-coded values such as "john@example.com" collide when tests run in parallel or against
+// shared environments. GenerateTestData produces realistic, unique values from a seeded
+// pseudo-random source, so a failing run can be reproduced by reusing its seed:
+//
+//	actor := test.ActorCalled("Registrar").WhoCan(
+//		api.CallAnApiAt("https://api.example.com"),
+//		data.WithSeed(42),
+//	)
+//
+//	person, _ := data.APerson().AnsweredBy(actor, ctx)
+//	actor.AttemptsTo(
+//		api.SendPostRequest("/users").WithBody(person),
+//	)
+package data
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// DefaultEmailDomain is the domain used for generated email addresses.
+// It uses the reserved .test top-level domain so that no real mailbox is ever addressed.
+const DefaultEmailDomain = "example.test"
+
+// Address is a generated postal address
+type Address struct {
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+}
+
+// String returns the address in a single line
+func (a Address) String() string {
+	return fmt.Sprintf("%s, %s %s, %s", a.Street, a.PostalCode, a.City, a.Country)
+}
+
+// GenerateTestData enables an actor to generate deterministic fake test data
+type GenerateTestData interface {
+	abilities.Ability
+	// Seed returns the seed of the generator, so a run can be reproduced
+	Seed() int64
+	// FirstName returns a random first name
+	FirstName() string
+	// LastName returns a random last name
+	LastName() string
+	// Email returns a unique email address for the given name in the given domain
+	Email(firstName, lastName, domain string) string
+	// UUID returns a random version 4 UUID
+	UUID() string
+	// Address returns a random postal address
+	Address() Address
+	// IntBetween returns a random integer in the inclusive range [min, max]
+	IntBetween(min, max int) int
+	// AlphaNumeric returns a random string of lowercase letters and digits
+	AlphaNumeric(length int) string
+}
+
+// generateTestData implements the GenerateTestData interface
+type generateTestData struct {
+	seed     int64
+	random   *rand.Rand
+	sequence int
+	mutex    sync.Mutex
+}
+
+// WithSeed creates a GenerateTestData ability that always produces the same sequence of values
+func WithSeed(seed int64) GenerateTestData {
+	return &generateTestData{
+		seed:   seed,
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// WithRandomSeed creates a GenerateTestData ability seeded from the current time.
+// The chosen seed is available through Seed() for reproducing failures.
+func WithRandomSeed() GenerateTestData {
+	return WithSeed(time.Now().UnixNano())
+}
+
+// Seed returns the seed of the generator
+func (g *generateTestData) Seed() int64 {
+	return g.seed
+}
+
+// FirstName returns a random first name
+func (g *generateTestData) FirstName() string {
+	return g.pick(firstNames)
+}
+
+// LastName returns a random last name
+func (g *generateTestData) LastName() string {
+	return g.pick(lastNames)
+}
+
+// Email returns a unique email address; a sequence number prevents collisions between calls
+func (g *generateTestData) Email(firstName, lastName, domain string) string {
+	if domain == "" {
+		domain = DefaultEmailDomain
+	}
+
+	g.mutex.Lock()
+	g.sequence++
+	sequence := g.sequence
+	g.mutex.Unlock()
+
+	return fmt.Sprintf("%s.%s.%s%d@%s",
+		emailPart(firstName), emailPart(lastName), g.AlphaNumeric(4), sequence, domain)
+}
+
+// UUID returns a random version 4 UUID
+func (g *generateTestData) UUID() string {
+	bytes := make([]byte, 16)
+
+	g.mutex.Lock()
+	_, _ = g.random.Read(bytes)
+	g.mutex.Unlock()
+
+	bytes[6] = (bytes[6] & 0x0f) | 0x40 // version 4
+	bytes[8] = (bytes[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", bytes[0:4], bytes[4:6], bytes[6:8], bytes[8:10], bytes[10:16])
+}
+
+// Address returns a random postal address
+func (g *generateTestData) Address() Address {
+	return Address{
+		Street:     fmt.Sprintf("%d %s", g.IntBetween(1, 999), g.pick(streets)),
+		City:       g.pick(cities),
+		PostalCode: fmt.Sprintf("%05d", g.IntBetween(10000, 99999)),
+		Country:    g.pick(countries),
+	}
+}
+
+// IntBetween returns a random integer in the inclusive range [min, max]
+func (g *generateTestData) IntBetween(min, max int) int {
+	if max < min {
+		min, max = max, min
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return min + g.random.Intn(max-min+1)
+}
+
+// AlphaNumeric returns a random string of lowercase letters and digits
+func (g *generateTestData) AlphaNumeric(length int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var builder strings.Builder
+	for i := 0; i < length; i++ {
+		builder.WriteByte(alphabet[g.random.Intn(len(alphabet))])
+	}
+	return builder.String()
+}
+
+// pick returns a random element of values
+func (g *generateTestData) pick(values []string) string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return values[g.random.Intn(len(values))]
+}
+
+// emailPart converts a name into a lowercase string safe for the local part of an email
+func emailPart(name string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+		}
+	}
+	if builder.Len() == 0 {
+		return "user"
+	}
+	return builder.String()
+}
+
+var firstNames = []string{
+	"Alice", "Bob", "Carol", "David", "Emma", "Frank", "Grace", "Henry", "Isabel", "Jack",
+	"Katherine", "Liam", "Maria", "Noah", "Olivia", "Peter", "Quinn", "Rachel", "Samuel", "Tina",
+	"Umar", "Victoria", "William", "Xenia", "Yusuf", "Zoe",
+}
+
+var lastNames = []string{
+	"Anderson", "Brown", "Clark", "Davis", "Evans", "Fischer", "Garcia", "Harris", "Ivanova", "Johnson",
+	"Kowalski", "Lopez", "Martin", "Nguyen", "O'Brien", "Patel", "Quintero", "Rossi", "Smith", "Taylor",
+	"Ueda", "Varga", "Williams", "Xu", "Young", "Zimmermann",
+}
+
+var streets = []string{
+	"Main Street", "Oak Avenue", "Maple Drive", "Cedar Lane", "Elm Street", "Park Road",
+	"Lake View", "Hillside Avenue", "River Road", "Station Street",
+}
+
+var cities = []string{
+	"Springfield", "Riverside", "Fairview", "Greenville", "Madison", "Franklin",
+	"Georgetown", "Clinton", "Salem", "Bristol",
+}
+
+var countries = []string{
+	"United States", "United Kingdom", "Canada", "Germany", "France", "Netherlands",
+	"Spain", "Italy", "Australia", "Japan",
+}
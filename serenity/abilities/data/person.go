@@ -0,0 +1,88 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Person is a generated user profile
+type Person struct {
+	ID        string  `json:"id"`
+	FirstName string  `json:"firstName"`
+	LastName  string  `json:"lastName"`
+	Email     string  `json:"email"`
+	Address   Address `json:"address"`
+}
+
+// FullName returns the first and last name separated by a space
+func (p Person) FullName() string {
+	return p.FirstName + " " + p.LastName
+}
+
+// PersonBuilder is a question that generates a Person, with optional fixed fields
+type PersonBuilder struct {
+	firstName   string
+	lastName    string
+	emailDomain string
+}
+
+// APerson creates a builder for a generated person
+func APerson() *PersonBuilder {
+	return &PersonBuilder{emailDomain: DefaultEmailDomain}
+}
+
+// WithFirstName fixes the first name instead of generating it
+func (pb *PersonBuilder) WithFirstName(firstName string) *PersonBuilder {
+	pb.firstName = firstName
+	return pb
+}
+
+// WithLastName fixes the last name instead of generating it
+func (pb *PersonBuilder) WithLastName(lastName string) *PersonBuilder {
+	pb.lastName = lastName
+	return pb
+}
+
+// WithEmailDomain sets the domain of the generated email address
+func (pb *PersonBuilder) WithEmailDomain(domain string) *PersonBuilder {
+	pb.emailDomain = domain
+	return pb
+}
+
+// AnsweredBy generates a person using the actor's GenerateTestData ability
+func (pb *PersonBuilder) AnsweredBy(actor core.Actor, ctx context.Context) (Person, error) {
+	generator, err := core.AbilityOf[GenerateTestData](actor)
+	if err != nil {
+		return Person{}, fmt.Errorf("actor does not have the ability to generate test data: %w", err)
+	}
+
+	return pb.Build(generator), nil
+}
+
+// Build generates a person with the given generator
+func (pb *PersonBuilder) Build(generator GenerateTestData) Person {
+	person := Person{
+		ID:        generator.UUID(),
+		FirstName: pb.firstName,
+		LastName:  pb.lastName,
+	}
+
+	if person.FirstName == "" {
+		person.FirstName = generator.FirstName()
+	}
+	if person.LastName == "" {
+		person.LastName = generator.LastName()
+	}
+
+	person.Email = generator.Email(person.FirstName, person.LastName, pb.emailDomain)
+	person.Address = generator.Address()
+
+	return person
+}
+
+// Description returns the question description
+func (pb *PersonBuilder) Description() string {
+	return "a generated person"
+}
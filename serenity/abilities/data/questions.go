@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// generated is a question that produces a new value from the actor's GenerateTestData ability
+type generated[T any] struct {
+	description string
+	generate    func(generator GenerateTestData) T
+}
+
+// AnsweredBy generates a value using the actor's GenerateTestData ability
+func (g generated[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
+	generator, err := core.AbilityOf[GenerateTestData](actor)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("actor does not have the ability to generate test data: %w", err)
+	}
+
+	return g.generate(generator), nil
+}
+
+// Description returns the question description
+func (g generated[T]) Description() string {
+	return g.description
+}
+
+// AFirstName returns a question that generates a first name
+func AFirstName() core.Question[string] {
+	return generated[string]{
+		description: "a generated first name",
+		generate:    GenerateTestData.FirstName,
+	}
+}
+
+// ALastName returns a question that generates a last name
+func ALastName() core.Question[string] {
+	return generated[string]{
+		description: "a generated last name",
+		generate:    GenerateTestData.LastName,
+	}
+}
+
+// AFullName returns a question that generates a first and last name separated by a space
+func AFullName() core.Question[string] {
+	return generated[string]{
+		description: "a generated full name",
+		generate: func(generator GenerateTestData) string {
+			return generator.FirstName() + " " + generator.LastName()
+		},
+	}
+}
+
+// AnEmail returns a question that generates a unique email address in DefaultEmailDomain
+func AnEmail() core.Question[string] {
+	return AnEmailAt(DefaultEmailDomain)
+}
+
+// AnEmailAt returns a question that generates a unique email address in the given domain
+func AnEmailAt(domain string) core.Question[string] {
+	return generated[string]{
+		description: fmt.Sprintf("a generated email address at %s", domain),
+		generate: func(generator GenerateTestData) string {
+			return generator.Email(generator.FirstName(), generator.LastName(), domain)
+		},
+	}
+}
+
+// AUUID returns a question that generates a version 4 UUID
+func AUUID() core.Question[string] {
+	return generated[string]{
+		description: "a generated UUID",
+		generate:    GenerateTestData.UUID,
+	}
+}
+
+// AnAddress returns a question that generates a postal address
+func AnAddress() core.Question[Address] {
+	return generated[Address]{
+		description: "a generated address",
+		generate:    GenerateTestData.Address,
+	}
+}
+
+// ANumberBetween returns a question that generates an integer in the inclusive range [min, max]
+func ANumberBetween(min, max int) core.Question[int] {
+	return generated[int]{
+		description: fmt.Sprintf("a generated number between %d and %d", min, max),
+		generate: func(generator GenerateTestData) int {
+			return generator.IntBetween(min, max)
+		},
+	}
+}
+
+// AnAlphaNumericString returns a question that generates a string of lowercase letters and digits
+func AnAlphaNumericString(length int) core.Question[string] {
+	return generated[string]{
+		description: fmt.Sprintf("a generated %d-character string", length),
+		generate: func(generator GenerateTestData) string {
+			return generator.AlphaNumeric(length)
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Execute creates an interaction that executes a statement, such as an INSERT or UPDATE,
+// using the actor's database
+func Execute(query string, args ...any) core.Activity {
+	return core.Do(fmt.Sprintf("#actor executes %s", query), func(actor core.Actor, ctx context.Context) error {
+		database, err := core.AbilityOf[UseADatabase](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use a database: %w", err)
+		}
+
+		if _, err := database.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to execute %s: %w", query, err)
+		}
+		return nil
+	})
+}
+
+// ApplyMigrations creates an interaction that applies the SQL migrations in dir that have
+// not been applied yet. Migrations are files named after their version, such as
+// 001_create_orders.sql, applied in version order and recorded in MigrationsTable.
+func ApplyMigrations(dir string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor applies the migrations in %s", dir), func(actor core.Actor, ctx context.Context) error {
+		database, err := core.AbilityOf[UseADatabase](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use a database: %w", err)
+		}
+
+		migrations, err := loadMigrations(dir)
+		if err != nil {
+			return err
+		}
+
+		applied, err := appliedVersions(ctx, database)
+		if err != nil {
+			return err
+		}
+
+		record := fmt.Sprintf("INSERT INTO %s (version) VALUES (%s)", MigrationsTable, database.Placeholder(1))
+		for _, m := range migrations {
+			if applied[m.version] {
+				continue
+			}
+
+			statements, err := os.ReadFile(m.path) // #nosec G304 -- migrations are read from the test's own directory
+			if err != nil {
+				return fmt.Errorf("failed to read migration: %w", err)
+			}
+			if _, err := database.Exec(ctx, string(statements)); err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", filepath.Base(m.path), err)
+			}
+			if _, err := database.Exec(ctx, record, m.version); err != nil {
+				return fmt.Errorf("failed to record migration %s: %w", filepath.Base(m.path), err)
+			}
+		}
+		return nil
+	})
+}
+
+// SeedFromFile creates an interaction that loads test data from a file. A .sql file is
+// executed as is; a .yaml or .yml file maps table names to lists of rows, which are
+// inserted in the order they appear.
+func SeedFromFile(path string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor seeds the database from %s", path), func(actor core.Actor, ctx context.Context) error {
+		database, err := core.AbilityOf[UseADatabase](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use a database: %w", err)
+		}
+
+		data, err := os.ReadFile(path) // #nosec G304 -- seed files are provided by the test
+		if err != nil {
+			return fmt.Errorf("failed to read seed file: %w", err)
+		}
+
+		switch filepath.Ext(path) {
+		case ".sql":
+			if _, err := database.Exec(ctx, string(data)); err != nil {
+				return fmt.Errorf("failed to seed from %s: %w", path, err)
+			}
+			return nil
+		case ".yaml", ".yml":
+			tables, err := loadSeed(data)
+			if err != nil {
+				return fmt.Errorf("failed to parse seed file %s: %w", path, err)
+			}
+			for _, table := range tables {
+				for _, row := range table.rows {
+					if _, err := database.Exec(ctx, insertStatement(database, table.name, row), row.values...); err != nil {
+						return fmt.Errorf("failed to seed table %s: %w", table.name, err)
+					}
+				}
+			}
+			return nil
+		default:
+			return fmt.Errorf("unsupported seed file %s: expected .sql, .yaml or .yml", path)
+		}
+	})
+}
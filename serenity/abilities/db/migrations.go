@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MigrationsTable records the versions applied by ApplyMigrations
+const MigrationsTable = "schema_migrations"
+
+// migration is a single versioned SQL file
+type migration struct {
+	version int
+	path    string
+}
+
+// loadMigrations returns the migrations in dir ordered by version. A migration is a
+// .sql file whose name starts with its version, e.g. 001_create_orders.sql.
+// Files ending in .down.sql are ignored.
+func loadMigrations(dir string) ([]migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var migrations []migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") || strings.HasSuffix(name, ".down.sql") {
+			continue
+		}
+
+		digits := len(name) - len(strings.TrimLeft(name, "0123456789"))
+		if digits == 0 {
+			return nil, fmt.Errorf("migration %s does not start with a version number", name)
+		}
+		version, err := strconv.Atoi(name[:digits])
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has an invalid version: %w", name, err)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version %d", other, name, version)
+		}
+		seen[version] = name
+
+		migrations = append(migrations, migration{version: version, path: filepath.Join(dir, name)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+// appliedVersions creates the migrations table if needed and returns the versions recorded in it
+func appliedVersions(ctx context.Context, database UseADatabase) (map[int]bool, error) {
+	if _, err := database.Exec(ctx, "CREATE TABLE IF NOT EXISTS "+MigrationsTable+" (version BIGINT PRIMARY KEY)"); err != nil {
+		return nil, fmt.Errorf("failed to create %s table: %w", MigrationsTable, err)
+	}
+
+	rows, err := database.Query(ctx, "SELECT version FROM "+MigrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() // Ignore cleanup error
+	}()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// seedTable is the rows to insert into one table, in file order
+type seedTable struct {
+	name string
+	rows []seedRow
+}
+
+// seedRow is one row to insert, with columns in file order
+type seedRow struct {
+	columns []string
+	values  []any
+}
+
+// loadSeed parses a YAML seed file mapping table names to lists of rows:
+//
+//	customers:
+//	  - id: 1
+//	    name: Ada
+//	orders:
+//	  - id: 10
+//	    customer_id: 1
+//
+// Tables are seeded in the order they appear, so parents can precede children.
+func loadSeed(data []byte) ([]seedTable, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of table names to rows", root.Line)
+	}
+
+	var tables []seedTable
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		name, rowsNode := root.Content[i], root.Content[i+1]
+		if rowsNode.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("line %d: expected a list of rows for table %s", rowsNode.Line, name.Value)
+		}
+
+		table := seedTable{name: name.Value}
+		for _, rowNode := range rowsNode.Content {
+			if rowNode.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("line %d: expected a mapping of columns to values in table %s", rowNode.Line, name.Value)
+			}
+
+			var row seedRow
+			for j := 0; j+1 < len(rowNode.Content); j += 2 {
+				var value any
+				if err := rowNode.Content[j+1].Decode(&value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", rowNode.Content[j+1].Line, err)
+				}
+				row.columns = append(row.columns, rowNode.Content[j].Value)
+				row.values = append(row.values, value)
+			}
+			table.rows = append(table.rows, row)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// insertStatement builds the INSERT for a seed row using the ability's placeholders
+func insertStatement(database UseADatabase, table string, row seedRow) string {
+	placeholders := make([]string, len(row.columns))
+	for i := range row.columns {
+		placeholders[i] = database.Placeholder(i + 1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		table, strings.Join(row.columns, ", "), strings.Join(placeholders, ", "))
+}
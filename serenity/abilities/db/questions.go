@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// NumberOfRowsIn returns how many rows of a table match an optional condition
+type NumberOfRowsIn struct {
+	table     string
+	condition string
+	args      []any
+}
+
+// NewNumberOfRowsIn creates a question for the number of rows in the table
+func NewNumberOfRowsIn(table string) NumberOfRowsIn {
+	return NumberOfRowsIn{table: table}
+}
+
+// Where restricts the count to the rows matching the condition, e.g. Where("state = ?", "paid")
+func (nr NumberOfRowsIn) Where(condition string, args ...any) NumberOfRowsIn {
+	nr.condition = condition
+	nr.args = args
+	return nr
+}
+
+// AnsweredBy counts the rows using the actor's database
+func (nr NumberOfRowsIn) AnsweredBy(actor core.Actor, ctx context.Context) (int, error) {
+	database, err := core.AbilityOf[UseADatabase](actor)
+	if err != nil {
+		return 0, fmt.Errorf("actor does not have the ability to use a database: %w", err)
+	}
+
+	query := "SELECT COUNT(*) FROM " + nr.table // #nosec G202 -- table names come from the test, not from input
+	if nr.condition != "" {
+		query += " WHERE " + nr.condition
+	}
+
+	rows, err := database.Query(ctx, query, nr.args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count rows in %s: %w", nr.table, err)
+	}
+	defer func() {
+		_ = rows.Close() // Ignore cleanup error
+	}()
+
+	count := 0
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, fmt.Errorf("failed to count rows in %s: %w", nr.table, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to count rows in %s: %w", nr.table, err)
+	}
+	return count, nil
+}
+
+// Description returns the question description
+func (nr NumberOfRowsIn) Description() string {
+	if nr.condition != "" {
+		return fmt.Sprintf("the number of rows in %s where %s", nr.table, nr.condition)
+	}
+	return fmt.Sprintf("the number of rows in %s", nr.table)
+}
+
+// SchemaVersion returns the highest migration version applied by ApplyMigrations,
+// or 0 if none has been applied. Like ApplyMigrations, it creates MigrationsTable if needed.
+type SchemaVersion struct{}
+
+// AnsweredBy reads the schema version using the actor's database
+func (sv SchemaVersion) AnsweredBy(actor core.Actor, ctx context.Context) (int, error) {
+	database, err := core.AbilityOf[UseADatabase](actor)
+	if err != nil {
+		return 0, fmt.Errorf("actor does not have the ability to use a database: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, database)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	for v := range applied {
+		version = max(version, v)
+	}
+	return version, nil
+}
+
+// Description returns the question description
+func (sv SchemaVersion) Description() string {
+	return "the schema version"
+}
+
+// QueryOneAs creates a question that runs a query expected to return exactly one row and
+// scans it into a T. A struct T receives the columns in the fields tagged `db:"column"`,
+// or in the fields whose names match the columns ignoring case and underscores; any
+// other T, such as int or string, receives the single column of the row.
+func QueryOneAs[T any](query string, args ...any) core.Question[T] {
+	return core.NewQuestion(fmt.Sprintf("the %s returned by %s", typeName[T](), query), func(actor core.Actor, ctx context.Context) (T, error) {
+		var zero T
+
+		values, err := queryAs[T](actor, ctx, query, args)
+		if err != nil {
+			return zero, err
+		}
+		if len(values) != 1 {
+			return zero, fmt.Errorf("expected %s to return one row, got %d", query, len(values))
+		}
+		return values[0], nil
+	})
+}
+
+// QueryAllAs creates a question that runs a query and scans every row into a T,
+// as QueryOneAs does. A query without rows answers an empty slice.
+func QueryAllAs[T any](query string, args ...any) core.Question[[]T] {
+	return core.NewQuestion(fmt.Sprintf("the %ss returned by %s", typeName[T](), query), func(actor core.Actor, ctx context.Context) ([]T, error) {
+		values, err := queryAs[T](actor, ctx, query, args)
+		if err != nil {
+			return nil, err
+		}
+		if values == nil {
+			values = []T{}
+		}
+		return values, nil
+	})
+}
+
+// queryAs runs a query with the actor's database and scans the rows into values of T
+func queryAs[T any](actor core.Actor, ctx context.Context, query string, args []any) ([]T, error) {
+	database, err := core.AbilityOf[UseADatabase](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to use a database: %w", err)
+	}
+
+	rows, err := database.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", query, err)
+	}
+	defer func() {
+		_ = rows.Close() // Ignore cleanup error
+	}()
+
+	values, err := scanAll[T](rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan the rows of %s: %w", query, err)
+	}
+	return values, nil
+}
+
+// typeName returns the unqualified name of T for question descriptions
+func typeName[T any]() string {
+	name := reflect.TypeOf((*T)(nil)).Elem().String()
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// scanAll scans every row into a T. Structs are scanned field by field, matching columns
+// to fields by their `db:"column"` tag or, without a tag, by the field name ignoring
+// case and underscores; `db:"-"` skips a field. Other types require a single column.
+func scanAll[T any](rows *sql.Rows) ([]T, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var zero T
+	target := reflect.TypeOf(&zero).Elem()
+
+	var fields [][]int
+	if isStruct(target) {
+		fields, err = fieldsFor(target, columns)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(columns) != 1 {
+		return nil, fmt.Errorf("cannot scan %d columns into %s", len(columns), target)
+	}
+
+	var values []T
+	for rows.Next() {
+		var value T
+		destination := reflect.ValueOf(&value).Elem()
+
+		var pointers []any
+		if fields == nil {
+			pointers = []any{destination.Addr().Interface()}
+		} else {
+			pointers = make([]any, len(fields))
+			for i, index := range fields {
+				pointers[i] = destination.FieldByIndex(index).Addr().Interface()
+			}
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// isStruct reports whether t is scanned field by field rather than as a single value
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(scannerType)
+}
+
+// fieldsFor returns the index of the field each column is scanned into
+func fieldsFor(t reflect.Type, columns []string) ([][]int, error) {
+	byName := make(map[string][]int)
+	collectFields(t, nil, byName)
+
+	fields := make([][]int, len(columns))
+	for i, column := range columns {
+		index, ok := byName[normalizeColumn(column)]
+		if !ok {
+			return nil, fmt.Errorf("column %s has no matching field in %s", column, t)
+		}
+		fields[i] = index
+	}
+	return fields, nil
+}
+
+// collectFields maps the normalized column name of every exported field of t to its index.
+// Fields of embedded structs are included unless a shallower field has the same name.
+func collectFields(t reflect.Type, parent []int, byName map[string][]int) {
+	var embedded [][]int
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		index := append(append([]int(nil), parent...), i)
+		if field.Anonymous && tag == "" && isStruct(field.Type) {
+			embedded = append(embedded, index)
+			continue
+		}
+
+		name := field.Name
+		if tag != "" {
+			name = tag
+		}
+		if _, taken := byName[normalizeColumn(name)]; !taken {
+			byName[normalizeColumn(name)] = index
+		}
+	}
+
+	for _, index := range embedded {
+		collectFields(t.FieldByIndex(index[len(parent):]).Type, index, byName)
+	}
+}
+
+// normalizeColumn lowercases a name and drops underscores, so that the order_id column
+// matches an OrderID field
+func normalizeColumn(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
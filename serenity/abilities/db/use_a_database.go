@@ -0,0 +1,249 @@
+// Package db provides the ability to use a SQL database through database/sql, together
+// with interactions that modify it and questions about its contents.
+//
+// The ability works with any registered driver. Tests that write to a shared database
+// can isolate themselves by running in a transaction that is rolled back on Shutdown,
+// or by cleaning the tables they touch:
+//
+//	actor := test.ActorCalled("Clerk").WhoCan(
+//		db.UseADatabaseAt("postgres", dsn).WithinTransaction(),
+//	)
+//
+//	actor.AttemptsTo(
+//		db.Execute("INSERT INTO orders (id, state) VALUES ($1, $2)", 42, "new"),
+//		ensure.That(db.NewNumberOfRowsIn("orders").Where("state = $1", "new"), expectations.Equals(1)),
+//	)
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// UseADatabase enables an actor to query and modify a SQL database
+type UseADatabase interface {
+	abilities.Ability
+	// Exec executes a statement that returns no rows
+	Exec(ctx context.Context, query string, args ...any) (sql.Result, error)
+	// Query executes a statement that returns rows. The caller must close the rows.
+	Query(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	// WithinTransaction runs all statements in a single transaction that is rolled back
+	// when the ability is discarded, so nothing the test writes is ever committed
+	WithinTransaction() UseADatabase
+	// CleaningTables deletes all rows from the tables, in the given order, when the
+	// ability is discarded
+	CleaningTables(tables ...string) UseADatabase
+	// WithPlaceholders sets the bind parameter style of statements built by this package,
+	// such as the inserts of SeedFromFile
+	WithPlaceholders(style Placeholders) UseADatabase
+	// Placeholder returns the bind parameter for the 1-based position
+	Placeholder(position int) string
+}
+
+// Placeholders is the bind parameter style of a database driver
+type Placeholders int
+
+const (
+	// QuestionMarks binds parameters with ?, as SQLite and MySQL do
+	QuestionMarks Placeholders = iota
+	// DollarNumbers binds parameters with $1, $2, ..., as PostgreSQL does
+	DollarNumbers
+)
+
+// executor is implemented by both *sql.DB and *sql.Tx
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// useADatabase implements the UseADatabase interface
+type useADatabase struct {
+	driverName     string
+	dataSourceName string
+	db             *sql.DB
+	ownsDB         bool
+	transactional  bool
+	tx             *sql.Tx
+	cleanTables    []string
+	placeholders   Placeholders
+	mutex          sync.Mutex
+}
+
+// Using creates a new UseADatabase ability for an open database handle.
+// The handle is not closed when the ability is discarded.
+func Using(db *sql.DB) UseADatabase {
+	return &useADatabase{db: db}
+}
+
+// UseADatabaseAt creates a new UseADatabase ability that opens the database with the
+// driver and data source name on first use and closes it when the ability is discarded.
+// The postgres and pgx drivers use DollarNumbers placeholders, all others QuestionMarks.
+func UseADatabaseAt(driverName, dataSourceName string) UseADatabase {
+	placeholders := QuestionMarks
+	if driverName == "postgres" || driverName == "pgx" {
+		placeholders = DollarNumbers
+	}
+	return &useADatabase{
+		driverName:     driverName,
+		dataSourceName: dataSourceName,
+		ownsDB:         true,
+		placeholders:   placeholders,
+	}
+}
+
+// WithinTransaction runs all statements in a transaction rolled back on Discard
+func (u *useADatabase) WithinTransaction() UseADatabase {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.transactional = true
+	return u
+}
+
+// CleaningTables deletes all rows from the tables on Discard
+func (u *useADatabase) CleaningTables(tables ...string) UseADatabase {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.cleanTables = append(u.cleanTables, tables...)
+	return u
+}
+
+// WithPlaceholders sets the bind parameter style
+func (u *useADatabase) WithPlaceholders(style Placeholders) UseADatabase {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.placeholders = style
+	return u
+}
+
+// Placeholder returns the bind parameter for the 1-based position
+func (u *useADatabase) Placeholder(position int) string {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.placeholders == DollarNumbers {
+		return fmt.Sprintf("$%d", position)
+	}
+	return "?"
+}
+
+// Exec executes a statement that returns no rows
+func (u *useADatabase) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	exec, err := u.executor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return exec.ExecContext(ctx, query, args...)
+}
+
+// Query executes a statement that returns rows
+func (u *useADatabase) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	exec, err := u.executor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return exec.QueryContext(ctx, query, args...)
+}
+
+// executor returns the transaction in transactional mode and the database otherwise,
+// opening the database and beginning the transaction on first use
+func (u *useADatabase) executor(ctx context.Context) (executor, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if err := u.open(); err != nil {
+		return nil, err
+	}
+
+	if !u.transactional {
+		return u.db, nil
+	}
+
+	if u.tx == nil {
+		// the transaction outlives the activity that started it, so it must not be
+		// bound to the activity's context
+		tx, err := u.db.BeginTx(context.WithoutCancel(ctx), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		u.tx = tx
+	}
+	return u.tx, nil
+}
+
+// open opens the database if the ability owns it. The caller must hold the mutex.
+func (u *useADatabase) open() error {
+	if u.db != nil {
+		return nil
+	}
+
+	db, err := sql.Open(u.driverName, u.dataSourceName)
+	if err != nil {
+		return fmt.Errorf("failed to open %s database: %w", u.driverName, err)
+	}
+	u.db = db
+	return nil
+}
+
+// Inspect describes the isolation mode of the ability
+func (u *useADatabase) Inspect() string {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	state := "provided database"
+	if u.driverName != "" {
+		state = u.driverName + " database"
+	}
+
+	switch {
+	case u.tx != nil:
+		state += ", transaction open"
+	case u.transactional:
+		state += ", transactional"
+	}
+	if len(u.cleanTables) > 0 {
+		state += fmt.Sprintf(", cleaning %d tables", len(u.cleanTables))
+	}
+	return state
+}
+
+// Discard rolls back the transaction, cleans the configured tables and closes the
+// database if the ability opened it
+func (u *useADatabase) Discard() error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	var errs []error
+
+	if u.tx != nil {
+		if err := u.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			errs = append(errs, fmt.Errorf("failed to roll back transaction: %w", err))
+		}
+		u.tx = nil
+	}
+
+	if u.db != nil {
+		for _, table := range u.cleanTables {
+			// #nosec G202 -- table names come from the test configuration, not from input
+			if _, err := u.db.ExecContext(context.Background(), "DELETE FROM "+table); err != nil {
+				errs = append(errs, fmt.Errorf("failed to clean table %s: %w", table, err))
+			}
+		}
+
+		if u.ownsDB {
+			if err := u.db.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close database: %w", err))
+			}
+			u.db = nil
+		}
+	}
+
+	return errors.Join(errs...)
+}
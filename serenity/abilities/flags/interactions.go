@@ -0,0 +1,34 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// EnableFlag creates an interaction that turns the flag on
+func EnableFlag(name string) core.Activity {
+	return setFlag(fmt.Sprintf("#actor enables the %s flag", name), name, true)
+}
+
+// DisableFlag creates an interaction that turns the flag off
+func DisableFlag(name string) core.Activity {
+	return setFlag(fmt.Sprintf("#actor disables the %s flag", name), name, false)
+}
+
+// SetFlag creates an interaction that sets the flag to a value, such as a variation name
+func SetFlag(name string, value any) core.Activity {
+	return setFlag(fmt.Sprintf("#actor sets the %s flag to %v", name, value), name, value)
+}
+
+// setFlag creates the interaction shared by EnableFlag, DisableFlag and SetFlag
+func setFlag(description, name string, value any) core.Activity {
+	return core.Do(description, func(actor core.Actor, ctx context.Context) error {
+		features, err := core.AbilityOf[ToggleFeatures](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to toggle features: %w", err)
+		}
+		return features.SetFlag(ctx, name, value)
+	})
+}
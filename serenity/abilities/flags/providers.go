@@ -0,0 +1,91 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+)
+
+// InMemoryProvider keeps flags in memory. Pass it to a system under test running in the
+// test process, which reads the flags with Enabled or Flag.
+type InMemoryProvider struct {
+	flags map[string]any
+	mutex sync.RWMutex
+}
+
+// InMemory creates an empty InMemoryProvider
+func InMemory() *InMemoryProvider {
+	return &InMemoryProvider{flags: make(map[string]any)}
+}
+
+// Enabled reports whether the flag is set to true
+func (p *InMemoryProvider) Enabled(name string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	enabled, _ := p.flags[name].(bool)
+	return enabled
+}
+
+// Flag returns the value of the flag
+func (p *InMemoryProvider) Flag(ctx context.Context, name string) (any, bool, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	value, found := p.flags[name]
+	return value, found, nil
+}
+
+// SetFlag sets the value of the flag
+func (p *InMemoryProvider) SetFlag(ctx context.Context, name string, value any) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.flags[name] = value
+	return nil
+}
+
+// RemoveFlag removes the flag
+func (p *InMemoryProvider) RemoveFlag(ctx context.Context, name string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.flags, name)
+	return nil
+}
+
+// configFeatures sets the environment overrides of config.Feature
+type configFeatures struct{}
+
+// ConfigFeatures creates a provider that sets flags through the SERENITY_FEATURE_*
+// environment overrides read by config.Feature. The environment is shared by the whole
+// process, so tests using it must not run in parallel.
+func ConfigFeatures() Provider {
+	return configFeatures{}
+}
+
+// Flag returns the value of the environment override, as a bool when it parses as one
+func (configFeatures) Flag(ctx context.Context, name string) (any, bool, error) {
+	value, found := os.LookupEnv(config.FeatureEnvVar(name))
+	if !found {
+		return nil, false, nil
+	}
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		return enabled, true, nil
+	}
+	return value, true, nil
+}
+
+// SetFlag sets the environment override
+func (configFeatures) SetFlag(ctx context.Context, name string, value any) error {
+	return os.Setenv(config.FeatureEnvVar(name), fmt.Sprint(value))
+}
+
+// RemoveFlag unsets the environment override
+func (configFeatures) RemoveFlag(ctx context.Context, name string) error {
+	return os.Unsetenv(config.FeatureEnvVar(name))
+}
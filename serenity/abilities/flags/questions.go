@@ -0,0 +1,47 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// FlagValue returns the value of a flag as a T
+type FlagValue[T any] struct {
+	name string
+}
+
+// NewFlagValue creates a question for the value of the flag
+func NewFlagValue[T any](name string) FlagValue[T] {
+	return FlagValue[T]{name: name}
+}
+
+// AnsweredBy reads the flag through the actor's ToggleFeatures ability
+func (fv FlagValue[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
+	var result T
+
+	features, err := core.AbilityOf[ToggleFeatures](actor)
+	if err != nil {
+		return result, fmt.Errorf("actor does not have the ability to toggle features: %w", err)
+	}
+
+	value, found, err := features.Flag(ctx, fv.name)
+	if err != nil {
+		return result, fmt.Errorf("failed to read flag %s: %w", fv.name, err)
+	}
+	if !found {
+		return result, fmt.Errorf("flag %s is not set", fv.name)
+	}
+
+	result, ok := value.(T)
+	if !ok {
+		return result, fmt.Errorf("flag %s is %T, not %T", fv.name, value, result)
+	}
+	return result, nil
+}
+
+// Description returns the question description
+func (fv FlagValue[T]) Description() string {
+	return fmt.Sprintf("the value of the %s flag", fv.name)
+}
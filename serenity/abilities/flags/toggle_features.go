@@ -0,0 +1,130 @@
+// Package flags provides the ability to set and read feature flags for the duration of a
+// test, so scenarios can verify behavior under every flag state. Flags changed by an
+// actor are restored to their original values on Shutdown.
+//
+// The ability works with any flag system through a Provider. InMemory suits systems under
+// test that run in the test process; ConfigFeatures sets the environment overrides read
+// by config.Feature. Adapters for LaunchDarkly, Unleash or OpenFeature implement Provider
+// on top of the vendor's API:
+//
+//	actor := test.ActorCalled("Shopper").WhoCan(flags.ToggleFeaturesWith(provider))
+//
+//	actor.AttemptsTo(
+//		flags.EnableFlag("new-checkout"),
+//		ensure.That(flags.NewFlagValue[bool]("new-checkout"), expectations.Equals(true)),
+//	)
+package flags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// Provider reads and changes the flags of a feature flag system
+type Provider interface {
+	// Flag returns the value of the flag and whether it is set
+	Flag(ctx context.Context, name string) (value any, found bool, err error)
+	// SetFlag sets the value of the flag
+	SetFlag(ctx context.Context, name string, value any) error
+	// RemoveFlag removes the flag, so that the system falls back to its default
+	RemoveFlag(ctx context.Context, name string) error
+}
+
+// ToggleFeatures enables an actor to change feature flags for the duration of a test
+type ToggleFeatures interface {
+	abilities.Ability
+	// SetFlag sets the flag, remembering its original value the first time it is changed
+	SetFlag(ctx context.Context, name string, value any) error
+	// Flag returns the current value of the flag and whether it is set
+	Flag(ctx context.Context, name string) (any, bool, error)
+}
+
+// original is the state of a flag before the actor first changed it
+type original struct {
+	name  string
+	value any
+	found bool
+}
+
+// toggleFeatures implements the ToggleFeatures interface
+type toggleFeatures struct {
+	provider  Provider
+	originals []original
+	changed   map[string]bool
+	mutex     sync.Mutex
+}
+
+// ToggleFeaturesWith creates a new ToggleFeatures ability changing flags through the provider
+func ToggleFeaturesWith(provider Provider) ToggleFeatures {
+	return &toggleFeatures{provider: provider, changed: make(map[string]bool)}
+}
+
+// SetFlag sets the flag, remembering its original value the first time it is changed
+func (tf *toggleFeatures) SetFlag(ctx context.Context, name string, value any) error {
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+
+	if !tf.changed[name] {
+		previous, found, err := tf.provider.Flag(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to read flag %s before changing it: %w", name, err)
+		}
+		tf.originals = append(tf.originals, original{name: name, value: previous, found: found})
+		tf.changed[name] = true
+	}
+
+	if err := tf.provider.SetFlag(ctx, name, value); err != nil {
+		return fmt.Errorf("failed to set flag %s: %w", name, err)
+	}
+	return nil
+}
+
+// Flag returns the current value of the flag
+func (tf *toggleFeatures) Flag(ctx context.Context, name string) (any, bool, error) {
+	return tf.provider.Flag(ctx, name)
+}
+
+// Inspect lists the flags changed by the actor
+func (tf *toggleFeatures) Inspect() string {
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+
+	if len(tf.originals) == 0 {
+		return "no flags changed"
+	}
+	names := make([]string, len(tf.originals))
+	for i, o := range tf.originals {
+		names[i] = o.name
+	}
+	return fmt.Sprintf("changed flags %v", names)
+}
+
+// Discard restores every changed flag to its original value, in reverse order
+func (tf *toggleFeatures) Discard() error {
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+
+	ctx := context.Background()
+	var errs []error
+	for i := len(tf.originals) - 1; i >= 0; i-- {
+		o := tf.originals[i]
+
+		var err error
+		if o.found {
+			err = tf.provider.SetFlag(ctx, o.name, o.value)
+		} else {
+			err = tf.provider.RemoveFlag(ctx, o.name)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore flag %s: %w", o.name, err))
+		}
+	}
+
+	tf.originals = nil
+	tf.changed = make(map[string]bool)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// invoke is an interaction that calls a unary gRPC method
+type invoke[Resp proto.Message] struct {
+	method   string
+	request  proto.Message
+	location core.Location
+}
+
+// Invoke creates an interaction that calls the unary method, e.g. "/helloworld.Greeter/SayHello",
+// and decodes the response into a new Resp. Like HTTP requests, a call completed with a
+// non-OK status does not fail the interaction; ask LastStatusCode to verify it.
+func Invoke[Resp proto.Message](method string, request proto.Message) core.Activity {
+	return &invoke[Resp]{method: method, request: request, location: core.CallerLocation()}
+}
+
+// Location returns where the interaction was constructed
+func (i *invoke[Resp]) Location() core.Location {
+	return i.location
+}
+
+// Description returns the interaction description
+func (i *invoke[Resp]) Description() string {
+	return fmt.Sprintf("#actor invokes %s", i.method)
+}
+
+// PerformAs calls the method using the actor's CallAService ability
+func (i *invoke[Resp]) PerformAs(actor core.Actor, ctx context.Context) error {
+	if i.request == nil {
+		return fmt.Errorf("request is nil")
+	}
+
+	service, err := core.AbilityOf[CallAService](actor)
+	if err != nil {
+		return fmt.Errorf("actor does not have the ability to call a gRPC service: %w", err)
+	}
+
+	var zero Resp
+	response := zero.ProtoReflect().Type().New().Interface()
+
+	if err := service.Invoke(i.method, i.request, response, ctx); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to invoke %s: %w", i.method, err)
+	}
+	return nil
+}
+
+// FailureMode returns the failure mode for invocations (default: FailFast)
+func (i *invoke[Resp]) FailureMode() core.FailureMode {
+	return core.FailFast
+}
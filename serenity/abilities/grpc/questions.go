@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// LastStatusCode returns the status code of the last call
+type LastStatusCode struct{}
+
+// AnsweredBy returns the status code of the last gRPC call
+func (ls LastStatusCode) AnsweredBy(actor core.Actor, ctx context.Context) (codes.Code, error) {
+	service, err := core.AbilityOf[CallAService](actor)
+	if err != nil {
+		return codes.Unknown, fmt.Errorf("actor does not have the ability to call a gRPC service: %w", err)
+	}
+
+	st := service.LastStatus()
+	if st == nil {
+		return codes.Unknown, fmt.Errorf("no call made")
+	}
+
+	return st.Code(), nil
+}
+
+// Description returns the question description
+func (ls LastStatusCode) Description() string {
+	return "the last gRPC status code"
+}
+
+// LastStatusMessage returns the status message of the last call
+type LastStatusMessage struct{}
+
+// AnsweredBy returns the status message of the last gRPC call
+func (ls LastStatusMessage) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	service, err := core.AbilityOf[CallAService](actor)
+	if err != nil {
+		return "", fmt.Errorf("actor does not have the ability to call a gRPC service: %w", err)
+	}
+
+	st := service.LastStatus()
+	if st == nil {
+		return "", fmt.Errorf("no call made")
+	}
+
+	return st.Message(), nil
+}
+
+// Description returns the question description
+func (ls LastStatusMessage) Description() string {
+	return "the last gRPC status message"
+}
+
+// ResponseMessage returns the response of the last call as a message of type T
+type ResponseMessage[T any] struct{}
+
+// NewResponseMessage creates a new question for the response of the last call
+func NewResponseMessage[T any]() ResponseMessage[T] {
+	return ResponseMessage[T]{}
+}
+
+// AnsweredBy returns the response of the last successful gRPC call
+func (rm ResponseMessage[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
+	var result T
+
+	service, err := core.AbilityOf[CallAService](actor)
+	if err != nil {
+		return result, fmt.Errorf("actor does not have the ability to call a gRPC service: %w", err)
+	}
+
+	response := service.LastResponse()
+	if response == nil {
+		if st := service.LastStatus(); st != nil {
+			return result, fmt.Errorf("no response available, last call failed with %s: %s", st.Code(), st.Message())
+		}
+		return result, fmt.Errorf("no response available")
+	}
+
+	result, ok := response.(T)
+	if !ok {
+		return result, fmt.Errorf("last response is %T, not %T", response, result)
+	}
+	return result, nil
+}
+
+// Description returns the question description
+func (rm ResponseMessage[T]) Description() string {
+	return "the last gRPC response"
+}
+
+// ServiceIsServing creates a question, answered with the standard health-checking protocol,
+// for whether the server reports the service, e.g. "orders.v1.OrderService", as SERVING.
+// An empty service name asks about the server as a whole.
+func ServiceIsServing(service string) core.Question[bool] {
+	description := fmt.Sprintf("whether %s is serving", service)
+	if service == "" {
+		description = "whether the server is serving"
+	}
+
+	return core.NewQuestion(description, func(actor core.Actor, ctx context.Context) (bool, error) {
+		conn, err := connectionOf(actor)
+		if err != nil {
+			return false, err
+		}
+
+		response := &healthpb.HealthCheckResponse{}
+		err = conn.Invoke(ctx, healthpb.Health_Check_FullMethodName, &healthpb.HealthCheckRequest{Service: service}, response)
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to check health of %s: %w", service, err)
+		}
+		return response.GetStatus() == healthpb.HealthCheckResponse_SERVING, nil
+	})
+}
+
+// AvailableServices creates a question, answered with the server reflection protocol,
+// for the fully qualified names of the services the server exposes
+func AvailableServices() core.Question[[]string] {
+	return core.NewQuestion("the available gRPC services", func(actor core.Actor, ctx context.Context) ([]string, error) {
+		conn, err := connectionOf(actor)
+		if err != nil {
+			return nil, err
+		}
+
+		services, err := listServices(ctx, conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list services: %w", err)
+		}
+		return services, nil
+	})
+}
+
+// MethodExists creates a question, answered with the server reflection protocol, for
+// whether the server exposes the method, e.g. "/orders.v1.OrderService/PlaceOrder"
+func MethodExists(method string) core.Question[bool] {
+	return core.NewQuestion(fmt.Sprintf("whether %s exists", method), func(actor core.Actor, ctx context.Context) (bool, error) {
+		conn, err := connectionOf(actor)
+		if err != nil {
+			return false, err
+		}
+
+		exists, err := methodExists(ctx, conn, method)
+		if err != nil {
+			return false, fmt.Errorf("failed to look up %s: %w", method, err)
+		}
+		return exists, nil
+	})
+}
+
+// connectionOf returns the connection of the actor's CallAService ability
+func connectionOf(actor core.Actor) (grpcgo.ClientConnInterface, error) {
+	service, err := core.AbilityOf[CallAService](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to call a gRPC service: %w", err)
+	}
+	return service.Connection()
+}
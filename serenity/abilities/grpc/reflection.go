@@ -0,0 +1,138 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// reflectionMethods are the reflection protocol versions, newest first. Their messages
+// are wire compatible, so the v1 types are used for both.
+var reflectionMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+// reflectionStream describes the bidirectional reflection stream
+var reflectionStream = &grpcgo.StreamDesc{StreamName: "ServerReflectionInfo", ServerStreams: true, ClientStreams: true}
+
+// askReflection sends one request to the server reflection service and returns the
+// response, falling back to v1alpha for servers that do not implement v1
+func askReflection(ctx context.Context, conn grpcgo.ClientConnInterface, request *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	var err error
+	for _, method := range reflectionMethods {
+		var response *rpb.ServerReflectionResponse
+		response, err = askReflectionAt(ctx, conn, method, request)
+		if status.Code(err) != codes.Unimplemented {
+			return response, err
+		}
+	}
+	return nil, fmt.Errorf("server reflection is not available: %w", err)
+}
+
+// askReflectionAt performs a single request and response exchange on the reflection method
+func askReflectionAt(ctx context.Context, conn grpcgo.ClientConnInterface, method string, request *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := conn.NewStream(ctx, reflectionStream, method)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(request); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	response := &rpb.ServerReflectionResponse{}
+	if err := stream.RecvMsg(response); err != nil {
+		return nil, err
+	}
+	if reflectionError := response.GetErrorResponse(); reflectionError != nil {
+		return nil, status.Error(codes.Code(reflectionError.GetErrorCode()), reflectionError.GetErrorMessage())
+	}
+	return response, nil
+}
+
+// listServices returns the fully qualified names of the services exposed by the server
+func listServices(ctx context.Context, conn grpcgo.ClientConnInterface) ([]string, error) {
+	response, err := askReflection(ctx, conn, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services := []string{}
+	for _, service := range response.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	return services, nil
+}
+
+// methodExists reports whether the server exposes the method, given as
+// "/package.Service/Method", "package.Service/Method" or "package.Service.Method"
+func methodExists(ctx context.Context, conn grpcgo.ClientConnInterface, method string) (bool, error) {
+	service, name, err := splitMethod(method)
+	if err != nil {
+		return false, err
+	}
+
+	response, err := askReflection(ctx, conn, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, encoded := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(encoded, file); err != nil {
+			return false, fmt.Errorf("failed to decode file descriptor: %w", err)
+		}
+
+		prefix := ""
+		if file.GetPackage() != "" {
+			prefix = file.GetPackage() + "."
+		}
+		for _, s := range file.GetService() {
+			if prefix+s.GetName() != service {
+				continue
+			}
+			for _, m := range s.GetMethod() {
+				if m.GetName() == name {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// splitMethod splits a method name into its fully qualified service and method names
+func splitMethod(method string) (string, string, error) {
+	method = strings.TrimPrefix(method, "/")
+
+	separator := strings.LastIndex(method, "/")
+	if separator < 0 {
+		separator = strings.LastIndex(method, ".")
+	}
+	if separator <= 0 || separator == len(method)-1 {
+		return "", "", fmt.Errorf("invalid method name %q, expected package.Service/Method", method)
+	}
+	return method[:separator], method[separator+1:], nil
+}
@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/tlsconfig"
+)
+
+// WithTLS secures the connection with TLS configured by the options, e.g. for services
+// requiring mutual TLS:
+//
+//	grpc.CallAServiceAt("payments.internal:443", grpc.WithTLS(
+//		tlsconfig.ClientCertificate("certs/client.pem", "certs/client-key.pem"),
+//		tlsconfig.RootCAs(internalCA),
+//	))
+//
+// An invalid configuration fails every call with an Unavailable status describing it.
+func WithTLS(opts ...tlsconfig.Option) grpcgo.DialOption {
+	config, err := tlsconfig.New(opts...)
+	if err != nil {
+		return grpcgo.WithTransportCredentials(invalidCredentials{err: fmt.Errorf("invalid TLS configuration: %w", err)})
+	}
+	return grpcgo.WithTransportCredentials(credentials.NewTLS(config))
+}
+
+// WithClientCertificate secures the connection with TLS, presenting the certificate and
+// key read from PEM files. Pass the options to WithTLS to combine them with root CAs.
+func WithClientCertificate(certFile, keyFile string) grpcgo.DialOption {
+	return WithTLS(tlsconfig.ClientCertificate(certFile, keyFile))
+}
+
+// WithRootCAs secures the connection with TLS, trusting server certificates issued by the
+// PEM encoded certificate authorities
+func WithRootCAs(pem []byte) grpcgo.DialOption {
+	return WithTLS(tlsconfig.RootCAs(pem))
+}
+
+// invalidCredentials fails every handshake with the error of an invalid configuration
+type invalidCredentials struct {
+	err error
+}
+
+// ClientHandshake returns the configuration error
+func (c invalidCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, c.err
+}
+
+// ServerHandshake returns the configuration error
+func (c invalidCredentials) ServerHandshake(net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, c.err
+}
+
+// Info describes the protocol as TLS
+func (c invalidCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+// Clone returns the credentials, which hold no state
+func (c invalidCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+// OverrideServerName has no effect
+func (c invalidCredentials) OverrideServerName(string) error {
+	return nil
+}
@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// CallAServiceViaGRPCWeb creates a new CallAService ability calling unary methods through a
+// gRPC-web bridge, such as Envoy's grpc_web filter, at baseURL, e.g. "https://api.example.com".
+// Calls are sent as HTTP requests with the given client, or http.DefaultClient if it is nil,
+// so browser-facing deployments are tested the way web clients reach them. Streaming calls
+// are not supported.
+func CallAServiceViaGRPCWeb(baseURL string, client *http.Client) CallAService {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &callAService{target: baseURL, conn: &webConn{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}}
+}
+
+// grpc-web frame flags
+const (
+	webDataFrame    byte = 0x00
+	webTrailerFrame byte = 0x80
+)
+
+// webConn is a connection calling methods through a gRPC-web bridge
+type webConn struct {
+	baseURL string
+	client  *http.Client
+}
+
+// Invoke sends the request in a gRPC-web frame and decodes the response frames. Failures to
+// reach the bridge are returned as Unavailable statuses, like those of a gRPC connection.
+func (c *webConn) Invoke(ctx context.Context, method string, args, reply any, _ ...grpcgo.CallOption) error {
+	request, ok := args.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "gRPC-web request %T is not a protocol buffers message", args)
+	}
+	payload, err := proto.Marshal(request)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode request: %v", err)
+	}
+
+	var body bytes.Buffer
+	body.Grow(5 + len(payload))
+	body.WriteByte(webDataFrame)
+	_ = binary.Write(&body, binary.BigEndian, uint32(len(payload)))
+	body.Write(payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method, &body)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("Accept", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		for key, values := range md {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "gRPC-web request failed: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	message, trailers, err := readWebFrames(resp.Body)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read gRPC-web response: %v", err)
+	}
+	if err := webStatus(resp, trailers).Err(); err != nil {
+		return err
+	}
+
+	response, ok := reply.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "gRPC-web response %T is not a protocol buffers message", reply)
+	}
+	if err := proto.Unmarshal(message, response); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode response: %v", err)
+	}
+	return nil
+}
+
+// NewStream fails, as the ability calls unary methods only
+func (c *webConn) NewStream(context.Context, *grpcgo.StreamDesc, string, ...grpcgo.CallOption) (grpcgo.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streaming calls are not supported over gRPC-web")
+}
+
+// readWebFrames returns the message of the first data frame and the trailers of the trailer frame
+func readWebFrames(body io.Reader) ([]byte, http.Header, error) {
+	var message []byte
+	trailers := make(http.Header)
+	header := make([]byte, 5)
+	for {
+		if _, err := io.ReadFull(body, header); err != nil {
+			if err == io.EOF {
+				return message, trailers, nil
+			}
+			return nil, nil, err
+		}
+
+		frame := make([]byte, binary.BigEndian.Uint32(header[1:]))
+		if _, err := io.ReadFull(body, frame); err != nil {
+			return nil, nil, err
+		}
+
+		if header[0]&webTrailerFrame != 0 {
+			// the trailers are a header block without the blank line ending it
+			reader := textproto.NewReader(bufio.NewReader(io.MultiReader(bytes.NewReader(frame), strings.NewReader("\r\n"))))
+			fields, err := reader.ReadMIMEHeader()
+			if err != nil && err != io.EOF {
+				return nil, nil, fmt.Errorf("invalid trailers: %w", err)
+			}
+			for key, values := range fields {
+				trailers[key] = values
+			}
+		} else if message == nil {
+			message = frame
+		}
+	}
+}
+
+// webStatus returns the status from the trailers, or from the headers of a trailers-only
+// response, falling back to the HTTP status
+func webStatus(resp *http.Response, trailers http.Header) *status.Status {
+	fields := trailers
+	if fields.Get("Grpc-Status") == "" {
+		fields = resp.Header
+	}
+
+	if value := fields.Get("Grpc-Status"); value != "" {
+		code, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return status.Newf(codes.Internal, "invalid grpc-status %q", value)
+		}
+		message, err := url.PathUnescape(fields.Get("Grpc-Message"))
+		if err != nil {
+			message = fields.Get("Grpc-Message")
+		}
+		return status.New(codes.Code(code), message)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status.New(httpStatusCode(resp.StatusCode), "gRPC-web bridge responded with "+resp.Status)
+	}
+	return status.New(codes.Internal, "gRPC-web response has no grpc-status")
+}
+
+// httpStatusCode maps the HTTP status of a response without a gRPC status to a code, as
+// gRPC clients do
+func httpStatusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,20 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// MarkLogPosition creates an interaction that names the current end of the log, so
+// later questions can be narrowed to the lines written since
+func MarkLogPosition(name string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor marks the log position %s", name), func(actor core.Actor, ctx context.Context) error {
+		logs, err := core.AbilityOf[TailLogs](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to tail logs: %w", err)
+		}
+		return logs.Mark(ctx, name)
+	})
+}
@@ -0,0 +1,86 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// ErrorPattern matches the log lines counted as errors by NoErrorLogsSince: JSON and
+// logfmt entries at the error, fatal or panic level, and plain lines with ERROR, FATAL
+// or PANIC in capitals
+var ErrorPattern = regexp.MustCompile(`(?i:"level"\s*:\s*"(error|fatal|panic)"|\blevel=(error|fatal|panic)\b)|\b(ERROR|FATAL|PANIC)\b`)
+
+// linesSince returns the lines written after the mark, or all lines if mark is empty
+func linesSince(actor core.Actor, ctx context.Context, mark string) ([]string, error) {
+	logs, err := core.AbilityOf[TailLogs](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to tail logs: %w", err)
+	}
+
+	lines, err := logs.Lines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if mark == "" {
+		return lines, nil
+	}
+
+	position, ok := logs.Position(mark)
+	if !ok {
+		return nil, fmt.Errorf("no log position marked as %s", mark)
+	}
+	return lines[position:], nil
+}
+
+// LinesMatching creates a question for the log lines matching the regular expression
+// that were written since the mark, or since the ability was created if since is empty
+func LinesMatching(pattern, since string) core.Question[[]string] {
+	description := fmt.Sprintf("the log lines matching %s", pattern)
+	if since != "" {
+		description += " since " + since
+	}
+
+	return core.NewQuestion(description, func(actor core.Actor, ctx context.Context) ([]string, error) {
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+
+		lines, err := linesSince(actor, ctx, since)
+		if err != nil {
+			return nil, err
+		}
+		return matching(lines, expression), nil
+	})
+}
+
+// NoErrorLogsSince creates a question for whether no line matching ErrorPattern was
+// written since the mark, or since the ability was created if marker is empty
+func NoErrorLogsSince(marker string) core.Question[bool] {
+	description := "whether no errors were logged"
+	if marker != "" {
+		description += " since " + marker
+	}
+
+	return core.NewQuestion(description, func(actor core.Actor, ctx context.Context) (bool, error) {
+		lines, err := linesSince(actor, ctx, marker)
+		if err != nil {
+			return false, err
+		}
+		return len(matching(lines, ErrorPattern)) == 0, nil
+	})
+}
+
+// matching returns the lines matching the expression
+func matching(lines []string, expression *regexp.Regexp) []string {
+	matches := []string{}
+	for _, line := range lines {
+		if expression.MatchString(line) {
+			matches = append(matches, line)
+		}
+	}
+	return matches
+}
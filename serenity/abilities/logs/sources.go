@@ -0,0 +1,140 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileLog follows a file from the offset it has read up to
+type fileLog struct {
+	path    string
+	offset  int64
+	partial []byte
+	mutex   sync.Mutex
+}
+
+// File creates a Log following the file at path from its current end. A file that
+// does not exist yet is followed from its start once it is created.
+func File(path string) Log {
+	log := &fileLog{path: path}
+	if info, err := os.Stat(path); err == nil {
+		log.offset = info.Size()
+	}
+	return log
+}
+
+// ReadLines returns the complete lines appended to the file since the previous call
+func (f *fileLog) ReadLines(ctx context.Context) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	file, err := os.Open(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close() // Ignore cleanup error
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < f.offset {
+		// The file was truncated or rotated
+		f.offset = 0
+		f.partial = nil
+	}
+
+	if _, err := file.Seek(f.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	f.offset += int64(len(content))
+
+	return f.split(content), nil
+}
+
+// split returns the complete lines of the content, keeping an incomplete last line
+// until the rest of it is read
+func (f *fileLog) split(content []byte) []string {
+	content = append(f.partial, content...)
+	end := bytes.LastIndexByte(content, '\n')
+	if end < 0 {
+		f.partial = content
+		return nil
+	}
+
+	f.partial = append([]byte(nil), content[end+1:]...)
+	return splitLines(string(content[:end]))
+}
+
+// Description names the file
+func (f *fileLog) Description() string {
+	return "log file " + f.path
+}
+
+// dockerLog follows the output of a Docker container since a point in time
+type dockerLog struct {
+	container string
+	since     time.Time
+	read      int
+	mutex     sync.Mutex
+}
+
+// DockerContainer creates a Log following the standard output and error of the container
+// from now on, using the docker command
+func DockerContainer(container string) Log {
+	return &dockerLog{container: container, since: time.Now()}
+}
+
+// ReadLines returns the lines the container logged since the previous call
+func (d *dockerLog) ReadLines(ctx context.Context) ([]string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// #nosec G204 -- the container is chosen by the test author
+	cmd := exec.CommandContext(ctx, "docker", "logs", "--since", d.since.Format(time.RFC3339Nano), d.container)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("docker logs failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	if len(output) == 0 {
+		return nil, nil
+	}
+	lines := splitLines(strings.TrimSuffix(string(output), "\n"))
+	if len(lines) <= d.read {
+		return nil, nil
+	}
+	added := lines[d.read:]
+	d.read = len(lines)
+	return added, nil
+}
+
+// Description names the container
+func (d *dockerLog) Description() string {
+	return "logs of container " + d.container
+}
+
+// splitLines splits the text into lines, dropping carriage returns
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
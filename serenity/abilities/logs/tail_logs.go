@@ -0,0 +1,119 @@
+// Package logs provides the ability to tail the logs of the system under test during a
+// scenario, so tests can assert that it logged, or did not log, specific events.
+//
+// Only lines written after the ability is created are seen. Positions in the log are
+// marked by name to narrow later questions to the lines written since:
+//
+//	actor := test.ActorCalled("Operator").WhoCan(logs.TailFile("/var/log/orders.log"))
+//
+//	actor.AttemptsTo(
+//		logs.MarkLogPosition("before checkout"),
+//		api.SendPostRequest("/checkout").WithBody(cart),
+//		ensure.That(logs.LinesMatching(`"event":"order_placed"`, "before checkout"), expectations.Equals([]string{
+//			`{"level":"info","event":"order_placed","order":42}`,
+//		})),
+//		ensure.That(logs.NoErrorLogsSince("before checkout"), expectations.Equals(true)),
+//	)
+package logs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// Log is a growing log of the system under test
+type Log interface {
+	// ReadLines returns the lines appended to the log since the previous call
+	ReadLines(ctx context.Context) ([]string, error)
+	// Description names the log
+	Description() string
+}
+
+// TailLogs enables an actor to follow the log of the system under test
+type TailLogs interface {
+	abilities.Ability
+	// Lines returns every line written to the log since the ability was created
+	Lines(ctx context.Context) ([]string, error)
+	// Mark names the current end of the log
+	Mark(ctx context.Context, name string) error
+	// Position returns the number of lines that preceded the named mark
+	Position(name string) (int, bool)
+}
+
+// tailLogs implements the TailLogs interface
+type tailLogs struct {
+	log   Log
+	lines []string
+	marks map[string]int
+	mutex sync.Mutex
+}
+
+// TailingWith creates a new TailLogs ability following the log
+func TailingWith(log Log) TailLogs {
+	return &tailLogs{log: log, marks: make(map[string]int)}
+}
+
+// TailFile creates a new TailLogs ability following the file at path, starting at its
+// current end. A file that is truncated or replaced by rotation is followed from its start.
+func TailFile(path string) TailLogs {
+	return TailingWith(File(path))
+}
+
+// TailDockerContainer creates a new TailLogs ability following the output of the
+// container, starting now. It requires the docker command.
+func TailDockerContainer(container string) TailLogs {
+	return TailingWith(DockerContainer(container))
+}
+
+// Lines returns every line written to the log since the ability was created
+func (t *tailLogs) Lines(ctx context.Context) ([]string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if err := t.read(ctx); err != nil {
+		return nil, err
+	}
+	return append([]string(nil), t.lines...), nil
+}
+
+// Mark names the current end of the log
+func (t *tailLogs) Mark(ctx context.Context, name string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if err := t.read(ctx); err != nil {
+		return err
+	}
+	t.marks[name] = len(t.lines)
+	return nil
+}
+
+// Position returns the number of lines that preceded the named mark
+func (t *tailLogs) Position(name string) (int, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	position, ok := t.marks[name]
+	return position, ok
+}
+
+// read appends the new lines of the log
+func (t *tailLogs) read(ctx context.Context) error {
+	lines, err := t.log.ReadLines(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", t.log.Description(), err)
+	}
+	t.lines = append(t.lines, lines...)
+	return nil
+}
+
+// Inspect describes the log and the number of lines read
+func (t *tailLogs) Inspect() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return fmt.Sprintf("%s, %d lines read, %d marks", t.log.Description(), len(t.lines), len(t.marks))
+}
@@ -0,0 +1,28 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Record creates an interaction that notes the value under the subject
+func Record(subject string, value any) core.Activity {
+	return core.Do(fmt.Sprintf("#actor notes the %s", subject), func(actor core.Actor, ctx context.Context) error {
+		Of(actor).Record(subject, value)
+		return nil
+	})
+}
+
+// RecordAnswer creates an interaction that notes the answer to the question under the subject
+func RecordAnswer[T any](subject string, question core.Question[T]) core.Activity {
+	return core.Do(fmt.Sprintf("#actor notes %s as the %s", question.Description(), subject), func(actor core.Actor, ctx context.Context) error {
+		answer, err := question.AnsweredBy(actor, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to answer %s: %w", question.Description(), err)
+		}
+		Of(actor).Record(subject, answer)
+		return nil
+	})
+}
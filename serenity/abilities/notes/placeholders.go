@@ -0,0 +1,81 @@
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// placeholder matches "{{subject}}", allowing spaces around the subject
+var placeholder = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// Resolve replaces every "{{subject}}" placeholder in the template with the value the
+// actor noted under the subject, formatted with fmt.Sprint. It fails if a subject has
+// no note, so that a mistyped placeholder is not sent to the system under test.
+func Resolve(actor core.Actor, template string) (string, error) {
+	return resolve(actor, template, sprint)
+}
+
+// ResolveJSON resolves the placeholders of a JSON template as Resolve does, escaping the
+// values so that they can stand inside JSON strings, e.g. {"reason": "{{reason}}"}
+func ResolveJSON(actor core.Actor, template string) (string, error) {
+	return resolve(actor, template, func(value any) string {
+		quoted, _ := json.Marshal(fmt.Sprint(value))
+		return string(quoted[1 : len(quoted)-1])
+	})
+}
+
+// resolve replaces the placeholders of the template with the notes on their subjects,
+// formatted with format, failing if a subject has no note
+func resolve(actor core.Actor, template string, format func(value any) string) (string, error) {
+	if !strings.Contains(template, "{{") {
+		return template, nil
+	}
+
+	var missing []string
+	resolved := replace(actor, template, format, func(subject string) string {
+		missing = append(missing, subject)
+		return "{{" + subject + "}}"
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no notes on %s for the placeholders in %q", strings.Join(missing, ", "), template)
+	}
+	return resolved, nil
+}
+
+// Fill replaces the "{{subject}}" placeholders in the text that the actor has notes on,
+// leaving the others unchanged. Actors fill the descriptions of activities before
+// reporting them.
+func Fill(actor core.Actor, text string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	return replace(actor, text, sprint, func(subject string) string {
+		return "{{" + subject + "}}"
+	})
+}
+
+// sprint formats a noted value with fmt.Sprint
+func sprint(value any) string {
+	return fmt.Sprint(value)
+}
+
+// replace replaces the placeholders with the notes on their subjects, formatted with
+// format, or with the result of unknown for subjects without a note
+func replace(actor core.Actor, text string, format func(value any) string, unknown func(subject string) string) string {
+	notepad, _ := core.AbilityOf[TakeNotes](actor)
+
+	return placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		subject := placeholder.FindStringSubmatch(match)[1]
+		if notepad != nil {
+			if value, ok := notepad.Recall(subject); ok {
+				return format(value)
+			}
+		}
+		return unknown(subject)
+	})
+}
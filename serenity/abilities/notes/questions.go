@@ -0,0 +1,31 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Recall creates a question for the value the actor noted under the subject
+func Recall[T any](subject string) core.Question[T] {
+	return core.NewQuestion(fmt.Sprintf("the noted %s", subject), func(actor core.Actor, ctx context.Context) (T, error) {
+		var result T
+
+		notepad, err := core.AbilityOf[TakeNotes](actor)
+		if err != nil {
+			return result, fmt.Errorf("actor does not have the ability to take notes: %w", err)
+		}
+
+		value, ok := notepad.Recall(subject)
+		if !ok {
+			return result, fmt.Errorf("no note on the %s", subject)
+		}
+
+		result, ok = value.(T)
+		if !ok {
+			return result, fmt.Errorf("the note on the %s is %T, not %T", subject, value, result)
+		}
+		return result, nil
+	})
+}
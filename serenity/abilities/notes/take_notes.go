@@ -0,0 +1,103 @@
+// Package notes provides the ability to take notes: values an actor records during a
+// scenario, such as an order ID or an access token, and recalls in later activities.
+//
+//	actor := test.ActorCalled("Buyer").WhoCan(notes.UsingAnEmptyNotepad())
+//
+//	actor.AttemptsTo(
+//		notes.Record("order id", "ord-42"),
+//		ensure.That(notes.Recall[string]("order id"), expectations.Equals("ord-42")),
+//	)
+package notes
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// TakeNotes enables an actor to remember values between activities
+type TakeNotes interface {
+	abilities.Ability
+	// Record remembers the value under the subject, replacing any earlier note
+	Record(subject string, value any)
+	// Recall returns the value noted under the subject and whether there is one
+	Recall(subject string) (any, bool)
+}
+
+// takeNotes implements the TakeNotes interface
+type takeNotes struct {
+	notes map[string]any
+	mutex sync.RWMutex
+}
+
+// UsingAnEmptyNotepad creates a new TakeNotes ability without any notes
+func UsingAnEmptyNotepad() TakeNotes {
+	return &takeNotes{notes: make(map[string]any)}
+}
+
+// Record remembers the value under the subject
+func (tn *takeNotes) Record(subject string, value any) {
+	tn.mutex.Lock()
+	defer tn.mutex.Unlock()
+
+	tn.notes[subject] = value
+}
+
+// Recall returns the value noted under the subject
+func (tn *takeNotes) Recall(subject string) (any, bool) {
+	tn.mutex.RLock()
+	defer tn.mutex.RUnlock()
+
+	value, ok := tn.notes[subject]
+	return value, ok
+}
+
+// Inspect lists the subjects of the notes. Values are left out, as they may be secrets.
+func (tn *takeNotes) Inspect() string {
+	tn.mutex.RLock()
+	defer tn.mutex.RUnlock()
+
+	if len(tn.notes) == 0 {
+		return "no notes"
+	}
+	subjects := make([]string, 0, len(tn.notes))
+	for subject := range tn.notes {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+	return fmt.Sprintf("notes on %v", subjects)
+}
+
+// Snapshot returns a copy of the actor's notes, or nil if the actor takes no notes or its
+// notepad can't list them. The values may be secrets, so mask them before reporting them.
+func Snapshot(actor core.Actor) map[string]any {
+	notepad, err := core.AbilityOf[TakeNotes](actor)
+	if err != nil {
+		return nil
+	}
+	tn, ok := notepad.(*takeNotes)
+	if !ok {
+		return nil
+	}
+
+	tn.mutex.RLock()
+	defer tn.mutex.RUnlock()
+
+	return maps.Clone(tn.notes)
+}
+
+// Of returns the actor's notepad, giving the actor an empty one if it has none, so that
+// tasks can take notes without requiring the ability up front
+func Of(actor core.Actor) TakeNotes {
+	if notepad, err := core.AbilityOf[TakeNotes](actor); err == nil {
+		return notepad
+	}
+
+	notepad := UsingAnEmptyNotepad()
+	actor.WhoCan(notepad)
+	return notepad
+}
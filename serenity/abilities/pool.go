@@ -0,0 +1,128 @@
+package abilities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPoolClosed is returned when an ability is acquired from a closed pool
+var ErrPoolClosed = errors.New("ability pool is closed")
+
+// Pool lends a limited number of abilities, such as browsers or database connections, to
+// scenarios running in parallel. Abilities are created on demand, returned to the pool
+// when a scenario is done with them and reused by the next one, so a large parallel run
+// needs no more of them than the size of the pool:
+//
+//	var databases = abilities.NewPool(4, func(ctx context.Context) (db.UseADatabase, error) {
+//		return db.UseADatabaseAt("postgres", dsn), nil
+//	})
+//
+//	func TestCheckout(t *testing.T) {
+//		t.Parallel()
+//		test := serenity.NewSerenityTest(t)
+//		actor := test.ActorCalled("Shopper").WhoCan(serenity.Borrow(test, databases))
+//		// ...
+//	}
+type Pool[T Ability] struct {
+	create func(ctx context.Context) (T, error)
+	slots  chan struct{}
+	idle   []T
+	closed bool
+	mutex  sync.Mutex
+}
+
+// NewPool creates a pool lending at most size abilities at a time, created with create
+func NewPool[T Ability](size int, create func(ctx context.Context) (T, error)) *Pool[T] {
+	if size < 1 {
+		size = 1
+	}
+	return &Pool[T]{create: create, slots: make(chan struct{}, size)}
+}
+
+// Acquire lends an idle ability, or creates one if fewer than size abilities exist. When
+// all of them are lent it waits for one to be released or for the context to be done.
+func (p *Pool[T]) Acquire(ctx context.Context) (T, error) {
+	var none T
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return none, ctx.Err()
+	}
+
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		<-p.slots
+		return none, ErrPoolClosed
+	}
+	if n := len(p.idle); n > 0 {
+		ability := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mutex.Unlock()
+		return ability, nil
+	}
+	p.mutex.Unlock()
+
+	ability, err := p.create(ctx)
+	if err != nil {
+		<-p.slots
+		return none, fmt.Errorf("failed to create pooled ability: %w", err)
+	}
+	return ability, nil
+}
+
+// Release returns a lent ability to the pool. Once the pool is closed, the ability is
+// discarded instead.
+func (p *Pool[T]) Release(ability T) error {
+	p.mutex.Lock()
+	closed := p.closed
+	if !closed {
+		p.idle = append(p.idle, ability)
+	}
+	p.mutex.Unlock()
+	<-p.slots
+
+	if closed {
+		return discard(ability)
+	}
+	return nil
+}
+
+// Idle returns the number of abilities waiting in the pool to be lent
+func (p *Pool[T]) Idle() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.idle)
+}
+
+// Lent returns the number of abilities currently lent
+func (p *Pool[T]) Lent() int {
+	return len(p.slots)
+}
+
+// Close discards the idle abilities. Abilities still lent are discarded when released.
+func (p *Pool[T]) Close() error {
+	p.mutex.Lock()
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.mutex.Unlock()
+
+	var errs []error
+	for _, ability := range idle {
+		if err := discard(ability); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// discard discards the ability if it is Discardable
+func discard(ability Ability) error {
+	if discardable, ok := ability.(Discardable); ok {
+		return discardable.Discard()
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/clock"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+const (
+	// pullWaitTime is how long PullFrom waits for a message to arrive
+	pullWaitTime = 10 * time.Second
+	// pullInterval is the pause between pulls that return no message
+	pullInterval = 100 * time.Millisecond
+)
+
+// PublishTo creates an interaction that publishes a message to a topic, given by its
+// short name or as projects/<project>/topics/<topic>
+func PublishTo(topic, data string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor publishes a message to %s", topic), func(actor core.Actor, ctx context.Context) error {
+		ability, err := core.AbilityOf[UsePubSub](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use Pub/Sub: %w", err)
+		}
+
+		if _, err := ability.Publish(ctx, topic, Message{Data: []byte(data)}); err != nil {
+			return fmt.Errorf("failed to publish message to %s: %w", topic, err)
+		}
+		return nil
+	})
+}
+
+// PullFrom creates an interaction that waits for a message to arrive in a subscription,
+// acknowledges it and remembers it for LastReceivedMessageAs. The wait is measured on
+// the actor's clock, so a virtual clock gives up without delay.
+func PullFrom(subscription string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor pulls a message from %s", subscription), func(actor core.Actor, ctx context.Context) error {
+		ability, err := core.AbilityOf[UsePubSub](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use Pub/Sub: %w", err)
+		}
+
+		c := clock.Of(actor)
+		deadline := c.Now().Add(pullWaitTime)
+		for {
+			message, err := ability.Pull(ctx, subscription)
+			if err != nil {
+				return fmt.Errorf("failed to pull message from %s: %w", subscription, err)
+			}
+			if message != nil {
+				ability.RecordReceivedMessage(*message)
+				return nil
+			}
+
+			if c.Now().After(deadline) {
+				return fmt.Errorf("no message arrived in %s within %s", subscription, pullWaitTime)
+			}
+			if err := c.Sleep(ctx, pullInterval); err != nil {
+				return err
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// LastReceivedMessageData returns the data of the last message pulled by PullFrom
+type LastReceivedMessageData struct{}
+
+// AnsweredBy returns the data of the last received message
+func (ld LastReceivedMessageData) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	message, err := lastReceivedMessage(actor)
+	if err != nil {
+		return "", err
+	}
+	return string(message.Data), nil
+}
+
+// Description returns the question description
+func (ld LastReceivedMessageData) Description() string {
+	return "the data of the last received message"
+}
+
+// LastReceivedMessageAs creates a question that decodes the JSON data of the last
+// message pulled by PullFrom into a T
+func LastReceivedMessageAs[T any]() core.Question[T] {
+	return core.NewQuestion("the last received message", func(actor core.Actor, ctx context.Context) (T, error) {
+		var value T
+
+		message, err := lastReceivedMessage(actor)
+		if err != nil {
+			return value, err
+		}
+
+		if err := json.Unmarshal(message.Data, &value); err != nil {
+			return value, fmt.Errorf("failed to decode last received message: %w", err)
+		}
+		return value, nil
+	})
+}
+
+// lastReceivedMessage returns the last message received by the actor
+func lastReceivedMessage(actor core.Actor) (*Message, error) {
+	ability, err := core.AbilityOf[UsePubSub](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to use Pub/Sub: %w", err)
+	}
+
+	message := ability.LastReceivedMessage()
+	if message == nil {
+		return nil, errors.New("no message has been received")
+	}
+	return message, nil
+}
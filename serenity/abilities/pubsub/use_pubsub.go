@@ -0,0 +1,207 @@
+// Package pubsub provides the ability to use Google Cloud Pub/Sub through its REST API,
+// together with interactions that publish and pull messages and questions about them.
+//
+// The ability talks to the Pub/Sub emulator when PUBSUB_EMULATOR_HOST is set:
+//
+//	actor := test.ActorCalled("Shipper").WhoCan(pubsub.UsePubSubIn("test-project"))
+//
+//	actor.AttemptsTo(
+//		pubsub.PublishTo("orders", `{"order":42}`),
+//		pubsub.PullFrom("orders-audit"),
+//		ensure.That(pubsub.LastReceivedMessageAs[OrderEvent](), expectations.Equals(OrderEvent{Order: 42})),
+//	)
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// DefaultEndpoint is the Pub/Sub REST endpoint used outside the emulator
+const DefaultEndpoint = "https://pubsub.googleapis.com"
+
+// Message is a Pub/Sub message as represented by the REST API
+type Message struct {
+	ID          string            `json:"messageId,omitempty"`
+	Data        []byte            `json:"data,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	PublishTime string            `json:"publishTime,omitempty"`
+}
+
+// UsePubSub enables an actor to publish messages to topics and pull them from subscriptions
+type UsePubSub interface {
+	abilities.Ability
+	// Publish publishes the message to the topic and returns its ID
+	Publish(ctx context.Context, topic string, message Message) (string, error)
+	// Pull pulls and acknowledges one message from the subscription, or returns nil
+	// if none is available
+	Pull(ctx context.Context, subscription string) (*Message, error)
+	// WithHTTPClient sends requests with the client, e.g. one that adds OAuth credentials
+	WithHTTPClient(client *http.Client) UsePubSub
+	// RecordReceivedMessage remembers the message as the last one received
+	RecordReceivedMessage(message Message)
+	// LastReceivedMessage returns the last message received, or nil
+	LastReceivedMessage() *Message
+}
+
+// usePubSub implements the UsePubSub interface
+type usePubSub struct {
+	endpoint     string
+	project      string
+	client       *http.Client
+	lastReceived *Message
+	mutex        sync.RWMutex
+}
+
+// UsePubSubIn creates a new UsePubSub ability for the project. It uses the emulator at
+// PUBSUB_EMULATOR_HOST when the variable is set, and DefaultEndpoint otherwise.
+func UsePubSubIn(project string) UsePubSub {
+	endpoint := DefaultEndpoint
+	if host := os.Getenv("PUBSUB_EMULATOR_HOST"); host != "" {
+		endpoint = "http://" + host
+	}
+	return UsePubSubAt(endpoint, project)
+}
+
+// UsePubSubAt creates a new UsePubSub ability for the project at the REST endpoint
+func UsePubSubAt(endpoint, project string) UsePubSub {
+	return &usePubSub{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		project:  project,
+		client:   http.DefaultClient,
+	}
+}
+
+// WithHTTPClient sends requests with the client
+func (u *usePubSub) WithHTTPClient(client *http.Client) UsePubSub {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.client = client
+	return u
+}
+
+// Publish publishes the message to the topic and returns its ID
+func (u *usePubSub) Publish(ctx context.Context, topic string, message Message) (string, error) {
+	var response struct {
+		MessageIDs []string `json:"messageIds"`
+	}
+	request := map[string]any{"messages": []Message{message}}
+	if err := u.call(ctx, u.resourceName("topics", topic)+":publish", request, &response); err != nil {
+		return "", err
+	}
+	if len(response.MessageIDs) == 0 {
+		return "", fmt.Errorf("publish to %s returned no message ID", topic)
+	}
+	return response.MessageIDs[0], nil
+}
+
+// Pull pulls and acknowledges one message from the subscription
+func (u *usePubSub) Pull(ctx context.Context, subscription string) (*Message, error) {
+	var response struct {
+		ReceivedMessages []struct {
+			AckID   string  `json:"ackId"`
+			Message Message `json:"message"`
+		} `json:"receivedMessages"`
+	}
+	name := u.resourceName("subscriptions", subscription)
+	if err := u.call(ctx, name+":pull", map[string]any{"maxMessages": 1}, &response); err != nil {
+		return nil, err
+	}
+	if len(response.ReceivedMessages) == 0 {
+		return nil, nil
+	}
+
+	received := response.ReceivedMessages[0]
+	if err := u.call(ctx, name+":acknowledge", map[string]any{"ackIds": []string{received.AckID}}, nil); err != nil {
+		return nil, err
+	}
+	return &received.Message, nil
+}
+
+// resourceName expands a short topic or subscription name to its full name in the project
+func (u *usePubSub) resourceName(collection, name string) string {
+	if strings.HasPrefix(name, "projects/") {
+		return name
+	}
+	return fmt.Sprintf("projects/%s/%s/%s", u.project, collection, name)
+}
+
+// call posts the request as JSON to the method of the REST API and decodes the response
+func (u *usePubSub) call(ctx context.Context, method string, request, response any) error {
+	u.mutex.RLock()
+	client := u.client
+	u.mutex.RUnlock()
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, u.endpoint+"/v1/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer func() {
+		_ = httpResponse.Body.Close() // Ignore cleanup error
+	}()
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response of %s: %w", method, err)
+	}
+	if httpResponse.StatusCode >= 300 {
+		return fmt.Errorf("%s failed with status %d: %s", method, httpResponse.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	if response == nil {
+		return nil
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", method, err)
+	}
+	return nil
+}
+
+// RecordReceivedMessage remembers the message as the last one received
+func (u *usePubSub) RecordReceivedMessage(message Message) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.lastReceived = &message
+}
+
+// LastReceivedMessage returns the last message received
+func (u *usePubSub) LastReceivedMessage() *Message {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	return u.lastReceived
+}
+
+// Inspect describes the project, endpoint and last received message
+func (u *usePubSub) Inspect() string {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	state := fmt.Sprintf("project %s at %s", u.project, u.endpoint)
+	if u.lastReceived != nil {
+		state += fmt.Sprintf(", last received message %s", u.lastReceived.ID)
+	}
+	return state
+}
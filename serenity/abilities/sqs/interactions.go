@@ -0,0 +1,99 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// receiveWaitSeconds is how long ReceiveFrom waits for a message to arrive
+const receiveWaitSeconds = 10
+
+// SendToQueue creates an interaction that sends a message to a queue, given by name or URL
+func SendToQueue(queue, body string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor sends a message to %s", queue), func(actor core.Actor, ctx context.Context) error {
+		ability, err := core.AbilityOf[UseSQS](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use SQS: %w", err)
+		}
+
+		url, err := ability.QueueURL(ctx, queue)
+		if err != nil {
+			return err
+		}
+		client, err := ability.SQS(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.SendMessage(ctx, &awssqs.SendMessageInput{QueueUrl: aws.String(url), MessageBody: aws.String(body)}); err != nil {
+			return fmt.Errorf("failed to send message to %s: %w", queue, err)
+		}
+		return nil
+	})
+}
+
+// PublishToTopic creates an interaction that publishes a message to an SNS topic
+func PublishToTopic(topicARN, message string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor publishes a message to %s", topicARN), func(actor core.Actor, ctx context.Context) error {
+		ability, err := core.AbilityOf[UseSQS](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use SQS: %w", err)
+		}
+
+		client, err := ability.SNS(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.Publish(ctx, &awssns.PublishInput{TopicArn: aws.String(topicARN), Message: aws.String(message)}); err != nil {
+			return fmt.Errorf("failed to publish message to %s: %w", topicARN, err)
+		}
+		return nil
+	})
+}
+
+// ReceiveFrom creates an interaction that waits for a message to arrive in a queue,
+// deletes it from the queue and remembers it for LastReceivedMessageAs
+func ReceiveFrom(queue string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor receives a message from %s", queue), func(actor core.Actor, ctx context.Context) error {
+		ability, err := core.AbilityOf[UseSQS](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to use SQS: %w", err)
+		}
+
+		url, err := ability.QueueURL(ctx, queue)
+		if err != nil {
+			return err
+		}
+		client, err := ability.SQS(ctx)
+		if err != nil {
+			return err
+		}
+
+		output, err := client.ReceiveMessage(ctx, &awssqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(url),
+			MaxNumberOfMessages: 1,
+			WaitTimeSeconds:     receiveWaitSeconds,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to receive message from %s: %w", queue, err)
+		}
+		if len(output.Messages) == 0 {
+			return fmt.Errorf("no message arrived in %s within %d seconds", queue, receiveWaitSeconds)
+		}
+
+		message := output.Messages[0]
+		if _, err := client.DeleteMessage(ctx, &awssqs.DeleteMessageInput{QueueUrl: aws.String(url), ReceiptHandle: message.ReceiptHandle}); err != nil {
+			return fmt.Errorf("failed to delete received message from %s: %w", queue, err)
+		}
+
+		ability.RecordReceivedMessage(message)
+		return nil
+	})
+}
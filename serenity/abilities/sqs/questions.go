@@ -0,0 +1,110 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// QueueDepth returns the approximate number of messages available in a queue
+type QueueDepth struct {
+	queue string
+}
+
+// NewQueueDepth creates a question for the depth of the queue, given by name or URL
+func NewQueueDepth(queue string) QueueDepth {
+	return QueueDepth{queue: queue}
+}
+
+// AnsweredBy reads the ApproximateNumberOfMessages attribute of the queue
+func (qd QueueDepth) AnsweredBy(actor core.Actor, ctx context.Context) (int, error) {
+	ability, err := core.AbilityOf[UseSQS](actor)
+	if err != nil {
+		return 0, fmt.Errorf("actor does not have the ability to use SQS: %w", err)
+	}
+
+	url, err := ability.QueueURL(ctx, qd.queue)
+	if err != nil {
+		return 0, err
+	}
+	client, err := ability.SQS(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	output, err := client.GetQueueAttributes(ctx, &awssqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(url),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read attributes of %s: %w", qd.queue, err)
+	}
+
+	depth, err := strconv.Atoi(output.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)])
+	if err != nil {
+		return 0, fmt.Errorf("failed to read depth of %s: %w", qd.queue, err)
+	}
+	return depth, nil
+}
+
+// Description returns the question description
+func (qd QueueDepth) Description() string {
+	return fmt.Sprintf("the depth of %s", qd.queue)
+}
+
+// LastReceivedMessageBody returns the body of the last message received by ReceiveFrom
+type LastReceivedMessageBody struct{}
+
+// AnsweredBy returns the body of the last received message
+func (lb LastReceivedMessageBody) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	message, err := lastReceivedMessage(actor)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(message.Body), nil
+}
+
+// Description returns the question description
+func (lb LastReceivedMessageBody) Description() string {
+	return "the body of the last received message"
+}
+
+// LastReceivedMessageAs creates a question that decodes the JSON body of the last
+// message received by ReceiveFrom into a T
+func LastReceivedMessageAs[T any]() core.Question[T] {
+	return core.NewQuestion("the last received message", func(actor core.Actor, ctx context.Context) (T, error) {
+		var value T
+
+		message, err := lastReceivedMessage(actor)
+		if err != nil {
+			return value, err
+		}
+
+		if err := json.Unmarshal([]byte(aws.ToString(message.Body)), &value); err != nil {
+			return value, fmt.Errorf("failed to decode last received message: %w", err)
+		}
+		return value, nil
+	})
+}
+
+// lastReceivedMessage returns the last message received by the actor
+func lastReceivedMessage(actor core.Actor) (*types.Message, error) {
+	ability, err := core.AbilityOf[UseSQS](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to use SQS: %w", err)
+	}
+
+	message := ability.LastReceivedMessage()
+	if message == nil {
+		return nil, errors.New("no message has been received")
+	}
+	return message, nil
+}
@@ -0,0 +1,198 @@
+// Package sqs provides the ability to use AWS SQS queues and SNS topics, together with
+// interactions that send, publish and receive messages and questions about them.
+//
+// SNS delivery is usually verified by subscribing an SQS queue to the topic and receiving
+// from the queue, which is why both services share one ability:
+//
+//	actor := test.ActorCalled("Shipper").WhoCan(sqs.UseSQSAt("http://localhost:4566", "us-east-1"))
+//
+//	actor.AttemptsTo(
+//		sqs.PublishToTopic(topicARN, `{"order":42}`),
+//		sqs.ReceiveFrom("order-events"),
+//		ensure.That(sqs.LastReceivedMessageAs[OrderEvent](), expectations.Equals(OrderEvent{Order: 42})),
+//	)
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// SQSClient is the subset of the SQS API used by the ability; *sqs.Client implements it
+type SQSClient interface {
+	GetQueueUrl(ctx context.Context, params *awssqs.GetQueueUrlInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueUrlOutput, error)
+	SendMessage(ctx context.Context, params *awssqs.SendMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.SendMessageOutput, error)
+	ReceiveMessage(ctx context.Context, params *awssqs.ReceiveMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error)
+	DeleteMessage(ctx context.Context, params *awssqs.DeleteMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.DeleteMessageOutput, error)
+	GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error)
+}
+
+// SNSClient is the subset of the SNS API used by the ability; *sns.Client implements it
+type SNSClient interface {
+	Publish(ctx context.Context, params *awssns.PublishInput, optFns ...func(*awssns.Options)) (*awssns.PublishOutput, error)
+}
+
+// UseSQS enables an actor to send messages to SQS queues, publish to SNS topics and
+// receive the messages that arrive in queues
+type UseSQS interface {
+	abilities.Ability
+	// SQS returns the SQS client
+	SQS(ctx context.Context) (SQSClient, error)
+	// SNS returns the SNS client
+	SNS(ctx context.Context) (SNSClient, error)
+	// QueueURL resolves a queue name to its URL. URLs are returned unchanged.
+	QueueURL(ctx context.Context, queue string) (string, error)
+	// RecordReceivedMessage remembers the message as the last one received
+	RecordReceivedMessage(message types.Message)
+	// LastReceivedMessage returns the last message received, or nil
+	LastReceivedMessage() *types.Message
+}
+
+// useSQS implements the UseSQS interface
+type useSQS struct {
+	endpoint     string
+	region       string
+	sqsClient    SQSClient
+	snsClient    SNSClient
+	queueURLs    map[string]string
+	lastReceived *types.Message
+	mutex        sync.Mutex
+}
+
+// Using creates a new UseSQS ability with existing clients. The SNS client may be nil
+// if the actor does not publish to topics.
+func Using(sqsClient SQSClient, snsClient SNSClient) UseSQS {
+	return &useSQS{sqsClient: sqsClient, snsClient: snsClient, queueURLs: make(map[string]string)}
+}
+
+// UseSQSAt creates a new UseSQS ability whose clients are created on first use from the
+// default AWS configuration for the region. A non-empty endpoint, such as
+// http://localhost:4566 for LocalStack, replaces the AWS endpoints of both services.
+func UseSQSAt(endpoint, region string) UseSQS {
+	return &useSQS{endpoint: endpoint, region: region, queueURLs: make(map[string]string)}
+}
+
+// SQS returns the SQS client, creating it if needed
+func (u *useSQS) SQS(ctx context.Context) (SQSClient, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if err := u.connect(ctx); err != nil {
+		return nil, err
+	}
+	return u.sqsClient, nil
+}
+
+// SNS returns the SNS client, creating it if needed
+func (u *useSQS) SNS(ctx context.Context) (SNSClient, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if err := u.connect(ctx); err != nil {
+		return nil, err
+	}
+	if u.snsClient == nil {
+		return nil, fmt.Errorf("no SNS client configured")
+	}
+	return u.snsClient, nil
+}
+
+// connect creates the clients from the default configuration. The caller must hold the mutex.
+func (u *useSQS) connect(ctx context.Context) error {
+	if u.sqsClient != nil {
+		return nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(u.region))
+	if err != nil {
+		return fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	u.sqsClient = awssqs.NewFromConfig(cfg, func(o *awssqs.Options) {
+		if u.endpoint != "" {
+			o.BaseEndpoint = aws.String(u.endpoint)
+		}
+	})
+	u.snsClient = awssns.NewFromConfig(cfg, func(o *awssns.Options) {
+		if u.endpoint != "" {
+			o.BaseEndpoint = aws.String(u.endpoint)
+		}
+	})
+	return nil
+}
+
+// QueueURL resolves a queue name to its URL, caching the result
+func (u *useSQS) QueueURL(ctx context.Context, queue string) (string, error) {
+	if strings.HasPrefix(queue, "https://") || strings.HasPrefix(queue, "http://") {
+		return queue, nil
+	}
+
+	client, err := u.SQS(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u.mutex.Lock()
+	url, ok := u.queueURLs[queue]
+	u.mutex.Unlock()
+	if ok {
+		return url, nil
+	}
+
+	output, err := client.GetQueueUrl(ctx, &awssqs.GetQueueUrlInput{QueueName: aws.String(queue)})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve queue %s: %w", queue, err)
+	}
+	url = aws.ToString(output.QueueUrl)
+
+	u.mutex.Lock()
+	u.queueURLs[queue] = url
+	u.mutex.Unlock()
+
+	return url, nil
+}
+
+// RecordReceivedMessage remembers the message as the last one received
+func (u *useSQS) RecordReceivedMessage(message types.Message) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.lastReceived = &message
+}
+
+// LastReceivedMessage returns the last message received
+func (u *useSQS) LastReceivedMessage() *types.Message {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.lastReceived
+}
+
+// Inspect describes the endpoint and the last received message
+func (u *useSQS) Inspect() string {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	state := "provided clients"
+	if u.region != "" || u.endpoint != "" {
+		state = "region " + u.region
+		if u.endpoint != "" {
+			state += " at " + u.endpoint
+		}
+	}
+
+	if u.lastReceived != nil {
+		state += fmt.Sprintf(", last received message %s", aws.ToString(u.lastReceived.MessageId))
+	}
+	return state
+}
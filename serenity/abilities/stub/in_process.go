@@ -0,0 +1,102 @@
+package stub
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// InProcessStubs is a ManageStubs ability backed by a stub server running inside the test process.
+// The server is closed when the test shuts down.
+type InProcessStubs struct {
+	server   *httptest.Server
+	mutex    sync.RWMutex
+	stubs    []*Stub
+	received []ReceivedRequest
+}
+
+// InProcess starts an in-process stub server. Point the system under test at URL().
+// Requests that match no stub are answered with 404 Not Found.
+func InProcess() *InProcessStubs {
+	stubs := &InProcessStubs{}
+	stubs.server = httptest.NewServer(http.HandlerFunc(stubs.serve))
+	return stubs
+}
+
+// URL returns the base URL of the stub server
+func (s *InProcessStubs) URL() string {
+	return s.server.URL
+}
+
+// Register adds a stub; stubs registered later take precedence
+func (s *InProcessStubs) Register(ctx context.Context, stub *Stub) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stubs = append(s.stubs, stub)
+	return nil
+}
+
+// Reset removes all stubs and forgets the received requests
+func (s *InProcessStubs) Reset(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stubs = nil
+	s.received = nil
+	return nil
+}
+
+// RequestsMatching returns the received requests matching the pattern
+func (s *InProcessStubs) RequestsMatching(ctx context.Context, pattern RequestPattern) ([]ReceivedRequest, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	matching := make([]ReceivedRequest, 0)
+	for _, request := range s.received {
+		if pattern.Matches(request) {
+			matching = append(matching, request)
+		}
+	}
+	return matching, nil
+}
+
+// Discard stops the stub server
+func (s *InProcessStubs) Discard() error {
+	s.server.Close()
+	return nil
+}
+
+// serve records the request and answers it with the most recently registered matching stub
+func (s *InProcessStubs) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	request := ReceivedRequest{
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.RawQuery,
+		Headers: r.Header.Clone(),
+		Body:    string(body),
+	}
+
+	s.mutex.Lock()
+	s.received = append(s.received, request)
+	var matched *Stub
+	for i := len(s.stubs) - 1; i >= 0; i-- {
+		if s.stubs[i].Request.Matches(request) {
+			matched = s.stubs[i]
+			break
+		}
+	}
+	s.mutex.Unlock()
+
+	if matched == nil {
+		http.Error(w, "no stub matches "+request.Method+" "+request.Path, http.StatusNotFound)
+		return
+	}
+
+	for key, value := range matched.Response.Headers {
+		w.Header().Set(key, value)
+	}
+	w.WriteHeader(matched.Response.Status)
+	_, _ = io.WriteString(w, matched.Response.Body)
+}
@@ -0,0 +1,49 @@
+package stub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// StubFor creates an interaction that registers the stubs with the actor's stub server
+func StubFor(stubs ...*Stub) core.Activity {
+	descriptions := make([]string, 0, len(stubs))
+	for _, stub := range stubs {
+		descriptions = append(descriptions, stub.String())
+	}
+
+	return core.Do(
+		fmt.Sprintf("#actor stubs %s", strings.Join(descriptions, ", ")),
+		func(actor core.Actor, ctx context.Context) error {
+			stubServer, err := core.AbilityOf[ManageStubs](actor)
+			if err != nil {
+				return fmt.Errorf("actor does not have the ability to manage stubs: %w", err)
+			}
+
+			for _, stub := range stubs {
+				if err := stubServer.Register(ctx, stub); err != nil {
+					return fmt.Errorf("failed to register stub %s: %w", stub, err)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// ResetStubs creates an interaction that removes all stubs and forgets the received requests
+func ResetStubs() core.Activity {
+	return core.Do("#actor resets the stubs", func(actor core.Actor, ctx context.Context) error {
+		stubServer, err := core.AbilityOf[ManageStubs](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to manage stubs: %w", err)
+		}
+
+		if err := stubServer.Reset(ctx); err != nil {
+			return fmt.Errorf("failed to reset stubs: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,18 @@
+package stub
+
+import (
+	"context"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// ManageStubs enables an actor to program a stub server and inspect the requests it received
+type ManageStubs interface {
+	abilities.Ability
+	// Register adds a stub; stubs registered later take precedence
+	Register(ctx context.Context, stub *Stub) error
+	// Reset removes all stubs and forgets the received requests
+	Reset(ctx context.Context) error
+	// RequestsMatching returns the received requests matching the pattern
+	RequestsMatching(ctx context.Context, pattern RequestPattern) ([]ReceivedRequest, error)
+}
@@ -0,0 +1,61 @@
+package stub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// RequestsReceivedMatching returns the requests received by the stub server that match a pattern
+type RequestsReceivedMatching struct {
+	pattern RequestPattern
+}
+
+// NewRequestsReceivedMatching creates a question for the received requests matching the pattern
+func NewRequestsReceivedMatching(pattern RequestPattern) RequestsReceivedMatching {
+	return RequestsReceivedMatching{pattern: pattern}
+}
+
+// AnsweredBy returns the matching requests from the actor's stub server
+func (rr RequestsReceivedMatching) AnsweredBy(actor core.Actor, ctx context.Context) ([]ReceivedRequest, error) {
+	stubServer, err := core.AbilityOf[ManageStubs](actor)
+	if err != nil {
+		return nil, fmt.Errorf("actor does not have the ability to manage stubs: %w", err)
+	}
+
+	requests, err := stubServer.RequestsMatching(ctx, rr.pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find requests matching %s: %w", rr.pattern, err)
+	}
+	return requests, nil
+}
+
+// Description returns the question description
+func (rr RequestsReceivedMatching) Description() string {
+	return fmt.Sprintf("the requests received matching %s", rr.pattern)
+}
+
+// NumberOfRequestsMatching returns how many received requests match a pattern
+type NumberOfRequestsMatching struct {
+	pattern RequestPattern
+}
+
+// NewNumberOfRequestsMatching creates a question for the number of received requests matching the pattern
+func NewNumberOfRequestsMatching(pattern RequestPattern) NumberOfRequestsMatching {
+	return NumberOfRequestsMatching{pattern: pattern}
+}
+
+// AnsweredBy returns the number of matching requests
+func (nr NumberOfRequestsMatching) AnsweredBy(actor core.Actor, ctx context.Context) (int, error) {
+	requests, err := NewRequestsReceivedMatching(nr.pattern).AnsweredBy(actor, ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(requests), nil
+}
+
+// Description returns the question description
+func (nr NumberOfRequestsMatching) Description() string {
+	return fmt.Sprintf("the number of requests received matching %s", nr.pattern)
+}
@@ -0,0 +1,199 @@
+// Package stub provides the ManageStubs ability for programming HTTP stubs of the services
+// the system under test depends on, and for verifying the requests those services received.
+//
+// Two implementations are available: UsingWireMock talks to a running WireMock server through
+// its admin API, and InProcess starts a lightweight stub server inside the test process:
+//
+//	payments := stub.InProcess()
+//	actor := test.ActorCalled("Integrator").WhoCan(payments)
+//
+//	actor.AttemptsTo(
+//		stub.ResetStubs(),
+//		stub.StubFor(
+//			stub.Post("/payments").WillReturn(201).WithJSONBody(map[string]string{"state": "accepted"}),
+//		),
+//		// ... exercise the system under test configured with payments.URL() ...
+//		ensure.That(stub.NewNumberOfRequestsMatching(stub.PostRequestsTo("/payments")), expectations.Equals(1)),
+//	)
+package stub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RequestPattern describes which requests a stub answers or a verification counts.
+// Empty fields match any value.
+type RequestPattern struct {
+	Method       string
+	Path         string
+	Headers      map[string]string
+	BodyContains string
+}
+
+// RequestsTo matches requests with any method to the path
+func RequestsTo(path string) RequestPattern {
+	return RequestPattern{Path: path}
+}
+
+// GetRequestsTo matches GET requests to the path
+func GetRequestsTo(path string) RequestPattern {
+	return RequestPattern{Method: http.MethodGet, Path: path}
+}
+
+// PostRequestsTo matches POST requests to the path
+func PostRequestsTo(path string) RequestPattern {
+	return RequestPattern{Method: http.MethodPost, Path: path}
+}
+
+// WithHeader additionally requires a header with the exact value
+func (p RequestPattern) WithHeader(key, value string) RequestPattern {
+	headers := make(map[string]string, len(p.Headers)+1)
+	for k, v := range p.Headers {
+		headers[k] = v
+	}
+	headers[key] = value
+	p.Headers = headers
+	return p
+}
+
+// WithBodyContaining additionally requires the body to contain the text
+func (p RequestPattern) WithBodyContaining(text string) RequestPattern {
+	p.BodyContains = text
+	return p
+}
+
+// String returns a readable description of the pattern, used in reports
+func (p RequestPattern) String() string {
+	method := p.Method
+	if method == "" {
+		method = "ANY"
+	}
+	path := p.Path
+	if path == "" {
+		path = "any path"
+	}
+
+	description := fmt.Sprintf("%s %s", method, path)
+	keys := make([]string, 0, len(p.Headers))
+	for key := range p.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		description += fmt.Sprintf(" with %s: %s", key, p.Headers[key])
+	}
+	if p.BodyContains != "" {
+		description += fmt.Sprintf(" with body containing %q", p.BodyContains)
+	}
+	return description
+}
+
+// Matches reports whether a received request matches the pattern
+func (p RequestPattern) Matches(request ReceivedRequest) bool {
+	if p.Method != "" && !strings.EqualFold(p.Method, request.Method) {
+		return false
+	}
+	if p.Path != "" && p.Path != request.Path {
+		return false
+	}
+	for key, value := range p.Headers {
+		if http.Header(request.Headers).Get(key) != value {
+			return false
+		}
+	}
+	if p.BodyContains != "" && !strings.Contains(request.Body, p.BodyContains) {
+		return false
+	}
+	return true
+}
+
+// ReceivedRequest is a request received by the stub server
+type ReceivedRequest struct {
+	Method  string
+	Path    string
+	Query   string
+	Headers map[string][]string
+	Body    string
+}
+
+// Stub pairs a request pattern with the response returned for matching requests
+type Stub struct {
+	Request  RequestPattern
+	Response Response
+}
+
+// Response is the response returned by a stub
+type Response struct {
+	Status  int
+	Headers map[string]string
+	Body    string
+}
+
+// For starts a stub for requests matching the pattern; it returns 200 with an empty body
+// unless configured otherwise
+func For(pattern RequestPattern) *Stub {
+	return &Stub{
+		Request:  pattern,
+		Response: Response{Status: http.StatusOK},
+	}
+}
+
+// Get starts a stub for GET requests to the path
+func Get(path string) *Stub {
+	return For(GetRequestsTo(path))
+}
+
+// Post starts a stub for POST requests to the path
+func Post(path string) *Stub {
+	return For(PostRequestsTo(path))
+}
+
+// Put starts a stub for PUT requests to the path
+func Put(path string) *Stub {
+	return For(RequestPattern{Method: http.MethodPut, Path: path})
+}
+
+// Delete starts a stub for DELETE requests to the path
+func Delete(path string) *Stub {
+	return For(RequestPattern{Method: http.MethodDelete, Path: path})
+}
+
+// WillReturn sets the response status
+func (s *Stub) WillReturn(status int) *Stub {
+	s.Response.Status = status
+	return s
+}
+
+// WithHeader adds a response header
+func (s *Stub) WithHeader(key, value string) *Stub {
+	if s.Response.Headers == nil {
+		s.Response.Headers = make(map[string]string)
+	}
+	s.Response.Headers[key] = value
+	return s
+}
+
+// WithBody sets the response body
+func (s *Stub) WithBody(body string) *Stub {
+	s.Response.Body = body
+	return s
+}
+
+// WithJSONBody sets the response body to the JSON encoding of data and the matching content type.
+// Values that can't be encoded are formatted with their default representation.
+func (s *Stub) WithJSONBody(data interface{}) *Stub {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return s.WithBody(fmt.Sprintf("%v", data))
+	}
+	return s.WithHeader("Content-Type", "application/json").WithBody(string(encoded))
+}
+
+// String returns a readable description of the stub, used in reports
+func (s *Stub) String() string {
+	return fmt.Sprintf("%s to return %d", s.Request, s.Response.Status)
+}
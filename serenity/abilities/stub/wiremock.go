@@ -0,0 +1,164 @@
+package stub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// wireMock is a ManageStubs ability that programs a WireMock server through its admin API
+type wireMock struct {
+	baseURL string
+	client  *http.Client
+}
+
+// UsingWireMock creates a ManageStubs ability for the WireMock server at baseURL
+// (for example "http://localhost:8080"; the admin API is expected under /__admin)
+func UsingWireMock(baseURL string) ManageStubs {
+	return UsingWireMockWithClient(baseURL, http.DefaultClient)
+}
+
+// UsingWireMockWithClient is like UsingWireMock but sends admin requests with the given client
+func UsingWireMockWithClient(baseURL string, client *http.Client) ManageStubs {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &wireMock{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// wireMockPattern is the JSON representation of a WireMock request pattern
+type wireMockPattern struct {
+	Method       string                       `json:"method,omitempty"`
+	URLPath      string                       `json:"urlPath,omitempty"`
+	Headers      map[string]map[string]string `json:"headers,omitempty"`
+	BodyPatterns []map[string]string          `json:"bodyPatterns,omitempty"`
+}
+
+// wireMockResponse is the JSON representation of a WireMock response definition
+type wireMockResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// wireMockRequest is the JSON representation of a request logged by WireMock
+type wireMockRequest struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// Register creates a stub mapping
+func (w *wireMock) Register(ctx context.Context, stub *Stub) error {
+	mapping := map[string]interface{}{
+		"request": toWireMockPattern(stub.Request),
+		"response": wireMockResponse{
+			Status:  stub.Response.Status,
+			Headers: stub.Response.Headers,
+			Body:    stub.Response.Body,
+		},
+	}
+	return w.post(ctx, "/__admin/mappings", mapping, http.StatusCreated, nil)
+}
+
+// Reset removes all stub mappings and clears the request journal
+func (w *wireMock) Reset(ctx context.Context) error {
+	return w.post(ctx, "/__admin/reset", nil, http.StatusOK, nil)
+}
+
+// RequestsMatching finds the journaled requests matching the pattern
+func (w *wireMock) RequestsMatching(ctx context.Context, pattern RequestPattern) ([]ReceivedRequest, error) {
+	var found struct {
+		Requests []wireMockRequest `json:"requests"`
+	}
+	if err := w.post(ctx, "/__admin/requests/find", toWireMockPattern(pattern), http.StatusOK, &found); err != nil {
+		return nil, err
+	}
+
+	requests := make([]ReceivedRequest, 0, len(found.Requests))
+	for _, logged := range found.Requests {
+		request := ReceivedRequest{
+			Method:  logged.Method,
+			Path:    logged.URL,
+			Headers: make(map[string][]string, len(logged.Headers)),
+			Body:    logged.Body,
+		}
+		if parsed, err := url.Parse(logged.URL); err == nil {
+			request.Path = parsed.Path
+			request.Query = parsed.RawQuery
+		}
+		for key, value := range logged.Headers {
+			http.Header(request.Headers).Set(key, value)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// post sends an admin request and decodes the response into result when it is not nil
+func (w *wireMock) post(ctx context.Context, path string, payload interface{}, expectedStatus int, result interface{}) error {
+	var body io.Reader = http.NoBody
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode WireMock request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create WireMock request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("WireMock request to %s failed: %w", path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != expectedStatus {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("WireMock %s responded with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to decode WireMock response: %w", err)
+		}
+	}
+	return nil
+}
+
+// toWireMockPattern converts a request pattern into WireMock's JSON representation
+func toWireMockPattern(pattern RequestPattern) wireMockPattern {
+	converted := wireMockPattern{
+		Method:  pattern.Method,
+		URLPath: pattern.Path,
+	}
+	if converted.Method == "" {
+		converted.Method = "ANY"
+	}
+	if len(pattern.Headers) > 0 {
+		converted.Headers = make(map[string]map[string]string, len(pattern.Headers))
+		for key, value := range pattern.Headers {
+			converted.Headers[key] = map[string]string{"equalTo": value}
+		}
+	}
+	if pattern.BodyContains != "" {
+		converted.BodyPatterns = []map[string]string{{"contains": pattern.BodyContains}}
+	}
+	return converted
+}
@@ -0,0 +1,203 @@
+// Package tenancy partitions the data a test run creates in a shared environment. Every
+// name, ID and email address generated through a partition carries the run's token, so a
+// cleanup task can delete everything the run created without touching other runs' data:
+//
+//	actor := test.ActorCalled("Registrar").WhoCan(
+//		api.CallAnApiAt("https://staging.example.com"),
+//		tenancy.ForThisRun(),
+//	)
+//
+//	actor.AttemptsTo(
+//		api.SendPostRequest("/users").WithBody(answerable.Format(`{"email": "%s"}`, tenancy.AnEmail())),
+//		api.SendPostRequest("/topics").WithBody(answerable.Format(`{"name": "%s"}`, tenancy.Namespaced("orders"))),
+//	)
+//	defer actor.AttemptsTo(tenancy.CleanUp(func(token string) core.Activity {
+//		return api.SendDeleteRequest("/admin/test-data?tag=" + token)
+//	}))
+//
+// The token of the run is read from the SERENITY_RUN_TOKEN environment variable, so the
+// jobs of one CI pipeline can share it, or generated once per process.
+package tenancy
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/abilities/data"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// EnvVar is the environment variable holding the token of the run
+const EnvVar = "SERENITY_RUN_TOKEN"
+
+// Partition enables an actor to namespace the data it creates with a token
+type Partition interface {
+	abilities.Ability
+	// Token returns the token carried by the data of the partition
+	Token() string
+	// Namespace returns the name prefixed with the token, e.g. "run-k3x9q2m7-orders"
+	Namespace(name string) string
+	// Unique returns a short string that differs on every call, e.g. "x7p21", to tell the
+	// values generated in the partition apart
+	Unique() string
+}
+
+// partition implements the Partition interface
+type partition struct {
+	token string
+}
+
+// sequence numbers the unique strings of the process, so no two values generated in the
+// process collide; random letters set them apart from those of other processes
+var sequence atomic.Int64
+
+// WithToken creates a partition whose data carries the token. The token should be safe in
+// IDs, email addresses and topic names, such as lowercase letters, digits and hyphens.
+func WithToken(token string) Partition {
+	return &partition{token: token}
+}
+
+// ForThisRun creates a partition with the token of the run, shared by every actor of the
+// process
+func ForThisRun() Partition {
+	return WithToken(RunToken())
+}
+
+// Token returns the token of the partition
+func (p *partition) Token() string {
+	return p.token
+}
+
+// Namespace prefixes the name with the token
+func (p *partition) Namespace(name string) string {
+	return p.token + "-" + name
+}
+
+// Unique returns random letters followed by the next number of the process
+func (p *partition) Unique() string {
+	return randomString(4) + fmt.Sprint(sequence.Add(1))
+}
+
+// Inspect describes the partition
+func (p *partition) Inspect() string {
+	return fmt.Sprintf("partitioned by %s", p.token)
+}
+
+// runToken is generated once per process unless EnvVar is set
+var runToken = sync.OnceValue(func() string {
+	if token := os.Getenv(EnvVar); token != "" {
+		return token
+	}
+
+	return "run-" + randomString(8)
+})
+
+// randomString returns a random string of lowercase letters and digits
+func randomString(length int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	random := make([]byte, length)
+	_, _ = rand.Read(random)
+	for i, b := range random {
+		random[i] = alphabet[int(b)%len(alphabet)]
+	}
+	return string(random)
+}
+
+// RunToken returns the token of the run, read from EnvVar or generated once per process,
+// e.g. "run-k3x9q2m7"
+func RunToken() string {
+	return runToken()
+}
+
+// Of returns the actor's partition, or one with the token of the run if the actor has
+// none, so that tasks can namespace data without requiring the ability up front
+func Of(actor core.Actor) Partition {
+	if p, err := core.AbilityOf[Partition](actor); err == nil {
+		return p
+	}
+	return ForThisRun()
+}
+
+// Cleaner creates an activity deleting the data carrying the token, e.g. with a request
+// to an admin endpoint or a database statement
+type Cleaner func(token string) core.Activity
+
+// CleanUp creates a task that performs the cleaners with the token of the actor's partition.
+// Every cleaner is performed even if another fails; the task fails listing their errors.
+func CleanUp(cleaners ...Cleaner) core.Activity {
+	return core.Do("#actor deletes the test data of the partition", func(actor core.Actor, ctx context.Context) error {
+		token := Of(actor).Token()
+
+		var errs []error
+		for _, cleaner := range cleaners {
+			activity := cleaner(token)
+			if err := activity.PerformAs(actor, ctx); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", activity.Description(), err))
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// partitioned is a question answered with a value derived from the actor's partition
+type partitioned struct {
+	description string
+	answer      func(p Partition) string
+}
+
+// AnsweredBy derives the value from the actor's partition
+func (pq partitioned) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	return pq.answer(Of(actor)), nil
+}
+
+// Description returns the question description
+func (pq partitioned) Description() string {
+	return pq.description
+}
+
+// Token returns a question about the token of the actor's partition
+func Token() core.Question[string] {
+	return partitioned{
+		description: "the partition token",
+		answer:      Partition.Token,
+	}
+}
+
+// Namespaced returns a question about the name prefixed with the partition token, e.g. for
+// topics and queues
+func Namespaced(name string) core.Question[string] {
+	return partitioned{
+		description: fmt.Sprintf("the partitioned name of %s", name),
+		answer: func(p Partition) string {
+			return p.Namespace(name)
+		},
+	}
+}
+
+// AnID returns a question that generates an ID carrying the partition token, e.g.
+// "run-k3x9q2m7-x7p21"
+func AnID() core.Question[string] {
+	return partitioned{
+		description: "a partitioned ID",
+		answer: func(p Partition) string {
+			return p.Namespace(p.Unique())
+		},
+	}
+}
+
+// AnEmail returns a question that generates an email address in data.DefaultEmailDomain
+// whose local part carries the partition token, e.g. "run-k3x9q2m7.x7p21@example.test"
+func AnEmail() core.Question[string] {
+	return partitioned{
+		description: "a partitioned email address",
+		answer: func(p Partition) string {
+			return fmt.Sprintf("%s.%s@%s", p.Token(), p.Unique(), data.DefaultEmailDomain)
+		},
+	}
+}
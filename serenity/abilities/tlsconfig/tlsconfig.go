@@ -0,0 +1,84 @@
+// Package tlsconfig builds the TLS configuration of abilities calling services over TLS,
+// such as internal services requiring mutual TLS:
+//
+//	config, err := tlsconfig.New(
+//		tlsconfig.ClientCertificate("certs/client.pem", "certs/client-key.pem"),
+//		tlsconfig.RootCAs(internalCA),
+//	)
+//
+// The api and grpc abilities accept the same options directly.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// Option changes a TLS configuration
+type Option func(config *tls.Config) error
+
+// ClientCertificate presents the certificate and key, read from PEM files, to servers
+// requiring mutual TLS
+func ClientCertificate(certFile, keyFile string) Option {
+	return func(config *tls.Config) error {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate %s: %w", certFile, err)
+		}
+		config.Certificates = append(config.Certificates, certificate)
+		return nil
+	}
+}
+
+// RootCAs trusts server certificates issued by the PEM encoded certificate authorities
+// instead of those of the system
+func RootCAs(pem []byte) Option {
+	return func(config *tls.Config) error {
+		if config.RootCAs == nil {
+			config.RootCAs = x509.NewCertPool()
+		}
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return errors.New("no root CA certificates found in PEM")
+		}
+		return nil
+	}
+}
+
+// ServerName verifies the server certificate against the name instead of the host dialed
+func ServerName(name string) Option {
+	return func(config *tls.Config) error {
+		config.ServerName = name
+		return nil
+	}
+}
+
+// InsecureSkipVerify accepts any server certificate. Use it only against test
+// environments with self-signed certificates.
+func InsecureSkipVerify() Option {
+	return func(config *tls.Config) error {
+		config.InsecureSkipVerify = true // #nosec G402 -- opted into for test environments
+		return nil
+	}
+}
+
+// New creates a TLS configuration requiring TLS 1.2 or later, changed by the options
+func New(opts ...Option) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if err := Apply(config, opts...); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Apply changes the configuration with the options and returns their errors
+func Apply(config *tls.Config, opts ...Option) error {
+	var errs []error
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -58,11 +58,22 @@
 //		return db.GetUser("123")
 //	}), expectations.NotNil())
 //
+// Supplier creates a Question[T] from a function that needs neither an actor nor a context,
+// such as reading the clock, an environment variable or a random seed:
+//
+//	ensure.That(answerable.Supplier("the region", func() (string, error) {
+//		return os.Getenv("REGION"), nil
+//	}), expectations.Equals("eu-west-1"))
+//
 // The created Question[T] from ValueOf is independent of any actor context - it will always
 // return the same static value regardless of which actor asks the question.
 //
 // The created Question[T] from ResultOf executes the provided function each time it is asked,
-// allowing for dynamic behavior and actor-dependent calculations.
+// allowing for dynamic behavior and actor-dependent calculations. Supplier likewise calls its
+// function each time, so the value is computed when the question is asked, not when it is created.
+//
+// Cached wraps any question so that it is asked only once per actor; Invalidate creates an
+// interaction that forgets the remembered answer when the underlying state changes.
 package answerable
 
 import (
@@ -82,14 +93,21 @@ import (
 //   - value: The static value to be wrapped as a Question
 //
 // Returns:
-//   - core.Question[T]: A question that always returns the provided value
+//   - *ValueQuestion[T]: A question that always returns the provided value
 //
 // Example:
 //
 //	q := answerable.ValueOf(42)
 //	result, err := q.AnsweredBy(actor) // result = 42, err = nil
-func ValueOf[T any](value T) core.Question[T] {
-	return &valueQuestion[T]{value: value}
+//
+// The description shows the value, masking registered secrets and truncating values longer
+// than MaxValueDescriptionLength characters. Large or sensitive values can be described
+// otherwise:
+//
+//	answerable.ValueOf(fixtureBody).WithDescription("the fixture order")
+//	answerable.ValueOf(apiKey).Sensitive()
+func ValueOf[T any](value T) *ValueQuestion[T] {
+	return &ValueQuestion[T]{value: value}
 }
 
 // ResultOf creates a core.Question[T] from a function with a custom description.
@@ -122,3 +140,33 @@ func ResultOf[T any](description string, fn func(core.Actor, context.Context) (T
 		function:    fn,
 	}
 }
+
+// Supplier creates a core.Question[T] from a function computing a value without an actor
+// or context, such as the current time or an environment variable.
+//
+// The function is executed each time the question is answered, unlike ValueOf, which
+// captures its value when the question is created.
+//
+// Parameters:
+//   - description: Human-readable description for test reports
+//   - supply: Function that returns (value, error)
+//
+// Returns:
+//   - core.Question[T]: A question that executes the function when answered
+//
+// Example:
+//
+//	seed := answerable.Supplier("the random seed", func() (int64, error) {
+//		return strconv.ParseInt(os.Getenv("SEED"), 10, 64)
+//	})
+func Supplier[T any](description string, supply func() (T, error)) core.Question[T] {
+	if supply == nil {
+		panic("Supplier: function parameter cannot be nil")
+	}
+	return &functionQuestion[T]{
+		description: description,
+		function: func(core.Actor, context.Context) (T, error) {
+			return supply()
+		},
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/nchursin/serenity-go/serenity/abilities"
 	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/secrets"
 )
 
 // mockActor implements core.Actor for testing
@@ -33,6 +35,22 @@ func (m *mockActor) AbilityTo(ability abilities.Ability) (abilities.Ability, err
 	return nil, nil
 }
 
+func (m *mockActor) WithPacing(activitiesPerSecond float64) core.Actor {
+	return m
+}
+
+func (m *mockActor) WhoCanUseShared(abilityTypes ...abilities.Ability) core.Actor {
+	return m
+}
+
+func (m *mockActor) Is(traits ...core.Trait) core.Actor {
+	return m
+}
+
+func (m *mockActor) With(key, value string) core.Actor {
+	return m
+}
+
 func (m *mockActor) AttemptsTo(activities ...core.Activity) {
 }
 
@@ -286,3 +304,31 @@ type customError struct{}
 func (e *customError) Error() string {
 	return "custom error"
 }
+
+func TestValueOf_LargeAndSensitiveValues(t *testing.T) {
+	t.Run("truncates long values", func(t *testing.T) {
+		body := strings.Repeat("x", MaxValueDescriptionLength+50)
+
+		desc := ValueOf(body).Description()
+		require.Equal(t, strings.Repeat("x", MaxValueDescriptionLength)+fmt.Sprintf("... (%d characters) (string)", len(body)), desc)
+	})
+
+	t.Run("uses the description override", func(t *testing.T) {
+		q := ValueOf(strings.Repeat("x", 1<<20)).WithDescription("the fixture order")
+		require.Equal(t, "the fixture order", q.Description())
+	})
+
+	t.Run("masks registered secrets", func(t *testing.T) {
+		secrets.Register("s3cr3t-in-value")
+
+		desc := ValueOf("token=s3cr3t-in-value").Description()
+		require.Equal(t, "token="+secrets.Masked+" (string)", desc)
+	})
+
+	t.Run("hides sensitive values without registering them", func(t *testing.T) {
+		q := ValueOf(200).Sensitive()
+
+		require.Equal(t, secrets.Masked+" (int)", q.Description())
+		require.Equal(t, "status 200", secrets.Mask("status 200"))
+	})
+}
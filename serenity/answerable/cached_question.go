@@ -0,0 +1,117 @@
+package answerable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Invalidator is implemented by questions that cache their answers
+type Invalidator interface {
+	// Description returns the question description
+	Description() string
+	// InvalidateFor drops the cached answer of the given actor
+	InvalidateFor(actor core.Actor)
+}
+
+// CachedQuestion[T] wraps a question and memoizes its answer per actor.
+// Since actors are created per test, the cache never leaks answers between tests.
+type CachedQuestion[T any] struct {
+	question core.Question[T]
+	mutex    sync.Mutex
+	answers  map[core.Actor]*cachedAnswer[T]
+}
+
+// cachedAnswer holds a single actor's answer; its mutex ensures the question is asked only once
+// even when the actor asks concurrently
+type cachedAnswer[T any] struct {
+	mutex    sync.Mutex
+	value    T
+	answered bool
+}
+
+// Cached creates a question that asks the wrapped question once per actor and then returns the
+// remembered answer. Errors are not cached, so a failed answer is retried on the next ask.
+// Use Invalidate to drop the remembered answer after the underlying state has changed.
+//
+// Example:
+//
+//	orders := answerable.Cached(OrdersFromAllPages{})
+//
+//	actor.AttemptsTo(
+//		ensure.That(orders, expectations.ArrayLengthEquals(3)),
+//		ensure.That(orders, expectations.Satisfies("are all paid", allPaid)), // answered from cache
+//		api.SendPostRequest("/orders").WithBody(newOrder),
+//		answerable.Invalidate(orders),
+//		ensure.That(orders, expectations.ArrayLengthEquals(4)), // asked again
+//	)
+func Cached[T any](question core.Question[T]) *CachedQuestion[T] {
+	if question == nil {
+		panic("Cached: question parameter cannot be nil")
+	}
+	return &CachedQuestion[T]{
+		question: question,
+		answers:  make(map[core.Actor]*cachedAnswer[T]),
+	}
+}
+
+// AnsweredBy returns the cached answer of the actor, asking the wrapped question on the first call
+func (c *CachedQuestion[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
+	c.mutex.Lock()
+	answer, exists := c.answers[actor]
+	if !exists {
+		answer = &cachedAnswer[T]{}
+		c.answers[actor] = answer
+	}
+	c.mutex.Unlock()
+
+	answer.mutex.Lock()
+	defer answer.mutex.Unlock()
+
+	if answer.answered {
+		return answer.value, nil
+	}
+
+	value, err := c.question.AnsweredBy(actor, ctx)
+	if err != nil {
+		return value, err
+	}
+
+	answer.value = value
+	answer.answered = true
+	return value, nil
+}
+
+// Description returns the description of the wrapped question
+func (c *CachedQuestion[T]) Description() string {
+	return c.question.Description()
+}
+
+// InvalidateFor drops the cached answer of the given actor
+func (c *CachedQuestion[T]) InvalidateFor(actor core.Actor) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.answers, actor)
+}
+
+// Invalidate creates an interaction that drops the performing actor's cached answers to the
+// given questions, so they are asked again the next time they are used
+func Invalidate(questions ...Invalidator) core.Activity {
+	descriptions := make([]string, 0, len(questions))
+	for _, question := range questions {
+		descriptions = append(descriptions, question.Description())
+	}
+
+	return core.Do(
+		fmt.Sprintf("#actor forgets the answers to %s", strings.Join(descriptions, ", ")),
+		func(actor core.Actor, ctx context.Context) error {
+			for _, question := range questions {
+				question.InvalidateFor(actor)
+			}
+			return nil
+		},
+	)
+}
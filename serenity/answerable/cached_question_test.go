@@ -0,0 +1,124 @@
+package answerable
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+func countingQuestion(calls *int) core.Question[int] {
+	return ResultOf("the number of orders", func(actor core.Actor, ctx context.Context) (int, error) {
+		*calls++
+		return *calls, nil
+	})
+}
+
+func TestCached_AnswersOncePerActor(t *testing.T) {
+	calls := 0
+	question := Cached(countingQuestion(&calls))
+	alice := &mockActor{name: "Alice"}
+	bob := &mockActor{name: "Bob"}
+
+	first, err := question.AnsweredBy(alice, context.Background())
+	require.NoError(t, err)
+	second, err := question.AnsweredBy(alice, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, first)
+	require.Equal(t, 1, second)
+
+	other, err := question.AnsweredBy(bob, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, other)
+	require.Equal(t, 2, calls)
+
+	require.Equal(t, "the number of orders", question.Description())
+}
+
+func TestCached_DoesNotCacheErrors(t *testing.T) {
+	calls := 0
+	question := Cached(ResultOf("a flaky answer", func(actor core.Actor, ctx context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("temporarily unavailable")
+		}
+		return "ready", nil
+	}))
+	actor := &mockActor{name: "Alice"}
+
+	_, err := question.AnsweredBy(actor, context.Background())
+	require.EqualError(t, err, "temporarily unavailable")
+
+	answer, err := question.AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "ready", answer)
+	require.Equal(t, 2, calls)
+}
+
+func TestCached_Invalidate(t *testing.T) {
+	ordersCalls, usersCalls := 0, 0
+	orders := Cached(countingQuestion(&ordersCalls))
+	users := Cached(ResultOf("the number of users", func(actor core.Actor, ctx context.Context) (int, error) {
+		usersCalls++
+		return usersCalls, nil
+	}))
+	alice := &mockActor{name: "Alice"}
+	bob := &mockActor{name: "Bob"}
+
+	_, _ = orders.AnsweredBy(alice, context.Background())
+	_, _ = orders.AnsweredBy(bob, context.Background())
+	_, _ = users.AnsweredBy(alice, context.Background())
+
+	invalidate := Invalidate(orders, users)
+	require.Equal(t, "#actor forgets the answers to the number of orders, the number of users", invalidate.Description())
+	require.NoError(t, invalidate.PerformAs(alice, context.Background()))
+
+	answer, err := orders.AnsweredBy(alice, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, answer)
+
+	// Other actors keep their cached answers
+	answer, err = orders.AnsweredBy(bob, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, answer)
+
+	answer, err = users.AnsweredBy(alice, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, answer)
+}
+
+func TestCached_ConcurrentAsksShareOneAnswer(t *testing.T) {
+	var mutex sync.Mutex
+	calls := 0
+	question := Cached(ResultOf("an expensive scan", func(actor core.Actor, ctx context.Context) (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls++
+		return 42, nil
+	}))
+	actor := &mockActor{name: "Alice"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answer, err := question.AnsweredBy(actor, context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 42, answer)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, calls)
+}
+
+func TestCached_NilQuestionPanics(t *testing.T) {
+	require.PanicsWithValue(t, "Cached: question parameter cannot be nil", func() {
+		Cached[int](nil)
+	})
+}
@@ -0,0 +1,57 @@
+package answerable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Pair holds the answers to two questions asked together by Zip
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Combine creates a question that asks both questions and combines their answers, so that
+// an assertion can relate two facts about the system, e.g. that the API and the database
+// count the same orders:
+//
+//	ordersInSync := answerable.Combine(OrdersViaAPI(), OrdersInDatabase(), func(api, db int) (int, error) {
+//		return api - db, nil
+//	})
+//	actor.AttemptsTo(ensure.That(ordersInSync, expectations.Equals(0)))
+//
+// The question is described as "<first> and <second>".
+func Combine[A, B, C any](first core.Question[A], second core.Question[B], combine func(a A, b B) (C, error)) core.Question[C] {
+	if first == nil || second == nil {
+		panic("Combine: question parameters cannot be nil")
+	}
+	if combine == nil {
+		panic("Combine: combine parameter cannot be nil")
+	}
+
+	description := fmt.Sprintf("%s and %s", first.Description(), second.Description())
+	return ResultOf(description, func(actor core.Actor, ctx context.Context) (C, error) {
+		var combined C
+
+		a, err := first.AnsweredBy(actor, ctx)
+		if err != nil {
+			return combined, fmt.Errorf("failed to answer %s: %w", first.Description(), err)
+		}
+		b, err := second.AnsweredBy(actor, ctx)
+		if err != nil {
+			return combined, fmt.Errorf("failed to answer %s: %w", second.Description(), err)
+		}
+
+		return combine(a, b)
+	})
+}
+
+// Zip creates a question answering both questions as a Pair, for expectations such as
+// Satisfies that compare the answers themselves
+func Zip[A, B any](first core.Question[A], second core.Question[B]) core.Question[Pair[A, B]] {
+	return Combine(first, second, func(a A, b B) (Pair[A, B], error) {
+		return Pair[A, B]{First: a, Second: b}, nil
+	})
+}
@@ -0,0 +1,40 @@
+package answerable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+func TestCombine_RelatesTwoAnswers(t *testing.T) {
+	viaAPI := ResultOf("the number of orders via the API", func(actor core.Actor, ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	inDatabase := ResultOf("the number of orders in the database", func(actor core.Actor, ctx context.Context) (int, error) {
+		return 40, nil
+	})
+
+	difference := Combine(viaAPI, inDatabase, func(api, db int) (int, error) { return api - db, nil })
+	answer, err := difference.AnsweredBy(&mockActor{name: "Auditor"}, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, answer)
+	require.Equal(t, "the number of orders via the API and the number of orders in the database", difference.Description())
+
+	pair, err := Zip(viaAPI, ValueOf("orders")).AnsweredBy(&mockActor{name: "Auditor"}, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Pair[int, string]{First: 42, Second: "orders"}, pair)
+}
+
+func TestCombine_ReportsWhichQuestionFailed(t *testing.T) {
+	failing := ResultOf("the database count", func(actor core.Actor, ctx context.Context) (int, error) {
+		return 0, errors.New("connection refused")
+	})
+
+	_, err := Combine(ValueOf(1), failing, func(a, b int) (bool, error) { return a == b, nil }).
+		AnsweredBy(&mockActor{name: "Auditor"}, context.Background())
+	require.EqualError(t, err, "failed to answer the database count: connection refused")
+}
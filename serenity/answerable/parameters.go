@@ -0,0 +1,156 @@
+package answerable
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Answerable is a parameter of an activity given either as a value of type T, with Given,
+// or as a core.Question[T] answered when the activity is performed, with Asked. Activities
+// accepting Answerable parameters can be defined before the data they depend on exists:
+//
+//	func ShowOrder(id answerable.Answerable[string]) core.Activity { ... }
+//
+//	actor.AttemptsTo(
+//		api.SendPostRequest("/orders").WithBody(order),
+//		ShowOrder(answerable.Asked(api.NewJSONPath("id"))),
+//	)
+type Answerable[T any] struct {
+	value    T
+	question core.Question[T]
+}
+
+// Given creates a parameter with the value
+func Given[T any](value T) Answerable[T] {
+	return Answerable[T]{value: value}
+}
+
+// Asked creates a parameter answered by the question when the activity is performed
+func Asked[T any](question core.Question[T]) Answerable[T] {
+	return Answerable[T]{question: question}
+}
+
+// IsQuestion reports whether the parameter is answered by a question
+func (p Answerable[T]) IsQuestion() bool {
+	return p.question != nil
+}
+
+// String describes the parameter for reports: the description of its question, or its value
+func (p Answerable[T]) String() string {
+	if p.question != nil {
+		return Describe(p.question)
+	}
+	return fmt.Sprint(p.value)
+}
+
+// Resolve returns the value of the parameter, answering it if it is a question
+func Resolve[T any](actor core.Actor, ctx context.Context, parameter Answerable[T]) (T, error) {
+	if parameter.question == nil {
+		return parameter.value, nil
+	}
+	answer, err := parameter.question.AnsweredBy(actor, ctx)
+	if err != nil {
+		return answer, fmt.Errorf("failed to answer %s: %w", Describe(parameter.question), err)
+	}
+	return answer, nil
+}
+
+// IsQuestion reports whether the value is a question, about an answer of any type
+func IsQuestion(value any) bool {
+	_, ok := questionMethods(value)
+	return ok
+}
+
+// Answer answers the value if it is a question about an answer of any type, and returns
+// the value unchanged otherwise
+func Answer(actor core.Actor, ctx context.Context, value any) (any, error) {
+	answeredBy, ok := questionMethods(value)
+	if !ok {
+		return value, nil
+	}
+
+	results := answeredBy.Call([]reflect.Value{reflect.ValueOf(&actor).Elem(), reflect.ValueOf(&ctx).Elem()})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return nil, fmt.Errorf("failed to answer %s: %w", Describe(value), err)
+	}
+	return results[0].Interface(), nil
+}
+
+// Describe describes a parameter for reports: the description of a question, without
+// the "asks " prefix of core.NewQuestion, or the formatted value
+func Describe(parameter any) string {
+	if question, ok := parameter.(interface{ Description() string }); ok && IsQuestion(parameter) {
+		return strings.TrimPrefix(question.Description(), "asks ")
+	}
+	return fmt.Sprint(parameter)
+}
+
+// Format creates a question for the text formatted as by fmt.Sprintf, with every
+// argument that is a question replaced by its answer
+func Format(format string, args ...any) core.Question[string] {
+	descriptions := make([]any, len(args))
+	for i, arg := range args {
+		if IsQuestion(arg) {
+			descriptions[i] = described(Describe(arg))
+		} else {
+			descriptions[i] = arg
+		}
+	}
+
+	return &functionQuestion[string]{
+		description: fmt.Sprintf(format, descriptions...),
+		function: func(actor core.Actor, ctx context.Context) (string, error) {
+			answers := make([]any, len(args))
+			for i, arg := range args {
+				answer, err := Answer(actor, ctx, arg)
+				if err != nil {
+					return "", err
+				}
+				answers[i] = answer
+			}
+			return fmt.Sprintf(format, answers...), nil
+		},
+	}
+}
+
+// described stands in for a question argument in the description of Format, printing
+// the question's description whatever the verb
+type described string
+
+// Format prints the description in angle brackets
+func (d described) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(f, "<%s>", string(d))
+}
+
+// Types in the signature of core.Question's AnsweredBy method
+var (
+	actorType   = reflect.TypeFor[core.Actor]()
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// questionMethods returns the AnsweredBy method of the value if the value is a
+// core.Question of some type
+func questionMethods(value any) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	if _, ok := value.(interface{ Description() string }); !ok {
+		return reflect.Value{}, false
+	}
+
+	method := reflect.ValueOf(value).MethodByName("AnsweredBy")
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+	signature := method.Type()
+	if signature.NumIn() != 2 || signature.In(0) != actorType || signature.In(1) != contextType ||
+		signature.NumOut() != 2 || signature.Out(1) != errorType {
+		return reflect.Value{}, false
+	}
+	return method, true
+}
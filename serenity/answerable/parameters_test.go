@@ -0,0 +1,61 @@
+package answerable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+func TestResolve_ValuesAndQuestions(t *testing.T) {
+	actor := &mockActor{name: "TestActor"}
+	ctx := context.Background()
+
+	value, err := Resolve(actor, ctx, Given("/orders"))
+	require.NoError(t, err)
+	require.Equal(t, "/orders", value)
+
+	value, err = Resolve(actor, ctx, Asked[string](ValueOf("/orders/7")))
+	require.NoError(t, err)
+	require.Equal(t, "/orders/7", value)
+
+	failing := ResultOf("the order ID", func(core.Actor, context.Context) (string, error) {
+		return "", errors.New("no order")
+	})
+	_, err = Resolve(actor, ctx, Asked(failing))
+	require.EqualError(t, err, "failed to answer the order ID: no order")
+}
+
+func TestAnswerable_Describes(t *testing.T) {
+	require.Equal(t, "/orders", Given("/orders").String())
+	require.False(t, Given("/orders").IsQuestion())
+
+	orderID := ResultOf("the order ID", func(core.Actor, context.Context) (int, error) { return 7, nil })
+	require.Equal(t, "the order ID", Asked(orderID).String())
+	require.True(t, Asked(orderID).IsQuestion())
+}
+
+func TestFormat_AnswersQuestionArguments(t *testing.T) {
+	actor := &mockActor{name: "TestActor"}
+	orderID := core.NewQuestion("the order ID", func(core.Actor, context.Context) (int, error) {
+		return 7, nil
+	})
+
+	path := Format("/customers/%s/orders/%d", "ada", orderID)
+
+	require.Equal(t, "/customers/ada/orders/<the order ID>", path.Description())
+	answer, err := path.AnsweredBy(actor, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "/customers/ada/orders/7", answer)
+}
+
+func TestIsQuestion(t *testing.T) {
+	require.True(t, IsQuestion(ValueOf(1)))
+	require.True(t, IsQuestion(core.NewQuestion("a list", func(core.Actor, context.Context) ([]string, error) { return nil, nil })))
+	require.False(t, IsQuestion("text"))
+	require.False(t, IsQuestion(nil))
+	require.False(t, IsQuestion(errors.New("a value with a description-like method")))
+}
@@ -0,0 +1,62 @@
+package answerable
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/clock"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Polling creates a question that asks the question every interval until the answer
+// satisfies until, and answers with that value, so a scenario can wait for a state and
+// then continue with it:
+//
+//	shipped := answerable.Polling(OrderFromAPI(42), func(o Order) bool {
+//		return o.Status == "SHIPPED"
+//	}, time.Second, time.Minute)
+//
+//	order := serenity.MustAnswer(actor, shipped)
+//
+// Errors of the question are retried as well, e.g. while the resource does not exist yet.
+// If no answer satisfies until within the timeout, the error matches ErrQuestionTimedOut
+// and reports the last answer or error. Waiting uses the actor's clock, so virtual time
+// from clock.UseVirtualTime applies.
+func Polling[T any](question core.Question[T], until func(answer T) bool, interval, timeout time.Duration) core.Question[T] {
+	if question == nil {
+		panic("Polling: question parameter cannot be nil")
+	}
+	if until == nil {
+		panic("Polling: until parameter cannot be nil")
+	}
+
+	description := fmt.Sprintf("%s, polled until it meets the condition", question.Description())
+	return ResultOf(description, func(actor core.Actor, ctx context.Context) (T, error) {
+		c := clock.Of(actor)
+		deadline := c.Now().Add(timeout)
+
+		for attempts := 1; ; attempts++ {
+			answer, err := question.AnsweredBy(actor, ctx)
+			if err == nil && until(answer) {
+				return answer, nil
+			}
+			if ctx.Err() != nil {
+				return answer, ctx.Err()
+			}
+
+			remaining := deadline.Sub(c.Now())
+			if remaining <= 0 {
+				if err != nil {
+					return answer, fmt.Errorf("%w: %s did not meet the condition within %s and %d attempts, the last attempt failed: %w",
+						ErrQuestionTimedOut, question.Description(), timeout, attempts, err)
+				}
+				return answer, fmt.Errorf("%w: %s did not meet the condition within %s and %d attempts, the last answer was %v",
+					ErrQuestionTimedOut, question.Description(), timeout, attempts, answer)
+			}
+			if err := c.Sleep(ctx, min(interval, remaining)); err != nil {
+				return answer, err
+			}
+		}
+	})
+}
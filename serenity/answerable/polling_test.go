@@ -0,0 +1,76 @@
+package answerable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+func TestPolling(t *testing.T) {
+	actor := &mockActor{name: "TestActor"}
+
+	t.Run("answers the first value meeting the condition", func(t *testing.T) {
+		statuses := []string{"PENDING", "PACKED", "SHIPPED", "DELIVERED"}
+		asked := 0
+		status := ResultOf("the order status", func(actor core.Actor, ctx context.Context) (string, error) {
+			asked++
+			return statuses[asked-1], nil
+		})
+
+		shipped := Polling(status, func(s string) bool { return s == "SHIPPED" }, time.Millisecond, time.Second)
+
+		result, err := shipped.AnsweredBy(actor, context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "SHIPPED", result)
+		require.Equal(t, 3, asked)
+		require.Equal(t, "the order status, polled until it meets the condition", shipped.Description())
+	})
+
+	t.Run("retries errors", func(t *testing.T) {
+		asked := 0
+		order := ResultOf("the order", func(actor core.Actor, ctx context.Context) (int, error) {
+			asked++
+			if asked < 3 {
+				return 0, errors.New("not found")
+			}
+			return 42, nil
+		})
+
+		result, err := Polling(order, func(int) bool { return true }, time.Millisecond, time.Second).AnsweredBy(actor, context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 42, result)
+	})
+
+	t.Run("times out with the last answer", func(t *testing.T) {
+		pending := Polling(ValueOf("PENDING"), func(s string) bool { return s == "SHIPPED" }, time.Millisecond, 5*time.Millisecond)
+
+		_, err := pending.AnsweredBy(actor, context.Background())
+		require.ErrorIs(t, err, ErrQuestionTimedOut)
+		require.ErrorContains(t, err, "PENDING (string) did not meet the condition within 5ms")
+		require.ErrorContains(t, err, "the last answer was PENDING")
+	})
+
+	t.Run("times out with the last error", func(t *testing.T) {
+		failure := errors.New("not found")
+		missing := ResultOf("the order", func(actor core.Actor, ctx context.Context) (int, error) {
+			return 0, failure
+		})
+
+		_, err := Polling(missing, func(int) bool { return true }, time.Millisecond, 5*time.Millisecond).AnsweredBy(actor, context.Background())
+		require.ErrorIs(t, err, ErrQuestionTimedOut)
+		require.ErrorIs(t, err, failure)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := Polling(ValueOf(1), func(int) bool { return false }, time.Millisecond, time.Second).AnsweredBy(actor, ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
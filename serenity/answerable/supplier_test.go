@@ -0,0 +1,47 @@
+package answerable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupplier(t *testing.T) {
+	actor := &mockActor{name: "TestActor"}
+
+	t.Run("computes the value each time it is asked", func(t *testing.T) {
+		calls := 0
+		q := Supplier("the call count", func() (int, error) {
+			calls++
+			return calls, nil
+		})
+		require.Equal(t, 0, calls, "the value should not be computed on creation")
+
+		first, err := q.AnsweredBy(actor, context.Background())
+		require.NoError(t, err)
+		second, err := q.AnsweredBy(actor, context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, 1, first)
+		require.Equal(t, 2, second)
+		require.Equal(t, "the call count", q.Description())
+	})
+
+	t.Run("returns the error of the function", func(t *testing.T) {
+		supplyErr := errors.New("SEED is not set")
+		q := Supplier("the random seed", func() (int64, error) {
+			return 0, supplyErr
+		})
+
+		_, err := q.AnsweredBy(actor, context.Background())
+		require.ErrorIs(t, err, supplyErr)
+	})
+
+	t.Run("panics on a nil function", func(t *testing.T) {
+		require.PanicsWithValue(t, "Supplier: function parameter cannot be nil", func() {
+			Supplier[int]("nothing", nil)
+		})
+	})
+}
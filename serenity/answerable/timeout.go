@@ -0,0 +1,58 @@
+package answerable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// ErrQuestionTimedOut is matched by the errors of questions that did not answer in time
+var ErrQuestionTimedOut = errors.New("question timed out")
+
+// WithTimeout creates a question that gives the question at most the timeout to answer.
+// The question is asked with a context that is cancelled at the deadline; if it has not
+// answered by then, e.g. because it ignores its context, it is abandoned and the error
+// matches ErrQuestionTimedOut and context.DeadlineExceeded:
+//
+//	actor.AttemptsTo(
+//		ensure.That(answerable.WithTimeout(SearchIndexSize{}, 5*time.Second), expectations.Equals(3)),
+//	)
+//
+// The question keeps the description of the wrapped question.
+func WithTimeout[T any](question core.Question[T], timeout time.Duration) core.Question[T] {
+	if question == nil {
+		panic("WithTimeout: question parameter cannot be nil")
+	}
+
+	return ResultOf(question.Description(), func(actor core.Actor, parent context.Context) (T, error) {
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+
+		type result struct {
+			value T
+			err   error
+		}
+		// Buffered, so an abandoned question does not block forever when it answers
+		answered := make(chan result, 1)
+		go func() {
+			value, err := question.AnsweredBy(actor, ctx)
+			answered <- result{value: value, err: err}
+		}()
+
+		var r result
+		select {
+		case r = <-answered:
+		case <-ctx.Done():
+			r.err = ctx.Err()
+		}
+
+		// Overruns of the deadline, rather than cancellations of the scenario, are timeouts
+		if r.err != nil && parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return r.value, fmt.Errorf("%w: %s did not answer within %s: %w", ErrQuestionTimedOut, question.Description(), timeout, r.err)
+		}
+		return r.value, r.err
+	})
+}
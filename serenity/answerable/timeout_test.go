@@ -0,0 +1,70 @@
+package answerable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+func TestWithTimeout(t *testing.T) {
+	actor := &mockActor{name: "TestActor"}
+
+	t.Run("answers within the timeout", func(t *testing.T) {
+		q := WithTimeout(ValueOf(42), time.Second)
+
+		result, err := q.AnsweredBy(actor, context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 42, result)
+		require.Equal(t, "42 (int)", q.Description())
+	})
+
+	t.Run("abandons a question ignoring its context", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		hanging := ResultOf("the hanging count", func(actor core.Actor, ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		})
+
+		_, err := WithTimeout(hanging, 10*time.Millisecond).AnsweredBy(actor, context.Background())
+		require.ErrorIs(t, err, ErrQuestionTimedOut)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.EqualError(t, err, "question timed out: the hanging count did not answer within 10ms: context deadline exceeded")
+	})
+
+	t.Run("reports a question giving up at the deadline as timed out", func(t *testing.T) {
+		waiting := ResultOf("the waiting count", func(actor core.Actor, ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+
+		_, err := WithTimeout(waiting, 10*time.Millisecond).AnsweredBy(actor, context.Background())
+		require.ErrorIs(t, err, ErrQuestionTimedOut)
+	})
+
+	t.Run("passes on other errors and cancellations", func(t *testing.T) {
+		failure := errors.New("index unavailable")
+		failing := ResultOf("the failing count", func(actor core.Actor, ctx context.Context) (int, error) {
+			return 0, failure
+		})
+
+		_, err := WithTimeout(failing, time.Second).AnsweredBy(actor, context.Background())
+		require.Equal(t, failure, err)
+
+		waiting := ResultOf("the waiting count", func(actor core.Actor, ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = WithTimeout(waiting, time.Second).AnsweredBy(actor, ctx)
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrQuestionTimedOut)
+	})
+}
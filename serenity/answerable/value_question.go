@@ -4,34 +4,75 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"unicode/utf8"
 
 	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/secrets"
 )
 
-// valueQuestion[T] implements core.Question[T] for static values.
+// MaxValueDescriptionLength is the number of characters of a value shown in the description
+// of a ValueOf question; longer values are truncated with a note of their full length
+const MaxValueDescriptionLength = 200
+
+// ValueQuestion[T] implements core.Question[T] for static values.
 // It always returns the same value regardless of which actor asks the question.
-type valueQuestion[T any] struct {
-	value T
+type ValueQuestion[T any] struct {
+	value       T
+	description string
+	sensitive   bool
 }
 
 // AnsweredBy returns the static value when asked by any actor.
 // For error types, the error is returned as the value rather than as a failure condition.
-func (v *valueQuestion[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
+func (v *ValueQuestion[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
 	return v.value, nil
 }
 
+// WithDescription describes the value in reports with the description instead of the value
+// itself, e.g. "the 2 MB fixture order"
+func (v *ValueQuestion[T]) WithDescription(description string) *ValueQuestion[T] {
+	v.description = description
+	return v
+}
+
+// Sensitive leaves the value out of the description. The value is not registered as a
+// secret, so messages that print it, such as those of failed assertions, are masked only
+// if it is registered with secrets.Register.
+func (v *ValueQuestion[T]) Sensitive() *ValueQuestion[T] {
+	v.sensitive = true
+	return v
+}
+
 // Description returns a human-readable description of the value.
 // Format: "value (type)" for normal values, "error message (error)" for error types.
-func (v *valueQuestion[T]) Description() string {
+// Registered secrets are masked, and values longer than MaxValueDescriptionLength
+// characters are truncated, e.g. "{"items":[...... (1048576 characters) (string)".
+func (v *ValueQuestion[T]) Description() string {
+	if v.description != "" {
+		return v.description
+	}
+	if v.sensitive {
+		return fmt.Sprintf("%s (%T)", secrets.Masked, v.value)
+	}
+
 	// Special handling for error types using reflection
 	if isError(v.value) {
 		// Convert to any for type assertion on generic types
 		if err, ok := any(v.value).(error); ok {
-			return fmt.Sprintf("error %v (error)", err)
+			return fmt.Sprintf("error %s (error)", truncate(secrets.Mask(err.Error())))
 		}
 	}
 
-	return fmt.Sprintf("%v (%T)", v.value, v.value)
+	return fmt.Sprintf("%s (%T)", truncate(secrets.Mask(fmt.Sprintf("%v", v.value))), v.value)
+}
+
+// truncate shortens text longer than MaxValueDescriptionLength characters, noting its length
+func truncate(text string) string {
+	length := utf8.RuneCountInString(text)
+	if length <= MaxValueDescriptionLength {
+		return text
+	}
+	return fmt.Sprintf("%s... (%d characters)", string([]rune(text)[:MaxValueDescriptionLength]), length)
 }
 
 // isError checks if the provided value is of error type using reflection.
@@ -0,0 +1,130 @@
+// Package artifacts provides questions about artifacts produced by the system under
+// test, such as generated PDFs, zip archives, images, spreadsheets and HTML or XML
+// documents, so document-generation features can be verified inside scenarios instead
+// of by shelling out to other tools.
+//
+// Every question reads its artifact from a Source, such as the body of the last API
+// response or a downloaded file:
+//
+//	actor.AttemptsTo(
+//		api.SendGetRequest("/invoices/42.pdf"),
+//		ensure.That(artifacts.PDFPageCount(artifacts.LastResponseBody()), expectations.Equals(2)),
+//		ensure.That(artifacts.PDFTextContent(artifacts.LastResponseBody()), expectations.Contains("Total: 42.00")),
+//		ensure.That(artifacts.ZipEntries(artifacts.File("out/export.zip")), expectations.Equals([]string{"orders.csv"})),
+//		ensure.That(artifacts.HTMLSelect("ul.orders > li"), expectations.Equals([]string{"Order 1", "Order 2"})),
+//	)
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Source provides the content of an artifact
+type Source interface {
+	// Content returns the content of the artifact
+	Content(actor core.Actor, ctx context.Context) ([]byte, error)
+	// Description describes the artifact
+	Description() string
+}
+
+// source implements Source with a function
+type source struct {
+	description string
+	content     func(actor core.Actor, ctx context.Context) ([]byte, error)
+}
+
+// Content returns the content of the artifact
+func (s source) Content(actor core.Actor, ctx context.Context) ([]byte, error) {
+	return s.content(actor, ctx)
+}
+
+// Description describes the artifact
+func (s source) Description() string {
+	return s.description
+}
+
+// File reads the artifact from the file at path
+func File(path string) Source {
+	return source{
+		description: path,
+		content: func(actor core.Actor, ctx context.Context) ([]byte, error) {
+			content, err := os.ReadFile(path) // #nosec G304 -- the path is chosen by the test author
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			return content, nil
+		},
+	}
+}
+
+// Bytes uses the content as the artifact, described by name
+func Bytes(name string, content []byte) Source {
+	return source{
+		description: name,
+		content: func(actor core.Actor, ctx context.Context) ([]byte, error) {
+			return content, nil
+		},
+	}
+}
+
+// LastResponseBody reads the artifact from the body of the last response received by
+// the actor's API ability. The body can still be read by other questions afterwards.
+func LastResponseBody() Source {
+	return source{
+		description: "the last response body",
+		content: func(actor core.Actor, ctx context.Context) ([]byte, error) {
+			callAbility, err := core.AbilityOf[api.CallAnAPI](actor)
+			if err != nil {
+				return nil, fmt.Errorf("actor does not have the ability to call an API: %w", err)
+			}
+
+			resp := callAbility.LastResponse()
+			if resp == nil {
+				return nil, fmt.Errorf("no response available")
+			}
+
+			defer func() {
+				_ = resp.Body.Close() // Ignore cleanup error
+			}()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+
+			// Restore body for potential re-reading
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			return body, nil
+		},
+	}
+}
+
+// question answers a question about the content of an artifact
+type question[T any] struct {
+	description string
+	source      Source
+	answer      func(content []byte) (T, error)
+}
+
+// AnsweredBy reads the artifact and answers the question about it
+func (q question[T]) AnsweredBy(actor core.Actor, ctx context.Context) (T, error) {
+	var result T
+
+	content, err := q.source.Content(actor, ctx)
+	if err != nil {
+		return result, err
+	}
+	return q.answer(content)
+}
+
+// Description returns the question description
+func (q question[T]) Description() string {
+	return fmt.Sprintf("%s of %s", q.description, q.source.Description())
+}
@@ -0,0 +1,39 @@
+package artifacts
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register the GIF decoder
+	_ "image/jpeg" // Register the JPEG decoder
+	_ "image/png"  // Register the PNG decoder
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// Dimensions is the size of an image in pixels
+type Dimensions struct {
+	Width  int
+	Height int
+}
+
+// String formats the dimensions as WIDTHxHEIGHT
+func (d Dimensions) String() string {
+	return fmt.Sprintf("%dx%d", d.Width, d.Height)
+}
+
+// ImageDimensions creates a question for the dimensions of the PNG, JPEG or GIF image.
+// Other formats are supported once their decoder is registered with the image package.
+func ImageDimensions(source Source) core.Question[Dimensions] {
+	return question[Dimensions]{
+		description: "the image dimensions",
+		source:      source,
+		answer: func(content []byte) (Dimensions, error) {
+			config, _, err := image.DecodeConfig(bytes.NewReader(content))
+			if err != nil {
+				return Dimensions{}, fmt.Errorf("failed to decode image: %w", err)
+			}
+			return Dimensions{Width: config.Width, Height: config.Height}, nil
+		},
+	}
+}
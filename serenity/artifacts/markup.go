@@ -0,0 +1,152 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/xmlquery"
+	"golang.org/x/net/html"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// HTMLSelection is a question for the text or attribute values of the elements of an
+// HTML document matching a CSS selector
+type HTMLSelection struct {
+	selector  string
+	source    Source
+	attribute string
+}
+
+// HTMLSelect creates a question for the text of the elements of the last response body
+// matching the CSS selector, e.g. "ul.orders > li". The text of each element has its
+// whitespace collapsed.
+func HTMLSelect(selector string) HTMLSelection {
+	return HTMLSelection{selector: selector, source: LastResponseBody()}
+}
+
+// In selects the elements in the document provided by the source instead
+func (s HTMLSelection) In(source Source) HTMLSelection {
+	s.source = source
+	return s
+}
+
+// Attribute answers the values of the attribute instead of the text, skipping elements
+// without it
+func (s HTMLSelection) Attribute(name string) HTMLSelection {
+	s.attribute = name
+	return s
+}
+
+// AnsweredBy returns the text or attribute values of the matching elements in document order
+func (s HTMLSelection) AnsweredBy(actor core.Actor, ctx context.Context) ([]string, error) {
+	selector, err := cascadia.ParseGroup(s.selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSS selector %q: %w", s.selector, err)
+	}
+
+	content, err := s.source.Content(actor, ctx)
+	if err != nil {
+		return nil, err
+	}
+	document, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	values := []string{}
+	for _, element := range cascadia.QueryAll(document, selector) {
+		if s.attribute == "" {
+			values = append(values, textOf(element))
+			continue
+		}
+		for _, attr := range element.Attr {
+			if attr.Key == s.attribute {
+				values = append(values, attr.Val)
+				break
+			}
+		}
+	}
+	return values, nil
+}
+
+// Description returns the question description
+func (s HTMLSelection) Description() string {
+	return fmt.Sprintf("%s of the elements matching %q in %s", s.answered(), s.selector, s.source.Description())
+}
+
+// answered describes what is answered about the elements
+func (s HTMLSelection) answered() string {
+	if s.attribute == "" {
+		return "the text"
+	}
+	return fmt.Sprintf("the %s attributes", s.attribute)
+}
+
+// textOf returns the text of the node and its descendants with whitespace collapsed
+func textOf(node *html.Node) string {
+	var text strings.Builder
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+			text.WriteString(" ")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(node)
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// XMLSelection is a question for the text of the nodes of an XML document matching an
+// XPath expression
+type XMLSelection struct {
+	expression string
+	source     Source
+}
+
+// XMLXPath creates a question for the text of the nodes of the last response body
+// matching the XPath expression. Attributes are selected with expressions such as
+// "//link/@href"; elements answer their inner text.
+func XMLXPath(expression string) XMLSelection {
+	return XMLSelection{expression: expression, source: LastResponseBody()}
+}
+
+// In selects the nodes in the document provided by the source instead
+func (s XMLSelection) In(source Source) XMLSelection {
+	s.source = source
+	return s
+}
+
+// AnsweredBy returns the text of the matching nodes in document order
+func (s XMLSelection) AnsweredBy(actor core.Actor, ctx context.Context) ([]string, error) {
+	content, err := s.source.Content(actor, ctx)
+	if err != nil {
+		return nil, err
+	}
+	document, err := xmlquery.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse XML: %w", err)
+	}
+
+	nodes, err := xmlquery.QueryAll(document, s.expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath expression %q: %w", s.expression, err)
+	}
+
+	values := make([]string, len(nodes))
+	for i, node := range nodes {
+		values[i] = node.InnerText()
+	}
+	return values, nil
+}
+
+// Description returns the question description
+func (s XMLSelection) Description() string {
+	return fmt.Sprintf("the text of the nodes matching %q in %s", s.expression, s.source.Description())
+}
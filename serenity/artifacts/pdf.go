@@ -0,0 +1,71 @@
+package artifacts
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// PDFTextContent creates a question for the plain text of the PDF document, with the
+// text of each page on its own line
+func PDFTextContent(source Source) core.Question[string] {
+	return question[string]{
+		description: "the text content",
+		source:      source,
+		answer: func(content []byte) (string, error) {
+			return withPDF(content, func(reader *pdf.Reader) (string, error) {
+				pages := make([]string, reader.NumPage())
+				fonts := make(map[string]*pdf.Font)
+				for i := range pages {
+					page := reader.Page(i + 1)
+					for _, name := range page.Fonts() {
+						if _, ok := fonts[name]; !ok {
+							font := page.Font(name)
+							fonts[name] = &font
+						}
+					}
+
+					text, err := page.GetPlainText(fonts)
+					if err != nil {
+						return "", fmt.Errorf("failed to extract the text of page %d: %w", i+1, err)
+					}
+					pages[i] = text
+				}
+				return strings.Join(pages, "\n"), nil
+			})
+		},
+	}
+}
+
+// PDFPageCount creates a question for the number of pages of the PDF document
+func PDFPageCount(source Source) core.Question[int] {
+	return question[int]{
+		description: "the page count",
+		source:      source,
+		answer: func(content []byte) (int, error) {
+			return withPDF(content, func(reader *pdf.Reader) (int, error) {
+				return reader.NumPage(), nil
+			})
+		},
+	}
+}
+
+// withPDF parses the document and answers with it. The parser panics on some malformed
+// documents, which is reported as an error.
+func withPDF[T any](content []byte, answer func(reader *pdf.Reader) (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed PDF: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return result, fmt.Errorf("failed to parse PDF: %w", err)
+	}
+	return answer(reader)
+}
@@ -0,0 +1,217 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// CSVRecords creates a question for the records of the CSV document, including the
+// header. Records may have different numbers of fields.
+func CSVRecords(source Source) core.Question[[][]string] {
+	return question[[][]string]{
+		description: "the CSV records",
+		source:      source,
+		answer:      parseCSV,
+	}
+}
+
+// CSVRecordsAs creates a question for the records of the CSV document decoded into
+// values of type T. The header names the columns; see ExcelSheetAs for the mapping.
+func CSVRecordsAs[T any](source Source) core.Question[[]T] {
+	return question[[]T]{
+		description: fmt.Sprintf("the CSV records as %s", reflect.TypeFor[T]()),
+		source:      source,
+		answer: func(content []byte) ([]T, error) {
+			records, err := parseCSV(content)
+			if err != nil {
+				return nil, err
+			}
+			return decodeRows[T](records)
+		},
+	}
+}
+
+// ExcelRows creates a question for the rows of the sheet of the Excel workbook, or of
+// its first sheet if sheet is empty. Trailing empty cells of each row are omitted.
+func ExcelRows(source Source, sheet string) core.Question[[][]string] {
+	return question[[][]string]{
+		description: describeSheet("the rows", sheet),
+		source:      source,
+		answer: func(content []byte) ([][]string, error) {
+			return readSheet(content, sheet)
+		},
+	}
+}
+
+// ExcelSheetAs creates a question for the rows of the sheet of the Excel workbook, or
+// of its first sheet if sheet is empty, decoded into values of type T.
+//
+// The first row is the header. Each column is decoded into the field tagged with its
+// header, e.g. `table:"Order ID"`, or else into the field whose name matches the header
+// ignoring case, spaces and underscores. Fields tagged `table:"-"` are skipped, and
+// columns without a field are ignored. Fields may be strings, numbers, booleans or
+// implement encoding.TextUnmarshaler; empty cells leave the field at its zero value.
+func ExcelSheetAs[T any](source Source, sheet string) core.Question[[]T] {
+	return question[[]T]{
+		description: describeSheet(fmt.Sprintf("the rows as %s", reflect.TypeFor[T]()), sheet),
+		source:      source,
+		answer: func(content []byte) ([]T, error) {
+			rows, err := readSheet(content, sheet)
+			if err != nil {
+				return nil, err
+			}
+			return decodeRows[T](rows)
+		},
+	}
+}
+
+// describeSheet describes a question about the sheet
+func describeSheet(description, sheet string) string {
+	if sheet == "" {
+		return description + " of the first sheet"
+	}
+	return fmt.Sprintf("%s of sheet %s", description, sheet)
+}
+
+// parseCSV reads all records of the CSV document
+func parseCSV(content []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	return records, nil
+}
+
+// readSheet reads all rows of the sheet of the workbook
+func readSheet(content []byte, sheet string) ([][]string, error) {
+	workbook, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel workbook: %w", err)
+	}
+	defer func() {
+		_ = workbook.Close() // Ignore cleanup error
+	}()
+
+	if sheet == "" {
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("workbook has no sheets")
+		}
+		sheet = sheets[0]
+	}
+
+	rows, err := workbook.GetRows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sheet %s: %w", sheet, err)
+	}
+	return rows, nil
+}
+
+// decodeRows decodes the rows after the header into values of type T
+func decodeRows[T any](rows [][]string) ([]T, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no header row")
+	}
+
+	target := reflect.TypeFor[T]()
+	if target.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot decode rows into %s: not a struct", target)
+	}
+
+	fields := make([][]int, len(rows[0]))
+	for column, header := range rows[0] {
+		fields[column] = fieldFor(target, header)
+	}
+
+	result := make([]T, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		var value T
+		v := reflect.ValueOf(&value).Elem()
+		for column, cell := range row {
+			if column >= len(fields) || fields[column] == nil || cell == "" {
+				continue
+			}
+			if err := setField(v.FieldByIndex(fields[column]), cell); err != nil {
+				return nil, fmt.Errorf("row %d, column %s: %w", i+2, rows[0][column], err)
+			}
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// fieldFor returns the index of the field of the struct type decoding the column, or nil
+func fieldFor(target reflect.Type, header string) []int {
+	normalized := normalizeHeader(header)
+	for _, field := range reflect.VisibleFields(target) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		tag, tagged := field.Tag.Lookup("table")
+		switch {
+		case tag == "-":
+			continue
+		case tagged && tag == header:
+			return field.Index
+		case !tagged && normalizeHeader(field.Name) == normalized:
+			return field.Index
+		}
+	}
+	return nil
+}
+
+// normalizeHeader lowercases the header and removes spaces and underscores
+func normalizeHeader(header string) string {
+	return strings.NewReplacer(" ", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(header)))
+}
+
+// setField parses the cell into the field
+func setField(field reflect.Value, cell string) error {
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(cell))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
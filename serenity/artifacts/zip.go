@@ -0,0 +1,30 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// ZipEntries creates a question for the names of the entries in the zip archive, in
+// the order they are stored
+func ZipEntries(source Source) core.Question[[]string] {
+	return question[[]string]{
+		description: "the zip entries",
+		source:      source,
+		answer: func(content []byte) ([]string, error) {
+			reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to open zip archive: %w", err)
+			}
+
+			names := make([]string, len(reader.File))
+			for i, file := range reader.File {
+				names[i] = file.Name
+			}
+			return names, nil
+		},
+	}
+}
@@ -0,0 +1,253 @@
+// Package chaos injects faults into interactions to verify that acceptance scenarios
+// and retry policies behave under adverse conditions.
+//
+// A Monkey decides, for every interaction it intercepts, whether to add latency,
+// fail the interaction before it runs, or drop its outcome after it ran. It can
+// intercept activities (Monkey.Around) and HTTP traffic (Monkey.Transport):
+//
+//	monkey := chaos.NewMonkey(42).
+//		AddingLatency(50*time.Millisecond, 200*time.Millisecond, 30).
+//		Failing(10).
+//		DroppingResponses(5)
+//
+//	actor := test.ActorCalled("Customer").WhoCan(api.Using(&http.Client{
+//		Transport: monkey.Transport(http.DefaultTransport),
+//	}))
+//
+// Decisions come from a seeded random source, so a failing run can be reproduced
+// with the same seed.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// ErrInjectedFailure is returned by interactions the monkey decided to fail
+var ErrInjectedFailure = errors.New("injected failure")
+
+// ErrDroppedResponse is returned by interactions whose outcome the monkey dropped.
+// The interaction did run, so side effects on the system under test remain.
+var ErrDroppedResponse = errors.New("response dropped")
+
+// Injections counts the faults injected by a monkey
+type Injections struct {
+	Intercepted int
+	Delayed     int
+	Failed      int
+	Dropped     int
+}
+
+// Monkey injects latency, failures and dropped responses into a configurable
+// percentage of the interactions it intercepts. It is safe for concurrent use.
+type Monkey struct {
+	mutex       sync.Mutex
+	random      *rand.Rand
+	minLatency  time.Duration
+	maxLatency  time.Duration
+	latencyRate float64
+	failureRate float64
+	dropRate    float64
+	injections  Injections
+}
+
+// NewMonkey creates a monkey that injects no faults until configured.
+// The seed makes its decisions reproducible.
+func NewMonkey(seed int64) *Monkey {
+	return &Monkey{random: rand.New(rand.NewSource(seed))}
+}
+
+// AddingLatency delays the given percentage of interactions by a random duration between min and max
+func (m *Monkey) AddingLatency(min, max time.Duration, percentage float64) *Monkey {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if max < min {
+		min, max = max, min
+	}
+	m.minLatency = min
+	m.maxLatency = max
+	m.latencyRate = percentage
+	return m
+}
+
+// Failing fails the given percentage of interactions with ErrInjectedFailure before they run
+func (m *Monkey) Failing(percentage float64) *Monkey {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.failureRate = percentage
+	return m
+}
+
+// DroppingResponses discards the outcome of the given percentage of interactions after they
+// ran, returning ErrDroppedResponse instead
+func (m *Monkey) DroppingResponses(percentage float64) *Monkey {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.dropRate = percentage
+	return m
+}
+
+// Injections returns the number of faults injected so far
+func (m *Monkey) Injections() Injections {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.injections
+}
+
+// Around wraps an activity so that the monkey intercepts it. The wrapped activity keeps
+// the description and failure mode of the original.
+func (m *Monkey) Around(activity core.Activity) core.Activity {
+	return &chaoticActivity{monkey: m, activity: activity}
+}
+
+// Transport wraps an HTTP transport so that the monkey intercepts every request.
+// A nil base uses http.DefaultTransport.
+func (m *Monkey) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &chaoticTransport{monkey: m, base: base}
+}
+
+// fault describes the faults chosen for a single interaction
+type fault struct {
+	delay time.Duration
+	fail  bool
+	drop  bool
+}
+
+// decide picks the faults for the next interaction and records them
+func (m *Monkey) decide() fault {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var f fault
+	m.injections.Intercepted++
+
+	if m.chance(m.latencyRate) {
+		f.delay = m.minLatency
+		if spread := m.maxLatency - m.minLatency; spread > 0 {
+			f.delay += time.Duration(m.random.Int63n(int64(spread) + 1))
+		}
+		m.injections.Delayed++
+	}
+
+	if m.chance(m.failureRate) {
+		f.fail = true
+		m.injections.Failed++
+	} else if m.chance(m.dropRate) {
+		f.drop = true
+		m.injections.Dropped++
+	}
+
+	return f
+}
+
+// chance reports whether a random draw falls within the percentage. The caller must hold the mutex.
+func (m *Monkey) chance(percentage float64) bool {
+	return percentage > 0 && m.random.Float64()*100 < percentage
+}
+
+// wait sleeps for the chosen latency or until the context is done
+func (f fault) wait(ctx context.Context) error {
+	if f.delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(f.delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// chaoticActivity performs an activity with injected faults
+type chaoticActivity struct {
+	monkey   *Monkey
+	activity core.Activity
+}
+
+// Description returns the description of the wrapped activity
+func (c *chaoticActivity) Description() string {
+	return c.activity.Description()
+}
+
+// FailureMode returns the failure mode of the wrapped activity
+func (c *chaoticActivity) FailureMode() core.FailureMode {
+	return c.activity.FailureMode()
+}
+
+// Location returns where the wrapped activity was constructed
+func (c *chaoticActivity) Location() core.Location {
+	return core.LocationOf(c.activity)
+}
+
+// PerformAs performs the wrapped activity unless the monkey decides otherwise
+func (c *chaoticActivity) PerformAs(actor core.Actor, ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	f := c.monkey.decide()
+	if err := f.wait(ctx); err != nil {
+		return err
+	}
+	if f.fail {
+		return fmt.Errorf("chaos: %w", ErrInjectedFailure)
+	}
+
+	if err := c.activity.PerformAs(actor, ctx); err != nil {
+		return err
+	}
+
+	if f.drop {
+		return fmt.Errorf("chaos: %w", ErrDroppedResponse)
+	}
+	return nil
+}
+
+// chaoticTransport sends HTTP requests with injected faults
+type chaoticTransport struct {
+	monkey *Monkey
+	base   http.RoundTripper
+}
+
+// RoundTrip sends the request unless the monkey decides otherwise
+func (c *chaoticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f := c.monkey.decide()
+	if err := f.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	if f.fail {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("chaos: %s %s: %w", req.Method, req.URL, ErrInjectedFailure)
+	}
+
+	resp, err := c.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.drop {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("chaos: %s %s: %w", req.Method, req.URL, ErrDroppedResponse)
+	}
+	return resp, nil
+}
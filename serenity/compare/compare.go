@@ -0,0 +1,186 @@
+// Package compare performs the same activities against two environments and asserts that
+// the responses are equivalent, e.g. staging and production, or the old and the new
+// version of a service during a migration.
+//
+// The actor's CallAnAPI ability is pointed at each base URL in turn, so requests carry the
+// same headers and credentials. The status and body of the last responses are compared,
+// ignoring the paths that legitimately differ:
+//
+//	actor.AttemptsTo(
+//		compare.BaseURLs(legacyURL, rewriteURL).
+//			Named("legacy", "rewrite").
+//			Performing(api.SendGetRequest("/products/42")).
+//			Ignoring("body.id", "body.items.*.updatedAt"),
+//	)
+//
+// Differences fail the activity with a core.AssertionError listing them, and are attached
+// to the report as differences.json.
+package compare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+)
+
+// environment is a named base URL
+type environment struct {
+	name    string
+	baseURL string
+}
+
+// Comparison is an activity that performs activities against two base URLs and compares
+// the last responses
+type Comparison struct {
+	baseline   environment
+	candidate  environment
+	activities []core.Activity
+	ignored    []string
+	location   core.Location
+
+	mutex       sync.Mutex
+	differences []Difference
+}
+
+// BaseURLs creates a comparison of the baseline with the candidate, named "baseline" and
+// "candidate" in reports
+func BaseURLs(baseline, candidate string) *Comparison {
+	return &Comparison{
+		baseline:  environment{name: "baseline", baseURL: baseline},
+		candidate: environment{name: "candidate", baseURL: candidate},
+		location:  core.CallerLocation(),
+	}
+}
+
+// Named names the environments in reports, e.g. "staging" and "production"
+func (c *Comparison) Named(baseline, candidate string) *Comparison {
+	c.baseline.name, c.candidate.name = baseline, candidate
+	return c
+}
+
+// Performing sets the activities performed against each environment
+func (c *Comparison) Performing(activities ...core.Activity) *Comparison {
+	c.activities = activities
+	return c
+}
+
+// Ignoring excludes the paths from the comparison. The status is under "status" and the
+// body under "body", e.g. "body.createdAt"; "*" matches any key or index.
+func (c *Comparison) Ignoring(paths ...string) *Comparison {
+	c.ignored = append(c.ignored, paths...)
+	return c
+}
+
+// Differences returns the differences found by the last run
+func (c *Comparison) Differences() []Difference {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.differences
+}
+
+// Description returns the activity description
+func (c *Comparison) Description() string {
+	return fmt.Sprintf("#actor compares the responses of %s and %s", c.baseline.name, c.candidate.name)
+}
+
+// FailureMode returns FailFast
+func (c *Comparison) FailureMode() core.FailureMode {
+	return core.FailFast
+}
+
+// Location returns where the comparison was constructed
+func (c *Comparison) Location() core.Location {
+	return c.location
+}
+
+// PerformAs performs the activities against both environments, each reported as a
+// sub-step, and compares the responses. The actor's base URL is restored afterwards.
+func (c *Comparison) PerformAs(actor core.Actor, ctx context.Context) (err error) {
+	if len(c.activities) == 0 {
+		return errors.New("comparison has no activities to perform")
+	}
+
+	ability, err := core.AbilityOf[api.CallAnAPI](actor)
+	if err != nil {
+		return err
+	}
+	original := ability.GetBaseURL()
+	defer func() {
+		if restoreErr := ability.SetBaseURL(original); restoreErr != nil && err == nil {
+			err = fmt.Errorf("failed to restore base URL %s: %w", original, restoreErr)
+		}
+	}()
+
+	baseline, err := c.responseOf(actor, ctx, ability, c.baseline)
+	if err != nil {
+		return err
+	}
+	candidate, err := c.responseOf(actor, ctx, ability, c.candidate)
+	if err != nil {
+		return err
+	}
+
+	differences := Diff(baseline, candidate, c.ignored...)
+	c.mutex.Lock()
+	c.differences = differences
+	c.mutex.Unlock()
+
+	if len(differences) == 0 {
+		return nil
+	}
+
+	var report bytes.Buffer
+	encoder := json.NewEncoder(&report)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(differences); err == nil {
+		core.Publish(actor, events.AttachmentAdded{Actor: actor.Name(), Name: "differences.json", MediaType: "application/json", Content: report.Bytes()})
+	}
+
+	lines := make([]string, len(differences))
+	for i, difference := range differences {
+		lines[i] = "  " + difference.String()
+	}
+	return core.NewAssertionError(baseline, candidate, "responses of %s and %s differ (%s != %s):\n%s",
+		c.baseline.name, c.candidate.name, c.baseline.name, c.candidate.name, strings.Join(lines, "\n"))
+}
+
+// responseOf performs the activities against the environment and returns the status and
+// body of the last response as a document
+func (c *Comparison) responseOf(actor core.Actor, ctx context.Context, ability api.CallAnAPI, env environment) (map[string]any, error) {
+	err := core.PerformAsSubStep(actor, ctx, core.Do(
+		fmt.Sprintf("#actor calls %s at %s", env.name, env.baseURL),
+		func(actor core.Actor, ctx context.Context) error {
+			if err := ability.SetBaseURL(env.baseURL); err != nil {
+				return err
+			}
+			for _, activity := range c.activities {
+				if err := core.PerformAsSubStep(actor, ctx, activity); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", env.name, err)
+	}
+
+	status, err := core.Answer(actor, api.LastResponseStatus{})
+	if err != nil {
+		return nil, err
+	}
+	body, err := core.Answer(actor, api.LastResponseBody{})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"status": json.Number(fmt.Sprint(status)), "body": Decode([]byte(body))}, nil
+}
@@ -0,0 +1,152 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Missing stands for a value that is absent on one side of a difference
+var Missing = missing{}
+
+// missing is the type of Missing
+type missing struct{}
+
+// String returns "<missing>"
+func (missing) String() string { return "<missing>" }
+
+// MarshalJSON renders the missing value as "<missing>"
+func (missing) MarshalJSON() ([]byte, error) { return []byte(`"<missing>"`), nil }
+
+// Difference is a value that differs between the baseline and the candidate
+type Difference struct {
+	// Path locates the value, e.g. "body.items.0.price"
+	Path string
+	// Baseline is the value in the baseline, or Missing
+	Baseline any
+	// Candidate is the value in the candidate, or Missing
+	Candidate any
+}
+
+// String describes the difference, e.g. "body.items.0.price: 10 != 12"
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s != %s", d.Path, render(d.Baseline), render(d.Candidate))
+}
+
+// Diff compares two values decoded from JSON and returns their differences in path order.
+// Values under the ignored paths are not compared. Paths separate object keys and array
+// indexes with dots, and "*" matches any key or index: "body.items.*.updatedAt".
+func Diff(baseline, candidate any, ignored ...string) []Difference {
+	var differences []Difference
+	diff(nil, baseline, candidate, ignored, &differences)
+	return differences
+}
+
+// Decode decodes JSON, keeping numbers as written so that large integers are compared
+// exactly; 1 and 1.0 differ. Text that is not JSON is returned as a string.
+func Decode(data []byte) any {
+	var decoded any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return string(data)
+	}
+	return decoded
+}
+
+// diff appends the differences between the values at path
+func diff(path []string, baseline, candidate any, ignored []string, differences *[]Difference) {
+	if isIgnored(path, ignored) {
+		return
+	}
+
+	switch b := baseline.(type) {
+	case map[string]any:
+		if c, ok := candidate.(map[string]any); ok {
+			keys := slices.Sorted(maps.Keys(b))
+			for key := range c {
+				if _, found := b[key]; !found {
+					keys = append(keys, key)
+				}
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				diff(append(slices.Clone(path), key), valueOf(b, key), valueOf(c, key), ignored, differences)
+			}
+			return
+		}
+	case []any:
+		if c, ok := candidate.([]any); ok {
+			for i := range max(len(b), len(c)) {
+				diff(append(slices.Clone(path), strconv.Itoa(i)), elementOf(b, i), elementOf(c, i), ignored, differences)
+			}
+			return
+		}
+	}
+
+	if !equal(baseline, candidate) {
+		*differences = append(*differences, Difference{Path: strings.Join(path, "."), Baseline: baseline, Candidate: candidate})
+	}
+}
+
+// isIgnored reports whether one of the ignored paths matches the path
+func isIgnored(path []string, ignored []string) bool {
+	for _, pattern := range ignored {
+		segments := strings.Split(pattern, ".")
+		if len(segments) != len(path) {
+			continue
+		}
+		matches := true
+		for i, segment := range segments {
+			if segment != "*" && segment != path[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// valueOf returns the value of the key, or Missing
+func valueOf(object map[string]any, key string) any {
+	if value, found := object[key]; found {
+		return value
+	}
+	return Missing
+}
+
+// elementOf returns the element at the index, or Missing
+func elementOf(array []any, index int) any {
+	if index < len(array) {
+		return array[index]
+	}
+	return Missing
+}
+
+// equal compares scalar values, and values of different kinds
+func equal(baseline, candidate any) bool {
+	if b, ok := baseline.(json.Number); ok {
+		c, ok := candidate.(json.Number)
+		return ok && b == c
+	}
+	return fmt.Sprintf("%T %v", baseline, baseline) == fmt.Sprintf("%T %v", candidate, candidate)
+}
+
+// render formats a value of a difference as JSON
+func render(value any) string {
+	if value == Missing {
+		return Missing.String()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,37 @@
+package compare
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffListsChangedAddedAndRemovedValues(t *testing.T) {
+	baseline := Decode([]byte(`{"id": 1, "name": "Book", "tags": ["new"], "price": 10}`))
+	candidate := Decode([]byte(`{"id": 2, "name": "Book", "tags": ["new", "sale"], "currency": "EUR", "price": 10.0}`))
+
+	differences := Diff(baseline, candidate, "id")
+
+	require.Equal(t, []Difference{
+		{Path: "currency", Baseline: Missing, Candidate: "EUR"},
+		{Path: "price", Baseline: json.Number("10"), Candidate: json.Number("10.0")},
+		{Path: "tags.1", Baseline: Missing, Candidate: "sale"},
+	}, differences)
+	require.Equal(t, `tags.1: <missing> != "sale"`, differences[2].String())
+}
+
+func TestDiffIgnoresWildcardPaths(t *testing.T) {
+	baseline := Decode([]byte(`{"items": [{"sku": "a", "updatedAt": "monday"}, {"sku": "b", "updatedAt": "monday"}]}`))
+	candidate := Decode([]byte(`{"items": [{"sku": "a", "updatedAt": "tuesday"}, {"sku": "c", "updatedAt": "tuesday"}]}`))
+
+	require.Equal(t, []Difference{
+		{Path: "items.1.sku", Baseline: "b", Candidate: "c"},
+	}, Diff(baseline, candidate, "items.*.updatedAt"))
+}
+
+func TestDiffComparesTextThatIsNotJSON(t *testing.T) {
+	require.Empty(t, Diff(Decode([]byte("OK")), Decode([]byte("OK"))))
+	require.Equal(t, []Difference{{Path: "", Baseline: "OK", Candidate: `{"status": "ok"}`}},
+		Diff(Decode([]byte("OK")), `{"status": "ok"}`))
+}
@@ -0,0 +1,429 @@
+// Package config provides per-environment configuration for Serenity-Go test suites.
+//
+// Configuration is organized in profiles (dev, stage, prod, ...) stored in a YAML or JSON
+// file. Each profile exposes base URLs, credentials, timeouts, and feature flags, so the
+// same suite can target different environments without code edits:
+//
+//	default: dev
+//	profiles:
+//	  dev:
+//	    urls:
+//	      ordersService: http://localhost:8080
+//	    credentials:
+//	      admin:
+//	        username: admin
+//	        password: admin
+//	    timeouts:
+//	      request: 5s
+//	    features:
+//	      newCheckout: true
+//	  stage:
+//	    urls:
+//	      ordersService: https://orders.stage.example.com
+//
+// Profile Selection:
+//
+//	The profile is selected by the SERENITY_PROFILE environment variable, falling back
+//	to the file's "default" entry, or to the only profile when the file defines just one.
+//
+// Environment Overrides:
+//
+//	Every value can be overridden with an environment variable, which takes precedence
+//	over the file. Names are converted to upper snake case ("ordersService" -> "ORDERS_SERVICE"):
+//
+//	SERENITY_URL_ORDERS_SERVICE=https://orders.local
+//	SERENITY_CREDENTIALS_ADMIN_USERNAME=root
+//	SERENITY_CREDENTIALS_ADMIN_PASSWORD=secret
+//	SERENITY_CREDENTIALS_ADMIN_TOKEN=abc
+//	SERENITY_TIMEOUT_REQUEST=30s
+//	SERENITY_FEATURE_NEW_CHECKOUT=false
+//
+// Usage:
+//
+//	// Load the file referenced by SERENITY_CONFIG lazily
+//	actor := test.ActorCalled("Buyer").WhoCan(
+//		api.CallAnApiAt(config.URL("ordersService")),
+//	)
+//
+//	// Or load and activate a configuration explicitly
+//	cfg, err := config.Load("testdata/environments.yaml")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	config.Use(cfg)
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+const (
+	// ConfigEnvVar names the environment variable holding the path of the configuration file
+	ConfigEnvVar = "SERENITY_CONFIG"
+
+	// ProfileEnvVar names the environment variable selecting the active profile
+	ProfileEnvVar = "SERENITY_PROFILE"
+
+	// envPrefix is the prefix of all environment overrides
+	envPrefix = "SERENITY_"
+)
+
+// Credentials holds the credentials for a system under test
+type Credentials struct {
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	Token    string `json:"token" yaml:"token"`
+}
+
+// Config holds the settings of a single environment profile.
+// Lookups consult environment overrides first and the profile second.
+type Config struct {
+	profile     string
+	urls        map[string]string
+	credentials map[string]Credentials
+	timeouts    map[string]time.Duration
+	features    map[string]bool
+}
+
+// file is the on-disk representation of a configuration file
+type file struct {
+	Default  string             `json:"default" yaml:"default"`
+	Profiles map[string]profile `json:"profiles" yaml:"profiles"`
+}
+
+// profile is the on-disk representation of a single profile
+type profile struct {
+	URLs        map[string]string      `json:"urls" yaml:"urls"`
+	Credentials map[string]Credentials `json:"credentials" yaml:"credentials"`
+	Timeouts    map[string]string      `json:"timeouts" yaml:"timeouts"`
+	Features    map[string]bool        `json:"features" yaml:"features"`
+}
+
+// New creates an empty configuration that only resolves environment overrides
+func New() *Config {
+	return &Config{
+		urls:        make(map[string]string),
+		credentials: make(map[string]Credentials),
+		timeouts:    make(map[string]time.Duration),
+		features:    make(map[string]bool),
+	}
+}
+
+// Load loads the configuration file at path and selects the active profile
+// using SERENITY_PROFILE, the file's default, or its only profile.
+// Files with .json extension are parsed as JSON, everything else as YAML.
+func Load(path string) (*Config, error) {
+	return LoadProfile(path, os.Getenv(ProfileEnvVar))
+}
+
+// LoadProfile loads the configuration file at path and selects the given profile.
+// An empty profile name falls back to the file's default or its only profile.
+func LoadProfile(path string, profileName string) (*Config, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the test suite
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file %s: %w", path, err)
+	}
+
+	var parsed file
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &parsed)
+	} else {
+		err = yaml.Unmarshal(data, &parsed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+
+	name, err := parsed.selectProfile(profileName)
+	if err != nil {
+		return nil, fmt.Errorf("configuration file %s: %w", path, err)
+	}
+
+	cfg, err := fromProfile(name, parsed.Profiles[name])
+	if err != nil {
+		return nil, fmt.Errorf("configuration file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// selectProfile resolves the name of the profile to use
+func (f file) selectProfile(requested string) (string, error) {
+	name := requested
+	if name == "" {
+		name = f.Default
+	}
+	if name == "" && len(f.Profiles) == 1 {
+		for only := range f.Profiles {
+			name = only
+		}
+	}
+	if name == "" {
+		return "", fmt.Errorf("no profile selected; set %s or a default profile (available: %s)",
+			ProfileEnvVar, strings.Join(f.profileNames(), ", "))
+	}
+	if _, ok := f.Profiles[name]; !ok {
+		return "", fmt.Errorf("profile '%s' not found (available: %s)", name, strings.Join(f.profileNames(), ", "))
+	}
+	return name, nil
+}
+
+// profileNames returns the sorted names of all profiles
+func (f file) profileNames() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fromProfile converts a parsed profile into a Config
+func fromProfile(name string, p profile) (*Config, error) {
+	cfg := New()
+	cfg.profile = name
+
+	for key, value := range p.URLs {
+		cfg.urls[key] = value
+	}
+	for key, value := range p.Credentials {
+		cfg.credentials[key] = value
+	}
+	for key, value := range p.Features {
+		cfg.features[key] = value
+	}
+	for key, value := range p.Timeouts {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout '%s' in profile '%s': %w", key, name, err)
+		}
+		cfg.timeouts[key] = timeout
+	}
+
+	return cfg, nil
+}
+
+// Profile returns the name of the profile this configuration was loaded from
+func (c *Config) Profile() string {
+	return c.profile
+}
+
+// WithURL sets a base URL and returns the configuration for chaining
+func (c *Config) WithURL(name, url string) *Config {
+	c.urls[name] = url
+	return c
+}
+
+// WithCredentials sets credentials and returns the configuration for chaining
+func (c *Config) WithCredentials(name string, credentials Credentials) *Config {
+	c.credentials[name] = credentials
+	return c
+}
+
+// WithTimeout sets a timeout and returns the configuration for chaining
+func (c *Config) WithTimeout(name string, timeout time.Duration) *Config {
+	c.timeouts[name] = timeout
+	return c
+}
+
+// WithFeature sets a feature flag and returns the configuration for chaining
+func (c *Config) WithFeature(name string, enabled bool) *Config {
+	c.features[name] = enabled
+	return c
+}
+
+// URL returns the base URL with the given name
+func (c *Config) URL(name string) (string, error) {
+	if value, ok := lookupEnv("URL", name); ok {
+		return value, nil
+	}
+	if value, ok := c.urls[name]; ok {
+		return value, nil
+	}
+	return "", c.notFound("URL", name)
+}
+
+// CredentialsFor returns the credentials with the given name.
+// Each credential field can be overridden separately. The password and token are
+// registered with the secrets package so they are masked in reports.
+func (c *Config) CredentialsFor(name string) (Credentials, error) {
+	credentials, found := c.credentials[name]
+
+	overrides := map[string]*string{
+		"USERNAME": &credentials.Username,
+		"PASSWORD": &credentials.Password,
+		"TOKEN":    &credentials.Token,
+	}
+	for field, target := range overrides {
+		if value, ok := lookupEnv("CREDENTIALS", name+"_"+field); ok {
+			*target = value
+			found = true
+		}
+	}
+
+	if !found {
+		return Credentials{}, c.notFound("credentials", name)
+	}
+
+	secrets.Register(credentials.Password, credentials.Token)
+	return credentials, nil
+}
+
+// Timeout returns the timeout with the given name
+func (c *Config) Timeout(name string) (time.Duration, error) {
+	if value, ok := lookupEnv("TIMEOUT", name); ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timeout override %s: %w", envName("TIMEOUT", name), err)
+		}
+		return timeout, nil
+	}
+	if value, ok := c.timeouts[name]; ok {
+		return value, nil
+	}
+	return 0, c.notFound("timeout", name)
+}
+
+// Feature returns whether the feature flag with the given name is enabled.
+// Unknown flags are disabled.
+func (c *Config) Feature(name string) bool {
+	if value, ok := lookupEnv("FEATURE", name); ok {
+		enabled, err := strconv.ParseBool(value)
+		return err == nil && enabled
+	}
+	return c.features[name]
+}
+
+// FeatureEnvVar returns the environment variable overriding the feature flag with the
+// given name, e.g. FeatureEnvVar("newCheckout") == "SERENITY_FEATURE_NEW_CHECKOUT"
+func FeatureEnvVar(name string) string {
+	return envName("FEATURE", name)
+}
+
+// notFound creates the error returned for missing configuration values
+func (c *Config) notFound(kind, name string) error {
+	profileName := c.profile
+	if profileName == "" {
+		profileName = "<none>"
+	}
+	return fmt.Errorf("%s '%s' is not configured in profile '%s' (set it in the configuration file or via %s)",
+		kind, name, profileName, envName(strings.ToUpper(kind), name))
+}
+
+// lookupEnv returns the environment override for a configuration value
+func lookupEnv(kind, name string) (string, bool) {
+	return os.LookupEnv(envName(kind, name))
+}
+
+// envName returns the environment variable name for a configuration value,
+// e.g. envName("URL", "ordersService") == "SERENITY_URL_ORDERS_SERVICE"
+func envName(kind, name string) string {
+	return envPrefix + kind + "_" + envKey(name)
+}
+
+// envKey converts a configuration name to upper snake case
+func envKey(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			b.WriteRune('_')
+			b.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToUpper(r))
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+var (
+	active     *Config
+	activeErr  error
+	activeOnce sync.Once
+	activeMu   sync.RWMutex
+)
+
+// Use makes cfg the active configuration used by the package-level helpers
+func Use(cfg *Config) {
+	activeOnce.Do(func() {}) // Prevent a later lazy load from replacing cfg
+
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	active = cfg
+	activeErr = nil
+}
+
+// Active returns the active configuration.
+// Unless Use was called, the file referenced by SERENITY_CONFIG is loaded on first use;
+// without SERENITY_CONFIG an empty configuration resolving only environment overrides is used.
+func Active() (*Config, error) {
+	activeOnce.Do(func() {
+		path := os.Getenv(ConfigEnvVar)
+		if path == "" {
+			active = New()
+			return
+		}
+		active, activeErr = Load(path)
+	})
+
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return active, activeErr
+}
+
+// URL returns the base URL with the given name from the active configuration.
+// It panics if the URL is not configured, since suites can't run without it.
+//
+// Example:
+//
+//	actor := test.ActorCalled("Buyer").WhoCan(api.CallAnApiAt(config.URL("ordersService")))
+func URL(name string) string {
+	return mustResolve(func(cfg *Config) (string, error) { return cfg.URL(name) })
+}
+
+// CredentialsFor returns the credentials with the given name from the active configuration.
+// It panics if the credentials are not configured.
+func CredentialsFor(name string) Credentials {
+	return mustResolve(func(cfg *Config) (Credentials, error) { return cfg.CredentialsFor(name) })
+}
+
+// Timeout returns the timeout with the given name from the active configuration.
+// It panics if the timeout is not configured.
+func Timeout(name string) time.Duration {
+	return mustResolve(func(cfg *Config) (time.Duration, error) { return cfg.Timeout(name) })
+}
+
+// Feature returns whether the feature flag with the given name is enabled in the active configuration.
+// It panics if the active configuration can't be loaded.
+func Feature(name string) bool {
+	return mustResolve(func(cfg *Config) (bool, error) { return cfg.Feature(name), nil })
+}
+
+// mustResolve resolves a value from the active configuration, panicking on failure
+func mustResolve[T any](resolve func(cfg *Config) (T, error)) T {
+	cfg, err := Active()
+	if err != nil {
+		panic(fmt.Sprintf("config: %v", err))
+	}
+
+	value, err := resolve(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("config: %v", err))
+	}
+	return value
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const environmentsYAML = `
+default: dev
+profiles:
+  dev:
+    urls:
+      ordersService: http://localhost:8080
+    credentials:
+      admin:
+        username: admin
+        password: dev-password
+    timeouts:
+      request: 5s
+    features:
+      newCheckout: true
+  stage:
+    urls:
+      ordersService: https://orders.stage.example.com
+    timeouts:
+      request: 30s
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoad_DefaultProfile(t *testing.T) {
+	cfg, err := Load(writeFile(t, "environments.yaml", environmentsYAML))
+	require.NoError(t, err)
+	require.Equal(t, "dev", cfg.Profile())
+
+	url, err := cfg.URL("ordersService")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080", url)
+
+	credentials, err := cfg.CredentialsFor("admin")
+	require.NoError(t, err)
+	require.Equal(t, Credentials{Username: "admin", Password: "dev-password"}, credentials)
+
+	timeout, err := cfg.Timeout("request")
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, timeout)
+
+	require.True(t, cfg.Feature("newCheckout"))
+	require.False(t, cfg.Feature("unknownFeature"))
+}
+
+func TestLoad_ProfileFromEnvironment(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "stage")
+
+	cfg, err := Load(writeFile(t, "environments.yaml", environmentsYAML))
+	require.NoError(t, err)
+	require.Equal(t, "stage", cfg.Profile())
+
+	url, err := cfg.URL("ordersService")
+	require.NoError(t, err)
+	require.Equal(t, "https://orders.stage.example.com", url)
+
+	_, err = cfg.CredentialsFor("admin")
+	require.EqualError(t, err, "credentials 'admin' is not configured in profile 'stage' "+
+		"(set it in the configuration file or via SERENITY_CREDENTIALS_ADMIN)")
+}
+
+func TestLoad_JSON(t *testing.T) {
+	path := writeFile(t, "environments.json", `{
+		"profiles": {
+			"prod": {"urls": {"ordersService": "https://orders.example.com"}, "timeouts": {"request": "1m"}}
+		}
+	}`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "prod", cfg.Profile())
+
+	timeout, err := cfg.Timeout("request")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, timeout)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := LoadProfile(writeFile(t, "environments.yaml", environmentsYAML), "qa")
+	require.ErrorContains(t, err, "profile 'qa' not found (available: dev, stage)")
+
+	_, err = Load(writeFile(t, "environments.yaml", "profiles:\n  a: {}\n  b: {}\n"))
+	require.ErrorContains(t, err, "no profile selected")
+
+	_, err = Load(writeFile(t, "environments.yaml", "profiles:\n  a:\n    timeouts:\n      request: soon\n"))
+	require.ErrorContains(t, err, "invalid timeout 'request' in profile 'a'")
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read configuration file")
+}
+
+func TestEnvironmentOverrides(t *testing.T) {
+	cfg, err := Load(writeFile(t, "environments.yaml", environmentsYAML))
+	require.NoError(t, err)
+
+	t.Setenv("SERENITY_URL_ORDERS_SERVICE", "https://orders.local")
+	t.Setenv("SERENITY_URL_PAYMENTS_SERVICE", "https://payments.local")
+	t.Setenv("SERENITY_CREDENTIALS_ADMIN_PASSWORD", "override")
+	t.Setenv("SERENITY_TIMEOUT_REQUEST", "10s")
+	t.Setenv("SERENITY_FEATURE_NEW_CHECKOUT", "false")
+
+	url, err := cfg.URL("ordersService")
+	require.NoError(t, err)
+	require.Equal(t, "https://orders.local", url)
+
+	// Values missing from the file can be provided by the environment alone
+	url, err = cfg.URL("paymentsService")
+	require.NoError(t, err)
+	require.Equal(t, "https://payments.local", url)
+
+	credentials, err := cfg.CredentialsFor("admin")
+	require.NoError(t, err)
+	require.Equal(t, Credentials{Username: "admin", Password: "override"}, credentials)
+
+	timeout, err := cfg.Timeout("request")
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, timeout)
+
+	require.False(t, cfg.Feature("newCheckout"))
+}
+
+func TestActiveConfiguration(t *testing.T) {
+	Use(New().WithURL("ordersService", "http://orders.test").WithFeature("newCheckout", true))
+	t.Cleanup(func() { Use(New()) })
+
+	require.Equal(t, "http://orders.test", URL("ordersService"))
+	require.True(t, Feature("newCheckout"))
+	require.PanicsWithValue(t,
+		"config: timeout 'request' is not configured in profile '<none>' "+
+			"(set it in the configuration file or via SERENITY_TIMEOUT_REQUEST)",
+		func() { Timeout("request") },
+	)
+}
+
+func TestEnvKey(t *testing.T) {
+	tests := map[string]string{
+		"ordersService":   "ORDERS_SERVICE",
+		"orders-service":  "ORDERS_SERVICE",
+		"API":             "API",
+		"service2Backend": "SERVICE2_BACKEND",
+		"admin":           "ADMIN",
+	}
+
+	for name, expected := range tests {
+		require.Equal(t, expected, envKey(name), name)
+	}
+
+	require.Equal(t, "SERENITY_FEATURE_NEW_CHECKOUT", FeatureEnvVar("new-checkout"))
+}
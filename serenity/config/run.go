@@ -0,0 +1,403 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RunFileName is the name of the project-level run configuration file
+	RunFileName = "serenity.yaml"
+
+	// RunConfigEnvVar names the environment variable holding the path of the run
+	// configuration file, overriding the search for serenity.yaml
+	RunConfigEnvVar = "SERENITY_RUN_CONFIG"
+
+	// DefaultOutputDir is the directory holding the output folders of the runs unless
+	// configured otherwise, the same as reporting.DefaultOutputRoot
+	DefaultOutputDir = "target/serenity"
+
+	// DefaultKeptRuns is the number of run output folders kept unless configured otherwise
+	DefaultKeptRuns = 10
+)
+
+// RunSettings holds the operational defaults of a test run, such as the reporters and
+// the timeouts, so test code doesn't hard-code them. They are loaded from serenity.yaml:
+//
+//	reporters: [console, transcript, attachments, webhook]
+//	outputDir: target/serenity
+//	keepRuns: 10
+//	timeouts:
+//	  scenario: 2m
+//	  activity: 30s
+//	retries:
+//	  attempts: 3
+//	  delay: 1s
+//	parallel: true
+//	tags:
+//	  include: [smoke]
+//	  exclude: [slow]
+//	reporting:
+//	  webhook:
+//	    url: https://hooks.slack.com/services/T000/B000/XXXX
+//	    format: slack
+//
+// The reporting section holds the settings of each reporter under its registered name;
+// the reporter decodes and validates its own entry, see ReporterSettings.
+//
+// Every setting can be overridden with an environment variable, which takes precedence
+// over the file; reporters document the overrides of their settings:
+//
+//	SERENITY_REPORTERS=console,transcript
+//	SERENITY_OUTPUT_DIR=build/reports
+//	SERENITY_KEEP_RUNS=3
+//	SERENITY_SCENARIO_TIMEOUT=5m
+//	SERENITY_ACTIVITY_TIMEOUT=1m
+//	SERENITY_RETRY_ATTEMPTS=2
+//	SERENITY_RETRY_DELAY=500ms
+//	SERENITY_PARALLEL=false
+//	SERENITY_INCLUDE_TAGS=smoke,checkout
+//	SERENITY_EXCLUDE_TAGS=slow
+type RunSettings struct {
+	// Reporters names the reporters of the tests, e.g. "console"
+	Reporters []string
+	// OutputDir is the directory holding the output folder of each run, which reporters
+	// write their files to
+	OutputDir string
+	// KeepRuns is the number of run output folders kept, zero to keep all of them
+	KeepRuns int
+	// ScenarioTimeout bounds the context of each test, zero for no limit
+	ScenarioTimeout time.Duration
+	// ActivityTimeout bounds the context of each activity, zero for no limit
+	ActivityTimeout time.Duration
+	// Retries is the policy for activities that fail
+	Retries RetryPolicy
+	// Parallel runs the tests that opt in with serenity.Parallel in parallel with each other
+	Parallel bool
+	// Tags selects the tests to run by their tags
+	Tags TagFilter
+	// Reporting holds the raw settings of the reporters, keyed by their registered names,
+	// e.g. "webhook". Reporters decode their own entry with ReporterSettings.
+	Reporting map[string]yaml.Node
+}
+
+// RetryPolicy decides how often a failing activity is attempted
+type RetryPolicy struct {
+	// Attempts is the number of times an activity is attempted, including the first one
+	Attempts int
+	// Delay is the wait between attempts
+	Delay time.Duration
+}
+
+// TagFilter selects tests by their tags
+type TagFilter struct {
+	// Include lists the tags of the tests to run; when empty, all tests run
+	Include []string
+	// Exclude lists the tags of the tests to skip, taking precedence over Include
+	Exclude []string
+}
+
+// Allows reports whether a test with the given tags runs: it has none of the excluded
+// tags and, when tags are included, at least one of them
+func (f TagFilter) Allows(tags ...string) bool {
+	for _, tag := range tags {
+		if slices.Contains(f.Exclude, tag) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		if slices.Contains(f.Include, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReporterSettings decodes the entry of the named reporter in the reporting settings into
+// target, a pointer to the reporter's settings. Settings missing from the entry, or all of
+// them without one, keep the values target already has.
+func (s RunSettings) ReporterSettings(name string, target any) error {
+	node, ok := s.Reporting[name]
+	if !ok {
+		return nil
+	}
+	if err := node.Decode(target); err != nil {
+		return fmt.Errorf("invalid reporting.%s: %w", name, err)
+	}
+	return nil
+}
+
+// WithReporterSettings returns a copy of the settings whose reporting entry for the named
+// reporter holds value, e.g. to configure reporters in TestMain instead of serenity.yaml.
+// It fails if value can't be encoded as YAML.
+//
+//	settings, err := config.DefaultRunSettings().WithReporterSettings("webhook", webhook.Settings{URL: url})
+func (s RunSettings) WithReporterSettings(name string, value any) (RunSettings, error) {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return RunSettings{}, fmt.Errorf("invalid reporting.%s: %w", name, err)
+	}
+	reporting := make(map[string]yaml.Node, len(s.Reporting)+1)
+	for key, entry := range s.Reporting {
+		reporting[key] = entry
+	}
+	reporting[name] = node
+	s.Reporting = reporting
+	return s, nil
+}
+
+// DefaultRunSettings returns the settings used without a run configuration file: the
+// console reporter, a single attempt per activity and no timeouts
+func DefaultRunSettings() RunSettings {
+	return RunSettings{
+		Reporters: []string{"console"},
+		OutputDir: DefaultOutputDir,
+		KeepRuns:  DefaultKeptRuns,
+		Retries:   RetryPolicy{Attempts: 1},
+	}
+}
+
+// runFile is the on-disk representation of a run configuration file
+type runFile struct {
+	Reporters *[]string `yaml:"reporters"`
+	OutputDir string    `yaml:"outputDir"`
+	KeepRuns  *int      `yaml:"keepRuns"`
+	Timeouts  struct {
+		Scenario string `yaml:"scenario"`
+		Activity string `yaml:"activity"`
+	} `yaml:"timeouts"`
+	Retries struct {
+		Attempts int    `yaml:"attempts"`
+		Delay    string `yaml:"delay"`
+	} `yaml:"retries"`
+	Parallel bool `yaml:"parallel"`
+	Tags     struct {
+		Include []string `yaml:"include"`
+		Exclude []string `yaml:"exclude"`
+	} `yaml:"tags"`
+	Reporting map[string]yaml.Node `yaml:"reporting"`
+}
+
+// LoadRunSettings loads the run configuration file at path and applies the environment
+// overrides to it
+func LoadRunSettings(path string) (RunSettings, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the test suite
+	if err != nil {
+		return RunSettings{}, fmt.Errorf("failed to read run configuration file %s: %w", path, err)
+	}
+
+	var parsed runFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return RunSettings{}, fmt.Errorf("failed to parse run configuration file %s: %w", path, err)
+	}
+
+	settings, err := parsed.settings()
+	if err != nil {
+		return RunSettings{}, fmt.Errorf("run configuration file %s: %w", path, err)
+	}
+	return withRunOverrides(settings)
+}
+
+// settings converts the parsed file to settings, keeping the defaults of missing entries
+func (f runFile) settings() (RunSettings, error) {
+	settings := DefaultRunSettings()
+	if f.Reporters != nil {
+		settings.Reporters = *f.Reporters
+	}
+	if f.OutputDir != "" {
+		settings.OutputDir = f.OutputDir
+	}
+	if f.KeepRuns != nil {
+		settings.KeepRuns = *f.KeepRuns
+	}
+	if f.Retries.Attempts != 0 {
+		settings.Retries.Attempts = f.Retries.Attempts
+	}
+	settings.Parallel = f.Parallel
+	settings.Tags = TagFilter{Include: f.Tags.Include, Exclude: f.Tags.Exclude}
+	settings.Reporting = f.Reporting
+
+	durations := []struct {
+		name   string
+		value  string
+		target *time.Duration
+	}{
+		{"timeouts.scenario", f.Timeouts.Scenario, &settings.ScenarioTimeout},
+		{"timeouts.activity", f.Timeouts.Activity, &settings.ActivityTimeout},
+		{"retries.delay", f.Retries.Delay, &settings.Retries.Delay},
+	}
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(duration.value)
+		if err != nil {
+			return RunSettings{}, fmt.Errorf("invalid %s: %w", duration.name, err)
+		}
+		*duration.target = parsed
+	}
+	return settings, settings.validate()
+}
+
+// validate checks the settings for values that can't be applied
+func (s RunSettings) validate() error {
+	if s.Retries.Attempts < 1 {
+		return fmt.Errorf("invalid retries.attempts %d: an activity is attempted at least once", s.Retries.Attempts)
+	}
+	if s.KeepRuns < 0 {
+		return fmt.Errorf("invalid keepRuns %d: use 0 to keep all runs", s.KeepRuns)
+	}
+	if s.ScenarioTimeout < 0 || s.ActivityTimeout < 0 || s.Retries.Delay < 0 {
+		return errors.New("timeouts and delays can't be negative")
+	}
+	return nil
+}
+
+// withRunOverrides applies the environment overrides to the settings
+func withRunOverrides(settings RunSettings) (RunSettings, error) {
+	if value, ok := os.LookupEnv(envPrefix + "REPORTERS"); ok {
+		settings.Reporters = splitList(value)
+	}
+	if value, ok := os.LookupEnv(envPrefix + "OUTPUT_DIR"); ok {
+		settings.OutputDir = value
+	}
+	if value, ok := os.LookupEnv(envPrefix + "INCLUDE_TAGS"); ok {
+		settings.Tags.Include = splitList(value)
+	}
+	if value, ok := os.LookupEnv(envPrefix + "EXCLUDE_TAGS"); ok {
+		settings.Tags.Exclude = splitList(value)
+	}
+
+	err := ApplyOverrides(map[string]any{
+		"SCENARIO_TIMEOUT": &settings.ScenarioTimeout,
+		"ACTIVITY_TIMEOUT": &settings.ActivityTimeout,
+		"RETRY_DELAY":      &settings.Retries.Delay,
+		"RETRY_ATTEMPTS":   &settings.Retries.Attempts,
+		"KEEP_RUNS":        &settings.KeepRuns,
+		"PARALLEL":         &settings.Parallel,
+	})
+	if err != nil {
+		return RunSettings{}, err
+	}
+	return settings, settings.validate()
+}
+
+// ApplyOverrides sets the targets to the values of the environment variables SERENITY_<name>
+// that are set. Targets are *string, *int, *bool or *time.Duration; reporters use it to
+// apply the overrides of their settings after decoding them with ReporterSettings:
+//
+//	err := config.ApplyOverrides(map[string]any{"WEBHOOK_URL": &settings.URL})
+func ApplyOverrides(targets map[string]any) error {
+	for name, target := range targets {
+		value, ok := os.LookupEnv(envPrefix + name)
+		if !ok {
+			continue
+		}
+		var err error
+		switch target := target.(type) {
+		case *string:
+			*target = value
+		case *int:
+			*target, err = strconv.Atoi(value)
+		case *bool:
+			*target, err = strconv.ParseBool(value)
+		case *time.Duration:
+			*target, err = time.ParseDuration(value)
+		default:
+			err = fmt.Errorf("unsupported target %T", target)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid override %s: %w", envPrefix+name, err)
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// FindRunFile returns the path of the run configuration file: the one named by
+// SERENITY_RUN_CONFIG, or the nearest serenity.yaml in the working directory or its
+// parents up to the module root. It returns an empty path if there is none.
+func FindRunFile() (string, error) {
+	if path := os.Getenv(RunConfigEnvVar); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to find %s: %w", RunFileName, err)
+	}
+	for {
+		candidate := filepath.Join(dir, RunFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+var (
+	run     RunSettings
+	runErr  error
+	runOnce sync.Once
+	runMu   sync.RWMutex
+)
+
+// UseRunSettings makes settings the run settings returned by Run, e.g. in TestMain
+func UseRunSettings(settings RunSettings) {
+	runOnce.Do(func() {}) // Prevent a later lazy load from replacing settings
+
+	runMu.Lock()
+	defer runMu.Unlock()
+	run = settings
+	runErr = nil
+}
+
+// Run returns the settings of the test run. Unless UseRunSettings was called, they are
+// loaded on first use from the file found by FindRunFile; without a file, the default
+// settings with the environment overrides applied are used.
+func Run() (RunSettings, error) {
+	runOnce.Do(func() {
+		path, err := FindRunFile()
+		switch {
+		case err != nil:
+			runErr = err
+		case path == "":
+			run, runErr = withRunOverrides(DefaultRunSettings())
+		default:
+			run, runErr = LoadRunSettings(path)
+		}
+	})
+
+	runMu.RLock()
+	defer runMu.RUnlock()
+	return run, runErr
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const runYAML = `
+reporters: [console, transcript]
+outputDir: build/reports
+keepRuns: 3
+timeouts:
+  scenario: 2m
+  activity: 30s
+retries:
+  attempts: 3
+  delay: 1s
+parallel: true
+tags:
+  include: [smoke]
+  exclude: [slow]
+`
+
+func TestLoadRunSettings(t *testing.T) {
+	settings, err := LoadRunSettings(writeFile(t, RunFileName, runYAML))
+	require.NoError(t, err)
+	require.Equal(t, RunSettings{
+		Reporters:       []string{"console", "transcript"},
+		OutputDir:       "build/reports",
+		KeepRuns:        3,
+		ScenarioTimeout: 2 * time.Minute,
+		ActivityTimeout: 30 * time.Second,
+		Retries:         RetryPolicy{Attempts: 3, Delay: time.Second},
+		Parallel:        true,
+		Tags:            TagFilter{Include: []string{"smoke"}, Exclude: []string{"slow"}},
+	}, settings)
+
+	// Missing entries keep their defaults, and an empty list disables reporting
+	settings, err = LoadRunSettings(writeFile(t, RunFileName, "reporters: []\n"))
+	require.NoError(t, err)
+	require.Empty(t, settings.Reporters)
+	require.Equal(t, DefaultOutputDir, settings.OutputDir)
+	require.Equal(t, DefaultKeptRuns, settings.KeepRuns)
+	require.Equal(t, 1, settings.Retries.Attempts)
+}
+
+func TestLoadRunSettings_Errors(t *testing.T) {
+	_, err := LoadRunSettings(writeFile(t, RunFileName, "timeouts:\n  scenario: soon\n"))
+	require.ErrorContains(t, err, "invalid timeouts.scenario")
+
+	_, err = LoadRunSettings(writeFile(t, RunFileName, "retries:\n  attempts: -1\n"))
+	require.ErrorContains(t, err, "invalid retries.attempts -1")
+
+	t.Setenv("SERENITY_PARALLEL", "sometimes")
+	_, err = LoadRunSettings(writeFile(t, RunFileName, runYAML))
+	require.ErrorContains(t, err, "invalid override SERENITY_PARALLEL")
+}
+
+func TestRunSettingsEnvironmentOverrides(t *testing.T) {
+	t.Setenv("SERENITY_REPORTERS", "console, junit")
+	t.Setenv("SERENITY_OUTPUT_DIR", "out")
+	t.Setenv("SERENITY_KEEP_RUNS", "0")
+	t.Setenv("SERENITY_SCENARIO_TIMEOUT", "5m")
+	t.Setenv("SERENITY_RETRY_ATTEMPTS", "1")
+	t.Setenv("SERENITY_PARALLEL", "false")
+	t.Setenv("SERENITY_INCLUDE_TAGS", "")
+
+	settings, err := LoadRunSettings(writeFile(t, RunFileName, runYAML))
+	require.NoError(t, err)
+	require.Equal(t, []string{"console", "junit"}, settings.Reporters)
+	require.Equal(t, "out", settings.OutputDir)
+	require.Zero(t, settings.KeepRuns)
+	require.Equal(t, 5*time.Minute, settings.ScenarioTimeout)
+	require.Equal(t, 30*time.Second, settings.ActivityTimeout)
+	require.Equal(t, RetryPolicy{Attempts: 1, Delay: time.Second}, settings.Retries)
+	require.False(t, settings.Parallel)
+	require.Equal(t, TagFilter{Exclude: []string{"slow"}}, settings.Tags)
+}
+
+// notifierSettings stands in for the settings of a reporter
+type notifierSettings struct {
+	URL     string        `yaml:"url"`
+	Title   string        `yaml:"title"`
+	Retries int           `yaml:"retries"`
+	Timeout time.Duration `yaml:"timeout"`
+	Quiet   bool          `yaml:"quiet"`
+}
+
+func TestRunSettingsReporterSettings(t *testing.T) {
+	settings, err := LoadRunSettings(writeFile(t, RunFileName, runYAML+`
+reporting:
+  notifier:
+    url: https://hooks.example.com/run
+    retries: 2
+  broken:
+    retries: many
+`))
+	require.NoError(t, err, "reporters validate their own settings")
+
+	// Missing settings keep the values of the target
+	notifier := notifierSettings{Title: "Nightly"}
+	require.NoError(t, settings.ReporterSettings("notifier", &notifier))
+	require.Equal(t, notifierSettings{URL: "https://hooks.example.com/run", Title: "Nightly", Retries: 2}, notifier)
+
+	unset := notifierSettings{Title: "Nightly"}
+	require.NoError(t, settings.ReporterSettings("dashboard", &unset))
+	require.Equal(t, notifierSettings{Title: "Nightly"}, unset)
+
+	require.ErrorContains(t, settings.ReporterSettings("broken", &notifierSettings{}), "invalid reporting.broken")
+}
+
+func TestRunSettingsWithReporterSettings(t *testing.T) {
+	defaults := DefaultRunSettings()
+	settings, err := defaults.WithReporterSettings("notifier", notifierSettings{URL: "https://hooks.example.com/run", Quiet: true})
+	require.NoError(t, err)
+	require.Empty(t, defaults.Reporting, "the original settings are left unchanged")
+
+	var notifier notifierSettings
+	require.NoError(t, settings.ReporterSettings("notifier", &notifier))
+	require.Equal(t, notifierSettings{URL: "https://hooks.example.com/run", Quiet: true}, notifier)
+}
+
+func TestApplyOverrides(t *testing.T) {
+	t.Setenv("SERENITY_NOTIFIER_URL", "https://hooks.example.com/nightly")
+	t.Setenv("SERENITY_NOTIFIER_RETRIES", "4")
+	t.Setenv("SERENITY_NOTIFIER_TIMEOUT", "3s")
+	t.Setenv("SERENITY_NOTIFIER_QUIET", "true")
+
+	notifier := notifierSettings{Title: "Nightly"}
+	require.NoError(t, ApplyOverrides(map[string]any{
+		"NOTIFIER_URL":     &notifier.URL,
+		"NOTIFIER_TITLE":   &notifier.Title,
+		"NOTIFIER_RETRIES": &notifier.Retries,
+		"NOTIFIER_TIMEOUT": &notifier.Timeout,
+		"NOTIFIER_QUIET":   &notifier.Quiet,
+	}))
+	require.Equal(t, notifierSettings{URL: "https://hooks.example.com/nightly", Title: "Nightly", Retries: 4, Timeout: 3 * time.Second, Quiet: true}, notifier)
+
+	t.Setenv("SERENITY_NOTIFIER_RETRIES", "often")
+	require.ErrorContains(t, ApplyOverrides(map[string]any{"NOTIFIER_RETRIES": &notifier.Retries}), "invalid override SERENITY_NOTIFIER_RETRIES")
+}
+
+func TestFindRunFile(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "features", "checkout")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/shop\n"), 0600))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(nested))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	path, err := FindRunFile()
+	require.NoError(t, err)
+	require.Empty(t, path, "the search stops at the module root")
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, RunFileName), []byte(runYAML), 0600))
+	path, err = FindRunFile()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, RunFileName), path)
+
+	t.Setenv(RunConfigEnvVar, "ci/serenity.yaml")
+	path, err = FindRunFile()
+	require.NoError(t, err)
+	require.Equal(t, "ci/serenity.yaml", path)
+}
+
+func TestTagFilter(t *testing.T) {
+	filter := TagFilter{Include: []string{"smoke"}, Exclude: []string{"slow"}}
+
+	require.True(t, filter.Allows("smoke", "checkout"))
+	require.False(t, filter.Allows("smoke", "slow"), "exclusion takes precedence")
+	require.False(t, filter.Allows("checkout"))
+	require.True(t, TagFilter{}.Allows())
+}
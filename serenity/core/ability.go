@@ -0,0 +1,269 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// This file provides type-safe access to actor abilities using Go generics.
+//
+// Instead of the AbilityTo + type assertion dance:
+//
+//	ability, err := actor.AbilityTo(&api.CallAnAPI{})
+//	if err != nil {
+//		return err
+//	}
+//	callAbility := ability.(api.CallAnAPI)
+//
+// activities and questions can ask for the ability type directly:
+//
+//	callAbility, err := core.AbilityOf[api.CallAnAPI](actor)
+//	if err != nil {
+//		return err
+//	}
+
+// AbilityHolder is implemented by actors that can list the abilities they have.
+// Actors created by SerenityTest implement this interface.
+type AbilityHolder interface {
+	// Abilities returns a snapshot of the abilities the actor has, in the order they were added
+	Abilities() []abilities.Ability
+}
+
+// SharedAbilityUser is implemented by actors that can use the abilities shared by the
+// actors of a test. Actors created by SerenityTest implement this interface.
+type SharedAbilityUser interface {
+	// WhoCanUseShared gives the actor the shared abilities of the types, or all of them
+	// when no types are given, and returns the same actor for chaining
+	WhoCanUseShared(abilityTypes ...abilities.Ability) Actor
+}
+
+// WhoCanUseShared gives the actor abilities shared by all actors of the test, such as one
+// browser or one database pool, instead of abilities of its own. Shared abilities are
+// discarded once, after the last actor using them is done. The types are given as for
+// AbilityTo; none uses all shared abilities. It returns the actor for chaining, and panics
+// if the actor can't use shared abilities.
+//
+//	test.SharedAbility(db.UseADatabaseAt("postgres", dsn))
+//
+//	admin := core.WhoCanUseShared(test.ActorCalled("Admin"), (*db.UseADatabase)(nil))
+//	customer := core.WhoCanUseShared(test.ActorCalled("Customer"), (*db.UseADatabase)(nil))
+func WhoCanUseShared(actor Actor, abilityTypes ...abilities.Ability) Actor {
+	user, ok := actor.(SharedAbilityUser)
+	if !ok {
+		panic(fmt.Sprintf("WhoCanUseShared: actor '%s' can't use shared abilities", actor.Name()))
+	}
+	return user.WhoCanUseShared(abilityTypes...)
+}
+
+// AbilityOf returns the actor's ability of type T.
+// T can be either a concrete ability type or an interface implemented by the ability,
+// in which case the first ability implementing T is returned.
+//
+// Type Parameters:
+//   - T: The ability type to retrieve
+//
+// Parameters:
+//   - actor: The actor whose ability should be retrieved
+//
+// Returns:
+//   - T: The requested ability
+//   - error: Error listing the actor's available abilities if none matches T
+//
+// Example:
+//
+//	callAbility, err := core.AbilityOf[api.CallAnAPI](actor)
+//	if err != nil {
+//		return fmt.Errorf("actor needs API ability: %w", err)
+//	}
+//	resp := callAbility.LastResponse()
+func AbilityOf[T abilities.Ability](actor Actor) (T, error) {
+	var zero T
+
+	holder, ok := actor.(AbilityHolder)
+	if !ok {
+		// Fall back to type-based lookup for actors that can't list their abilities
+		ability, err := actor.AbilityTo(abilityTypeOf[T]())
+		if err != nil {
+			return zero, err
+		}
+		typed, ok := ability.(T)
+		if !ok {
+			return zero, fmt.Errorf("actor '%s' returned %s when asked for %s",
+				actor.Name(), AbilityName(ability), typeName[T]())
+		}
+		return typed, nil
+	}
+
+	available := holder.Abilities()
+	for _, ability := range available {
+		if typed, ok := ability.(T); ok {
+			return typed, nil
+		}
+	}
+
+	return zero, NewMissingAbilityError(actor.Name(), typeName[T](), available)
+}
+
+// MustAbilityOf returns the actor's ability of type T and panics if the actor doesn't have it.
+// Use it in test setup code where a missing ability is a programming error.
+//
+// Example:
+//
+//	callAbility := core.MustAbilityOf[api.CallAnAPI](actor)
+func MustAbilityOf[T abilities.Ability](actor Actor) T {
+	ability, err := AbilityOf[T](actor)
+	if err != nil {
+		panic(fmt.Sprintf("MustAbilityOf: %v", err))
+	}
+	return ability
+}
+
+// NewMissingAbilityError creates the error returned when an actor doesn't have a requested ability.
+// The message lists the abilities the actor actually has and, when one of them has a similar name,
+// suggests it as the closest match:
+//
+//	actor 'Bob' lacks api.CallAnAPI; has: examples.fileSystemAbility, api.callAnApi (did you mean *api.callAnApi?)
+//
+// Parameters:
+//   - actorName: Name of the actor that lacks the ability
+//   - requested: Human-readable name of the requested ability type
+//   - available: Abilities the actor has
+//
+// Returns:
+//   - error: A *MissingAbilityError, which matches ErrMissingAbility
+func NewMissingAbilityError(actorName string, requested string, available []abilities.Ability) error {
+	missing := &MissingAbilityError{
+		Actor:     actorName,
+		Requested: requested,
+		Available: abilityNames(available),
+	}
+
+	if match := closestAbility(requested, available); match != nil {
+		missing.Suggestion = reflect.TypeOf(match).String()
+	}
+
+	return missing
+}
+
+// AbilityName returns a human-readable name of the ability's type, e.g. "api.callAnAPI".
+// Pointer indirections are omitted.
+func AbilityName(ability abilities.Ability) string {
+	if ability == nil {
+		return "<nil>"
+	}
+	return typeString(reflect.TypeOf(ability))
+}
+
+// abilityNames returns the names of the abilities
+func abilityNames(available []abilities.Ability) []string {
+	names := make([]string, 0, len(available))
+	for _, ability := range available {
+		names = append(names, AbilityName(ability))
+	}
+	return names
+}
+
+// abilityTypeOf returns the type reference passed to Actor.AbilityTo for T:
+// a nil pointer to T when T is an interface, or the zero value of T otherwise
+func abilityTypeOf[T abilities.Ability]() abilities.Ability {
+	if reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Interface {
+		return (*T)(nil)
+	}
+	var zero T
+	return zero
+}
+
+// typeName returns a human-readable name of the type parameter T
+func typeName[T any]() string {
+	return typeString(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// typeString formats a type without pointer indirections
+func typeString(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+// closestAbility returns the available ability whose type name is most similar
+// to the requested name, or nil if none is similar enough to be a useful hint
+func closestAbility(requested string, available []abilities.Ability) abilities.Ability {
+	wanted := strings.ToLower(shortTypeName(requested))
+	if wanted == "" {
+		return nil
+	}
+
+	var best abilities.Ability
+	bestDistance := len(wanted)/3 + 1
+	for _, ability := range available {
+		candidate := strings.ToLower(shortTypeName(AbilityName(ability)))
+
+		distance := levenshtein(wanted, candidate)
+		if strings.Contains(candidate, wanted) || strings.Contains(wanted, candidate) {
+			distance = 0
+		}
+
+		if distance < bestDistance {
+			best = ability
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+// shortTypeName strips the package qualifier from a type name, e.g. "api.callAnAPI" -> "callAnAPI"
+func shortTypeName(name string) string {
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		return name[idx+1:]
+	}
+	return name
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// AbilityMatches reports whether an ability matches the requested ability type.
+// Actor implementations use it in AbilityTo.
+//
+// The requested type can be given in two forms:
+//
+//	actor.AbilityTo(&fileSystemAbility{})      // concrete type: matches abilities of exactly this type
+//	actor.AbilityTo((*FileSystemAbility)(nil)) // interface: matches any ability implementing FileSystemAbility
+func AbilityMatches(ability, abilityType abilities.Ability) bool {
+	if ability == nil || abilityType == nil {
+		return false
+	}
+
+	actual := reflect.TypeOf(ability)
+	requested := reflect.TypeOf(abilityType)
+
+	if requested.Kind() == reflect.Pointer && requested.Elem().Kind() == reflect.Interface {
+		return actual.Implements(requested.Elem())
+	}
+
+	return actual == requested
+}
@@ -1,9 +1,6 @@
 package core
 
-import (
-	"context"
-	"fmt"
-)
+import "context"
 
 // This file provides concrete implementations of the Activity interface
 // defined in interfaces.go. These implementations enable the creation
@@ -58,6 +55,9 @@ type task struct {
 
 	// activities contains the sequence of activities that compose this task
 	activities []Activity
+
+	// location is where the task was constructed
+	location Location
 }
 
 // Description returns the task's human-readable description.
@@ -102,16 +102,28 @@ func (t *task) Description() string {
 //	- The task description for identification
 //	- The specific activity that failed
 //	- The original error wrapped with context
+//
+//	The error is an *ActivityError and matches ErrActivityFailed.
 func (t *task) PerformAs(actor Actor, ctx context.Context) error {
 	for _, activity := range t.activities {
 		if err := activity.PerformAs(actor, ctx); err != nil {
-			return fmt.Errorf("task '%s' failed during activity '%s': %w",
-				t.Description(), activity.Description(), err)
+			return &ActivityError{
+				Actor:    actor.Name(),
+				Activity: t.Description(),
+				Step:     activity.Description(),
+				Location: LocationOf(activity),
+				Err:      err,
+			}
 		}
 	}
 	return nil
 }
 
+// Location returns where the task was constructed
+func (t *task) Location() Location {
+	return t.location
+}
+
 // FailureMode returns the failure mode for tasks.
 // Tasks use FailFast mode by default, meaning execution stops on first error.
 //
@@ -183,6 +195,7 @@ func TaskWhere(description string, activities ...Activity) Task {
 	return &task{
 		description: description,
 		activities:  activities,
+		location:    CallerLocation(),
 	}
 }
 
@@ -197,6 +210,9 @@ type interaction struct {
 
 	// perform is the function that executes when the interaction is performed
 	perform func(actor Actor, ctx context.Context) error
+
+	// location is where the interaction was constructed
+	location Location
 }
 
 // Do creates a new interaction with the given description and perform function.
@@ -290,9 +306,15 @@ func Do(description string, perform func(actor Actor, ctx context.Context) error
 	return &interaction{
 		description: description,
 		perform:     perform,
+		location:    CallerLocation(),
 	}
 }
 
+// Location returns where the interaction was constructed
+func (i *interaction) Location() Location {
+	return i.location
+}
+
 // Description returns the interaction's human-readable description.
 // This description is used in test reports and logging output.
 //
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// WorkBudget caps the total time a test run may spend performing activities.
+// Once the budget is exhausted, actors skip the remaining non-critical activities
+// (those whose FailureMode is not FailFast) and report them as skipped, which keeps
+// CI jobs within their time limits. Critical activities still run because skipping
+// them would invalidate the test.
+//
+// A budget starts counting when it is created, so declaring it as a package variable
+// shares one budget between all tests of the package:
+//
+//	var budget = core.NewWorkBudget(10 * time.Minute)
+//
+//	func TestCheckout(t *testing.T) {
+//		test := serenity.NewSerenityTest(t).WithWorkBudget(budget)
+//		...
+//	}
+type WorkBudget struct {
+	total    time.Duration
+	deadline time.Time
+}
+
+// NewWorkBudget creates a budget that is exhausted once the given duration has elapsed
+func NewWorkBudget(total time.Duration) *WorkBudget {
+	return &WorkBudget{total: total, deadline: time.Now().Add(total)}
+}
+
+// Remaining returns the time left in the budget, never less than zero
+func (b *WorkBudget) Remaining() time.Duration {
+	if b == nil {
+		return time.Duration(1<<63 - 1)
+	}
+	return max(time.Until(b.deadline), 0)
+}
+
+// Exhausted reports whether the budget has been used up; a nil budget is never exhausted
+func (b *WorkBudget) Exhausted() bool {
+	return b != nil && !time.Now().Before(b.deadline)
+}
+
+// Allows reports whether an activity may still be performed. Critical activities are
+// always allowed; the rest only while the budget lasts. The iterations of Repeat and
+// ForEach are as critical as the activities they perform.
+func (b *WorkBudget) Allows(activity Activity) bool {
+	return criticalityOf(activity) == FailFast || !b.Exhausted()
+}
+
+// SkipReason describes why activities are skipped once the budget is exhausted
+func (b *WorkBudget) SkipReason() string {
+	return fmt.Sprintf("work budget of %s exhausted", b.total)
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// This file defines the typed errors returned by the framework, so that custom tooling
+// and reporters can branch on failure categories instead of parsing messages:
+//
+//	var assertion *core.AssertionError
+//	switch {
+//	case errors.Is(err, core.ErrMissingAbility):
+//		// the test is misconfigured
+//	case errors.As(err, &assertion):
+//		fmt.Printf("expected %v, got %v\n", assertion.Expected, assertion.Actual)
+//	}
+
+var (
+	// ErrMissingAbility matches errors returned when an actor lacks a requested ability
+	ErrMissingAbility = errors.New("missing ability")
+
+	// ErrAssertionFailed matches errors returned when an answer does not meet an expectation
+	ErrAssertionFailed = errors.New("assertion failed")
+
+	// ErrActivityFailed matches errors returned when an activity of a task fails
+	ErrActivityFailed = errors.New("activity failed")
+
+	// ErrSkipped matches errors returned by activities that could not be performed and
+	// should be reported as skipped instead of failed
+	ErrSkipped = errors.New("activity skipped")
+
+	// ErrTestSkipped matches errors returned by activities that skip the rest of the test,
+	// e.g. a scenario that does not apply to the environment it runs in
+	ErrTestSkipped = errors.New("test skipped")
+)
+
+// MissingAbilityError is returned when an actor doesn't have a requested ability.
+// It matches ErrMissingAbility.
+type MissingAbilityError struct {
+	// Actor is the name of the actor that lacks the ability
+	Actor string
+	// Requested is the human-readable name of the requested ability type
+	Requested string
+	// Available lists the names of the abilities the actor has
+	Available []string
+	// Suggestion is the type of the available ability with the most similar name, if any
+	Suggestion string
+}
+
+// Error returns a message listing the available abilities and the suggestion
+func (e *MissingAbilityError) Error() string {
+	available := "no abilities"
+	if len(e.Available) > 0 {
+		available = strings.Join(e.Available, ", ")
+	}
+
+	message := fmt.Sprintf("actor '%s' lacks %s; has: %s", e.Actor, e.Requested, available)
+	if e.Suggestion != "" {
+		message += fmt.Sprintf(" (did you mean %s?)", e.Suggestion)
+	}
+	return message
+}
+
+// Is reports whether the target is ErrMissingAbility
+func (e *MissingAbilityError) Is(target error) bool {
+	return target == ErrMissingAbility
+}
+
+// AssertionError is returned by expectations when the actual value does not meet them.
+// It matches ErrAssertionFailed.
+type AssertionError struct {
+	// Expected describes what the expectation required, e.g. the expected value
+	Expected any
+	// Actual is the value that was evaluated
+	Actual any
+	// Message explains the mismatch
+	Message string
+	// Cause is the error the expectation returned, if it was not an AssertionError
+	Cause error
+}
+
+// NewAssertionError creates an assertion error with a formatted message
+func NewAssertionError(expected, actual any, format string, args ...any) *AssertionError {
+	return &AssertionError{
+		Expected: expected,
+		Actual:   actual,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// Error returns the message explaining the mismatch
+func (e *AssertionError) Error() string {
+	return e.Message
+}
+
+// Is reports whether the target is ErrAssertionFailed
+func (e *AssertionError) Is(target error) bool {
+	return target == ErrAssertionFailed
+}
+
+// Unwrap returns the error the expectation returned, if any
+func (e *AssertionError) Unwrap() error {
+	return e.Cause
+}
+
+// ActivityError is returned by composite activities when one of their steps fails.
+// It matches ErrActivityFailed and unwraps to the error of the failing step.
+type ActivityError struct {
+	// Actor is the name of the actor performing the activity
+	Actor string
+	// Activity is the description of the composite activity, e.g. a task
+	Activity string
+	// Step is the description of the activity within it that failed
+	Step string
+	// Location is where the failing step was constructed, if known
+	Location Location
+	// Err is the error returned by the failing step
+	Err error
+}
+
+// Error returns a message naming the activity and the failing step
+func (e *ActivityError) Error() string {
+	return fmt.Sprintf("task '%s' failed during activity '%s': %v", e.Activity, e.Step, e.Err)
+}
+
+// Unwrap returns the error of the failing step
+func (e *ActivityError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrActivityFailed
+func (e *ActivityError) Is(target error) bool {
+	return target == ErrActivityFailed
+}
+
+// SkippedError is returned by an activity that should be reported as skipped, e.g. an
+// assertion about a question that has no answer yet. It matches ErrSkipped and unwraps
+// to the error that caused the skip.
+type SkippedError struct {
+	// Reason explains why the activity was skipped
+	Reason string
+	// Err is the error that caused the skip, if any
+	Err error
+}
+
+// NewSkippedError creates an error skipping an activity for the reason
+func NewSkippedError(reason string, err error) *SkippedError {
+	return &SkippedError{Reason: reason, Err: err}
+}
+
+// Error returns the reason and the error that caused the skip
+func (e *SkippedError) Error() string {
+	if e.Err == nil {
+		return e.Reason
+	}
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+// Unwrap returns the error that caused the skip
+func (e *SkippedError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrSkipped
+func (e *SkippedError) Is(target error) bool {
+	return target == ErrSkipped
+}
+
+// SkipError is returned by an activity to skip the rest of the test: the activity and the
+// remaining ones are reported as skipped and the test is skipped. It matches ErrTestSkipped
+// and ErrSkipped.
+type SkipError struct {
+	// Reason explains why the test was skipped
+	Reason string
+}
+
+// NewSkipError creates an error skipping the rest of the test for the reason
+func NewSkipError(reason string) *SkipError {
+	return &SkipError{Reason: reason}
+}
+
+// Error returns the reason
+func (e *SkipError) Error() string {
+	return e.Reason
+}
+
+// Is reports whether the target is ErrTestSkipped or ErrSkipped
+func (e *SkipError) Is(target error) bool {
+	return target == ErrTestSkipped || target == ErrSkipped
+}
+
+// FailureModeError overrides the failure mode of the activity that returned it, so that an
+// activity can handle some of its failures differently from the others. It unwraps to
+// the original error.
+type FailureModeError struct {
+	// Mode is the failure mode to handle the error with
+	Mode FailureMode
+	// Err is the original error
+	Err error
+}
+
+// WithFailureModeOverride wraps the error so that it is handled with the failure mode
+func WithFailureModeOverride(err error, mode FailureMode) error {
+	if err == nil {
+		return nil
+	}
+	return &FailureModeError{Mode: mode, Err: err}
+}
+
+// Error returns the message of the original error
+func (e *FailureModeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the original error
+func (e *FailureModeError) Unwrap() error {
+	return e.Err
+}
+
+// FailureModeFor returns the failure mode to handle the error of the activity with: the
+// mode carried by a FailureModeError, or the activity's own failure mode
+func FailureModeFor(activity Activity, err error) FailureMode {
+	var override *FailureModeError
+	if errors.As(err, &override) {
+		return override.Mode
+	}
+	return activity.FailureMode()
+}
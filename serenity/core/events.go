@@ -0,0 +1,12 @@
+package core
+
+import "github.com/nchursin/serenity-go/serenity/events"
+
+// Publish passes the event to the actor if it publishes events, and does nothing otherwise.
+// Activities use it to announce what happened beyond their own start and finish,
+// for example ensure.That publishes events.AssertionFailed.
+func Publish(actor Actor, event events.Event) {
+	if publisher, ok := actor.(events.Publisher); ok {
+		publisher.Publish(event)
+	}
+}
@@ -133,6 +133,7 @@ import (
 	"time"
 
 	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/reporting"
 )
 
 // Actor represents a person or external system interacting with the system under test.
@@ -163,7 +164,7 @@ import (
 //	WhoCan() adds abilities to the actor, returning the same actor for chaining.
 //	AbilityTo() retrieves a specific ability by type for use in activities.
 //	AttemptsTo() executes one or more activities sequentially.
-//	AnswersTo() answers questions about system state (legacy method).
+//	AnswersTo() answers questions about system state (deprecated, use core.Answer).
 //
 // Example Usage:
 //
@@ -238,7 +239,12 @@ type Actor interface {
 	// Returns an error if the actor doesn't have the requested ability.
 	//
 	// Parameters:
-	//   - ability: A zero-value instance of the ability type to retrieve
+	//   - ability: A zero-value instance of the concrete ability type to retrieve,
+	//     or a nil pointer to an ability interface to retrieve any implementation of it
+	//
+	// Lookup by interface lets fake and real implementations be swapped freely:
+	//
+	//	ability, err := actor.AbilityTo((*api.CallAnAPI)(nil))
 	//
 	// Returns:
 	//   - abilities.Ability: The requested ability instance
@@ -294,7 +300,7 @@ type Actor interface {
 	AttemptsTo(activities ...Activity)
 
 	// AnswersTo answers a question about the system state.
-	// This is a legacy method - prefer using Question.AnsweredBy() directly.
+	// This is a legacy method - prefer using core.Answer.
 	//
 	// Parameters:
 	//   - question: The question to answer
@@ -313,10 +319,13 @@ type Actor interface {
 	//
 	// Recommended approach:
 	//
-	//	count, err := userCountQuestion.AnsweredBy(actor)
+	//	count, err := core.Answer(actor, userCountQuestion)
 	//	if err != nil {
 	//		return fmt.Errorf("failed to get user count: %w", err)
 	//	}
+	//
+	// Deprecated: Use core.Answer, which returns a typed answer and the error, or
+	// serenity.MustAnswer in tests.
 	AnswersTo(question Question[any]) (any, bool)
 }
 
@@ -760,6 +769,10 @@ type Question[T any] interface {
 //
 //	// Human-readable output
 //	fmt.Printf("Test %s: %s (%v)\n", result.Name, result.Status, result.Duration)
+//
+// Deprecated: Use reporting.TestResult, the result model shared by all reporters, and
+// convert with ToReporting and TestResultOf meanwhile. TestResult will be removed in a
+// future release.
 type TestResult struct {
 	// Name is the human-readable name or description of the test
 	Name string `json:"name"`
@@ -800,6 +813,9 @@ type TestResult struct {
 //
 //	// Get human-readable status
 //	fmt.Printf("Test status: %s\n", result.Status.String())
+//
+// Deprecated: Use reporting.Status, and convert with ToReporting and StatusOf meanwhile.
+// Status will be removed in a future release.
 type Status int
 
 const (
@@ -853,3 +869,59 @@ func (s Status) String() string {
 		return "unknown"
 	}
 }
+
+// ToReporting converts the status to the reporting status, and reports whether it has one:
+// pending and running tests have no outcome yet.
+//
+// Deprecated: Use reporting.Status.
+func (s Status) ToReporting() (reporting.Status, bool) {
+	switch s {
+	case StatusPassed:
+		return reporting.StatusPassed, true
+	case StatusFailed:
+		return reporting.StatusFailed, true
+	case StatusSkipped:
+		return reporting.StatusSkipped, true
+	default:
+		return reporting.StatusSkipped, false
+	}
+}
+
+// StatusOf converts the reporting status to a status.
+//
+// Deprecated: Use reporting.Status.
+func StatusOf(status reporting.Status) Status {
+	switch status {
+	case reporting.StatusPassed:
+		return StatusPassed
+	case reporting.StatusFailed:
+		return StatusFailed
+	case reporting.StatusSkipped:
+		return StatusSkipped
+	default:
+		return StatusPending
+	}
+}
+
+// ToReporting converts the result to a reporting result. Results of tests that are
+// pending or running are reported as skipped.
+//
+// Deprecated: Use reporting.TestResult.
+func (r *TestResult) ToReporting() reporting.TestResult {
+	status, _ := r.Status.ToReporting()
+	return reporting.NewResult(r.Name, status).
+		WithTimings(time.Time{}, r.Duration).
+		WithError(r.Error)
+}
+
+// TestResultOf converts the reporting result of a test to a result, without its steps.
+//
+// Deprecated: Use reporting.TestResult.
+func TestResultOf(result reporting.TestResult) *TestResult {
+	return &TestResult{
+		Name:     result.Name(),
+		Status:   StatusOf(result.Status()),
+		Duration: result.Duration(),
+		Error:    result.Error(),
+	}
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// frameworkPackage is the import path prefix of the framework's own packages,
+// whose frames are skipped when looking for the caller that constructed an activity
+const frameworkPackage = "github.com/nchursin/serenity-go/serenity/"
+
+// Location is the place in the source code where an activity was constructed
+type Location struct {
+	File string
+	Line int
+}
+
+// String returns the location as "file:line", or an empty string for an unknown location
+func (l Location) String() string {
+	if l.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// IsZero reports whether the location is unknown
+func (l Location) IsZero() bool {
+	return l.File == ""
+}
+
+// Locatable is implemented by activities that know where they were constructed.
+// Failure messages and reports include the location so that a failing step can be
+// opened directly in large suites.
+type Locatable interface {
+	// Location returns the place where the activity was constructed
+	Location() Location
+}
+
+// CallerLocation returns the location of the first caller outside the framework.
+// Activity constructors call it to record where the activity was created:
+//
+//	func SendReport(name string) core.Activity {
+//		return &sendReport{name: name, location: core.CallerLocation()}
+//	}
+func CallerLocation() Location {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !isFrameworkFrame(frame) {
+			return Location{File: frame.File, Line: frame.Line}
+		}
+		if !more {
+			return Location{}
+		}
+	}
+}
+
+// isFrameworkFrame reports whether the frame belongs to the framework's own, non-test code
+func isFrameworkFrame(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+	return strings.HasPrefix(frame.Function, frameworkPackage) || strings.HasPrefix(frame.Function, "runtime.")
+}
+
+// LocationOf returns where the activity was constructed, or a zero location if it doesn't know
+func LocationOf(activity Activity) Location {
+	if locatable, ok := activity.(Locatable); ok {
+		return locatable.Location()
+	}
+	return Location{}
+}
+
+// FailureLocation returns the location of the innermost step that failed with err,
+// falling back to the location of the activity itself
+func FailureLocation(activity Activity, err error) Location {
+	location := LocationOf(activity)
+	for ; err != nil; err = errors.Unwrap(err) {
+		if activityErr, ok := err.(*ActivityError); ok && !activityErr.Location.IsZero() {
+			location = activityErr.Location
+		}
+	}
+	return location
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PacedActor is implemented by actors whose activities can be paced.
+// Actors created by SerenityTest and the virtual actors of load swarms implement this
+// interface.
+type PacedActor interface {
+	// WithPacing limits the actor to the given number of activities per second, 0 for no
+	// limit, and returns the same actor for chaining
+	WithPacing(activitiesPerSecond float64) Actor
+}
+
+// WithPacing limits how fast the actor performs activities, which keeps acceptance suites
+// from exceeding the rate limits of third-party APIs. Every activity passed to AttemptsTo,
+// including sub-steps of composite activities, waits for its turn; 0 disables pacing.
+// It calls the actor's WithPacing method and returns the actor for chaining; actors that
+// don't implement PacedActor aren't paced and are returned unchanged.
+//
+//	partner := core.WithPacing(test.ActorCalled("PartnerClient").
+//		WhoCan(api.CallAnApiAt("https://partner.example.com")), 10) // at most 10 requests per second
+func WithPacing(actor Actor, activitiesPerSecond float64) Actor {
+	paced, ok := actor.(PacedActor)
+	if !ok {
+		return actor
+	}
+	return paced.WithPacing(activitiesPerSecond)
+}
+
+// Pacer spaces out activities so that they start at most at a fixed rate.
+// Actors use it to implement PacedActor; a nil Pacer never waits.
+type Pacer struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewPacer creates a pacer allowing the given number of activities per second.
+// It returns nil, which disables pacing, when activitiesPerSecond is not positive.
+func NewPacer(activitiesPerSecond float64) *Pacer {
+	if activitiesPerSecond <= 0 {
+		return nil
+	}
+	return &Pacer{interval: time.Duration(float64(time.Second) / activitiesPerSecond)}
+}
+
+// Wait blocks until the next activity may start or the context is done
+func (p *Pacer) Wait(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	p.mutex.Lock()
+	now := time.Now()
+	start := p.next
+	if start.Before(now) {
+		start = now
+	}
+	p.next = start.Add(p.interval)
+	p.mutex.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -257,3 +257,36 @@ func (q *question[T]) AnsweredBy(actor Actor, ctx context.Context) (T, error) {
 func Of[T any](description string, ask func(actor Actor, ctx context.Context) (T, error)) Question[T] {
 	return NewQuestion(description, ask)
 }
+
+// AnswerRecorder is implemented by actors that keep a record of the answers they receive,
+// for example to include them in reports or session transcripts
+type AnswerRecorder interface {
+	// RecordAnswer records the answer to the described question
+	RecordAnswer(question string, answer any)
+}
+
+// RecordAnswer passes the answer to the actor if it records answers, and does nothing otherwise.
+// Activities that ask questions, such as ensure.That, call it after receiving an answer.
+func RecordAnswer(actor Actor, question string, answer any) {
+	if recorder, ok := actor.(AnswerRecorder); ok {
+		recorder.RecordAnswer(question, answer)
+	}
+}
+
+// Answer asks the actor the question within the actor's context and records the answer.
+// It replaces the untyped Actor.AnswersTo:
+//
+//	count, err := core.Answer(actor, NumberOfOrders{})
+//	if err != nil {
+//		return err
+//	}
+//	// count is an int
+func Answer[T any](actor Actor, question Question[T]) (T, error) {
+	answer, err := question.AnsweredBy(actor, actor.Context())
+	if err != nil {
+		return answer, fmt.Errorf("failed to answer question '%s': %w", question.Description(), err)
+	}
+
+	RecordAnswer(actor, question.Description(), answer)
+	return answer, nil
+}
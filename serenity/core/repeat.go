@@ -0,0 +1,305 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// This file provides activity combinators that turn bulk operations into
+// declarative activities. Each iteration is performed through the actor,
+// so reporters show every iteration as a nested sub-step of the combinator.
+//
+// Factory Functions:
+//
+//	Repeat()  - Performs an activity a fixed number of times
+//	ForEach() - Performs an activity for each item answered by a question
+//
+// Usage Examples:
+//
+//	// Create 50 users
+//	actor.AttemptsTo(
+//		core.Repeat(50, func(i int) core.Activity {
+//			return api.SendPostRequest("/users").WithBody(newUser(i))
+//		}),
+//	)
+//
+//	// Verify each order line
+//	actor.AttemptsTo(
+//		core.ForEach(orderLines, func(line OrderLine) core.Activity {
+//			return ensure.That(answerable.ValueOf[any](line.Quantity), expectations.IsGreaterThan(0))
+//		}),
+//	)
+
+// repeat implements the Activity interface for a fixed number of iterations.
+//
+// Type repeat is private - use Repeat() factory function to create instances.
+type repeat struct {
+	// times is the number of iterations to perform
+	times int
+
+	// activityFactory creates the activity for the given zero-based iteration
+	activityFactory func(iteration int) Activity
+
+	// location is where the activity was constructed
+	location Location
+}
+
+// Repeat creates an activity that performs the activity produced by
+// activityFactory the given number of times. The factory receives the
+// zero-based iteration index, so each iteration can use its own data.
+//
+// Iterations run sequentially and stop at the first failure (FailFast behavior).
+//
+// Parameters:
+//   - times: Number of iterations to perform
+//   - activityFactory: Function that creates the activity for each iteration
+//
+// Returns:
+//   - Activity: An activity that performs all iterations
+//
+// Example:
+//
+//	actor.AttemptsTo(
+//		core.Repeat(3, func(i int) core.Activity {
+//			return api.SendPostRequest("/users").WithBody(map[string]any{"name": fmt.Sprintf("user-%d", i)})
+//		}),
+//	)
+func Repeat(times int, activityFactory func(iteration int) Activity) Activity {
+	if activityFactory == nil {
+		panic("Repeat: activityFactory parameter cannot be nil")
+	}
+	return &repeat{
+		times:           times,
+		activityFactory: activityFactory,
+		location:        CallerLocation(),
+	}
+}
+
+// Description returns the activity description
+func (r *repeat) Description() string {
+	return fmt.Sprintf("#actor repeats an activity %d times", r.times)
+}
+
+// PerformAs performs every iteration as the given actor
+func (r *repeat) PerformAs(actor Actor, ctx context.Context) error {
+	for i := 0; i < r.times; i++ {
+		activity := r.activityFactory(i)
+		if activity == nil {
+			return fmt.Errorf("iteration %d of %d produced no activity", i+1, r.times)
+		}
+
+		if err := PerformAsSubStep(actor, ctx, activity); err != nil {
+			return fmt.Errorf("iteration %d of %d failed during activity '%s': %w",
+				i+1, r.times, activity.Description(), err)
+		}
+	}
+	return nil
+}
+
+// Location returns where the activity was constructed
+func (r *repeat) Location() Location {
+	return r.location
+}
+
+// FailureMode returns the failure mode for repeat activities (default: FailFast)
+func (r *repeat) FailureMode() FailureMode {
+	return FailFast
+}
+
+// forEach implements the Activity interface for iterating over a question's answer.
+//
+// Type forEach is private - use ForEach() factory function to create instances.
+type forEach[T any] struct {
+	// items answers the slice of items to iterate over
+	items Question[[]T]
+
+	// activityFactory creates the activity for the given item
+	activityFactory func(item T) Activity
+
+	// location is where the activity was constructed
+	location Location
+}
+
+// ForEach creates an activity that answers the items question and performs
+// the activity produced by activityFactory for each item in the answer.
+// The question is answered when the activity is performed, not when it is created.
+//
+// Iterations run sequentially and stop at the first failure (FailFast behavior).
+//
+// Type Parameters:
+//   - T: The type of items answered by the question
+//
+// Parameters:
+//   - items: Question that answers the items to iterate over
+//   - activityFactory: Function that creates the activity for each item
+//
+// Returns:
+//   - Activity: An activity that performs an activity for every item
+//
+// Example:
+//
+//	actor.AttemptsTo(
+//		core.ForEach(answerable.ValueOf([]string{"/users", "/orders"}), func(path string) core.Activity {
+//			return api.SendGetRequest(path)
+//		}),
+//	)
+func ForEach[T any](items Question[[]T], activityFactory func(item T) Activity) Activity {
+	if items == nil {
+		panic("ForEach: items parameter cannot be nil")
+	}
+	if activityFactory == nil {
+		panic("ForEach: activityFactory parameter cannot be nil")
+	}
+	return &forEach[T]{
+		items:           items,
+		activityFactory: activityFactory,
+		location:        CallerLocation(),
+	}
+}
+
+// Description returns the activity description
+func (f *forEach[T]) Description() string {
+	return fmt.Sprintf("#actor performs an activity for each of %s", f.items.Description())
+}
+
+// PerformAs answers the items question and performs an activity for every item
+func (f *forEach[T]) PerformAs(actor Actor, ctx context.Context) error {
+	items, err := f.items.AnsweredBy(actor, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to answer question '%s': %w", f.items.Description(), err)
+	}
+
+	for i, item := range items {
+		activity := f.activityFactory(item)
+		if activity == nil {
+			return fmt.Errorf("item %d of %d produced no activity", i+1, len(items))
+		}
+
+		if err := PerformAsSubStep(actor, ctx, activity); err != nil {
+			return fmt.Errorf("item %d of %d failed during activity '%s': %w",
+				i+1, len(items), activity.Description(), err)
+		}
+	}
+	return nil
+}
+
+// Location returns where the activity was constructed
+func (f *forEach[T]) Location() Location {
+	return f.location
+}
+
+// FailureMode returns the failure mode for for-each activities (default: FailFast)
+func (f *forEach[T]) FailureMode() FailureMode {
+	return FailFast
+}
+
+// subStep wraps an activity performed from within another activity.
+// It is handed to the actor's AttemptsTo so that the actor reports it as a
+// nested step, while the error is captured and returned to the enclosing
+// activity instead of being handled by the actor.
+type subStep struct {
+	activity  Activity
+	ctx       context.Context
+	performed bool
+	skipped   bool
+	err       error
+}
+
+// Description returns the description of the wrapped activity
+func (s *subStep) Description() string {
+	return s.activity.Description()
+}
+
+// PerformAs performs the wrapped activity and records its outcome
+func (s *subStep) PerformAs(actor Actor, ctx context.Context) error {
+	s.performed = true
+	s.err = s.activity.PerformAs(actor, ctx)
+	return s.err
+}
+
+// FailureMode returns Ignore so that the enclosing activity decides how the failure is handled
+func (s *subStep) FailureMode() FailureMode {
+	return Ignore
+}
+
+// Metadata returns the metadata of the wrapped activity
+func (s *subStep) Metadata() map[string]string {
+	return MetadataOf(s.activity)
+}
+
+// PerformAsSubStep performs the activity through the actor so it is reported
+// as a sub-step, and returns the activity's error to the caller instead of
+// letting the actor handle it. Composite activities use it to perform their parts.
+// The sub-step is performed within ctx, the context of the enclosing activity, and
+// actors retry it on its own: its error is marked so that the enclosing activity is
+// not retried as a whole (see IsSubStepFailure).
+// A sub-step the actor skipped, e.g. once its work budget is exhausted, is not
+// performed. Actors that neither perform nor skip activities passed to AttemptsTo
+// (e.g. test doubles) fall back to performing the activity directly.
+func PerformAsSubStep(actor Actor, ctx context.Context, activity Activity) error {
+	step := &subStep{activity: activity, ctx: ctx}
+	actor.AttemptsTo(step)
+	if !step.performed && !step.skipped {
+		return activity.PerformAs(actor, ctx)
+	}
+	if step.err != nil {
+		return &subStepError{err: step.err}
+	}
+	return nil
+}
+
+// subStepError is the error of a sub-step the actor performed, and retried if its
+// retry policy says so
+type subStepError struct {
+	err error
+}
+
+// Error returns the message of the error of the sub-step
+func (e *subStepError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error of the sub-step
+func (e *subStepError) Unwrap() error {
+	return e.err
+}
+
+// IsSubStepFailure reports whether the error is, or wraps, that of a sub-step performed
+// through PerformAsSubStep. Actors retry sub-steps as they perform them, so they don't
+// retry the activities enclosing them for such errors: a flaky iteration is retried
+// without repeating the iterations that succeeded.
+func IsSubStepFailure(err error) bool {
+	var failure *subStepError
+	return errors.As(err, &failure)
+}
+
+// SubStepContext returns the context of the activity enclosing the sub-step, and whether
+// the activity is a sub-step. Actors perform sub-steps within it, so that the deadline
+// and cancellation of the enclosing activity bound its steps.
+func SubStepContext(activity Activity) (context.Context, bool) {
+	step, ok := activity.(*subStep)
+	if !ok || step.ctx == nil {
+		return nil, false
+	}
+	return step.ctx, true
+}
+
+// MarkSkipped records that the actor skipped the activity instead of performing it, so
+// that a sub-step the actor skipped is not performed by PerformAsSubStep. Actors call it
+// for every activity they skip.
+func MarkSkipped(activity Activity) {
+	if step, ok := activity.(*subStep); ok {
+		step.skipped = true
+	}
+}
+
+// criticalityOf returns the failure mode deciding whether the activity is critical: that
+// of the activity a sub-step performs, since sub-steps leave their failures to the
+// enclosing activity
+func criticalityOf(activity Activity) FailureMode {
+	if step, ok := activity.(*subStep); ok {
+		return step.activity.FailureMode()
+	}
+	return activity.FailureMode()
+}
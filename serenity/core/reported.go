@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// IssuesKey is the metadata key under which the issues an activity is linked to are
+// stored, separated by ", "
+const IssuesKey = "issues"
+
+// Annotated is implemented by activities that carry structured metadata for reports, such as
+// ticket IDs or links to requirements
+type Annotated interface {
+	// Metadata returns the metadata of the activity
+	Metadata() map[string]string
+}
+
+// MetadataOf returns the metadata of the activity, or nil if it has none
+func MetadataOf(activity Activity) map[string]string {
+	if annotated, ok := activity.(Annotated); ok {
+		return annotated.Metadata()
+	}
+	return nil
+}
+
+// ReportedActivity performs an activity under a business-language name and with metadata
+// for reports, while keeping its behavior and failure mode:
+//
+//	actor.AttemptsTo(
+//		core.WithReportedName(api.SendPostRequest("/customers").WithBody(customer), "Given an existing customer").
+//			WithMetadata("ticket", "SHOP-42"),
+//	)
+type ReportedActivity struct {
+	activity Activity
+	name     string
+	metadata map[string]string
+}
+
+// WithReportedName reports the activity under the name instead of its description
+func WithReportedName(activity Activity, name string) *ReportedActivity {
+	return reported(activity).WithReportedName(name)
+}
+
+// WithMetadata attaches the metadata entry to the activity for reports
+func WithMetadata(activity Activity, key, value string) *ReportedActivity {
+	return reported(activity).WithMetadata(key, value)
+}
+
+// LinkedToIssues links the activity to issues, e.g. "JIRA-123", for traceability reports
+func LinkedToIssues(activity Activity, issues ...string) *ReportedActivity {
+	return reported(activity).LinkedToIssues(issues...)
+}
+
+// Issues returns the issues listed in metadata under IssuesKey
+func Issues(metadata map[string]string) []string {
+	if metadata[IssuesKey] == "" {
+		return nil
+	}
+	return strings.Split(metadata[IssuesKey], ", ")
+}
+
+// reported wraps the activity, unless it is already wrapped
+func reported(activity Activity) *ReportedActivity {
+	if r, ok := activity.(*ReportedActivity); ok {
+		return r
+	}
+	return &ReportedActivity{activity: activity}
+}
+
+// WithReportedName reports the activity under the name instead of its description
+func (r *ReportedActivity) WithReportedName(name string) *ReportedActivity {
+	r.name = name
+	return r
+}
+
+// WithMetadata attaches the metadata entry to the activity for reports
+func (r *ReportedActivity) WithMetadata(key, value string) *ReportedActivity {
+	if r.metadata == nil {
+		r.metadata = make(map[string]string)
+	}
+	r.metadata[key] = value
+	return r
+}
+
+// LinkedToIssues links the activity to issues in addition to those it is already linked to
+func (r *ReportedActivity) LinkedToIssues(issues ...string) *ReportedActivity {
+	linked := Issues(r.metadata)
+	for _, issue := range issues {
+		if !slices.Contains(linked, issue) {
+			linked = append(linked, issue)
+		}
+	}
+	return r.WithMetadata(IssuesKey, strings.Join(linked, ", "))
+}
+
+// Description returns the reported name, or the description of the activity if none is set
+func (r *ReportedActivity) Description() string {
+	if r.name != "" {
+		return r.name
+	}
+	return r.activity.Description()
+}
+
+// PerformAs performs the activity
+func (r *ReportedActivity) PerformAs(actor Actor, ctx context.Context) error {
+	return r.activity.PerformAs(actor, ctx)
+}
+
+// FailureMode returns the failure mode of the activity
+func (r *ReportedActivity) FailureMode() FailureMode {
+	return r.activity.FailureMode()
+}
+
+// Location returns where the activity was constructed
+func (r *ReportedActivity) Location() Location {
+	return LocationOf(r.activity)
+}
+
+// Metadata returns the metadata of the activity together with the entries attached to it
+func (r *ReportedActivity) Metadata() map[string]string {
+	metadata := maps.Clone(MetadataOf(r.activity))
+	if metadata == nil {
+		metadata = make(map[string]string, len(r.metadata))
+	}
+	maps.Copy(metadata, r.metadata)
+	return metadata
+}
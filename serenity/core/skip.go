@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// skipIf implements the Activity interface for a condition that skips the rest of the test.
+//
+// Type skipIf is private - use SkipIf() factory function to create instances.
+type skipIf struct {
+	// condition decides whether the test is skipped
+	condition Question[bool]
+
+	// reason explains why the test is skipped
+	reason string
+
+	// location is where the activity was constructed
+	location Location
+}
+
+// SkipIf creates an activity that skips the rest of the test for the reason when the
+// question answers true, so environment-gated scenarios are reported as skipped rather
+// than passed or failed. The activity and the remaining ones are reported with
+// StatusSkipped, and test actors skip the test through TestContext.Skip.
+//
+// A predicate becomes a question with Of:
+//
+//	actor.AttemptsTo(
+//		core.SkipIf(core.Of("the payment sandbox is unavailable", func(actor core.Actor, ctx context.Context) (bool, error) {
+//			return os.Getenv("PAYMENTS_SANDBOX_URL") == "", nil
+//		}), "payments run only against the sandbox"),
+//		payments.PayByCard(card),
+//	)
+//
+// A condition that cannot be answered fails the activity.
+func SkipIf(condition Question[bool], reason string) Activity {
+	if condition == nil {
+		panic("SkipIf: condition parameter cannot be nil")
+	}
+	return &skipIf{
+		condition: condition,
+		reason:    reason,
+		location:  CallerLocation(),
+	}
+}
+
+// Description returns the activity description, e.g. "#actor skips the test if the
+// sandbox is unavailable"
+func (s *skipIf) Description() string {
+	return fmt.Sprintf("#actor skips the test if %s", strings.TrimPrefix(s.condition.Description(), "asks "))
+}
+
+// PerformAs answers the condition and returns a SkipError if it holds
+func (s *skipIf) PerformAs(actor Actor, ctx context.Context) error {
+	skip, err := Answer(actor, s.condition)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return NewSkipError(s.reason)
+	}
+	return nil
+}
+
+// FailureMode returns FailFast, as a condition that cannot be answered leaves the
+// scenario's applicability unknown
+func (s *skipIf) FailureMode() FailureMode {
+	return FailFast
+}
+
+// Location returns where the activity was constructed
+func (s *skipIf) Location() Location {
+	return s.location
+}
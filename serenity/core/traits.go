@@ -0,0 +1,130 @@
+package core
+
+import (
+	"sync"
+)
+
+// Trait is a characteristic of an actor, such as being an administrator, that reusable
+// activities and questions can adapt to
+type Trait string
+
+// TraitHolder is implemented by actors that carry traits and attributes
+type TraitHolder interface {
+	// HasTrait reports whether the actor was given the trait
+	HasTrait(trait Trait) bool
+	// Attribute returns the value of the attribute, and whether it is set
+	Attribute(key string) (string, bool)
+}
+
+// TraitBearer is implemented by actors that can be given traits and attributes.
+// Actors created by SerenityTest and the virtual actors of load swarms implement this
+// interface.
+type TraitBearer interface {
+	// Is gives the actor the traits and returns the same actor for chaining
+	Is(traits ...Trait) Actor
+	// With sets the attribute of the actor and returns the same actor for chaining
+	With(key, value string) Actor
+}
+
+// Is gives the actor traits, such as "admin", that reusable activities and questions can
+// adapt to with HasTrait. It calls the actor's Is method and returns the actor for
+// chaining; actors that don't implement TraitBearer are returned unchanged.
+//
+//	admin := core.Is(test.ActorCalled("Ada"), "admin")
+func Is(actor Actor, traits ...Trait) Actor {
+	bearer, ok := actor.(TraitBearer)
+	if !ok {
+		return actor
+	}
+	return bearer.Is(traits...)
+}
+
+// With sets an attribute of the actor, such as the tenant it acts for, that reusable
+// activities and questions can read with AttributeOf. It calls the actor's With method and
+// returns the actor for chaining; actors that don't implement TraitBearer are returned
+// unchanged.
+//
+//	admin := core.With(core.Is(test.ActorCalled("Ada"), "admin"), "tenant", "acme")
+func With(actor Actor, key, value string) Actor {
+	bearer, ok := actor.(TraitBearer)
+	if !ok {
+		return actor
+	}
+	return bearer.With(key, value)
+}
+
+// HasTrait reports whether the actor has the trait. Actors that carry no traits have none.
+//
+//	func OpenOrdersPage() core.Activity {
+//		return core.Do("#actor opens the orders page", func(actor core.Actor, ctx context.Context) error {
+//			if core.HasTrait(actor, "admin") {
+//				return api.SendGetRequest("/admin/orders").PerformAs(actor, ctx)
+//			}
+//			return api.SendGetRequest("/orders").PerformAs(actor, ctx)
+//		})
+//	}
+func HasTrait(actor Actor, trait Trait) bool {
+	if holder, ok := actor.(TraitHolder); ok {
+		return holder.HasTrait(trait)
+	}
+	return false
+}
+
+// AttributeOf returns the value of the actor's attribute, e.g. the tenant it acts for, and
+// whether it is set
+func AttributeOf(actor Actor, key string) (string, bool) {
+	if holder, ok := actor.(TraitHolder); ok {
+		return holder.Attribute(key)
+	}
+	return "", false
+}
+
+// Traits stores the traits and attributes of an actor. Actors use it to implement
+// TraitHolder and TraitBearer; the zero value is empty and ready to use.
+type Traits struct {
+	mutex      sync.RWMutex
+	traits     map[Trait]struct{}
+	attributes map[string]string
+}
+
+// Add gives the actor the traits
+func (t *Traits) Add(traits ...Trait) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.traits == nil {
+		t.traits = make(map[Trait]struct{}, len(traits))
+	}
+	for _, trait := range traits {
+		t.traits[trait] = struct{}{}
+	}
+}
+
+// Set sets the attribute, replacing any previous value
+func (t *Traits) Set(key, value string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.attributes == nil {
+		t.attributes = make(map[string]string)
+	}
+	t.attributes[key] = value
+}
+
+// Has reports whether the trait was added
+func (t *Traits) Has(trait Trait) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	_, ok := t.traits[trait]
+	return ok
+}
+
+// Attribute returns the value of the attribute, and whether it is set
+func (t *Traits) Attribute(key string) (string, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	value, ok := t.attributes[key]
+	return value, ok
+}
@@ -0,0 +1,135 @@
+// Package debug implements the interactive debug mode. When the SERENITY_DEBUG
+// environment variable is set to 1, actors pause before every activity, print what
+// they are about to do together with the state of their abilities, and wait for a
+// decision on standard input:
+//
+//	⏸  Customer is about to: #actor sends POST request to /orders
+//	   at /src/shop/checkout_test.go:42
+//	   api.callAnAPI: base URL http://localhost:8080, last response 201 Created (application/json)
+//	[Enter] continue, [s] skip, [a] abort >
+//
+// Because `go test` does not connect the terminal to the tests, build the test binary
+// and run it directly: go test -c -o shop.test && SERENITY_DEBUG=1 ./shop.test -test.run TestCheckout
+package debug
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// EnvVar is the environment variable that enables the debug mode when set to 1
+const EnvVar = "SERENITY_DEBUG"
+
+// Decision is the choice made when execution pauses before an activity
+type Decision int
+
+const (
+	// Continue performs the activity
+	Continue Decision = iota
+	// Skip skips the activity and continues with the next one
+	Skip
+	// Abort stops the test
+	Abort
+)
+
+// Stepper pauses execution before activities and asks what to do.
+// It is safe for concurrent use; pauses of concurrent actors are serialized.
+type Stepper struct {
+	mutex    sync.Mutex
+	input    *bufio.Reader
+	output   io.Writer
+	detached bool
+}
+
+// NewStepper creates a stepper reading decisions from in and printing to out
+func NewStepper(in io.Reader, out io.Writer) *Stepper {
+	return &Stepper{input: bufio.NewReader(in), output: out}
+}
+
+var (
+	fromEnvOnce sync.Once
+	fromEnv     *Stepper
+)
+
+// FromEnv returns the stepper for the terminal if the debug mode is enabled with
+// SERENITY_DEBUG=1, or nil otherwise. All tests of the process share the stepper.
+func FromEnv() *Stepper {
+	fromEnvOnce.Do(func() {
+		if os.Getenv(EnvVar) == "1" {
+			fromEnv = NewStepper(os.Stdin, os.Stdout)
+		}
+	})
+	return fromEnv
+}
+
+// Pause prints what the actor is about to do and waits for a decision.
+// A nil stepper always continues. When the input is closed, the stepper stops
+// pausing and continues with all remaining activities.
+func (s *Stepper) Pause(actor core.Actor, activity core.Activity) Decision {
+	if s == nil {
+		return Continue
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.detached {
+		return Continue
+	}
+
+	s.printf("⏸  %s is about to: %s\n", actor.Name(), activity.Description())
+	if location := core.LocationOf(activity); !location.IsZero() {
+		s.printf("   at %s\n", location)
+	}
+	if holder, ok := actor.(core.AbilityHolder); ok {
+		for _, ability := range holder.Abilities() {
+			s.printf("   %s\n", describe(ability))
+		}
+	}
+
+	for {
+		s.printf("[Enter] continue, [s] skip, [a] abort > ")
+
+		line, err := s.input.ReadString('\n')
+		if err != nil && line == "" {
+			s.printf("\ninput closed, leaving debug mode\n")
+			s.detached = true
+			return Continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "", "c", "continue":
+			return Continue
+		case "s", "skip":
+			return Skip
+		case "a", "abort", "q", "quit":
+			return Abort
+		default:
+			s.printf("unknown command %q\n", strings.TrimSpace(line))
+		}
+	}
+}
+
+// printf writes to the output with registered secrets masked
+func (s *Stepper) printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprint(s.output, secrets.Mask(fmt.Sprintf(format, args...)))
+}
+
+// describe names the ability and, if it is inspectable, its current state
+func describe(ability abilities.Ability) string {
+	name := core.AbilityName(ability)
+	if inspectable, ok := ability.(abilities.Inspectable); ok {
+		if state := inspectable.Inspect(); state != "" {
+			return name + ": " + state
+		}
+	}
+	return name
+}
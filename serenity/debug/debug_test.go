@@ -0,0 +1,69 @@
+package debug_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/debug"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+func TestStepperReadsDecisions(t *testing.T) {
+	var output bytes.Buffer
+	stepper := debug.NewStepper(strings.NewReader("\nwhat\ns\nabort\n"), &output)
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Debugger")
+	activity := core.Do("#actor places an order", func(actor core.Actor, ctx context.Context) error { return nil })
+
+	require.Equal(t, debug.Continue, stepper.Pause(actor, activity))
+	require.Equal(t, debug.Skip, stepper.Pause(actor, activity))
+	require.Equal(t, debug.Abort, stepper.Pause(actor, activity))
+
+	require.Contains(t, output.String(), "⏸  Debugger is about to: #actor places an order\n   at ")
+	require.Contains(t, output.String(), `unknown command "what"`)
+}
+
+func TestStepperShowsAbilityState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	stepper := debug.NewStepper(strings.NewReader("\n"), &output)
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Debugger").WhoCan(api.CallAnApiAt(server.URL))
+	actor.AttemptsTo(api.SendPostRequest("/orders"))
+
+	stepper.Pause(actor, api.SendGetRequest("/orders/1"))
+
+	require.Contains(t, output.String(),
+		"   api.callAnAPI: base URL "+server.URL+", last response 201 Created (application/json)\n")
+}
+
+func TestStepperContinuesWhenInputIsClosed(t *testing.T) {
+	var output bytes.Buffer
+	stepper := debug.NewStepper(strings.NewReader(""), &output)
+
+	test := serenity.NewSerenityTest(t)
+	actor := test.ActorCalled("Debugger")
+	activity := core.Do("#actor places an order", func(actor core.Actor, ctx context.Context) error { return nil })
+
+	require.Equal(t, debug.Continue, stepper.Pause(actor, activity))
+	require.Equal(t, debug.Continue, stepper.Pause(actor, activity))
+	require.Equal(t, 1, strings.Count(output.String(), "is about to"))
+
+	var disabled *debug.Stepper
+	require.Equal(t, debug.Continue, disabled.Pause(actor, activity))
+}
@@ -0,0 +1,74 @@
+package events
+
+import "strings"
+
+// Level is a level of the hierarchy tests are reported in
+type Level string
+
+const (
+	// LevelFeature groups the scenarios of a feature
+	LevelFeature Level = "feature"
+	// LevelScenario is a scenario, or a scenario outline of a data-driven run
+	LevelScenario Level = "scenario"
+	// LevelExample is one example, or row, of a scenario outline
+	LevelExample Level = "example"
+)
+
+// Context places a test in the reporting hierarchy, e.g. the feature it belongs to
+type Context struct {
+	Level Level  `json:"level"`
+	Name  string `json:"name"`
+}
+
+// Feature creates the context of a feature
+func Feature(name string) Context {
+	return Context{Level: LevelFeature, Name: name}
+}
+
+// Scenario creates the context of a scenario
+func Scenario(name string) Context {
+	return Context{Level: LevelScenario, Name: name}
+}
+
+// Example creates the context of an example of a scenario outline
+func Example(name string) Context {
+	return Context{Level: LevelExample, Name: name}
+}
+
+// String returns the level and the name, e.g. "Feature: Checkout"
+func (c Context) String() string {
+	level := string(c.Level)
+	if level != "" {
+		level = strings.ToUpper(level[:1]) + level[1:]
+	}
+	return level + ": " + c.Name
+}
+
+// ContextsOf derives the contexts of a test from its name: a top-level test is a
+// scenario, its subtests are the scenarios of the feature it names, and deeper
+// subtests are examples of those scenarios.
+//
+//	ContextsOf("TestCheckout")                  // Scenario: TestCheckout
+//	ContextsOf("TestCheckout/card")             // Feature: TestCheckout, Scenario: card
+//	ContextsOf("TestCheckout/card/visa/3DS")    // ..., Example: visa/3DS
+func ContextsOf(testName string) []Context {
+	segments := strings.SplitN(testName, "/", 3)
+	switch len(segments) {
+	case 1:
+		return []Context{Scenario(segments[0])}
+	case 2:
+		return []Context{Feature(segments[0]), Scenario(segments[1])}
+	default:
+		return []Context{Feature(segments[0]), Scenario(segments[1]), Example(segments[2])}
+	}
+}
+
+// Path renders the contexts from the outermost to the innermost, e.g.
+// "Feature: Checkout › Scenario: Pay by card"
+func Path(contexts []Context) string {
+	parts := make([]string, len(contexts))
+	for i, c := range contexts {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " › ")
+}
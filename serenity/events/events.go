@@ -0,0 +1,245 @@
+// Package events provides the event bus through which actors announce what happens
+// during a test. Reporters, metrics exporters and custom listeners subscribe to the
+// bus independently of each other and of the actor implementation.
+//
+// Listeners are notified synchronously, in subscription order, on the goroutine that
+// published the event:
+//
+//	test := serenity.NewSerenityTest(t)
+//	test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+//		if failed, ok := event.(events.AssertionFailed); ok {
+//			metrics.Inc("assertion_failures", failed.Actor)
+//		}
+//	}))
+package events
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Event is something that happened during a test
+type Event interface {
+	// Kind returns the name of the event type, e.g. "ActivityStarted"
+	Kind() string
+}
+
+// Outcome describes how a test or activity ended
+type Outcome int
+
+const (
+	// Passed indicates the test or activity succeeded
+	Passed Outcome = iota
+	// Failed indicates the test or activity returned an error
+	Failed
+	// Skipped indicates the test or activity was not performed
+	Skipped
+)
+
+// String returns the lower-case name of the outcome
+func (o Outcome) String() string {
+	switch o {
+	case Failed:
+		return "failed"
+	case Skipped:
+		return "skipped"
+	default:
+		return "passed"
+	}
+}
+
+// TestStarted is published when a test begins. Contexts place the test in the
+// feature > scenario > example hierarchy, from the outermost level.
+type TestStarted struct {
+	Test     string
+	Contexts []Context
+}
+
+// TestFinished is published when a test completes. Requirements lists the
+// requirements the test declared to cover, and Cases the test-management cases.
+type TestFinished struct {
+	Test         string
+	Outcome      Outcome
+	Duration     time.Duration
+	Err          error
+	Requirements []string
+	Cases        []string
+	Contexts     []Context
+}
+
+// RequirementCovered is published when a test declares that it covers a requirement,
+// e.g. an issue ID such as "JIRA-123"
+type RequirementCovered struct {
+	Test        string
+	Requirement string
+}
+
+// ActorStarted is published when a test creates an actor
+type ActorStarted struct {
+	Actor string
+}
+
+// ActivityStarted is published before an actor performs an activity.
+// The description still contains the #actor placeholder. Metadata holds the
+// entries attached to the activity for reports, if any.
+type ActivityStarted struct {
+	Actor    string
+	Activity string
+	Metadata map[string]string
+}
+
+// ActivityFinished is published after an actor performed or skipped an activity.
+// For skipped activities Err holds the reason. Location is the "file:line" where the
+// activity or, on failure, the failing step was constructed, if known.
+type ActivityFinished struct {
+	Actor    string
+	Activity string
+	Outcome  Outcome
+	Duration time.Duration
+	Err      error
+	Location string
+	Metadata map[string]string
+}
+
+// QuestionAnswered is published when an actor receives an answer to a question
+type QuestionAnswered struct {
+	Actor    string
+	Question string
+	Answer   any
+}
+
+// AttachmentAdded is published when an actor attaches a file, e.g. a screenshot or a
+// response body, to the activity it is performing
+type AttachmentAdded struct {
+	Actor     string
+	Name      string
+	MediaType string
+	Content   []byte
+}
+
+// RequestSent is published when an actor sent an HTTP request with the api ability. URL is
+// the address the request was sent to; Status is 0 when no response was received, and Err
+// holds the reason. Request is the request as sent, with the headers added by the ability,
+// and Response is nil without a response; listeners must not read its body while the test
+// runs, as the questions of the actor read it.
+type RequestSent struct {
+	Actor    string
+	Method   string
+	URL      string
+	Status   int
+	Duration time.Duration
+	Err      error
+	Request  *http.Request
+	Response *http.Response
+}
+
+// AssertionFailed is published when an answer does not meet the expectation of an assertion
+type AssertionFailed struct {
+	Actor     string
+	Assertion string
+	Err       error
+}
+
+// Kind returns "TestStarted"
+func (TestStarted) Kind() string { return "TestStarted" }
+
+// Kind returns "TestFinished"
+func (TestFinished) Kind() string { return "TestFinished" }
+
+// Kind returns "RequirementCovered"
+func (RequirementCovered) Kind() string { return "RequirementCovered" }
+
+// Kind returns "ActorStarted"
+func (ActorStarted) Kind() string { return "ActorStarted" }
+
+// Kind returns "ActivityStarted"
+func (ActivityStarted) Kind() string { return "ActivityStarted" }
+
+// Kind returns "ActivityFinished"
+func (ActivityFinished) Kind() string { return "ActivityFinished" }
+
+// Kind returns "QuestionAnswered"
+func (QuestionAnswered) Kind() string { return "QuestionAnswered" }
+
+// Kind returns "AttachmentAdded"
+func (AttachmentAdded) Kind() string { return "AttachmentAdded" }
+
+// Kind returns "RequestSent"
+func (RequestSent) Kind() string { return "RequestSent" }
+
+// Kind returns "AssertionFailed"
+func (AssertionFailed) Kind() string { return "AssertionFailed" }
+
+// Listener receives the events published on a bus
+type Listener interface {
+	// Notify is called for every published event
+	Notify(event Event)
+}
+
+// ListenerFunc adapts a function to the Listener interface
+type ListenerFunc func(event Event)
+
+// Notify calls the function
+func (f ListenerFunc) Notify(event Event) {
+	f(event)
+}
+
+// Publisher is implemented by actors that publish events, see core.Publish
+type Publisher interface {
+	// Publish notifies the subscribed listeners about the event
+	Publish(event Event)
+}
+
+// Bus delivers published events to its listeners. A nil bus discards all events.
+type Bus struct {
+	mutex     sync.RWMutex
+	listeners []*subscription
+}
+
+// subscription wraps a listener so that it can be identified on unsubscribe
+type subscription struct {
+	listener Listener
+}
+
+// NewBus creates an event bus without listeners
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds a listener to the bus and returns a function that removes it again
+func (b *Bus) Subscribe(listener Listener) (unsubscribe func()) {
+	s := &subscription{listener: listener}
+
+	b.mutex.Lock()
+	b.listeners = append(b.listeners, s)
+	b.mutex.Unlock()
+
+	return func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		for i, existing := range b.listeners {
+			if existing == s {
+				b.listeners = append(b.listeners[:i:i], b.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish notifies all listeners about the event, in subscription order
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mutex.RLock()
+	listeners := make([]*subscription, len(b.listeners))
+	copy(listeners, b.listeners)
+	b.mutex.RUnlock()
+
+	for _, s := range listeners {
+		s.listener.Notify(event)
+	}
+}
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusNotifiesListenersInSubscriptionOrder(t *testing.T) {
+	bus := NewBus()
+
+	var received []string
+	bus.Subscribe(ListenerFunc(func(event Event) { received = append(received, "first "+event.Kind()) }))
+	unsubscribe := bus.Subscribe(ListenerFunc(func(event Event) { received = append(received, "second "+event.Kind()) }))
+
+	bus.Publish(ActorStarted{Actor: "Ada"})
+	unsubscribe()
+	unsubscribe()
+	bus.Publish(TestStarted{Test: "TestCheckout"})
+
+	require.Equal(t, []string{
+		"first ActorStarted",
+		"second ActorStarted",
+		"first TestStarted",
+	}, received)
+}
+
+func TestNilBusDiscardsEvents(t *testing.T) {
+	var bus *Bus
+	require.NotPanics(t, func() { bus.Publish(ActorStarted{Actor: "Ada"}) })
+}
+
+func TestOutcomeString(t *testing.T) {
+	require.Equal(t, "passed", Passed.String())
+	require.Equal(t, "failed", Failed.String())
+	require.Equal(t, "skipped", Skipped.String())
+}
+
+func TestContextsOfDeriveTheHierarchyFromTheTestName(t *testing.T) {
+	require.Equal(t, []Context{Scenario("TestCheckout")}, ContextsOf("TestCheckout"))
+	require.Equal(t, []Context{Feature("TestCheckout"), Scenario("card")}, ContextsOf("TestCheckout/card"))
+	require.Equal(t, []Context{Feature("TestCheckout"), Scenario("card"), Example("visa/3DS")}, ContextsOf("TestCheckout/card/visa/3DS"))
+
+	require.Equal(t, "Feature: TestCheckout › Scenario: card", Path(ContextsOf("TestCheckout/card")))
+}
@@ -0,0 +1,70 @@
+package expectations
+
+import (
+	"reflect"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// ForAnyExpectation adapts a typed expectation to untyped values, such as the answers of
+// NewJSONPath, checking their type at runtime
+type ForAnyExpectation[T any] struct {
+	expectation ensure.Expectation[T]
+}
+
+// Evaluate checks that the actual value is a T and evaluates the typed expectation
+func (fa ForAnyExpectation[T]) Evaluate(actual any) error {
+	typed, ok := actual.(T)
+	if !ok {
+		if actual != nil || !nillable(reflect.TypeFor[T]()) {
+			return core.NewAssertionError(fa.expectation.Description(), actual, "expected a value of type %s, but got %T (%v)", reflect.TypeFor[T](), actual, actual)
+		}
+		// A nil answer is the zero value of pointer, slice, map and interface types
+	}
+	return fa.expectation.Evaluate(typed)
+}
+
+// Description returns the description of the typed expectation
+func (fa ForAnyExpectation[T]) Description() string {
+	return fa.expectation.Description()
+}
+
+// Convenience function adapting a typed expectation to untyped values, e.g.
+//
+//	ensure.That(api.NewJSONPath("items"), expectations.ForAny(expectations.HasNoDuplicates(func(id any) any { return id })))
+func ForAny[T any](expectation ensure.Expectation[T]) ensure.Expectation[any] {
+	return ForAnyExpectation[T]{expectation: expectation}
+}
+
+// AsTypeExpectation adapts an untyped expectation, such as IsGreaterThan, to typed questions
+type AsTypeExpectation[T any] struct {
+	expectation ensure.Expectation[any]
+}
+
+// Evaluate evaluates the untyped expectation with the typed value
+func (at AsTypeExpectation[T]) Evaluate(actual T) error {
+	return at.expectation.Evaluate(actual)
+}
+
+// Description returns the description of the untyped expectation
+func (at AsTypeExpectation[T]) Description() string {
+	return at.expectation.Description()
+}
+
+// Convenience function adapting an untyped expectation to the answers of typed questions, e.g.
+//
+//	ensure.That(api.LastResponseStatus{}, expectations.AsType[int](expectations.IsLessThan(500)))
+func AsType[T any](expectation ensure.Expectation[any]) ensure.Expectation[T] {
+	return AsTypeExpectation[T]{expectation: expectation}
+}
+
+// nillable reports whether nil is a value of the type
+func nillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return true
+	default:
+		return false
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/nchursin/serenity-go/serenity/core"
 	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
 )
 
@@ -22,15 +23,15 @@ func (ie IsEmptyExpectation) Evaluate(actual interface{}) error {
 	switch val.Kind() {
 	case reflect.String:
 		if val.String() != "" {
-			return fmt.Errorf("expected string to be empty, but got '%s'", val.String())
+			return core.NewAssertionError("empty", actual, "expected string to be empty, but got '%s'", val.String())
 		}
 	case reflect.Slice, reflect.Array:
 		if val.Len() != 0 {
-			return fmt.Errorf("expected slice/array to be empty, but got %d elements", val.Len())
+			return core.NewAssertionError("empty", actual, "expected slice/array to be empty, but got %d elements", val.Len())
 		}
 	case reflect.Map:
 		if val.Len() != 0 {
-			return fmt.Errorf("expected map to be empty, but got %d elements", val.Len())
+			return core.NewAssertionError("empty", actual, "expected map to be empty, but got %d elements", val.Len())
 		}
 	default:
 		return fmt.Errorf("IsEmpty expectation only works with strings, slices, arrays, and maps, but got %T", actual)
@@ -74,7 +75,7 @@ func (ale ArrayLengthEqualsExpectation) Evaluate(actual interface{}) error {
 	}
 
 	if length != ale.expectedLength {
-		return fmt.Errorf("expected length to be %d, but got %d", ale.expectedLength, length)
+		return core.NewAssertionError(ale.expectedLength, length, "expected length to be %d, but got %d", ale.expectedLength, length)
 	}
 	return nil
 }
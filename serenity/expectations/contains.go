@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/nchursin/serenity-go/serenity/core"
 	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
 )
 
@@ -21,10 +22,10 @@ func NewContains(substring string) ensure.Expectation[string] {
 // Evaluate evaluates the contains expectation
 func (c ContainsExpectation) Evaluate(actual string) error {
 	if actual == "" {
-		return fmt.Errorf("expected string to contain '%s', but got empty string", c.substring)
+		return core.NewAssertionError(c.substring, actual, "expected string to contain '%s', but got empty string", c.substring)
 	}
 	if !strings.Contains(actual, c.substring) {
-		return fmt.Errorf("expected string to contain '%s', but got '%s'", c.substring, actual)
+		return core.NewAssertionError(c.substring, actual, "expected string to contain '%s', but got '%s'", c.substring, actual)
 	}
 	return nil
 }
@@ -59,7 +60,7 @@ func (ck ContainsKeyExpectation) Evaluate(actual interface{}) error {
 	// Try to convert to map[string]interface{} for string keys
 	if mapStr, ok := actual.(map[string]interface{}); ok {
 		if _, exists := mapStr[ck.key]; !exists {
-			return fmt.Errorf("expected map to contain key '%s'", ck.key)
+			return core.NewAssertionError(ck.key, actual, "expected map to contain key '%s'", ck.key)
 		}
 		return nil
 	}
@@ -67,7 +68,7 @@ func (ck ContainsKeyExpectation) Evaluate(actual interface{}) error {
 	// Fallback to reflection for any map type
 	mapKey := reflect.ValueOf(ck.key)
 	if !val.MapIndex(mapKey).IsValid() {
-		return fmt.Errorf("expected map to contain key '%s'", ck.key)
+		return core.NewAssertionError(ck.key, actual, "expected map to contain key '%s'", ck.key)
 	}
 	return nil
 }
@@ -0,0 +1,252 @@
+package expectations
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// EqualsDecimalExpectation checks if a number equals the expected decimal exactly, without
+// the rounding errors of float64 arithmetic. The actual value may be a string, a
+// json.Number, an integer or a float, which compares by its shortest decimal representation.
+type EqualsDecimalExpectation struct {
+	expected string
+}
+
+// NewEqualsDecimal creates a new EqualsDecimal expectation
+func NewEqualsDecimal(expected string) ensure.Expectation[any] {
+	return EqualsDecimalExpectation{expected: expected}
+}
+
+// Evaluate evaluates the equals decimal expectation
+func (ed EqualsDecimalExpectation) Evaluate(actual any) error {
+	expected, err := parseDecimal(ed.expected)
+	if err != nil {
+		return fmt.Errorf("invalid expected decimal: %w", err)
+	}
+	value, err := parseDecimal(actual)
+	if err != nil {
+		return err
+	}
+	if value.Cmp(expected) != 0 {
+		return core.NewAssertionError(ed.expected, actual, "expected %s, but got %s", ed.expected, formatDecimal(value))
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (ed EqualsDecimalExpectation) Description() string {
+	return fmt.Sprintf("equals %s", ed.expected)
+}
+
+// Convenience function for creating EqualsDecimal expectations, e.g. EqualsDecimal("19.99")
+func EqualsDecimal(expected string) ensure.Expectation[any] {
+	return NewEqualsDecimal(expected)
+}
+
+// WithinToleranceExpectation checks if a number differs from the expected one by at most
+// epsilon. Both are decimals given as strings or numbers.
+type WithinToleranceExpectation struct {
+	expected any
+	epsilon  any
+}
+
+// NewWithinTolerance creates a new WithinTolerance expectation
+func NewWithinTolerance(expected, epsilon any) ensure.Expectation[any] {
+	return WithinToleranceExpectation{expected: expected, epsilon: epsilon}
+}
+
+// Evaluate evaluates the within tolerance expectation
+func (wt WithinToleranceExpectation) Evaluate(actual any) error {
+	expected, err := parseDecimal(wt.expected)
+	if err != nil {
+		return fmt.Errorf("invalid expected decimal: %w", err)
+	}
+	epsilon, err := parseDecimal(wt.epsilon)
+	if err != nil {
+		return fmt.Errorf("invalid tolerance: %w", err)
+	}
+	value, err := parseDecimal(actual)
+	if err != nil {
+		return err
+	}
+
+	difference := new(big.Rat).Sub(value, expected)
+	if difference.Abs(difference).Cmp(epsilon) > 0 {
+		return core.NewAssertionError(wt.expected, actual, "expected %s ± %s, but got %s (off by %s)",
+			formatDecimal(expected), formatDecimal(epsilon), formatDecimal(value), formatDecimal(difference))
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (wt WithinToleranceExpectation) Description() string {
+	return fmt.Sprintf("is within %v of %v", wt.epsilon, wt.expected)
+}
+
+// Convenience function for creating WithinTolerance expectations, e.g. WithinTolerance(19.99, 0.01)
+func WithinTolerance(expected, epsilon any) ensure.Expectation[any] {
+	return NewWithinTolerance(expected, epsilon)
+}
+
+// Money is an amount in a currency, given by its ISO 4217 code such as "EUR"
+type Money struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// EqualsMoneyExpectation checks if a price equals the expected amount in the currency.
+// The actual value may be a Money, a JSON object with "amount" and "currency" fields, a
+// string such as "19.99 EUR", "EUR 19.99" or "€19.99", or a bare number. The currency must
+// match when the value carries one, and the amount may not be more precise than the minor
+// unit of the currency, e.g. cents for EUR or whole yen for JPY.
+type EqualsMoneyExpectation struct {
+	amount   string
+	currency string
+}
+
+// NewEqualsMoney creates a new EqualsMoney expectation
+func NewEqualsMoney(amount, currency string) ensure.Expectation[any] {
+	return EqualsMoneyExpectation{amount: amount, currency: strings.ToUpper(currency)}
+}
+
+// Evaluate evaluates the equals money expectation
+func (em EqualsMoneyExpectation) Evaluate(actual any) error {
+	expectedDescription := em.amount + " " + em.currency
+	expected, err := parseDecimal(em.amount)
+	if err != nil {
+		return fmt.Errorf("invalid expected amount: %w", err)
+	}
+
+	money, err := moneyOf(actual)
+	if err != nil {
+		return err
+	}
+	if money.Currency != "" && money.Currency != em.currency {
+		return core.NewAssertionError(expectedDescription, actual, "expected %s, but got %s %s", expectedDescription, money.Amount, money.Currency)
+	}
+
+	value, err := parseDecimal(money.Amount)
+	if err != nil {
+		return err
+	}
+	units := minorUnits(em.currency)
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(units)), nil)))
+	if !scaled.IsInt() {
+		return core.NewAssertionError(expectedDescription, actual, "expected %s, but got %s, more precise than the %d decimal places of %s", expectedDescription, formatDecimal(value), units, em.currency)
+	}
+	if value.Cmp(expected) != 0 {
+		return core.NewAssertionError(expectedDescription, actual, "expected %s, but got %s %s", expectedDescription, formatDecimal(value), em.currency)
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (em EqualsMoneyExpectation) Description() string {
+	return fmt.Sprintf("equals %s %s", em.amount, em.currency)
+}
+
+// Convenience function for creating EqualsMoney expectations, e.g. EqualsMoney("19.99", "EUR")
+func EqualsMoney(amount, currency string) ensure.Expectation[any] {
+	return NewEqualsMoney(amount, currency)
+}
+
+// currencySymbols maps common currency symbols to their codes
+var currencySymbols = map[string]string{"€": "EUR", "$": "USD", "£": "GBP", "¥": "JPY"}
+
+// moneyPattern matches an amount with an optional currency code or symbol before or after it
+var moneyPattern = regexp.MustCompile(`^([A-Za-z]{3}|[€$£¥])?\s*([-+]?[\d,]*\.?\d+)\s*([A-Za-z]{3}|[€$£¥])?$`)
+
+// moneyOf returns the amount and currency of a price, with an empty currency if it has none
+func moneyOf(actual any) (Money, error) {
+	switch v := actual.(type) {
+	case Money:
+		return Money{Amount: v.Amount, Currency: strings.ToUpper(v.Currency)}, nil
+	case map[string]any:
+		amount, ok := v["amount"]
+		if !ok {
+			return Money{}, fmt.Errorf("expected a price, but the object has no amount: %v", v)
+		}
+		value, err := parseDecimal(amount)
+		if err != nil {
+			return Money{}, err
+		}
+		currency, _ := v["currency"].(string)
+		return Money{Amount: formatDecimal(value), Currency: strings.ToUpper(currency)}, nil
+	case string:
+		match := moneyPattern.FindStringSubmatch(strings.TrimSpace(v))
+		if match == nil || (match[1] != "" && match[3] != "") {
+			return Money{}, fmt.Errorf("expected a price, but got '%s'", v)
+		}
+		currency := strings.ToUpper(match[1] + match[3])
+		if code, ok := currencySymbols[currency]; ok {
+			currency = code
+		}
+		return Money{Amount: strings.ReplaceAll(match[2], ",", ""), Currency: currency}, nil
+	default:
+		value, err := parseDecimal(actual)
+		if err != nil {
+			return Money{}, err
+		}
+		return Money{Amount: formatDecimal(value)}, nil
+	}
+}
+
+// minorUnits returns the number of decimal places of the currency, 2 unless ISO 4217
+// defines otherwise
+func minorUnits(currency string) int {
+	switch currency {
+	case "BIF", "CLP", "DJF", "GNF", "ISK", "JPY", "KMF", "KRW", "PYG", "RWF", "UGX", "VND", "VUV", "XAF", "XOF", "XPF":
+		return 0
+	case "BHD", "IQD", "JOD", "KWD", "LYD", "OMR", "TND":
+		return 3
+	default:
+		return 2
+	}
+}
+
+// parseDecimal parses a decimal given as a string or a number
+func parseDecimal(value any) (*big.Rat, error) {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = strings.TrimSpace(v)
+	case json.Number:
+		text = v.String()
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		text = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		text = fmt.Sprint(v)
+	case *big.Rat:
+		return new(big.Rat).Set(v), nil
+	default:
+		return nil, fmt.Errorf("expected a decimal number, but got %T", value)
+	}
+
+	decimal, ok := new(big.Rat).SetString(text)
+	if !ok || strings.Contains(text, "/") {
+		return nil, fmt.Errorf("expected a decimal number, but got '%s'", text)
+	}
+	return decimal, nil
+}
+
+// formatDecimal formats a decimal without trailing zeros, e.g. "19.9"
+func formatDecimal(decimal *big.Rat) string {
+	if decimal.IsInt() {
+		return decimal.Num().String()
+	}
+	for places := 1; ; places++ {
+		scaled := new(big.Rat).Mul(decimal, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)))
+		if scaled.IsInt() || places >= 20 {
+			return decimal.FloatString(places)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package ensure
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
 )
 
 // Expectation represents an expectation that can be evaluated against actual values
@@ -16,20 +19,63 @@ type Expectation[T any] interface {
 	Description() string
 }
 
-// EnsureActivity represents an assertion that a question's answer meets an expectation
+// MismatchDescriber is an optional extension of Expectation for expectations that explain
+// how an actual value fails to meet them, e.g. "got 3 items, 1 more than allowed". Step
+// results then show the explanation together with the expectation and the actual value.
+type MismatchDescriber[T any] interface {
+	// DescribeMismatch explains how the actual value fails to meet the expectation
+	DescribeMismatch(actual T) string
+}
+
+// defaultFailureMode is the failure mode of assertions that do not set their own
+var defaultFailureMode atomic.Int32
+
+// SetDefaultFailureMode sets the failure mode of the assertions created afterwards that do
+// not set their own, e.g. core.NonCritical() in TestMain to let suites keep going after a
+// failed assertion. The default is core.FailFast.
+func SetDefaultFailureMode(mode core.FailureMode) {
+	defaultFailureMode.Store(int32(mode)) // #nosec G115 -- failure modes are small constants
+}
+
+// DefaultFailureMode returns the failure mode of assertions that do not set their own
+func DefaultFailureMode() core.FailureMode {
+	return core.FailureMode(defaultFailureMode.Load())
+}
+
+// EnsureActivity represents an assertion that a question's answer meets an expectation.
+// A failed assertion stops the scenario unless Continuing, AsWarning or WithFailureMode
+// choose another failure mode. By default, failing to answer the question is handled like
+// a failed assertion; OrSkip, OnErrorContinue and OnAnswerError change that:
+//
+//	actor.AttemptsTo(
+//		ensure.That(api.LastResponseStatus{}, expectations.Equals(200)).OrSkip(),
+//		ensure.That(api.NewResponseHeader("X-Cache"), expectations.Equals("HIT")).AsWarning(),
+//	)
 type EnsureActivity[T any] struct {
 	question    core.Question[T]
 	expectation Expectation[T]
+	location    core.Location
+	failureMode core.FailureMode
+
+	// onAnswerError handles the error of a question that cannot be answered, if set
+	onAnswerError func(err error) error
 }
 
 // That creates a new Ensure assertion with the new API
-func That[T any](question core.Question[T], expectation Expectation[T]) core.Activity {
+func That[T any](question core.Question[T], expectation Expectation[T]) *EnsureActivity[T] {
 	return &EnsureActivity[T]{
 		question:    question,
 		expectation: expectation,
+		location:    core.CallerLocation(),
+		failureMode: DefaultFailureMode(),
 	}
 }
 
+// Location returns where the assertion was constructed
+func (e *EnsureActivity[T]) Location() core.Location {
+	return e.location
+}
+
 // Description returns the activity description
 func (e *EnsureActivity[T]) Description() string {
 	questionDesc := e.question.Description()
@@ -42,10 +88,22 @@ func (e *EnsureActivity[T]) Description() string {
 func (e *EnsureActivity[T]) PerformAs(actor core.Actor, ctx context.Context) error {
 	actual, err := e.question.AnsweredBy(actor, ctx)
 	if err != nil {
-		return fmt.Errorf("failed to answer question '%s': %w", e.question.Description(), err)
+		err = fmt.Errorf("failed to answer question '%s': %w", e.question.Description(), err)
+		if e.onAnswerError != nil {
+			return e.onAnswerError(err)
+		}
+		return err
 	}
 
+	core.RecordAnswer(actor, e.question.Description(), actual)
+
 	if evaluateErr := e.expectation.Evaluate(actual); evaluateErr != nil {
+		evaluateErr = e.mismatch(actual, evaluateErr)
+		core.Publish(actor, events.AssertionFailed{
+			Actor:     actor.Name(),
+			Assertion: e.Description(),
+			Err:       evaluateErr,
+		})
 		return fmt.Errorf("assertion failed for '%s': %w", e.question.Description(), evaluateErr)
 	}
 
@@ -54,5 +112,68 @@ func (e *EnsureActivity[T]) PerformAs(actor core.Actor, ctx context.Context) err
 
 // FailureMode returns the failure mode for ensure activities (default: FailFast)
 func (e *EnsureActivity[T]) FailureMode() core.FailureMode {
-	return core.NonCritical()
+	return e.failureMode
+}
+
+// WithFailureMode sets how a failed assertion affects the rest of the scenario
+func (e *EnsureActivity[T]) WithFailureMode(mode core.FailureMode) *EnsureActivity[T] {
+	e.failureMode = mode
+	return e
+}
+
+// Continuing reports a failed assertion as a non-critical failure, so that the remaining
+// activities are performed
+func (e *EnsureActivity[T]) Continuing() *EnsureActivity[T] {
+	return e.WithFailureMode(core.NonCritical())
+}
+
+// AsWarning logs a failed assertion without failing the test
+func (e *EnsureActivity[T]) AsWarning() *EnsureActivity[T] {
+	return e.WithFailureMode(core.Optional())
+}
+
+// mismatch makes the failure of the expectation carry the expected and actual values, so
+// that reports can show both. Errors other than assertion errors are wrapped in one, and
+// expectations implementing MismatchDescriber explain the mismatch themselves.
+func (e *EnsureActivity[T]) mismatch(actual T, err error) error {
+	describer, describes := any(e.expectation).(MismatchDescriber[T])
+
+	var assertion *core.AssertionError
+	isAssertion := errors.As(err, &assertion)
+	if isAssertion && !describes {
+		return err
+	}
+
+	failure := &core.AssertionError{Expected: e.expectation.Description(), Actual: actual, Message: err.Error(), Cause: err}
+	if isAssertion {
+		failure.Expected = assertion.Expected
+	}
+	if describes {
+		failure.Message = describer.DescribeMismatch(actual)
+	}
+	return failure
+}
+
+// OrSkip reports the assertion as skipped instead of failed when the question cannot be
+// answered, e.g. because no response is available yet
+func (e *EnsureActivity[T]) OrSkip() *EnsureActivity[T] {
+	e.onAnswerError = func(err error) error {
+		return core.NewSkippedError("the question could not be answered", err)
+	}
+	return e
+}
+
+// OnErrorContinue reports a question that cannot be answered as a non-critical failure,
+// so that the remaining activities are performed
+func (e *EnsureActivity[T]) OnErrorContinue() *EnsureActivity[T] {
+	return e.OnAnswerError(core.NonCritical())
+}
+
+// OnAnswerError handles a question that cannot be answered with the failure mode, e.g.
+// core.Critical() to stop the test before later assertions
+func (e *EnsureActivity[T]) OnAnswerError(mode core.FailureMode) *EnsureActivity[T] {
+	e.onAnswerError = func(err error) error {
+		return core.WithFailureModeOverride(err, mode)
+	}
+	return e
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/nchursin/serenity-go/serenity/core"
 	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
 )
 
@@ -20,7 +21,7 @@ func NewEquals[T any](expected T) ensure.Expectation[T] {
 // Evaluate evaluates the equals expectation
 func (eq EqualsExpectation[T]) Evaluate(actual T) error {
 	if !reflect.DeepEqual(actual, eq.expected) {
-		return fmt.Errorf("expected %v, but got %v", eq.expected, actual)
+		return core.NewAssertionError(eq.expected, actual, "expected %v, but got %v", eq.expected, actual)
 	}
 	return nil
 }
@@ -0,0 +1,146 @@
+package expectations
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// FormatExpectation checks if a string is formatted as a kind of value, such as a UUID
+type FormatExpectation struct {
+	format   string
+	validate func(actual string) error
+}
+
+// NewFormat creates a new Format expectation for the format, described as e.g. "a UUID",
+// with a validate function explaining why a string is not in the format
+func NewFormat(format string, validate func(actual string) error) ensure.Expectation[string] {
+	return FormatExpectation{format: format, validate: validate}
+}
+
+// Evaluate evaluates the format expectation
+func (f FormatExpectation) Evaluate(actual string) error {
+	if err := f.validate(actual); err != nil {
+		return core.NewAssertionError(f.format, actual, "expected %s, but '%s' %v", f.format, actual, err)
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (f FormatExpectation) Description() string {
+	return "is " + f.format
+}
+
+// Convenience function for expecting a UUID such as "123e4567-e89b-12d3-a456-426614174000",
+// in any case and of any version
+func IsUUID() ensure.Expectation[string] {
+	return NewFormat("a UUID", validateUUID)
+}
+
+// Convenience function for expecting a ULID such as "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+func IsULID() ensure.Expectation[string] {
+	return NewFormat("a ULID", validateULID)
+}
+
+// Convenience function for expecting an ISO 8601 timestamp with a time zone, as defined by
+// RFC 3339, such as "2024-05-01T12:30:00Z" or "2024-05-01T14:30:00.123+02:00"
+func IsISO8601Timestamp() ensure.Expectation[string] {
+	return NewFormat("an ISO 8601 timestamp", validateTimestamp)
+}
+
+// Convenience function for expecting a bare email address such as "ada@example.com"
+func IsEmailAddress() ensure.Expectation[string] {
+	return NewFormat("an email address", validateEmailAddress)
+}
+
+// Convenience function for expecting an absolute URL with a scheme and a host
+func IsURL() ensure.Expectation[string] {
+	return NewFormat("a URL", validateURL)
+}
+
+// validateUUID checks the length, hyphens and hex digits of a UUID
+func validateUUID(actual string) error {
+	if len(actual) != 36 {
+		return fmt.Errorf("has %d characters instead of 36", len(actual))
+	}
+	for i, c := range []byte(actual) {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return fmt.Errorf("has '%c' instead of '-' at position %d", c, i+1)
+			}
+		default:
+			if !isHexDigit(c) {
+				return fmt.Errorf("has the non-hexadecimal character '%c' at position %d", c, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// isHexDigit reports whether the character is a hexadecimal digit
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// crockfordBase32 holds the digits of ULIDs
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// validateULID checks the length and Crockford base32 digits of a ULID
+func validateULID(actual string) error {
+	if len(actual) != 26 {
+		return fmt.Errorf("has %d characters instead of 26", len(actual))
+	}
+	for i, c := range strings.ToUpper(actual) {
+		if !strings.ContainsRune(crockfordBase32, c) {
+			return fmt.Errorf("has the character '%c' at position %d, which is not in Crockford's base32", c, i+1)
+		}
+	}
+	if actual[0] > '7' {
+		return fmt.Errorf("has a timestamp overflowing 48 bits, as it starts with '%c'", actual[0])
+	}
+	return nil
+}
+
+// validateTimestamp parses an RFC 3339 timestamp
+func validateTimestamp(actual string) error {
+	if _, err := time.Parse(time.RFC3339Nano, actual); err != nil {
+		if _, local := time.Parse("2006-01-02T15:04:05.999999999", actual); local == nil {
+			return fmt.Errorf("has no time zone, such as Z or +02:00")
+		}
+		return fmt.Errorf("cannot be parsed: %w", err)
+	}
+	return nil
+}
+
+// validateEmailAddress parses an email address without a display name
+func validateEmailAddress(actual string) error {
+	address, err := mail.ParseAddress(actual)
+	if err != nil {
+		return fmt.Errorf("cannot be parsed: %w", err)
+	}
+	if address.Address != actual {
+		return fmt.Errorf("is not a bare address, which would be '%s'", address.Address)
+	}
+	return nil
+}
+
+// validateURL parses an absolute URL
+func validateURL(actual string) error {
+	parsed, err := url.Parse(actual)
+	if err != nil {
+		return fmt.Errorf("cannot be parsed: %w", err)
+	}
+	if parsed.Scheme == "" {
+		return fmt.Errorf("has no scheme, such as https://")
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("has no host")
+	}
+	return nil
+}
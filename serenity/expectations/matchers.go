@@ -0,0 +1,96 @@
+package expectations
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// MatcherExpectation is a domain matcher built with NewMatcher
+type MatcherExpectation[T any] struct {
+	description string
+	check       func(actual T) string
+}
+
+// NewMatcher creates a domain matcher for expectation libraries. The description is a noun
+// phrase, e.g. "a valid IBAN", and check returns why the actual value does not match, or
+// an empty string if it does. Failures read "expected a valid IBAN, but <reason>".
+//
+//	func IsValidIBAN() ensure.Expectation[string] {
+//		return expectations.NewMatcher("a valid IBAN", func(actual string) string {
+//			if !ibanChecksumValid(actual) {
+//				return fmt.Sprintf("the checksum of '%s' is wrong", actual)
+//			}
+//			return ""
+//		})
+//	}
+func NewMatcher[T any](description string, check func(actual T) string) ensure.Expectation[T] {
+	return MatcherExpectation[T]{description: description, check: check}
+}
+
+// Evaluate evaluates the matcher
+func (m MatcherExpectation[T]) Evaluate(actual T) error {
+	if reason := m.check(actual); reason != "" {
+		return core.NewAssertionError(m.description, actual, "expected %s, but %s", m.description, reason)
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (m MatcherExpectation[T]) Description() string {
+	return "is " + m.description
+}
+
+// MatcherInfo describes a matcher published by an expectation library
+type MatcherInfo struct {
+	// Library is the import path or name of the library, e.g. "example.com/bank/matchers"
+	Library string
+	// Name is the name of the function creating the matcher, e.g. "IsValidIBAN"
+	Name string
+	// Description is the noun phrase the matcher expects, e.g. "a valid IBAN"
+	Description string
+	// AnswerType is the type of the answers the matcher evaluates, e.g. "string"
+	AnswerType string
+}
+
+// matcherRegistry holds the matchers registered by expectation libraries
+var matcherRegistry = struct {
+	matchers map[string]MatcherInfo
+	mutex    sync.RWMutex
+}{matchers: make(map[string]MatcherInfo)}
+
+// RegisterMatcher publishes a matcher of an expectation library, typically from the init
+// function of the file defining it, as scaffolded by "serenity-gen matcher". It panics if
+// the library registers the name twice.
+func RegisterMatcher(info MatcherInfo) {
+	matcherRegistry.mutex.Lock()
+	defer matcherRegistry.mutex.Unlock()
+
+	key := info.Library + "." + info.Name
+	if _, exists := matcherRegistry.matchers[key]; exists {
+		panic(fmt.Sprintf("expectations: matcher %s registered twice", key))
+	}
+	matcherRegistry.matchers[key] = info
+}
+
+// RegisteredMatchers returns the registered matchers, sorted by library and name, e.g. to
+// document the matchers available to a suite
+func RegisteredMatchers() []MatcherInfo {
+	matcherRegistry.mutex.RLock()
+	defer matcherRegistry.mutex.RUnlock()
+
+	matchers := make([]MatcherInfo, 0, len(matcherRegistry.matchers))
+	for _, info := range matcherRegistry.matchers {
+		matchers = append(matchers, info)
+	}
+	sort.Slice(matchers, func(i, j int) bool {
+		if matchers[i].Library != matchers[j].Library {
+			return matchers[i].Library < matchers[j].Library
+		}
+		return matchers[i].Name < matchers[j].Name
+	})
+	return matchers
+}
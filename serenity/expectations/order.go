@@ -0,0 +1,89 @@
+package expectations
+
+import (
+	"cmp"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// IsSortedByExpectation checks if a slice is sorted by a less function, allowing equal
+// neighbours
+type IsSortedByExpectation[T any] struct {
+	order string
+	less  func(a, b T) bool
+}
+
+// NewIsSortedBy creates a new IsSortedBy expectation, describing the order, e.g. "by price"
+func NewIsSortedBy[T any](order string, less func(a, b T) bool) ensure.Expectation[[]T] {
+	return IsSortedByExpectation[T]{order: order, less: less}
+}
+
+// Evaluate evaluates the is sorted by expectation
+func (is IsSortedByExpectation[T]) Evaluate(actual []T) error {
+	for i := 1; i < len(actual); i++ {
+		if is.less(actual[i], actual[i-1]) {
+			return core.NewAssertionError(is.order, actual, "expected sorted %s, but element %d (%v) comes after element %d (%v)",
+				is.order, i, actual[i], i-1, actual[i-1])
+		}
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (is IsSortedByExpectation[T]) Description() string {
+	return "is sorted " + is.order
+}
+
+// Convenience function for creating IsSortedBy expectations, e.g. for the items of a page
+//
+//	expectations.IsSortedBy(func(a, b Order) bool { return a.CreatedAt.After(b.CreatedAt) })
+func IsSortedBy[T any](less func(a, b T) bool) ensure.Expectation[[]T] {
+	return NewIsSortedBy("by the given order", less)
+}
+
+// Convenience function for expecting a slice of ordered elements in ascending order
+func IsSortedAscending[T cmp.Ordered]() ensure.Expectation[[]T] {
+	return NewIsSortedBy("in ascending order", cmp.Less[T])
+}
+
+// Convenience function for expecting a slice of ordered elements in descending order
+func IsSortedDescending[T cmp.Ordered]() ensure.Expectation[[]T] {
+	return NewIsSortedBy("in descending order", func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// HasNoDuplicatesExpectation checks if the elements of a slice have distinct keys
+type HasNoDuplicatesExpectation[T any, K comparable] struct {
+	key func(element T) K
+}
+
+// NewHasNoDuplicates creates a new HasNoDuplicates expectation
+func NewHasNoDuplicates[T any, K comparable](key func(element T) K) ensure.Expectation[[]T] {
+	return HasNoDuplicatesExpectation[T, K]{key: key}
+}
+
+// Evaluate evaluates the has no duplicates expectation
+func (hnd HasNoDuplicatesExpectation[T, K]) Evaluate(actual []T) error {
+	seen := make(map[K]int, len(actual))
+	for i, element := range actual {
+		key := hnd.key(element)
+		if first, ok := seen[key]; ok {
+			return core.NewAssertionError("no duplicates", actual, "expected no duplicates, but elements %d and %d share the key %v", first, i, key)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (hnd HasNoDuplicatesExpectation[T, K]) Description() string {
+	return "has no duplicates"
+}
+
+// Convenience function for creating HasNoDuplicates expectations, e.g. to check that
+// consecutive pages don't repeat items
+//
+//	expectations.HasNoDuplicates(func(o Order) string { return o.ID })
+func HasNoDuplicates[T any, K comparable](key func(element T) K) ensure.Expectation[[]T] {
+	return NewHasNoDuplicates(key)
+}
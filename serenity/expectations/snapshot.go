@@ -0,0 +1,158 @@
+package expectations
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	"github.com/nchursin/serenity-go/serenity/expectations/utils"
+)
+
+// UpdateSnapshotsEnvVar is the environment variable that switches snapshot expectations
+// into update mode: stored snapshots are overwritten with the actual values instead of compared
+const UpdateSnapshotsEnvVar = "UPDATE_SNAPSHOTS"
+
+// DefaultSnapshotDir is the directory, relative to the test package, where snapshots are stored
+const DefaultSnapshotDir = "testdata/snapshots"
+
+// SnapshotExpectation compares the actual value against a stored golden file
+type SnapshotExpectation[T any] struct {
+	dir  string
+	name string
+}
+
+// NewMatchesSnapshot creates a new MatchesSnapshot expectation stored in the given directory
+func NewMatchesSnapshot[T any](dir, name string) ensure.Expectation[T] {
+	return SnapshotExpectation[T]{dir: dir, name: name}
+}
+
+// MatchesSnapshot creates an expectation that compares the actual value with the golden file
+// testdata/snapshots/<name>.json. Values are normalized as indented JSON with sorted keys, so
+// formatting and key order don't cause mismatches; strings and byte slices holding JSON are
+// normalized the same way.
+//
+// Run the tests with UPDATE_SNAPSHOTS=1 to create or regenerate the stored snapshots.
+//
+// Example:
+//
+//	actor.AttemptsTo(
+//		api.SendGetRequest("/users/1"),
+//		ensure.That(api.LastResponseBody{}, expectations.MatchesSnapshot[string]("user-1")),
+//	)
+func MatchesSnapshot[T any](name string) ensure.Expectation[T] {
+	return NewMatchesSnapshot[T](DefaultSnapshotDir, name)
+}
+
+// MatchesSnapshotIn is like MatchesSnapshot but stores the snapshot in the given directory
+func MatchesSnapshotIn[T any](dir, name string) ensure.Expectation[T] {
+	return NewMatchesSnapshot[T](dir, name)
+}
+
+// Evaluate evaluates the snapshot expectation
+func (s SnapshotExpectation[T]) Evaluate(actual T) error {
+	path, err := s.path()
+	if err != nil {
+		return err
+	}
+
+	normalized, err := normalizeSnapshot(actual)
+	if err != nil {
+		return fmt.Errorf("failed to normalize value for snapshot '%s': %w", s.name, err)
+	}
+
+	if updateSnapshots() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(normalized), 0o644); err != nil {
+			return fmt.Errorf("failed to write snapshot '%s': %w", s.name, err)
+		}
+		return nil
+	}
+
+	stored, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("snapshot '%s' does not exist at %s (run with %s=1 to create it)",
+			s.name, path, UpdateSnapshotsEnvVar)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot '%s': %w", s.name, err)
+	}
+
+	expected, err := normalizeSnapshot(stored)
+	if err != nil {
+		return fmt.Errorf("failed to normalize snapshot '%s': %w", s.name, err)
+	}
+
+	if diff := utils.LineDiff(strings.TrimSuffix(expected, "\n"), strings.TrimSuffix(normalized, "\n")); diff != "" {
+		return core.NewAssertionError(expected, normalized,
+			"value does not match snapshot '%s' (- snapshot, + actual; run with %s=1 to update):\n%s",
+			s.name, UpdateSnapshotsEnvVar, diff)
+	}
+
+	return nil
+}
+
+// Description returns the expectation description
+func (s SnapshotExpectation[T]) Description() string {
+	return fmt.Sprintf("matches snapshot '%s'", s.name)
+}
+
+// path returns the golden file location, rejecting names that escape the snapshot directory
+func (s SnapshotExpectation[T]) path() (string, error) {
+	if s.name == "" {
+		return "", fmt.Errorf("snapshot name cannot be empty")
+	}
+
+	relative := filepath.Clean(filepath.FromSlash(s.name))
+	if filepath.IsAbs(relative) || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid snapshot name '%s'", s.name)
+	}
+
+	return filepath.Join(s.dir, relative+".json"), nil
+}
+
+// normalizeSnapshot converts a value into indented JSON with sorted keys
+func normalizeSnapshot(value interface{}) (string, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		raw = data
+	}
+
+	// Numbers are kept as written to avoid float64 precision loss
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		// Plain text that isn't JSON is stored as a JSON string
+		decoded = string(raw)
+	}
+
+	normalized, err := json.MarshalIndent(decoded, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(normalized) + "\n", nil
+}
+
+// updateSnapshots reports whether snapshot update mode is enabled
+func updateSnapshots() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(UpdateSnapshotsEnvVar))
+	return err == nil && enabled
+}
@@ -0,0 +1,92 @@
+package expectations
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// StatusClassExpectation checks if an HTTP status code belongs to a class, e.g. 2xx
+type StatusClassExpectation struct {
+	class int
+	name  string
+}
+
+// NewStatusClass creates a new StatusClass expectation for the class given by its first
+// digit, e.g. 2 for 2xx
+func NewStatusClass(class int, name string) ensure.Expectation[int] {
+	return StatusClassExpectation{class: class, name: name}
+}
+
+// Evaluate evaluates the status class expectation
+func (sc StatusClassExpectation) Evaluate(actual int) error {
+	if actual/100 != sc.class {
+		return core.NewAssertionError(fmt.Sprintf("%dxx", sc.class), actual, "expected %s status (%dxx), but got %s", sc.name, sc.class, formatStatus(actual))
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (sc StatusClassExpectation) Description() string {
+	return fmt.Sprintf("is a %s status", sc.name)
+}
+
+// Convenience function for expecting a 2xx status code
+func IsSuccessStatus() ensure.Expectation[int] {
+	return NewStatusClass(2, "success")
+}
+
+// Convenience function for expecting a 3xx status code
+func IsRedirect() ensure.Expectation[int] {
+	return NewStatusClass(3, "redirect")
+}
+
+// Convenience function for expecting a 4xx status code
+func IsClientError() ensure.Expectation[int] {
+	return NewStatusClass(4, "client error")
+}
+
+// Convenience function for expecting a 5xx status code
+func IsServerError() ensure.Expectation[int] {
+	return NewStatusClass(5, "server error")
+}
+
+// formatStatus formats a status code with its text, e.g. "404 Not Found"
+func formatStatus(code int) string {
+	if text := http.StatusText(code); text != "" {
+		return fmt.Sprintf("%d %s", code, text)
+	}
+	return fmt.Sprint(code)
+}
+
+// IsOneOfExpectation checks if a value equals any of the expected values
+type IsOneOfExpectation[T comparable] struct {
+	expected []T
+}
+
+// NewIsOneOf creates a new IsOneOf expectation
+func NewIsOneOf[T comparable](expected ...T) ensure.Expectation[T] {
+	return IsOneOfExpectation[T]{expected: expected}
+}
+
+// Evaluate evaluates the is one of expectation
+func (io IsOneOfExpectation[T]) Evaluate(actual T) error {
+	if !slices.Contains(io.expected, actual) {
+		return core.NewAssertionError(io.expected, actual, "expected one of %v, but got %v", io.expected, actual)
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (io IsOneOfExpectation[T]) Description() string {
+	return fmt.Sprintf("is one of %v", io.expected)
+}
+
+// Convenience function for creating IsOneOf expectations, e.g. IsOneOf(200, 201, 204)
+// for acceptable status codes
+func IsOneOf[T comparable](expected ...T) ensure.Expectation[T] {
+	return NewIsOneOf(expected...)
+}
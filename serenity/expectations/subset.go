@@ -0,0 +1,217 @@
+package expectations
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// ContainsSubsetExpectation checks if a map or struct contains the expected keys or fields
+// with matching values, ignoring any others. Nested maps and structs are compared the same
+// way and slices element by element. Struct fields are named by their json tags, and only
+// non-zero fields of an expected struct are checked.
+type ContainsSubsetExpectation struct {
+	expected any
+}
+
+// NewContainsSubset creates a new ContainsSubset expectation
+func NewContainsSubset(expected any) ensure.Expectation[any] {
+	return ContainsSubsetExpectation{expected: expected}
+}
+
+// Evaluate evaluates the contains subset expectation
+func (cs ContainsSubsetExpectation) Evaluate(actual any) error {
+	expected, err := subsetValue(reflect.ValueOf(cs.expected))
+	if err != nil {
+		return fmt.Errorf("invalid expected subset: %w", err)
+	}
+
+	content, err := json.Marshal(actual)
+	if err != nil {
+		return fmt.Errorf("cannot compare %T with a subset: %w", actual, err)
+	}
+	var generic any
+	if err := json.Unmarshal(content, &generic); err != nil {
+		return fmt.Errorf("cannot compare %T with a subset: %w", actual, err)
+	}
+
+	mismatches := compareSubset("", expected, generic)
+	if len(mismatches) > 0 {
+		return core.NewAssertionError(cs.expected, actual, "expected to contain the subset, but %s", strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (cs ContainsSubsetExpectation) Description() string {
+	return fmt.Sprintf("contains %+v", cs.expected)
+}
+
+// Convenience function for creating ContainsSubset expectations, e.g. to check some
+// fields of an API payload
+//
+//	ensure.That(api.NewResponseBodyAsJSON[any](), expectations.ContainsSubset(map[string]any{
+//		"status":   "paid",
+//		"customer": map[string]any{"name": "Ada"},
+//	}))
+func ContainsSubset(expected any) ensure.Expectation[any] {
+	return NewContainsSubset(expected)
+}
+
+// subsetValue converts the expected subset to the maps, slices and scalars JSON decodes
+// to, leaving out the zero fields of structs
+func subsetValue(value reflect.Value) (any, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if _, ok := value.Interface().(json.Marshaler); ok {
+			return jsonValue(value.Interface())
+		}
+		fields := make(map[string]any)
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			name, skip := jsonFieldName(field)
+			if skip || value.Field(i).IsZero() {
+				continue
+			}
+			converted, err := subsetValue(value.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			fields[name] = converted
+		}
+		return fields, nil
+	case reflect.Map:
+		entries := make(map[string]any, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			converted, err := subsetValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			entries[fmt.Sprint(iter.Key().Interface())] = converted
+		}
+		return entries, nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+		elements := make([]any, value.Len())
+		for i := range elements {
+			converted, err := subsetValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = converted
+		}
+		return elements, nil
+	case reflect.Invalid:
+		return nil, nil
+	default:
+		return jsonValue(value.Interface())
+	}
+}
+
+// jsonValue returns the value as decoded from its JSON encoding
+func jsonValue(value any) (any, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	err = json.Unmarshal(content, &decoded)
+	return decoded, err
+}
+
+// jsonFieldName returns the JSON name of an exported struct field, and whether it is skipped
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", true
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, false
+	}
+	return field.Name, false
+}
+
+// compareSubset returns the differences between the expected subset and the actual value
+// at the path
+func compareSubset(path string, expected, actual any) []string {
+	switch want := expected.(type) {
+	case map[string]any:
+		got, ok := actual.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s is %s instead of an object", pathOrRoot(path), describeJSON(actual))}
+		}
+		keys := make([]string, 0, len(want))
+		for key := range want {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var mismatches []string
+		for _, key := range keys {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			value, present := got[key]
+			if !present {
+				mismatches = append(mismatches, fmt.Sprintf("%s is missing", child))
+				continue
+			}
+			mismatches = append(mismatches, compareSubset(child, want[key], value)...)
+		}
+		return mismatches
+	case []any:
+		got, ok := actual.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s is %s instead of an array", pathOrRoot(path), describeJSON(actual))}
+		}
+		if len(got) != len(want) {
+			return []string{fmt.Sprintf("%s has %d elements instead of %d", pathOrRoot(path), len(got), len(want))}
+		}
+		var mismatches []string
+		for i := range want {
+			mismatches = append(mismatches, compareSubset(fmt.Sprintf("%s[%d]", path, i), want[i], got[i])...)
+		}
+		return mismatches
+	default:
+		if !reflect.DeepEqual(expected, actual) {
+			return []string{fmt.Sprintf("%s is %s instead of %s", pathOrRoot(path), describeJSON(actual), describeJSON(expected))}
+		}
+		return nil
+	}
+}
+
+// pathOrRoot names the value at the path, or the root value for an empty path
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "the value"
+	}
+	return path
+}
+
+// describeJSON formats a decoded JSON value for messages
+func describeJSON(value any) string {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(content)
+}
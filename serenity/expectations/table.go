@@ -0,0 +1,94 @@
+package expectations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// HasHeaderExpectation checks if the first row of a table, such as CSV records or the
+// rows of a spreadsheet, consists of the expected columns
+type HasHeaderExpectation struct {
+	columns []string
+}
+
+// NewHasHeader creates a new HasHeader expectation
+func NewHasHeader(columns ...string) ensure.Expectation[[][]string] {
+	return HasHeaderExpectation{columns: columns}
+}
+
+// Evaluate evaluates the has header expectation
+func (hh HasHeaderExpectation) Evaluate(actual [][]string) error {
+	if len(actual) == 0 {
+		return core.NewAssertionError(hh.columns, actual, "expected header %s, but the table is empty", formatRow(hh.columns))
+	}
+	if !slices.Equal(actual[0], hh.columns) {
+		return core.NewAssertionError(hh.columns, actual[0], "expected header %s, but got %s", formatRow(hh.columns), formatRow(actual[0]))
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (hh HasHeaderExpectation) Description() string {
+	return fmt.Sprintf("has header %s", formatRow(hh.columns))
+}
+
+// Convenience function for creating HasHeader expectations
+func HasHeader(columns ...string) ensure.Expectation[[][]string] {
+	return NewHasHeader(columns...)
+}
+
+// RowWhereExpectation checks if a table with a header row has a row whose value in the
+// column equals the expected value
+type RowWhereExpectation struct {
+	column string
+	value  string
+}
+
+// NewRowWhere creates a new RowWhere expectation
+func NewRowWhere(column, value string) ensure.Expectation[[][]string] {
+	return RowWhereExpectation{column: column, value: value}
+}
+
+// Evaluate evaluates the row where expectation
+func (rw RowWhereExpectation) Evaluate(actual [][]string) error {
+	if len(actual) == 0 {
+		return core.NewAssertionError(rw.value, actual, "expected a row where %s is '%s', but the table is empty", rw.column, rw.value)
+	}
+
+	index := slices.Index(actual[0], rw.column)
+	if index < 0 {
+		return core.NewAssertionError(rw.value, actual[0], "expected a row where %s is '%s', but there is no such column in %s", rw.column, rw.value, formatRow(actual[0]))
+	}
+
+	values := make([]string, 0, len(actual)-1)
+	for _, row := range actual[1:] {
+		if index >= len(row) {
+			values = append(values, "")
+			continue
+		}
+		if row[index] == rw.value {
+			return nil
+		}
+		values = append(values, row[index])
+	}
+	return core.NewAssertionError(rw.value, values, "expected a row where %s is '%s', but got %s", rw.column, rw.value, formatRow(values))
+}
+
+// Description returns the expectation description
+func (rw RowWhereExpectation) Description() string {
+	return fmt.Sprintf("has a row where %s is '%s'", rw.column, rw.value)
+}
+
+// Convenience function for creating RowWhere expectations
+func RowWhere(column, value string) ensure.Expectation[[][]string] {
+	return NewRowWhere(column, value)
+}
+
+// formatRow formats the cells of a row for messages
+func formatRow(cells []string) string {
+	return "[" + strings.Join(cells, ", ") + "]"
+}
@@ -0,0 +1,140 @@
+package expectations
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+)
+
+// TextOption changes how EqualsText compares strings
+type TextOption func(*textComparison)
+
+// textComparison describes how to compare localized strings
+type textComparison struct {
+	ignoreDiacritics bool
+	ignoreCase       bool
+	locale           string
+}
+
+// IgnoringDiacritics compares strings without their accents and other combining marks,
+// so that "Café" equals "Cafe"
+func IgnoringDiacritics() TextOption {
+	return func(c *textComparison) {
+		c.ignoreDiacritics = true
+	}
+}
+
+// IgnoringCase compares strings regardless of letter case
+func IgnoringCase() TextOption {
+	return func(c *textComparison) {
+		c.ignoreCase = true
+	}
+}
+
+// CollatedIn compares strings with the collation rules of the locale, given as a BCP 47
+// tag such as "de" or "sv-SE", so that strings the locale considers equal match
+func CollatedIn(locale string) TextOption {
+	return func(c *textComparison) {
+		c.locale = locale
+	}
+}
+
+// EqualsTextExpectation checks if a string equals the expected text once both are
+// normalized to Unicode NFC, so that precomposed and decomposed accents match
+type EqualsTextExpectation struct {
+	expected   string
+	comparison textComparison
+}
+
+// NewEqualsText creates a new EqualsText expectation
+func NewEqualsText(expected string, opts ...TextOption) ensure.Expectation[string] {
+	expectation := EqualsTextExpectation{expected: expected}
+	for _, opt := range opts {
+		opt(&expectation.comparison)
+	}
+	return expectation
+}
+
+// Evaluate evaluates the equals text expectation
+func (et EqualsTextExpectation) Evaluate(actual string) error {
+	equal, err := et.comparison.equal(actual, et.expected)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		return core.NewAssertionError(et.expected, actual, "expected %s %q, but got %q", et.comparison, et.expected, actual)
+	}
+	return nil
+}
+
+// Description returns the expectation description
+func (et EqualsTextExpectation) Description() string {
+	return fmt.Sprintf("equals %s '%s'", et.comparison, et.expected)
+}
+
+// Convenience function for creating EqualsText expectations
+func EqualsText(expected string, opts ...TextOption) ensure.Expectation[string] {
+	return NewEqualsText(expected, opts...)
+}
+
+// equal compares the strings as configured
+func (c textComparison) equal(actual, expected string) (bool, error) {
+	if c.locale != "" {
+		tag, err := language.Parse(c.locale)
+		if err != nil {
+			return false, fmt.Errorf("invalid locale '%s': %w", c.locale, err)
+		}
+		var opts []collate.Option
+		if c.ignoreDiacritics {
+			opts = append(opts, collate.IgnoreDiacritics)
+		}
+		if c.ignoreCase {
+			opts = append(opts, collate.IgnoreCase)
+		}
+		return collate.New(tag, opts...).CompareString(actual, expected) == 0, nil
+	}
+
+	return c.normalize(actual) == c.normalize(expected), nil
+}
+
+// normalize returns the string in NFC, without diacritics and lowercased if configured
+func (c textComparison) normalize(s string) string {
+	s = norm.NFC.String(s)
+	if c.ignoreDiacritics {
+		stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+		if err == nil {
+			s = stripped
+		}
+	}
+	if c.ignoreCase {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+// String describes the comparison, e.g. "(in de, ignoring case) text"
+func (c textComparison) String() string {
+	var qualifiers []string
+	if c.locale != "" {
+		qualifiers = append(qualifiers, "in "+c.locale)
+	}
+	if c.ignoreDiacritics {
+		qualifiers = append(qualifiers, "ignoring diacritics")
+	}
+	if c.ignoreCase {
+		qualifiers = append(qualifiers, "ignoring case")
+	}
+	if len(qualifiers) == 0 {
+		return "text"
+	}
+	return fmt.Sprintf("text (%s)", strings.Join(qualifiers, ", "))
+}
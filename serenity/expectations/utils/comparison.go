@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
 )
 
 // compareValues compares numeric values using the specified operator
@@ -19,11 +21,11 @@ func CompareValues(actual, expected interface{}, operator string) error {
 	switch operator {
 	case ">":
 		if actualFloat <= expectedFloat {
-			return fmt.Errorf("expected value to be greater than %v, but got %v", expected, actual)
+			return core.NewAssertionError(expected, actual, "expected value to be greater than %v, but got %v", expected, actual)
 		}
 	case "<":
 		if actualFloat >= expectedFloat {
-			return fmt.Errorf("expected value to be less than %v, but got %v", expected, actual)
+			return core.NewAssertionError(expected, actual, "expected value to be less than %v, but got %v", expected, actual)
 		}
 	}
 
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"strings"
+)
+
+// maxDiffCells caps the size of the table LineDiff matches the changed lines with, which
+// takes memory proportional to the product of their counts: about 8 MB
+const maxDiffCells = 1 << 20
+
+// LineDiff returns a line-by-line diff of two texts, prefixing removed lines with "- ",
+// added lines with "+ " and unchanged lines with "  ". It returns an empty string when
+// the texts are equal. When too many lines changed to match them, every changed line of
+// the expected text is listed as removed, followed by those of the actual one as added.
+func LineDiff(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+
+	// Only the lines between the common leading and trailing ones need matching
+	prefix := 0
+	for prefix < len(expectedLines) && prefix < len(actualLines) && expectedLines[prefix] == actualLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(expectedLines)-prefix && suffix < len(actualLines)-prefix &&
+		expectedLines[len(expectedLines)-1-suffix] == actualLines[len(actualLines)-1-suffix] {
+		suffix++
+	}
+
+	var builder strings.Builder
+	for _, line := range expectedLines[:prefix] {
+		builder.WriteString("  " + line + "\n")
+	}
+	changedExpected := expectedLines[prefix : len(expectedLines)-suffix]
+	changedActual := actualLines[prefix : len(actualLines)-suffix]
+	if (len(changedExpected)+1)*(len(changedActual)+1) > maxDiffCells {
+		for _, line := range changedExpected {
+			builder.WriteString("- " + line + "\n")
+		}
+		for _, line := range changedActual {
+			builder.WriteString("+ " + line + "\n")
+		}
+	} else {
+		writeMatched(&builder, changedExpected, changedActual)
+	}
+	for _, line := range expectedLines[len(expectedLines)-suffix:] {
+		builder.WriteString("  " + line + "\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// writeMatched writes the diff of the lines, matching them by their longest common
+// subsequence
+func writeMatched(builder *strings.Builder, expectedLines, actualLines []string) {
+	// Longest common subsequence table
+	lcs := make([][]int, len(expectedLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(actualLines)+1)
+	}
+	for i := len(expectedLines) - 1; i >= 0; i-- {
+		for j := len(actualLines) - 1; j >= 0; j-- {
+			if expectedLines[i] == actualLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(expectedLines) || j < len(actualLines) {
+		switch {
+		case i < len(expectedLines) && j < len(actualLines) && expectedLines[i] == actualLines[j]:
+			builder.WriteString("  " + expectedLines[i] + "\n")
+			i++
+			j++
+		case i < len(expectedLines) && (j == len(actualLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			builder.WriteString("- " + expectedLines[i] + "\n")
+			i++
+		default:
+			builder.WriteString("+ " + actualLines[j] + "\n")
+			j++
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package load
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Result summarizes the performance of a swarm run
+type Result struct {
+	// Name is the name of the swarm
+	Name string
+	// Actors is the number of concurrent virtual actors
+	Actors int
+	// Iterations is the number of completed task iterations
+	Iterations int
+	// Errors is the number of iterations that failed
+	Errors int
+	// Elapsed is the wall-clock duration of the run
+	Elapsed time.Duration
+	// Latencies holds the duration of every iteration, sorted in ascending order
+	Latencies []time.Duration
+	// ErrorSamples holds distinct error messages with their number of occurrences
+	ErrorSamples map[string]int
+}
+
+// newResult builds a result from the raw measurements
+func newResult(name string, actors int, elapsed time.Duration, latencies []time.Duration, errors map[string]int) *Result {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	failed := 0
+	for _, count := range errors {
+		failed += count
+	}
+
+	return &Result{
+		Name:         name,
+		Actors:       actors,
+		Iterations:   len(sorted),
+		Errors:       failed,
+		Elapsed:      elapsed,
+		Latencies:    sorted,
+		ErrorSamples: errors,
+	}
+}
+
+// ErrorRate returns the share of failed iterations between 0 and 1
+func (r *Result) ErrorRate() float64 {
+	if r.Iterations == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Iterations)
+}
+
+// Throughput returns the number of iterations per second
+func (r *Result) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Iterations) / r.Elapsed.Seconds()
+}
+
+// Percentile returns the latency below which the given percentage (0-100) of iterations completed,
+// using the nearest-rank method
+func (r *Result) Percentile(percentile float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	if percentile <= 0 {
+		return r.Latencies[0]
+	}
+	if percentile >= 100 {
+		return r.Latencies[len(r.Latencies)-1]
+	}
+
+	rank := int(math.Ceil(percentile/100*float64(len(r.Latencies)))) - 1
+	return r.Latencies[max(rank, 0)]
+}
+
+// Mean returns the average latency
+func (r *Result) Mean() time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, latency := range r.Latencies {
+		total += latency
+	}
+	return total / time.Duration(len(r.Latencies))
+}
+
+// Summary returns a single-line performance summary
+func (r *Result) Summary() string {
+	return fmt.Sprintf("%d iterations by %d actors in %s (%.1f/s): p50 %s, p90 %s, p95 %s, p99 %s, max %s, errors %.2f%%",
+		r.Iterations, r.Actors, r.Elapsed.Round(time.Millisecond), r.Throughput(),
+		roundLatency(r.Percentile(50)), roundLatency(r.Percentile(90)), roundLatency(r.Percentile(95)),
+		roundLatency(r.Percentile(99)), roundLatency(r.Percentile(100)), r.ErrorRate()*100)
+}
+
+// roundLatency rounds a latency for display
+func roundLatency(latency time.Duration) time.Duration {
+	if latency >= time.Millisecond {
+		return latency.Round(100 * time.Microsecond)
+	}
+	return latency.Round(time.Microsecond)
+}
@@ -0,0 +1,40 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultStatistics(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	result := newResult("checkout", 4, 2*time.Second, latencies, map[string]int{"timeout": 3, "500": 2})
+
+	require.Equal(t, 100, result.Iterations)
+	require.Equal(t, 5, result.Errors)
+	require.InDelta(t, 0.05, result.ErrorRate(), 1e-9)
+	require.InDelta(t, 50.0, result.Throughput(), 1e-9)
+	require.Equal(t, time.Millisecond, result.Percentile(0))
+	require.Equal(t, 50*time.Millisecond, result.Percentile(50))
+	require.Equal(t, 95*time.Millisecond, result.Percentile(95))
+	require.Equal(t, 99*time.Millisecond, result.Percentile(99))
+	require.Equal(t, 100*time.Millisecond, result.Percentile(100))
+	require.Equal(t, 50500*time.Microsecond, result.Mean())
+	require.Equal(t, "timeout", mostFrequentError(result.ErrorSamples))
+	require.Equal(t, "100 iterations by 4 actors in 2s (50.0/s): p50 50ms, p90 90ms, p95 95ms, p99 99ms, max 100ms, errors 5.00%",
+		result.Summary())
+}
+
+func TestEmptyResult(t *testing.T) {
+	result := newResult("idle", 1, 0, nil, map[string]int{})
+
+	require.Equal(t, time.Duration(0), result.Percentile(95))
+	require.Equal(t, time.Duration(0), result.Mean())
+	require.Equal(t, 0.0, result.ErrorRate())
+	require.Equal(t, 0.0, result.Throughput())
+}
@@ -0,0 +1,260 @@
+// Package load runs functional Screenplay tasks as load tests.
+//
+// A swarm spins up N concurrent virtual actors that perform the same task repeatedly and
+// measures the latency of every iteration. The same tasks used in functional tests can be
+// reused unchanged, and the performance summary is rendered through the reporting pipeline
+// when the swarm is performed by a test actor:
+//
+//	checkout := load.Swarm("checkout", 50).
+//		WhoCan(func(index int) []abilities.Ability {
+//			return []abilities.Ability{api.CallAnApiAt(shopURL)}
+//		}).
+//		Performing(AddToCartAndCheckout()).
+//		For(30 * time.Second).
+//		ExpectingErrorRateBelow(0.01).
+//		ExpectingPercentileBelow(95, 500*time.Millisecond)
+//
+//	actor.AttemptsTo(checkout)
+//	result := checkout.Result() // latency percentiles, error rate, throughput
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// SwarmActivity is an activity that performs a task with many concurrent virtual actors
+type SwarmActivity struct {
+	name        string
+	actors      int
+	iterations  int
+	duration    time.Duration
+	rampUp      time.Duration
+	abilities   func(index int) []abilities.Ability
+	activities  []core.Activity
+	maxErrors   *float64
+	percentiles []percentileThreshold
+
+	mutex  sync.Mutex
+	result *Result
+}
+
+// percentileThreshold is a latency limit for a percentile
+type percentileThreshold struct {
+	percentile float64
+	limit      time.Duration
+}
+
+// Swarm creates a swarm of the given number of concurrent virtual actors.
+// By default each actor performs the task once; use Iterations or For to run longer.
+func Swarm(name string, actors int) *SwarmActivity {
+	if actors < 1 {
+		actors = 1
+	}
+	return &SwarmActivity{
+		name:       name,
+		actors:     actors,
+		iterations: 1,
+	}
+}
+
+// WhoCan sets the factory that creates the abilities of each virtual actor.
+// Every actor gets its own abilities, as abilities such as CallAnAPI keep per-actor state.
+func (s *SwarmActivity) WhoCan(factory func(index int) []abilities.Ability) *SwarmActivity {
+	s.abilities = factory
+	return s
+}
+
+// Performing sets the activities each virtual actor performs in every iteration
+func (s *SwarmActivity) Performing(activities ...core.Activity) *SwarmActivity {
+	s.activities = activities
+	return s
+}
+
+// Iterations sets how many times each virtual actor performs the task
+func (s *SwarmActivity) Iterations(iterations int) *SwarmActivity {
+	s.iterations = iterations
+	s.duration = 0
+	return s
+}
+
+// For makes each virtual actor repeat the task until the duration has passed
+func (s *SwarmActivity) For(duration time.Duration) *SwarmActivity {
+	s.duration = duration
+	return s
+}
+
+// RampUp spreads the start of the virtual actors evenly over the duration
+func (s *SwarmActivity) RampUp(duration time.Duration) *SwarmActivity {
+	s.rampUp = duration
+	return s
+}
+
+// ExpectingErrorRateBelow fails the swarm when the share of failed iterations (0-1) reaches the limit
+func (s *SwarmActivity) ExpectingErrorRateBelow(limit float64) *SwarmActivity {
+	s.maxErrors = &limit
+	return s
+}
+
+// ExpectingPercentileBelow fails the swarm when the latency percentile (0-100) reaches the limit
+func (s *SwarmActivity) ExpectingPercentileBelow(percentile float64, limit time.Duration) *SwarmActivity {
+	s.percentiles = append(s.percentiles, percentileThreshold{percentile: percentile, limit: limit})
+	return s
+}
+
+// Result returns the result of the last run, or nil if the swarm has not run yet
+func (s *SwarmActivity) Result() *Result {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.result
+}
+
+// Description returns the activity description
+func (s *SwarmActivity) Description() string {
+	return fmt.Sprintf("#actor unleashes a swarm of %d actors performing %s", s.actors, s.name)
+}
+
+// FailureMode returns the failure mode for swarms (default: FailFast)
+func (s *SwarmActivity) FailureMode() core.FailureMode {
+	return core.FailFast
+}
+
+// PerformAs runs the swarm and reports its performance summary as a sub-step.
+// It returns an error when a threshold is exceeded.
+func (s *SwarmActivity) PerformAs(actor core.Actor, ctx context.Context) error {
+	result, err := s.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	core.RecordAnswer(actor, fmt.Sprintf("the performance of %s", s.name), result)
+
+	return core.PerformAsSubStep(actor, ctx, core.Do(
+		fmt.Sprintf("#actor measures %s", result.Summary()),
+		func(actor core.Actor, ctx context.Context) error {
+			return s.checkThresholds(result)
+		},
+	))
+}
+
+// Run runs the swarm without an actor and returns the measurements
+func (s *SwarmActivity) Run(ctx context.Context) (*Result, error) {
+	if len(s.activities) == 0 {
+		return nil, fmt.Errorf("swarm %s has no activities to perform", s.name)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var deadline time.Time
+	if s.duration > 0 {
+		deadline = time.Now().Add(s.duration)
+	}
+
+	var (
+		mutex     sync.Mutex
+		latencies []time.Duration
+		failures  = make(map[string]int)
+		wg        sync.WaitGroup
+	)
+
+	start := time.Now()
+	for index := 0; index < s.actors; index++ {
+		var abilityList []abilities.Ability
+		if s.abilities != nil {
+			abilityList = s.abilities(index)
+		}
+		actor := newVirtualActor(ctx, fmt.Sprintf("%s #%d", s.name, index+1), abilityList)
+
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			if s.rampUp > 0 {
+				delay := s.rampUp * time.Duration(index) / time.Duration(s.actors)
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			for iteration := 0; s.shouldContinue(ctx, iteration, deadline); iteration++ {
+				began := time.Now()
+				actor.AttemptsTo(s.activities...)
+				latency := time.Since(began)
+				err := actor.takeError()
+
+				mutex.Lock()
+				latencies = append(latencies, latency)
+				if err != nil {
+					failures[err.Error()]++
+				}
+				mutex.Unlock()
+			}
+		}(index)
+	}
+	wg.Wait()
+
+	result := newResult(s.name, s.actors, time.Since(start), latencies, failures)
+
+	s.mutex.Lock()
+	s.result = result
+	s.mutex.Unlock()
+
+	return result, nil
+}
+
+// shouldContinue reports whether a virtual actor should perform another iteration
+func (s *SwarmActivity) shouldContinue(ctx context.Context, iteration int, deadline time.Time) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if !deadline.IsZero() {
+		return time.Now().Before(deadline)
+	}
+	return iteration < s.iterations
+}
+
+// checkThresholds returns an error describing every exceeded threshold
+func (s *SwarmActivity) checkThresholds(result *Result) error {
+	var violations []string
+
+	if s.maxErrors != nil && result.ErrorRate() >= *s.maxErrors {
+		violation := fmt.Sprintf("error rate %.2f%% is not below %.2f%%", result.ErrorRate()*100, *s.maxErrors*100)
+		if sample := mostFrequentError(result.ErrorSamples); sample != "" {
+			violation += fmt.Sprintf(" (most frequent: %s)", sample)
+		}
+		violations = append(violations, violation)
+	}
+
+	for _, threshold := range s.percentiles {
+		if actual := result.Percentile(threshold.percentile); actual >= threshold.limit {
+			violations = append(violations, fmt.Sprintf("p%g latency %s is not below %s",
+				threshold.percentile, roundLatency(actual), threshold.limit))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(violations, "; "))
+}
+
+// mostFrequentError returns the error message that occurred most often
+func mostFrequentError(samples map[string]int) string {
+	mostFrequent, highest := "", 0
+	for message, count := range samples {
+		if count > highest || (count == highest && message < mostFrequent) {
+			mostFrequent, highest = message, count
+		}
+	}
+	return mostFrequent
+}
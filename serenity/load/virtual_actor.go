@@ -0,0 +1,171 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// virtualActor is a lightweight actor used by swarms. Instead of failing a test,
+// it remembers the first error of each iteration so the swarm can count it.
+type virtualActor struct {
+	name      string
+	ctx       context.Context
+	mutex     sync.RWMutex
+	abilities []abilities.Ability
+	pacer     *core.Pacer
+	traits    core.Traits
+	err       error
+}
+
+// newVirtualActor creates a virtual actor with the given abilities
+func newVirtualActor(ctx context.Context, name string, abilityList []abilities.Ability) *virtualActor {
+	return &virtualActor{
+		name:      name,
+		ctx:       ctx,
+		abilities: abilityList,
+	}
+}
+
+// Context returns the swarm context
+func (va *virtualActor) Context() context.Context {
+	return va.ctx
+}
+
+// Name returns the actor name
+func (va *virtualActor) Name() string {
+	return va.name
+}
+
+// WhoCan adds abilities to the actor
+func (va *virtualActor) WhoCan(abilityList ...abilities.Ability) core.Actor {
+	va.mutex.Lock()
+	defer va.mutex.Unlock()
+	va.abilities = append(va.abilities, abilityList...)
+	return va
+}
+
+// WhoCanUseShared returns the actor unchanged, as swarms share no abilities between their
+// virtual actors; give each actor its abilities with SwarmActivity.WhoCan
+func (va *virtualActor) WhoCanUseShared(abilityTypes ...abilities.Ability) core.Actor {
+	return va
+}
+
+// Abilities returns a snapshot of the actor's abilities
+func (va *virtualActor) Abilities() []abilities.Ability {
+	va.mutex.RLock()
+	defer va.mutex.RUnlock()
+
+	snapshot := make([]abilities.Ability, len(va.abilities))
+	copy(snapshot, va.abilities)
+	return snapshot
+}
+
+// WithPacing limits the actor to the given number of activities per second
+func (va *virtualActor) WithPacing(activitiesPerSecond float64) core.Actor {
+	va.mutex.Lock()
+	defer va.mutex.Unlock()
+	va.pacer = core.NewPacer(activitiesPerSecond)
+	return va
+}
+
+// Is gives the actor traits
+func (va *virtualActor) Is(traits ...core.Trait) core.Actor {
+	va.traits.Add(traits...)
+	return va
+}
+
+// With sets an attribute of the actor
+func (va *virtualActor) With(key, value string) core.Actor {
+	va.traits.Set(key, value)
+	return va
+}
+
+// HasTrait reports whether the actor was given the trait
+func (va *virtualActor) HasTrait(trait core.Trait) bool {
+	return va.traits.Has(trait)
+}
+
+// Attribute returns the value of the attribute, and whether it is set
+func (va *virtualActor) Attribute(key string) (string, bool) {
+	return va.traits.Attribute(key)
+}
+
+// AbilityTo returns the ability of the requested type
+func (va *virtualActor) AbilityTo(abilityType abilities.Ability) (abilities.Ability, error) {
+	va.mutex.RLock()
+	defer va.mutex.RUnlock()
+
+	for _, ability := range va.abilities {
+		if core.AbilityMatches(ability, abilityType) {
+			return ability, nil
+		}
+	}
+	return nil, core.NewMissingAbilityError(va.name, core.AbilityName(abilityType), va.abilities)
+}
+
+// AttemptsTo performs the activities, honoring their failure modes. Steps of Repeat and
+// ForEach are performed within the context of the activity enclosing them.
+func (va *virtualActor) AttemptsTo(activities ...core.Activity) {
+	for _, activity := range activities {
+		va.mutex.RLock()
+		pacer := va.pacer
+		va.mutex.RUnlock()
+
+		ctx := va.ctx
+		if enclosing, ok := core.SubStepContext(activity); ok {
+			ctx = enclosing
+		}
+		err := pacer.Wait(ctx)
+		if err == nil {
+			err = activity.PerformAs(va, ctx)
+		}
+		if errors.Is(err, core.ErrTestSkipped) {
+			return
+		}
+		if err == nil || errors.Is(err, core.ErrSkipped) {
+			continue
+		}
+
+		switch core.FailureModeFor(activity, err) {
+		case core.FailFast:
+			va.recordError(fmt.Errorf("%s: %w", activity.Description(), err))
+			return
+		case core.ErrorButContinue:
+			va.recordError(fmt.Errorf("%s: %w", activity.Description(), err))
+		case core.Ignore:
+		}
+	}
+}
+
+// AnswersTo answers the question
+func (va *virtualActor) AnswersTo(question core.Question[any]) (any, bool) {
+	answer, err := question.AnsweredBy(va, va.ctx)
+	if err != nil {
+		va.recordError(fmt.Errorf("failed to answer question '%s': %w", question.Description(), err))
+		return nil, false
+	}
+	return answer, true
+}
+
+// recordError remembers the first error of the current iteration
+func (va *virtualActor) recordError(err error) {
+	va.mutex.Lock()
+	defer va.mutex.Unlock()
+	if va.err == nil {
+		va.err = err
+	}
+}
+
+// takeError returns and clears the error of the current iteration
+func (va *virtualActor) takeError() error {
+	va.mutex.Lock()
+	defer va.mutex.Unlock()
+	err := va.err
+	va.err = nil
+	return err
+}
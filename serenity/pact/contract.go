@@ -0,0 +1,162 @@
+// Package pact turns API interactions performed by actors into consumer-driven contracts.
+//
+// Wrapping an actor's CallAnAPI ability with a Contract records every request it sends and
+// the response it receives as a Pact interaction. When the test shuts down, the contract is
+// written to a Pact specification v2 file, so acceptance tests double as consumer contracts
+// that the provider team can verify with any Pact verifier:
+//
+//	var ordersContract = pact.NewContract("web-shop", "orders-service").WritingTo("pacts")
+//
+//	func TestOrderLookup(t *testing.T) {
+//		test := serenity.NewSerenityTest(t)
+//		actor := test.ActorCalled("Buyer").WhoCan(
+//			ordersContract.Recording(api.CallAnApiAt(ordersURL)),
+//		)
+//
+//		actor.AttemptsTo(
+//			pact.Given("order 42 exists").UponReceiving("a request for order 42"),
+//			api.SendGetRequest("/orders/42"),
+//			ensure.That(api.LastResponseStatus{}, expectations.Equals(200)),
+//		)
+//	} // pacts/web-shop-orders-service.json is written on Shutdown
+//
+// A contract can be shared by many tests; each shutdown rewrites the file with all
+// interactions recorded so far.
+package pact
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SpecificationVersion is the Pact specification version of the generated files
+const SpecificationVersion = "2.0.0"
+
+// Contract collects the interactions between a consumer and a provider
+type Contract struct {
+	consumer     string
+	provider     string
+	dir          string
+	mutex        sync.Mutex
+	interactions []Interaction
+}
+
+// Interaction is a single request/response pair of the contract
+type Interaction struct {
+	Description   string   `json:"description"`
+	ProviderState string   `json:"providerState,omitempty"`
+	Request       Request  `json:"request"`
+	Response      Response `json:"response"`
+}
+
+// Request is the expected request of an interaction
+type Request struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// Response is the minimal response the consumer relies on
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// file is the on-disk Pact document
+type file struct {
+	Consumer     participant   `json:"consumer"`
+	Provider     participant   `json:"provider"`
+	Interactions []Interaction `json:"interactions"`
+	Metadata     metadata      `json:"metadata"`
+}
+
+type participant struct {
+	Name string `json:"name"`
+}
+
+type metadata struct {
+	PactSpecification struct {
+		Version string `json:"version"`
+	} `json:"pactSpecification"`
+}
+
+// NewContract creates a contract between the consumer and the provider, written to the "pacts" directory
+func NewContract(consumer, provider string) *Contract {
+	return &Contract{
+		consumer: consumer,
+		provider: provider,
+		dir:      "pacts",
+	}
+}
+
+// WritingTo sets the directory where the pact file is written
+func (c *Contract) WritingTo(dir string) *Contract {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.dir = dir
+	return c
+}
+
+// Path returns the location of the pact file
+func (c *Contract) Path() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return filepath.Join(c.dir, fmt.Sprintf("%s-%s.json", c.consumer, c.provider))
+}
+
+// Interactions returns the interactions recorded so far
+func (c *Contract) Interactions() []Interaction {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	interactions := make([]Interaction, len(c.interactions))
+	copy(interactions, c.interactions)
+	return interactions
+}
+
+// record adds an interaction, replacing a previous one with the same description and provider state
+func (c *Contract) record(interaction Interaction) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, existing := range c.interactions {
+		if existing.Description == interaction.Description && existing.ProviderState == interaction.ProviderState {
+			c.interactions[i] = interaction
+			return
+		}
+	}
+	c.interactions = append(c.interactions, interaction)
+}
+
+// Write writes the pact file with all interactions recorded so far
+func (c *Contract) Write() error {
+	path := c.Path()
+
+	c.mutex.Lock()
+	document := file{
+		Consumer:     participant{Name: c.consumer},
+		Provider:     participant{Name: c.provider},
+		Interactions: append([]Interaction{}, c.interactions...),
+	}
+	c.mutex.Unlock()
+	document.Metadata.PactSpecification.Version = SpecificationVersion
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pact: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create pact directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write pact file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package pact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// DescribeInteraction is an interaction that names the next request recorded in the contract
+// and sets the provider state it requires
+type DescribeInteraction struct {
+	description   string
+	providerState string
+}
+
+// UponReceiving describes the next request the actor sends, e.g. "a request for order 42".
+// Without a description, recorded interactions are named after their method and path.
+func UponReceiving(description string) *DescribeInteraction {
+	return &DescribeInteraction{description: description}
+}
+
+// Given sets the provider state required by the next request, e.g. "order 42 exists"
+func Given(providerState string) *DescribeInteraction {
+	return &DescribeInteraction{providerState: providerState}
+}
+
+// UponReceiving sets the description of the next request
+func (d *DescribeInteraction) UponReceiving(description string) *DescribeInteraction {
+	d.description = description
+	return d
+}
+
+// Given sets the provider state required by the next request
+func (d *DescribeInteraction) Given(providerState string) *DescribeInteraction {
+	d.providerState = providerState
+	return d
+}
+
+// Description returns the interaction description
+func (d *DescribeInteraction) Description() string {
+	switch {
+	case d.providerState == "":
+		return fmt.Sprintf("#actor expects the provider to receive %s", d.description)
+	case d.description == "":
+		return fmt.Sprintf("#actor expects the provider state '%s'", d.providerState)
+	default:
+		return fmt.Sprintf("#actor expects the provider to receive %s given '%s'", d.description, d.providerState)
+	}
+}
+
+// PerformAs sets the description of the next interaction recorded by the actor's RecordingAPI
+func (d *DescribeInteraction) PerformAs(actor core.Actor, ctx context.Context) error {
+	recording, err := core.AbilityOf[*RecordingAPI](actor)
+	if err != nil {
+		return fmt.Errorf("actor does not have the ability to record a contract: %w", err)
+	}
+
+	recording.describeNext(pendingInteraction{
+		description:   d.description,
+		providerState: d.providerState,
+	})
+	return nil
+}
+
+// FailureMode returns the failure mode for describing interactions (default: FailFast)
+func (d *DescribeInteraction) FailureMode() core.FailureMode {
+	return core.FailFast
+}
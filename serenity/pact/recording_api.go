@@ -0,0 +1,175 @@
+package pact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+)
+
+// RecordedRequestHeaders are the request headers included in recorded interactions.
+// Other headers, such as Authorization or User-Agent, are environment specific and
+// intentionally left out of the contract.
+var RecordedRequestHeaders = []string{"Accept", "Content-Type"}
+
+// RecordedResponseHeaders are the response headers included in recorded interactions
+var RecordedResponseHeaders = []string{"Content-Type"}
+
+// RecordingAPI is a CallAnAPI ability that records the interactions it performs in a contract.
+// It is discarded on test shutdown, which writes the pact file.
+type RecordingAPI struct {
+	api.CallAnAPI
+	contract *Contract
+	mutex    sync.Mutex
+	next     pendingInteraction
+}
+
+// pendingInteraction holds the description and provider state for the next request
+type pendingInteraction struct {
+	description   string
+	providerState string
+}
+
+// Recording wraps the CallAnAPI ability so that its requests are recorded in the contract
+func (c *Contract) Recording(ability api.CallAnAPI) *RecordingAPI {
+	return &RecordingAPI{
+		CallAnAPI: ability,
+		contract:  c,
+	}
+}
+
+// Contract returns the contract the ability records into
+func (r *RecordingAPI) Contract() *Contract {
+	return r.contract
+}
+
+// SetDefaultHeader sets a default header on the wrapped ability, if it supports them.
+// Default headers are not recorded unless listed in RecordedRequestHeaders.
+func (r *RecordingAPI) SetDefaultHeader(name, value string) {
+	if headers, ok := r.CallAnAPI.(api.DefaultHeaders); ok {
+		headers.SetDefaultHeader(name, value)
+	}
+}
+
+// RemoveDefaultHeader removes a default header from the wrapped ability, if it supports them
+func (r *RecordingAPI) RemoveDefaultHeader(name string) {
+	if headers, ok := r.CallAnAPI.(api.DefaultHeaders); ok {
+		headers.RemoveDefaultHeader(name)
+	}
+}
+
+// DefaultHeaderValues returns the default headers of the wrapped ability, or nil if it
+// does not support them
+func (r *RecordingAPI) DefaultHeaderValues() http.Header {
+	if headers, ok := r.CallAnAPI.(api.DefaultHeaders); ok {
+		return headers.DefaultHeaderValues()
+	}
+	return nil
+}
+
+// SendRequest sends the request through the wrapped ability and records the interaction
+func (r *RecordingAPI) SendRequest(req *http.Request, ctx context.Context) (*http.Response, error) {
+	requestBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body for pact: %w", err)
+	}
+
+	resp, err := r.CallAnAPI.SendRequest(req, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for pact: %w", err)
+	}
+
+	r.mutex.Lock()
+	next := r.next
+	r.next = pendingInteraction{}
+	r.mutex.Unlock()
+
+	if next.description == "" {
+		next.description = fmt.Sprintf("a %s request to %s", req.Method, req.URL.Path)
+	}
+
+	r.contract.record(Interaction{
+		Description:   next.description,
+		ProviderState: next.providerState,
+		Request: Request{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Query:   req.URL.RawQuery,
+			Headers: selectHeaders(req.Header, RecordedRequestHeaders),
+			Body:    decodeBody(requestBody),
+		},
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: selectHeaders(resp.Header, RecordedResponseHeaders),
+			Body:    decodeBody(responseBody),
+		},
+	})
+
+	return resp, nil
+}
+
+// Discard writes the pact file
+func (r *RecordingAPI) Discard() error {
+	return r.contract.Write()
+}
+
+// describeNext sets the description and provider state of the next recorded interaction
+func (r *RecordingAPI) describeNext(next pendingInteraction) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.next = next
+}
+
+// readBody reads a body and replaces it with a re-readable copy
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// decodeBody returns JSON bodies as decoded values and other bodies as strings
+func decodeBody(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err == nil {
+		return decoded
+	}
+	return string(data)
+}
+
+// selectHeaders returns the listed headers that are present
+func selectHeaders(header http.Header, names []string) map[string]string {
+	selected := make(map[string]string)
+	for _, name := range names {
+		if values := header.Values(name); len(values) > 0 {
+			selected[name] = strings.Join(values, ", ")
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
@@ -1,6 +1,9 @@
 package reporting
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // TestRunnerAdapter provides integration with test runners
 type TestRunnerAdapter struct {
@@ -49,14 +52,19 @@ func NewActivityTrackerWithActor(reporter Reporter, activity string, actorName s
 
 // getActivityDescription replaces #actor placeholder with actor name
 func (at *ActivityTracker) getActivityDescription() string {
-	if at.actorName == "" {
-		return at.activity // No actor name, return original
+	return stepDescription(at.activity, at.actorName)
+}
+
+// stepDescription replaces the leading #actor placeholder of an activity description with the actor name
+func stepDescription(activity string, actorName string) string {
+	if actorName == "" {
+		return activity // No actor name, return original
 	}
 
 	// Replace #actor with actor name
-	description := at.activity
+	description := activity
 	if len(description) >= 7 && description[:7] == "#actor " {
-		description = at.actorName + " " + description[7:] // Replace "#actor " with actor name
+		description = actorName + " " + description[7:] // Replace "#actor " with actor name
 	}
 
 	return description
@@ -79,25 +87,16 @@ func (at *ActivityTracker) Finish(err error) {
 	}
 
 	description := at.getActivityDescription()
-	result := &testResult{
-		name:     description,
-		status:   status,
-		duration: time.Since(at.startTime).Seconds(),
-		error:    activityErr,
-	}
+	result := NewResult(description, status).
+		WithTimings(at.startTime, time.Since(at.startTime)).
+		WithError(activityErr)
 
 	at.reporter.OnStepFinish(result)
 }
 
-// testResult implements TestResult interface
-type testResult struct {
-	name     string
-	status   Status
-	duration float64
-	error    error
+// Skip reports the activity as skipped with the given reason instead of performing it
+func (at *ActivityTracker) Skip(reason string) {
+	description := at.getActivityDescription()
+	at.reporter.OnStepStart(description)
+	at.reporter.OnStepFinish(NewResult(description, StatusSkipped).WithError(errors.New(reason)))
 }
-
-func (tr *testResult) Name() string      { return tr.name }
-func (tr *testResult) Status() Status    { return tr.status }
-func (tr *testResult) Duration() float64 { return tr.duration }
-func (tr *testResult) Error() error      { return tr.error }
@@ -0,0 +1,188 @@
+// Package annotations provides a reporter that emits GitHub Actions workflow commands for the
+// failed steps of tests, at the source locations the steps were written at, so failures
+// surface inline on the diffs of pull requests:
+//
+//	::error file=checkout/checkout_test.go,line=42,title=TestCheckout/pay_by_card::Buyer ensures that the last response status equals 201%0AExpected: 201%0AActual:   402
+//
+// Paths are relative to GITHUB_WORKSPACE, the checkout of the repository. The annotator only
+// emits commands when GITHUB_ACTIONS is "true", so the same run settings can select it for
+// local runs:
+//
+//	test := serenity.NewSerenityTestWithReporter(ctx, t, reporting.NewMultiReporter(
+//		console_reporter.NewConsoleReporter(),
+//		annotations.NewAnnotator(),
+//	))
+//
+// With the run settings, the "annotations" reporter selects it for every test once the
+// package is imported.
+package annotations
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/failures"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// Annotator is a reporter emitting a GitHub Actions error annotation for each failed test,
+// located at its innermost failed step
+type Annotator struct {
+	output    io.Writer
+	enabled   bool
+	workspace string
+	failures  *failures.Formatter
+	mutex     sync.Mutex
+}
+
+// NewAnnotator creates an annotator writing to os.Stdout, where the runner reads workflow
+// commands from, enabled when running in GitHub Actions
+func NewAnnotator() *Annotator {
+	return &Annotator{
+		output:    os.Stdout,
+		enabled:   os.Getenv("GITHUB_ACTIONS") == "true",
+		workspace: os.Getenv("GITHUB_WORKSPACE"),
+		failures:  failures.NewFormatter(),
+	}
+}
+
+// SetEnabled forces the annotations on or off, e.g. for runners that are not detected
+func (a *Annotator) SetEnabled(enabled bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.enabled = enabled
+}
+
+// Enabled reports whether the annotator emits annotations
+func (a *Annotator) Enabled() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.enabled
+}
+
+// SetWorkspace sets the directory paths are made relative to, GITHUB_WORKSPACE by default
+func (a *Annotator) SetWorkspace(dir string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.workspace = dir
+}
+
+// OnTestStart is a no-op
+func (a *Annotator) OnTestStart(testName string) {}
+
+// OnTestFinish annotates the innermost failed steps of a failed test, or the test itself
+// if none of them is located
+func (a *Annotator) OnTestFinish(result reporting.TestResult) {
+	if result.Status() != reporting.StatusFailed {
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.enabled {
+		return
+	}
+
+	annotated := false
+	for _, step := range failedSteps(result.Children()) {
+		located, ok := step.(reporting.LocatedResult)
+		if !ok || located.Location() == "" {
+			continue
+		}
+		file, line := a.split(located.Location())
+		message := step.Name()
+		if step.Error() != nil {
+			message += "\n" + a.failures.Format(step.Error())
+		}
+		a.emit(map[string]string{"file": file, "line": line, "title": result.Name()}, message)
+		annotated = true
+	}
+
+	if !annotated {
+		message := "test failed"
+		if result.Error() != nil {
+			message = a.failures.Format(result.Error())
+		}
+		a.emit(map[string]string{"title": result.Name()}, message)
+	}
+}
+
+// OnStepStart is a no-op
+func (a *Annotator) OnStepStart(stepDescription string) {}
+
+// OnStepFinish is a no-op; failed steps are annotated with their test
+func (a *Annotator) OnStepFinish(stepResult reporting.TestResult) {}
+
+// SetOutput sets the output destination
+func (a *Annotator) SetOutput(w io.Writer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.output = w
+}
+
+// emit writes an error command with the properties that are set. The caller must hold the
+// mutex.
+func (a *Annotator) emit(properties map[string]string, message string) {
+	var parts []string
+	for _, name := range []string{"file", "line", "title"} {
+		if value := properties[name]; value != "" {
+			parts = append(parts, name+"="+escapeProperty(secrets.Mask(value)))
+		}
+	}
+	_, _ = fmt.Fprintf(a.output, "::error %s::%s\n", strings.Join(parts, ","), escapeData(secrets.Mask(message)))
+}
+
+// split splits a "file:line" location, making the file relative to the workspace. The
+// caller must hold the mutex.
+func (a *Annotator) split(location string) (file, line string) {
+	file = location
+	if i := strings.LastIndex(location, ":"); i >= 0 {
+		if _, err := strconv.Atoi(location[i+1:]); err == nil {
+			file, line = location[:i], location[i+1:]
+		}
+	}
+	if a.workspace != "" {
+		if rel, err := filepath.Rel(a.workspace, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file), line
+}
+
+// failedSteps returns the failed steps without failed nested steps, which explain the
+// failure of the steps containing them
+func failedSteps(steps []reporting.TestResult) []reporting.TestResult {
+	var failed []reporting.TestResult
+	for _, step := range steps {
+		if step.Status() != reporting.StatusFailed {
+			continue
+		}
+		if nested := failedSteps(step.Children()); len(nested) > 0 {
+			failed = append(failed, nested...)
+		} else {
+			failed = append(failed, step)
+		}
+	}
+	return failed
+}
+
+// escapeData escapes the message of a workflow command
+func escapeData(text string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(text)
+}
+
+// escapeProperty escapes the value of a property of a workflow command
+func escapeProperty(text string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(text)
+}
@@ -0,0 +1,12 @@
+package annotations
+
+import (
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+func init() {
+	reporting.RegisterReporter("annotations", func(settings config.RunSettings) (reporting.Reporter, error) {
+		return NewAnnotator(), nil
+	})
+}
@@ -0,0 +1,104 @@
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// AttachmentWriter is a reporter that saves the attachments of tests and steps, such as
+// response bodies, to the output directory, under attachments/<test>/<name>
+type AttachmentWriter struct {
+	dir     *OutputDir
+	output  io.Writer
+	test    string
+	written map[string]bool
+	mutex   sync.Mutex
+}
+
+// NewAttachmentWriter creates a reporter saving attachments to the output directory. Files
+// it fails to write are reported to the output, os.Stderr by default.
+func NewAttachmentWriter(dir *OutputDir) *AttachmentWriter {
+	return &AttachmentWriter{dir: dir, output: os.Stderr}
+}
+
+// OnTestStart starts collecting the attachments of the test
+func (aw *AttachmentWriter) OnTestStart(testName string) {
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	aw.test = testName
+	aw.written = make(map[string]bool)
+}
+
+// OnTestFinish saves the attachments of the test
+func (aw *AttachmentWriter) OnTestFinish(result TestResult) {
+	aw.save(result.Attachments())
+}
+
+// OnStepStart is a no-op
+func (aw *AttachmentWriter) OnStepStart(stepDescription string) {}
+
+// OnStepFinish saves the attachments of the step; those of nested steps were saved when
+// they finished
+func (aw *AttachmentWriter) OnStepFinish(stepResult TestResult) {
+	aw.save(stepResult.Attachments())
+}
+
+// SetOutput sets where files that can't be written are reported
+func (aw *AttachmentWriter) SetOutput(w io.Writer) {
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	aw.output = w
+}
+
+// save writes the attachments to the folder of the current test, numbering the ones whose
+// names were already used by the test
+func (aw *AttachmentWriter) save(attachments []Attachment) {
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	for _, attachment := range attachments {
+		name := path.Join("attachments", safeName(aw.test), safeName(attachment.Name))
+		for n := 2; aw.written[name]; n++ {
+			extension := path.Ext(attachment.Name)
+			name = path.Join("attachments", safeName(aw.test),
+				fmt.Sprintf("%s-%d%s", safeName(strings.TrimSuffix(attachment.Name, extension)), n, extension))
+		}
+		if aw.written == nil {
+			aw.written = make(map[string]bool)
+		}
+		aw.written[name] = true
+
+		if _, err := aw.dir.WriteFile(name, attachment.Content); err != nil {
+			_, _ = fmt.Fprintf(aw.output, "⚠️ failed to save attachment %s of %s: %v\n", attachment.Name, aw.test, err)
+		}
+	}
+}
+
+// safeName replaces the characters of a test or attachment name that are unsafe in paths,
+// keeping the slashes separating subtests
+func safeName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '/':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	var parts []string
+	for _, part := range strings.Split(safe, "/") {
+		if part != "" && part != "." && part != ".." {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "_"
+	}
+	return strings.Join(parts, "/")
+}
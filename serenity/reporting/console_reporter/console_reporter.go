@@ -3,12 +3,17 @@ package console_reporter
 import (
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/nchursin/serenity-go/serenity/events"
 	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/failures"
+	"github.com/nchursin/serenity-go/serenity/secrets"
 )
 
 // activeStep represents a currently executing step
@@ -25,6 +30,7 @@ type ConsoleReporter struct {
 	indentLevel int
 	mutex       sync.RWMutex
 	activeSteps map[string]*activeStep // key: description + indent
+	failures    *failures.Formatter    // renders step errors, expanding assertion failures
 }
 
 // NewConsoleReporter creates a new console reporter
@@ -32,6 +38,7 @@ func NewConsoleReporter() *ConsoleReporter {
 	return &ConsoleReporter{
 		output:      os.Stdout,
 		activeSteps: make(map[string]*activeStep),
+		failures:    failures.NewFormatter(),
 	}
 }
 
@@ -40,6 +47,14 @@ func (cr *ConsoleReporter) SetOutput(w io.Writer) {
 	cr.output = w
 }
 
+// SetColors enables or disables ANSI colors in assertion failures
+func (cr *ConsoleReporter) SetColors(enabled bool) {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+
+	cr.failures.WithColors(enabled)
+}
+
 // OnTestStart is called when a test begins
 func (cr *ConsoleReporter) OnTestStart(testName string) {
 	cr.mutex.Lock()
@@ -49,6 +64,15 @@ func (cr *ConsoleReporter) OnTestStart(testName string) {
 	cr.writeLine("🚀 Starting: %s", testName)
 }
 
+// OnTestStartIn is called when a test begins; tests nested in a feature or scenario
+// outline show their hierarchy under the name
+func (cr *ConsoleReporter) OnTestStartIn(testName string, contexts []events.Context) {
+	cr.OnTestStart(testName)
+	if len(contexts) > 1 {
+		cr.writeLine("   %s", events.Path(contexts))
+	}
+}
+
 // OnTestFinish is called when a test completes
 func (cr *ConsoleReporter) OnTestFinish(result reporting.TestResult) {
 	emoji := "✅"
@@ -63,12 +87,20 @@ func (cr *ConsoleReporter) OnTestFinish(result reporting.TestResult) {
 		statusText = "SKIPPED"
 	}
 
-	cr.writeLine("%s %s: %s (%.2fs)", emoji, result.Name(), statusText, result.Duration())
+	cr.writeLine("%s %s: %s (%s)", emoji, result.Name(), statusText, reporting.FormatDuration(result.Duration()))
 
 	if result.Error() != nil {
 		cr.writeLine("   Error: %s", result.Error().Error())
 	}
 
+	if traced, ok := result.(reporting.TracedResult); ok && len(traced.Requirements()) > 0 {
+		cr.writeLine("   Covers: %s", strings.Join(traced.Requirements(), ", "))
+	}
+
+	if cased, ok := result.(reporting.CaseResult); ok && len(cased.Cases()) > 0 {
+		cr.writeLine("   Cases: %s", strings.Join(cased.Cases(), ", "))
+	}
+
 	cr.writeLine("")
 }
 
@@ -99,18 +131,44 @@ func (cr *ConsoleReporter) OnStepFinish(stepResult reporting.TestResult) {
 	cr.removeActiveStep(description, indentLevel)
 
 	emoji := "✅"
-	if stepResult.Status() == reporting.StatusFailed {
+	switch stepResult.Status() {
+	case reporting.StatusFailed:
 		emoji = "❌"
+	case reporting.StatusSkipped:
+		emoji = "⏭️"
 	}
 
 	indent := cr.getIndent()
 
 	// Overwrite the current line with completion status
-	cr.writeOverLine("%s%s %s (%.2fs)", indent, emoji, description, stepResult.Duration())
+	cr.writeOverLine("%s%s %s (%s)", indent, emoji, description, reporting.FormatDuration(stepResult.Duration()))
+
+	// Metadata attached to the step, e.g. ticket IDs, is listed under it in key order
+	if annotated, ok := stepResult.(reporting.AnnotatedResult); ok {
+		metadata := annotated.Metadata()
+		for _, key := range slices.Sorted(maps.Keys(metadata)) {
+			cr.writeLine("%s   %s: %s", indent, key, metadata[key])
+		}
+	}
+	for _, attachment := range stepResult.Attachments() {
+		cr.writeLine("%s   📎 %s (%s, %d bytes)", indent, attachment.Name, attachment.MediaType, len(attachment.Content))
+	}
 
-	// Handle error output on separate line if there's an error
+	// Handle error output on separate line if there's an error; skipped steps carry the reason
 	if stepResult.Error() != nil {
-		cr.writeLine("%s   Error: %s", indent, stepResult.Error().Error())
+		label := "Error"
+		if stepResult.Status() == reporting.StatusSkipped {
+			label = "Reason"
+		}
+		cr.mutex.RLock()
+		message := cr.failures.Format(stepResult.Error())
+		cr.mutex.RUnlock()
+		message = strings.ReplaceAll(message, "\n", "\n"+indent+"   ")
+		cr.writeLine("%s   %s: %s", indent, label, message)
+
+		if located, ok := stepResult.(reporting.LocatedResult); ok && located.Location() != "" && stepResult.Status() == reporting.StatusFailed {
+			cr.writeLine("%s   at %s", indent, located.Location())
+		}
 	}
 
 	cr.mutex.Lock()
@@ -182,7 +240,7 @@ func (cr *ConsoleReporter) writeWithoutNewline(format string, args ...interface{
 	cr.mutex.Lock()
 	defer cr.mutex.Unlock()
 	if cr.output != nil {
-		_, _ = fmt.Fprintf(cr.output, format, args...)
+		_, _ = fmt.Fprint(cr.output, secrets.Mask(fmt.Sprintf(format, args...)))
 	}
 }
 
@@ -191,7 +249,7 @@ func (cr *ConsoleReporter) writeOverLine(format string, args ...interface{}) {
 	cr.mutex.Lock()
 	defer cr.mutex.Unlock()
 	if cr.output != nil {
-		content := fmt.Sprintf(format, args...)
+		content := secrets.Mask(fmt.Sprintf(format, args...))
 		// Clear line completely and write new content
 		_, _ = fmt.Fprintf(cr.output, "\r%s\n", content)
 	}
@@ -209,6 +267,6 @@ func (cr *ConsoleReporter) writeLine(format string, args ...interface{}) {
 	cr.mutex.Lock()
 	defer cr.mutex.Unlock()
 	if cr.output != nil {
-		_, _ = fmt.Fprintf(cr.output, format+"\n", args...)
+		_, _ = fmt.Fprintln(cr.output, secrets.Mask(fmt.Sprintf(format, args...)))
 	}
 }
@@ -0,0 +1,156 @@
+// Package coverage measures which operations of an API the tests exercise: it records the
+// requests actors send with the api ability and compares them with the operations of an
+// OpenAPI spec, highlighting the untested ones:
+//
+//	Endpoint coverage: 3 of 5 operations (60.0%)
+//	  ✅ GET /pets (listPets): 4 calls by 2 tests
+//	  ✅ POST /pets (createPet): 1 call by 1 test
+//	  ✅ GET /pets/{petId} (showPetById): 2 calls by 1 test
+//	  ❌ PUT /pets/{petId} (updatePet)
+//	  ❌ DELETE /pets/{petId} (deletePet)
+//	Requests outside the spec
+//	  ⚠️ GET /health: 1 call
+//
+// Request paths match the paths of the spec by their trailing segments, so base URLs with a
+// path prefix such as /api/v1 need no configuration. The recorder is selected with the
+// "coverage" reporter of the run settings, available once the package is imported, whose
+// entry in the reporting settings names the spec (see Settings), and writes the report when
+// serenity.Main finishes the run. It is shared by the tests of the run, and attributes
+// requests to the test started last, so the tests covering an operation are only exact when
+// the tests don't run in parallel.
+package coverage
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Operation is an operation of the spec
+type Operation struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+}
+
+// String renders the operation as "GET /pets/{petId} (showPetById)"
+func (o Operation) String() string {
+	text := o.Method + " " + o.Path
+	if o.OperationID != "" {
+		text += " (" + o.OperationID + ")"
+	}
+	return text
+}
+
+// Spec holds the operations of an OpenAPI spec
+type Spec struct {
+	Operations []Operation
+}
+
+// methods are the HTTP methods of the operations of a path item, in report order
+var methods = []string{"get", "post", "put", "patch", "delete", "head", "options", "trace"}
+
+// LoadOpenAPI loads the operations of the OpenAPI 3 or Swagger 2 spec at path, in YAML or JSON
+func LoadOpenAPI(path string) (*Spec, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is configured by the test suite
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec %s: %w", path, err)
+	}
+	var document struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec %s: %w", path, err)
+	}
+
+	spec := &Spec{}
+	for _, path := range slices.Sorted(maps.Keys(document.Paths)) {
+		for _, method := range methods {
+			node, ok := document.Paths[path][method]
+			if !ok {
+				continue
+			}
+			var operation struct {
+				OperationID string `yaml:"operationId"`
+			}
+			if err := node.Decode(&operation); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			spec.Operations = append(spec.Operations, Operation{
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				OperationID: operation.OperationID,
+			})
+		}
+	}
+	if len(spec.Operations) == 0 {
+		return nil, fmt.Errorf("OpenAPI spec %s has no operations", path)
+	}
+	return spec, nil
+}
+
+// Match returns the operation of the request: the operation with the method whose path
+// matches the trailing segments of the request path, preferring literal segments over
+// parameters, e.g. /pets/mine over /pets/{petId}, and then longer paths
+func (s *Spec) Match(method, rawURL string) (Operation, bool) {
+	segments := segmentsOf(requestPath(rawURL))
+
+	best, bestScore, bestLength := Operation{}, -1, -1
+	for _, operation := range s.Operations {
+		if operation.Method != strings.ToUpper(method) {
+			continue
+		}
+		template := segmentsOf(operation.Path)
+		score, ok := matches(template, segments)
+		if ok && (score > bestScore || score == bestScore && len(template) > bestLength) {
+			best, bestScore, bestLength = operation, score, len(template)
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// matches reports whether the template segments match the trailing request segments, and
+// scores the match by its literal segments
+func matches(template, request []string) (int, bool) {
+	if len(template) > len(request) || len(template) == 0 && len(request) > 0 {
+		return 0, false
+	}
+	request = request[len(request)-len(template):]
+	score := 0
+	for i, segment := range template {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+		case segment == request[i]:
+			score++
+		default:
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+// requestPath returns the path of the request URL, which may be relative
+func requestPath(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		path, _, _ := strings.Cut(rawURL, "?")
+		return path
+	}
+	return parsed.Path
+}
+
+// segmentsOf splits a path into its non-empty segments
+func segmentsOf(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
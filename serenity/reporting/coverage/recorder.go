@@ -0,0 +1,226 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// ReportFileName is the name of the JSON report in the output directory
+const ReportFileName = "endpoint-coverage.json"
+
+// OperationCoverage is an operation of the spec with the requests exercising it
+type OperationCoverage struct {
+	Operation
+	Calls int      `json:"calls"`
+	Tests []string `json:"tests,omitempty"`
+}
+
+// Covered reports whether a test exercised the operation
+func (c OperationCoverage) Covered() bool {
+	return c.Calls > 0
+}
+
+// Uncovered is a request matching no operation of the spec
+type Uncovered struct {
+	Method string   `json:"method"`
+	Path   string   `json:"path"`
+	Calls  int      `json:"calls"`
+	Tests  []string `json:"tests,omitempty"`
+}
+
+// Report compares the requests of the tests with the operations of the spec
+type Report struct {
+	Operations []OperationCoverage `json:"operations"`
+	Uncovered  []Uncovered         `json:"outsideSpec,omitempty"`
+}
+
+// Covered returns the number of operations exercised by the tests
+func (r Report) Covered() int {
+	covered := 0
+	for _, operation := range r.Operations {
+		if operation.Covered() {
+			covered++
+		}
+	}
+	return covered
+}
+
+// Ratio returns the share of the operations exercised by the tests, from 0 to 1
+func (r Report) Ratio() float64 {
+	if len(r.Operations) == 0 {
+		return 0
+	}
+	return float64(r.Covered()) / float64(len(r.Operations))
+}
+
+// Render writes the report as text, marking the untested operations
+func (r Report) Render(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Endpoint coverage: %d of %d operations (%.1f%%)\n", r.Covered(), len(r.Operations), 100*r.Ratio())
+	for _, operation := range r.Operations {
+		if !operation.Covered() {
+			fmt.Fprintf(&b, "  ❌ %s\n", operation.Operation)
+			continue
+		}
+		fmt.Fprintf(&b, "  ✅ %s: %s by %s\n", operation.Operation,
+			plural(operation.Calls, "call"), plural(len(operation.Tests), "test"))
+	}
+	if len(r.Uncovered) > 0 {
+		b.WriteString("Requests outside the spec\n")
+		for _, request := range r.Uncovered {
+			fmt.Fprintf(&b, "  ⚠️ %s %s: %s\n", request.Method, request.Path, plural(request.Calls, "call"))
+		}
+	}
+	if _, err := io.WriteString(w, secrets.Mask(b.String())); err != nil {
+		return fmt.Errorf("failed to render the endpoint coverage: %w", err)
+	}
+	return nil
+}
+
+// plural renders the count of a noun, e.g. "1 call" or "2 calls"
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// Recorder is a reporter recording the requests of the tests against the operations of a
+// spec. When the run finishes, it renders the report to the output and saves it as JSON to
+// the output directory.
+type Recorder struct {
+	spec       *Spec
+	dir        *reporting.OutputDir
+	output     io.Writer
+	test       string
+	operations map[Operation]*OperationCoverage
+	uncovered  map[string]*Uncovered
+	mutex      sync.Mutex
+}
+
+// NewRecorder creates a recorder of the requests exercising the spec, saving the report to
+// the output directory unless it is nil. The report is rendered to os.Stdout by default.
+func NewRecorder(spec *Spec, dir *reporting.OutputDir) *Recorder {
+	return &Recorder{
+		spec:       spec,
+		dir:        dir,
+		output:     os.Stdout,
+		operations: make(map[Operation]*OperationCoverage),
+		uncovered:  make(map[string]*Uncovered),
+	}
+}
+
+// OnTestStart attributes the following requests to the test
+func (r *Recorder) OnTestStart(testName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.test = testName
+}
+
+// OnTestFinish is a no-op
+func (r *Recorder) OnTestFinish(result reporting.TestResult) {}
+
+// OnStepStart is a no-op
+func (r *Recorder) OnStepStart(stepDescription string) {}
+
+// OnStepFinish is a no-op
+func (r *Recorder) OnStepFinish(stepResult reporting.TestResult) {}
+
+// OnRequestSent records the request against the operation it matches. Requests without a
+// response didn't reach the API and are not recorded.
+func (r *Recorder) OnRequestSent(method, url string, status int) {
+	if status == 0 {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if operation, ok := r.spec.Match(method, url); ok {
+		coverage, ok := r.operations[operation]
+		if !ok {
+			coverage = &OperationCoverage{Operation: operation}
+			r.operations[operation] = coverage
+		}
+		coverage.Calls++
+		if !slices.Contains(coverage.Tests, r.test) {
+			coverage.Tests = append(coverage.Tests, r.test)
+		}
+		return
+	}
+
+	path := requestPath(url)
+	key := strings.ToUpper(method) + " " + path
+	request, ok := r.uncovered[key]
+	if !ok {
+		request = &Uncovered{Method: strings.ToUpper(method), Path: path}
+		r.uncovered[key] = request
+	}
+	request.Calls++
+	if !slices.Contains(request.Tests, r.test) {
+		request.Tests = append(request.Tests, r.test)
+	}
+}
+
+// SetOutput sets where the report is rendered, os.Stdout by default
+func (r *Recorder) SetOutput(w io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.output = w
+}
+
+// Report returns the coverage of the requests recorded so far, with the operations in the
+// order of the spec and the requests outside the spec by path
+func (r *Recorder) Report() Report {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	report := Report{Operations: make([]OperationCoverage, 0, len(r.spec.Operations))}
+	for _, operation := range r.spec.Operations {
+		coverage := OperationCoverage{Operation: operation}
+		if recorded, ok := r.operations[operation]; ok {
+			coverage.Calls = recorded.Calls
+			coverage.Tests = slices.Clone(recorded.Tests)
+		}
+		report.Operations = append(report.Operations, coverage)
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.uncovered)) {
+		request := *r.uncovered[key]
+		request.Tests = slices.Clone(request.Tests)
+		report.Uncovered = append(report.Uncovered, request)
+	}
+	return report
+}
+
+// OnRunFinish renders the report to the output and saves it to the output directory
+func (r *Recorder) OnRunFinish() {
+	report := r.Report()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := report.Render(r.output); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "⚠️ %v\n", err)
+	}
+	if r.dir == nil {
+		return
+	}
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err == nil {
+		_, err = r.dir.WriteFile(ReportFileName, []byte(secrets.Mask(string(content))))
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(r.output, "⚠️ failed to save the endpoint coverage: %v\n", err)
+	}
+}
@@ -0,0 +1,66 @@
+package coverage
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// endpointCoverage holds the recorder shared by the tests of the process
+var endpointCoverage struct {
+	recorder *Recorder
+	mutex    sync.Mutex
+}
+
+// Settings configures the "coverage" reporter. They are read from the coverage entry of the
+// reporting settings:
+//
+//	reporting:
+//	  coverage:
+//	    openapi: api/openapi.yaml
+//
+// and overridden by SERENITY_OPENAPI.
+type Settings struct {
+	// OpenAPI is the spec the requests of the tests are compared with
+	OpenAPI string `yaml:"openapi"`
+}
+
+func init() {
+	reporting.RegisterReporter("coverage", runCoverage)
+}
+
+// settingsOf returns the coverage settings of the run, with the environment overrides applied
+func settingsOf(run config.RunSettings) (Settings, error) {
+	var settings Settings
+	if err := run.ReporterSettings("coverage", &settings); err != nil {
+		return Settings{}, err
+	}
+	err := config.ApplyOverrides(map[string]any{"OPENAPI": &settings.OpenAPI})
+	return settings, err
+}
+
+// runCoverage returns the recorder comparing the requests of the run with the OpenAPI spec
+// of the settings
+func runCoverage(run config.RunSettings) (reporting.Reporter, error) {
+	endpointCoverage.mutex.Lock()
+	defer endpointCoverage.mutex.Unlock()
+
+	if endpointCoverage.recorder != nil {
+		return endpointCoverage.recorder, nil
+	}
+	settings, err := settingsOf(run)
+	if err != nil {
+		return nil, err
+	}
+	if settings.OpenAPI == "" {
+		return nil, errors.New("the coverage reporter needs reporting.coverage.openapi or SERENITY_OPENAPI")
+	}
+	spec, err := LoadOpenAPI(settings.OpenAPI)
+	if err != nil {
+		return nil, err
+	}
+	endpointCoverage.recorder = NewRecorder(spec, reporting.OutputDirOf(run))
+	return endpointCoverage.recorder, nil
+}
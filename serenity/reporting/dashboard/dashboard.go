@@ -0,0 +1,294 @@
+// Package dashboard provides an interactive console mode that renders a live summary of
+// the run: the current test, its running steps with spinners, and the counts of passed,
+// failed and skipped tests. Finished tests are listed above the summary:
+//
+//	✅ TestCatalog (212ms)
+//	❌ TestCheckout/pay_by_card (1.4s)
+//	   Error: expected status 201, but got 402
+//	⠙ TestCheckout/pay_by_invoice (0.8s)
+//	    ⠙ Buyer pays by invoice
+//	      ⠙ Buyer sends POST request to /invoices
+//	✅ 1 passed  ❌ 1 failed  ⏭️ 0 skipped  ⏱ 2.4s
+//
+// The dashboard redraws in place, so it needs a terminal; when the output is not one, e.g.
+// in CI logs, it falls back to the plain output of the console reporter. It is shared by
+// the tests of the run, and assumes they don't run in parallel:
+//
+//	board := dashboard.New()
+//	defer board.Close()
+//
+//	test := serenity.NewSerenityTestWithReporter(ctx, t, board)
+//
+// With the run settings, the "dashboard" reporter selects it for every test once the
+// package is imported, and serenity.Main closes it when the run finishes.
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+const (
+	// refreshInterval is the time between redraws animating the spinners
+	refreshInterval = 100 * time.Millisecond
+
+	// clearBlock moves the cursor to the start of the line, up the given number of lines,
+	// and clears the screen below it
+	clearBlock = "\r\033[%dA\033[J"
+
+	// clearLine clears the current line, for a block of a single line
+	clearLine = "\r\033[J"
+)
+
+// spinner holds the frames of the spinners of running tests and steps
+var spinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Dashboard is a reporter rendering a live summary of the run on a terminal, and the plain
+// console output elsewhere
+type Dashboard struct {
+	output   io.Writer
+	live     bool
+	plain    *console_reporter.ConsoleReporter
+	test     string
+	testTime time.Time
+	steps    []string
+	counts   map[reporting.Status]int
+	started  time.Time
+	frame    int
+	drawn    int
+	stop     chan struct{}
+	mutex    sync.Mutex
+}
+
+// New creates a dashboard writing to os.Stdout, live when it is a terminal
+func New() *Dashboard {
+	d := &Dashboard{
+		plain:  console_reporter.NewConsoleReporter(),
+		counts: make(map[reporting.Status]int),
+	}
+	d.SetOutput(os.Stdout)
+	return d
+}
+
+// IsTerminal reports whether the writer is a terminal the dashboard can redraw
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetOutput sets the output destination, rendering live when it is a terminal
+func (d *Dashboard) SetOutput(w io.Writer) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.output = w
+	d.live = IsTerminal(w) && os.Getenv("TERM") != "dumb"
+	d.plain.SetOutput(w)
+}
+
+// SetLive forces the live rendering on or off, e.g. for terminals that are not detected
+func (d *Dashboard) SetLive(live bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.live = live
+}
+
+// Live reports whether the dashboard renders live
+func (d *Dashboard) Live() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.live
+}
+
+// OnTestStart shows the test as the current one
+func (d *Dashboard) OnTestStart(testName string) {
+	if !d.Live() {
+		d.plain.OnTestStart(testName)
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.started.IsZero() {
+		d.started = time.Now()
+	}
+	d.test = testName
+	d.testTime = time.Now()
+	d.steps = nil
+	d.startRefreshing()
+	d.redraw("")
+}
+
+// OnTestStartIn shows the test as the current one; the plain output also shows its hierarchy
+func (d *Dashboard) OnTestStartIn(testName string, contexts []events.Context) {
+	if !d.Live() {
+		d.plain.OnTestStartIn(testName, contexts)
+		return
+	}
+	d.OnTestStart(testName)
+}
+
+// OnTestFinish lists the test above the summary and counts its outcome
+func (d *Dashboard) OnTestFinish(result reporting.TestResult) {
+	if !d.Live() {
+		d.plain.OnTestFinish(result)
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.counts[result.Status()]++
+	d.test = ""
+	d.steps = nil
+
+	line := fmt.Sprintf("%s %s (%s)", emojiOf(result.Status()), result.Name(), reporting.FormatDuration(result.Duration()))
+	if result.Error() != nil {
+		line += "\n   Error: " + strings.ReplaceAll(result.Error().Error(), "\n", "\n   ")
+	}
+	d.redraw(line)
+}
+
+// OnStepStart shows the step as running
+func (d *Dashboard) OnStepStart(stepDescription string) {
+	if !d.Live() {
+		d.plain.OnStepStart(stepDescription)
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.steps = append(d.steps, stepDescription)
+	d.redraw("")
+}
+
+// OnStepFinish removes the step from the running ones
+func (d *Dashboard) OnStepFinish(stepResult reporting.TestResult) {
+	if !d.Live() {
+		d.plain.OnStepFinish(stepResult)
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if len(d.steps) > 0 {
+		d.steps = d.steps[:len(d.steps)-1]
+	}
+	d.redraw("")
+}
+
+// Close stops animating the spinners and leaves the final summary on the terminal
+func (d *Dashboard) Close() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = nil
+	}
+	if d.live && !d.started.IsZero() {
+		d.redraw("")
+		d.drawn = 0
+		_, _ = fmt.Fprintln(d.output)
+	}
+}
+
+// OnRunFinish closes the dashboard at the end of the run
+func (d *Dashboard) OnRunFinish() {
+	d.Close()
+}
+
+// startRefreshing animates the spinners until the dashboard is closed. The caller must hold
+// the mutex.
+func (d *Dashboard) startRefreshing() {
+	if d.stop != nil {
+		return
+	}
+	d.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				d.mutex.Lock()
+				if d.test != "" {
+					d.frame++
+					d.redraw("")
+				}
+				d.mutex.Unlock()
+			}
+		}
+	}(d.stop)
+}
+
+// redraw replaces the summary drawn last, writing the finished lines above it. The caller
+// must hold the mutex.
+func (d *Dashboard) redraw(finished string) {
+	var b strings.Builder
+	switch {
+	case d.drawn > 1:
+		fmt.Fprintf(&b, clearBlock, d.drawn-1)
+	case d.drawn == 1:
+		b.WriteString(clearLine)
+	}
+	if finished != "" {
+		b.WriteString(finished)
+		b.WriteString("\n")
+	}
+
+	lines := d.summary()
+	b.WriteString(strings.Join(lines, "\n"))
+	d.drawn = len(lines)
+
+	_, _ = fmt.Fprint(d.output, secrets.Mask(b.String()))
+}
+
+// summary renders the current test, its running steps and the counters. The caller must
+// hold the mutex.
+func (d *Dashboard) summary() []string {
+	frame := spinner[d.frame%len(spinner)]
+
+	var lines []string
+	if d.test != "" {
+		lines = append(lines, fmt.Sprintf("%s %s (%s)", frame, d.test, reporting.FormatDuration(time.Since(d.testTime))))
+		for i, step := range d.steps {
+			lines = append(lines, fmt.Sprintf("%s%s %s", strings.Repeat("  ", i+2), frame, step))
+		}
+	}
+	return append(lines, fmt.Sprintf("✅ %d passed  ❌ %d failed  ⏭️ %d skipped  ⏱ %s",
+		d.counts[reporting.StatusPassed], d.counts[reporting.StatusFailed], d.counts[reporting.StatusSkipped],
+		reporting.FormatDuration(time.Since(d.started))))
+}
+
+// emojiOf returns the emoji of the status, as in the console output
+func emojiOf(status reporting.Status) string {
+	switch status {
+	case reporting.StatusFailed:
+		return "❌"
+	case reporting.StatusSkipped:
+		return "⏭️"
+	default:
+		return "✅"
+	}
+}
@@ -0,0 +1,17 @@
+package dashboard
+
+import (
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// runDashboard is the dashboard shared by the tests of the process
+var runDashboard = sync.OnceValue(New)
+
+func init() {
+	reporting.RegisterReporter("dashboard", func(settings config.RunSettings) (reporting.Reporter, error) {
+		return runDashboard(), nil
+	})
+}
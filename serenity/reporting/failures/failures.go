@@ -0,0 +1,196 @@
+// Package failures renders assertion failures for humans: expected and actual values
+// are shown one below the other, long strings and JSON documents are diffed line by
+// line, huge payloads are truncated and, optionally, the output is colorized.
+//
+//	assertion failed for 'the order status': expected shipped, but got pending
+//	  Expected: "shipped"
+//	  Actual:   "pending"
+//
+// Errors that don't wrap a *core.AssertionError are rendered unchanged.
+package failures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations/utils"
+)
+
+// DefaultMaxLength is the number of bytes of a value shown before it is truncated
+const DefaultMaxLength = 1000
+
+// diffThreshold is the length from which single-line strings are diffed
+const diffThreshold = 80
+
+// maxDiffLines is the number of lines above which values are not diffed; their expected
+// and actual values are shown truncated instead
+const maxDiffLines = 500
+
+// ANSI escape sequences used when colors are enabled
+const (
+	colorReset = "\033[0m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorBold  = "\033[1m"
+)
+
+// Formatter renders errors for display in test output
+type Formatter struct {
+	maxLength int
+	colors    bool
+}
+
+// NewFormatter creates a formatter without colors that truncates values after DefaultMaxLength bytes
+func NewFormatter() *Formatter {
+	return &Formatter{maxLength: DefaultMaxLength}
+}
+
+// WithMaxLength sets the number of bytes of a value shown before it is truncated; 0 disables truncation
+func (f *Formatter) WithMaxLength(maxLength int) *Formatter {
+	f.maxLength = maxLength
+	return f
+}
+
+// WithColors enables or disables ANSI colors: expected values are green, actual values red
+func (f *Formatter) WithColors(enabled bool) *Formatter {
+	f.colors = enabled
+	return f
+}
+
+// Format renders the error. Assertion failures are expanded into the expected and actual values
+// and, for long strings and JSON documents, a line diff; other errors are returned as is.
+func (f *Formatter) Format(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var assertion *core.AssertionError
+	if !errors.As(err, &assertion) {
+		return err.Error()
+	}
+
+	header, _, _ := strings.Cut(err.Error(), "\n")
+	lines := []string{f.paint(colorBold, Truncate(header, f.maxLength))}
+
+	expected, actual := f.render(assertion.Expected), f.render(assertion.Actual)
+	lines = append(lines,
+		"  Expected: "+f.paint(colorGreen, expected),
+		"  Actual:   "+f.paint(colorRed, actual),
+	)
+
+	if diff := f.diff(assertion.Expected, assertion.Actual); diff != "" {
+		lines = append(lines, "  Diff (- expected, + actual):")
+		for _, line := range strings.Split(diff, "\n") {
+			switch {
+			case strings.HasPrefix(line, "- "):
+				line = f.paint(colorGreen, line)
+			case strings.HasPrefix(line, "+ "):
+				line = f.paint(colorRed, line)
+			}
+			lines = append(lines, "    "+line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// render formats a value on a single line, truncated to the maximum length
+func (f *Formatter) render(value any) string {
+	switch v := value.(type) {
+	case string:
+		return Truncate(strconv.Quote(v), f.maxLength)
+	case []byte:
+		return Truncate(strconv.Quote(string(v)), f.maxLength)
+	case nil:
+		return "<nil>"
+	default:
+		return Truncate(fmt.Sprintf("%v", v), f.maxLength)
+	}
+}
+
+// diff returns a line diff of expected and actual when both are long or multi-line
+// strings, or an empty string when a diff would not help
+func (f *Formatter) diff(expected, actual any) string {
+	expectedText, ok := text(expected)
+	if !ok {
+		return ""
+	}
+	actualText, ok := text(actual)
+	if !ok {
+		return ""
+	}
+
+	if !isLong(expectedText) && !isLong(actualText) {
+		return ""
+	}
+
+	expectedText, actualText = prettyJSON(expectedText), prettyJSON(actualText)
+	if strings.Count(expectedText, "\n") >= maxDiffLines || strings.Count(actualText, "\n") >= maxDiffLines {
+		return ""
+	}
+	return utils.LineDiff(expectedText, actualText)
+}
+
+// paint wraps text in the color when colors are enabled
+func (f *Formatter) paint(color, text string) string {
+	if !f.colors {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// Truncate shortens text to maxLength bytes, appending "... (N more bytes)".
+// A maxLength of 0 or less disables truncation.
+func Truncate(text string, maxLength int) string {
+	if maxLength <= 0 || len(text) <= maxLength {
+		return text
+	}
+
+	cut := maxLength
+	// Avoid splitting a multi-byte character
+	for cut > 0 && !isRuneStart(text[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", text[:cut], len(text)-cut)
+}
+
+// isRuneStart reports whether the byte starts a UTF-8 encoded character
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// text returns the value as a string if it is textual
+func text(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
+
+// isLong reports whether the text is worth diffing
+func isLong(text string) bool {
+	return len(text) > diffThreshold || strings.Contains(text, "\n")
+}
+
+// prettyJSON indents the text if it is a JSON document, so that the diff shows the changed fields
+func prettyJSON(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return text
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(trimmed), "", "  "); err != nil {
+		return text
+	}
+	return indented.String()
+}
@@ -0,0 +1,81 @@
+package failures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+func TestFormatShowsExpectedAndActual(t *testing.T) {
+	err := fmt.Errorf("assertion failed for 'the order status': %w",
+		core.NewAssertionError("shipped", "pending", "expected shipped, but got pending"))
+
+	require.Equal(t, strings.Join([]string{
+		"assertion failed for 'the order status': expected shipped, but got pending",
+		`  Expected: "shipped"`,
+		`  Actual:   "pending"`,
+	}, "\n"), NewFormatter().Format(err))
+}
+
+func TestFormatDiffsJSONDocuments(t *testing.T) {
+	expected := `{"id": 1, "status": "shipped", "items": ["book", "pen"], "customer": "Ada Lovelace"}`
+	actual := `{"id": 1, "status": "pending", "items": ["book", "pen"], "customer": "Ada Lovelace"}`
+	err := core.NewAssertionError(expected, actual, "value does not match")
+
+	formatted := NewFormatter().Format(err)
+
+	require.Contains(t, formatted, "  Diff (- expected, + actual):\n")
+	require.Contains(t, formatted, `    -   "status": "shipped",`+"\n"+`    +   "status": "pending",`)
+	require.NotContains(t, formatted, `+   "id": 1`)
+}
+
+func TestFormatTruncatesHugePayloads(t *testing.T) {
+	actual := strings.Repeat("x", 300)
+	err := core.NewAssertionError(3, actual, "expected length to be 3, but got 300")
+
+	formatted := NewFormatter().WithMaxLength(50).Format(err)
+
+	require.Contains(t, formatted, `  Actual:   "`+strings.Repeat("x", 49)+"... (252 more bytes)")
+}
+
+func TestFormatDoesNotDiffHugeDocuments(t *testing.T) {
+	items := func(status string) string {
+		lines := make([]string, 10000)
+		for i := range lines {
+			lines[i] = fmt.Sprintf(`{"id": %d, "status": "%s"}`, i, status)
+		}
+		return "[" + strings.Join(lines, ",") + "]"
+	}
+	err := core.NewAssertionError(items("shipped"), items("pending"), "value does not match")
+
+	formatted := NewFormatter().Format(err)
+
+	require.NotContains(t, formatted, "Diff")
+	require.Contains(t, formatted, "  Expected: ")
+	require.Less(t, len(formatted), 3*DefaultMaxLength)
+}
+
+func TestFormatColorizesValues(t *testing.T) {
+	err := core.NewAssertionError(200, 500, "expected 200, but got 500")
+
+	formatted := NewFormatter().WithColors(true).Format(err)
+
+	require.Contains(t, formatted, "Expected: \033[32m200\033[0m")
+	require.Contains(t, formatted, "Actual:   \033[31m500\033[0m")
+}
+
+func TestFormatLeavesOtherErrorsUnchanged(t *testing.T) {
+	require.Equal(t, "connection refused", NewFormatter().Format(errors.New("connection refused")))
+	require.Empty(t, NewFormatter().Format(nil))
+}
+
+func TestTruncateKeepsCharactersIntact(t *testing.T) {
+	require.Equal(t, "short", Truncate("short", 10))
+	require.Equal(t, "ab... (3 more bytes)", Truncate("abçd", 3))
+	require.Equal(t, "unlimited", Truncate("unlimited", 0))
+}
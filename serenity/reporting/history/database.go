@@ -0,0 +1,118 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// schema creates the tables of a database store
+const schema = `
+CREATE TABLE IF NOT EXISTS serenity_runs (
+	seq     INTEGER PRIMARY KEY AUTOINCREMENT,
+	id      TEXT NOT NULL,
+	started TIMESTAMP NOT NULL
+);
+CREATE TABLE IF NOT EXISTS serenity_tests (
+	run_seq        INTEGER NOT NULL REFERENCES serenity_runs(seq),
+	name           TEXT NOT NULL,
+	status         TEXT NOT NULL,
+	duration_nanos INTEGER NOT NULL,
+	error          TEXT NOT NULL DEFAULT ''
+);`
+
+// Database is a store keeping runs in the serenity_runs and serenity_tests tables of a
+// SQLite database, which can also be queried directly, e.g. by dashboards
+type Database struct {
+	db *sql.DB
+}
+
+// OpenDatabase opens the database with the registered driver, e.g. "sqlite3", and creates
+// its tables if needed
+func OpenDatabase(driver, dsn string) (*Database, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", driver, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create the history tables: %w", err)
+	}
+	return &Database{db: db}, nil
+}
+
+// Append adds the run and its tests in a transaction
+func (d *Database) Append(ctx context.Context, run Run) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO serenity_runs (id, started) VALUES (?, ?)", run.ID, run.Started.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to insert run %s: %w", run.ID, err)
+	}
+	seq, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to insert run %s: %w", run.ID, err)
+	}
+	for _, test := range run.Tests {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO serenity_tests (run_seq, name, status, duration_nanos, error) VALUES (?, ?, ?, ?, ?)",
+			seq, test.Name, test.Status, int64(test.Duration), test.Error); err != nil {
+			return fmt.Errorf("failed to insert test %s: %w", test.Name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit run %s: %w", run.ID, err)
+	}
+	return nil
+}
+
+// Runs reads the runs in the order they were appended
+func (d *Database) Runs(ctx context.Context) ([]Run, error) {
+	rows, err := d.db.QueryContext(ctx, `
+SELECT r.seq, r.id, r.started, t.name, t.status, t.duration_nanos, t.error
+FROM serenity_runs r JOIN serenity_tests t ON t.run_seq = r.seq
+ORDER BY r.seq, t.rowid`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runs: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var runs []Run
+	var seqs []int64
+	for rows.Next() {
+		var (
+			seq      int64
+			run      Run
+			test     Test
+			duration int64
+		)
+		if err := rows.Scan(&seq, &run.ID, &run.Started, &test.Name, &test.Status, &duration, &test.Error); err != nil {
+			return nil, fmt.Errorf("failed to read runs: %w", err)
+		}
+		test.Duration = time.Duration(duration)
+		if len(seqs) == 0 || seqs[len(seqs)-1] != seq {
+			seqs = append(seqs, seq)
+			runs = append(runs, run)
+		}
+		last := &runs[len(runs)-1]
+		last.Tests = append(last.Tests, test)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read runs: %w", err)
+	}
+	return merge(runs), nil
+}
+
+// Close closes the database
+func (d *Database) Close() error {
+	return d.db.Close()
+}
@@ -0,0 +1,212 @@
+// Package history accumulates the results of runs over time in a store, a JSON lines file or
+// a SQLite database, and computes their trends: the pass rate of each run, the duration of
+// each scenario across runs, and the failures that are new since a baseline run. This
+// supports release-readiness views:
+//
+//	Pass rate
+//	  20261014-210000  97.5%  (39/40, 0 skipped)
+//	  20261015-210000  95.0%  (38/40, 1 skipped)
+//
+//	Duration per scenario
+//	  TestCheckout/pay_by_card  1.20s  1.30s  2.40s
+//
+//	New failures since 20261014-210000
+//	  TestCheckout/pay_by_card: expected status 201, but got 402
+//
+// The recorder is selected with the "history" reporter of the run settings, available once
+// the package is imported, and appends the run to the store when serenity.Main finishes it. The serenity-trends command renders the
+// trends of a store.
+package history
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// Run is the recorded outcome of a test run
+type Run struct {
+	// ID identifies the run, e.g. SERENITY_RUN_ID or the time it started; stored runs with
+	// the same ID, such as the packages of one CI job, are merged
+	ID string `json:"id"`
+	// Started is when the first test of the run started
+	Started time.Time `json:"started"`
+	// Tests are the results of the tests of the run
+	Tests []Test `json:"tests"`
+}
+
+// Test is the recorded result of a test
+type Test struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"durationNanos"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Test statuses
+const (
+	Passed  = "passed"
+	Failed  = "failed"
+	Skipped = "skipped"
+)
+
+// Store accumulates runs
+type Store interface {
+	// Append adds the run to the store
+	Append(ctx context.Context, run Run) error
+
+	// Runs returns the stored runs from the oldest, merging the runs with the same ID
+	Runs(ctx context.Context) ([]Run, error)
+
+	// Close releases the resources of the store
+	Close() error
+}
+
+// Open opens the store at path: a SQLite database for the .db, .sqlite and .sqlite3
+// extensions, and a JSON lines file otherwise. SQLite needs a driver registered as
+// "sqlite3", e.g. by importing github.com/mattn/go-sqlite3.
+func Open(path string) (Store, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".db", ".sqlite", ".sqlite3":
+		db, err := OpenDatabase("sqlite3", path)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	default:
+		return NewJSONLines(path), nil
+	}
+}
+
+// Recorder is a reporter collecting the results of the tests of a run and appending the run
+// to a store when the run finishes
+type Recorder struct {
+	path   string
+	run    Run
+	output io.Writer
+	mutex  sync.Mutex
+}
+
+// NewRecorder creates a recorder appending the run with the ID to the store at path, see
+// Open. Failures to store the run are reported to the output, os.Stderr by default.
+func NewRecorder(path, runID string) *Recorder {
+	return &Recorder{path: path, run: Run{ID: runID}, output: os.Stderr}
+}
+
+// OnTestStart starts the run with the first test
+func (r *Recorder) OnTestStart(testName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.run.Started.IsZero() {
+		r.run.Started = time.Now()
+	}
+}
+
+// OnTestFinish records the result of the test
+func (r *Recorder) OnTestFinish(result reporting.TestResult) {
+	test := Test{Name: result.Name(), Status: statusName(result.Status()), Duration: result.Duration()}
+	if result.Status() == reporting.StatusFailed {
+		if err := reporting.FailedStepError(result); err != nil {
+			test.Error = secrets.Mask(err.Error())
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.run.Tests = append(r.run.Tests, test)
+}
+
+// OnStepStart is a no-op
+func (r *Recorder) OnStepStart(stepDescription string) {}
+
+// OnStepFinish is a no-op
+func (r *Recorder) OnStepFinish(stepResult reporting.TestResult) {}
+
+// SetOutput sets where failures to store the run are reported, os.Stderr by default
+func (r *Recorder) SetOutput(w io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.output = w
+}
+
+// OnRunFinish stores the run, reporting a failure to store it to the output
+func (r *Recorder) OnRunFinish() {
+	if err := r.Save(context.Background()); err != nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		_, _ = fmt.Fprintf(r.output, "⚠️ %v\n", err)
+	}
+}
+
+// Run returns the run recorded so far
+func (r *Recorder) Run() Run {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	run := r.run
+	run.Tests = append([]Test{}, r.run.Tests...)
+	return run
+}
+
+// Save appends the run recorded so far to the store, unless it has no tests
+func (r *Recorder) Save(ctx context.Context) error {
+	run := r.Run()
+	if len(run.Tests) == 0 {
+		return nil
+	}
+
+	store, err := Open(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to open the history %s: %w", r.path, err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	if err := store.Append(ctx, run); err != nil {
+		return fmt.Errorf("failed to add the run to the history %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// merge merges the stored runs with the same ID, keeping the order of their first
+// appearance and the earliest start
+func merge(runs []Run) []Run {
+	var merged []Run
+	index := make(map[string]int)
+	for _, run := range runs {
+		i, ok := index[run.ID]
+		if !ok {
+			index[run.ID] = len(merged)
+			merged = append(merged, run)
+			continue
+		}
+		if run.Started.Before(merged[i].Started) {
+			merged[i].Started = run.Started
+		}
+		merged[i].Tests = append(merged[i].Tests, run.Tests...)
+	}
+	return merged
+}
+
+// statusName returns the recorded name of the status
+func statusName(status reporting.Status) string {
+	switch status {
+	case reporting.StatusFailed:
+		return Failed
+	case reporting.StatusSkipped:
+		return Skipped
+	default:
+		return Passed
+	}
+}
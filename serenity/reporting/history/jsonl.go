@@ -0,0 +1,79 @@
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// JSONLines is a store keeping a run per line of a file. Runs are appended with a single
+// write, so the packages of one go test invocation can append to the same file.
+type JSONLines struct {
+	path string
+}
+
+// NewJSONLines creates a store in the file at path, which is created on the first append
+func NewJSONLines(path string) *JSONLines {
+	return &JSONLines{path: path}
+}
+
+// Append adds the run as the last line of the file
+func (j *JSONLines) Append(ctx context.Context, run Run) error {
+	line, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to encode run %s: %w", run.ID, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory of %s: %w", j.path, err)
+	}
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) // #nosec G304 -- path is configured by the test suite
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", j.path, err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write to %s: %w", j.path, err)
+	}
+	return file.Close()
+}
+
+// Runs reads the runs of the file; a missing file holds no runs
+func (j *JSONLines) Runs(ctx context.Context) ([]Run, error) {
+	file, err := os.Open(j.path) // #nosec G304 -- path is configured by the test suite
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", j.path, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var runs []Run
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("invalid run at %s:%d: %w", j.path, line, err)
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", j.path, err)
+	}
+	return merge(runs), nil
+}
+
+// Close is a no-op, the file is only open while reading or appending
+func (j *JSONLines) Close() error {
+	return nil
+}
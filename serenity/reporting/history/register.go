@@ -0,0 +1,65 @@
+package history
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// recorder holds the recorder shared by the tests of the process
+var recorder struct {
+	recorder *Recorder
+	mutex    sync.Mutex
+}
+
+// Settings configures the "history" reporter. They are read from the history entry of the
+// reporting settings:
+//
+//	reporting:
+//	  history:
+//	    store: target/serenity/history.db
+//
+// and overridden by SERENITY_HISTORY.
+type Settings struct {
+	// Store is the store the runs are appended to: a SQLite database for the .db
+	// extension, a JSON lines file otherwise. When empty, it is history.jsonl in the
+	// output directory, next to the run folders.
+	Store string `yaml:"store"`
+}
+
+func init() {
+	reporting.RegisterReporter("history", runRecorder)
+}
+
+// settingsOf returns the history settings of the run, with the environment overrides applied
+func settingsOf(run config.RunSettings) (Settings, error) {
+	var settings Settings
+	if err := run.ReporterSettings("history", &settings); err != nil {
+		return Settings{}, err
+	}
+	err := config.ApplyOverrides(map[string]any{"HISTORY": &settings.Store})
+	return settings, err
+}
+
+// runRecorder returns the recorder appending the run to the history store of the settings
+func runRecorder(run config.RunSettings) (reporting.Reporter, error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.recorder != nil {
+		return recorder.recorder, nil
+	}
+	settings, err := settingsOf(run)
+	if err != nil {
+		return nil, err
+	}
+	dir := reporting.OutputDirOf(run)
+	path := settings.Store
+	if path == "" {
+		path = filepath.Join(dir.Root(), "history.jsonl")
+	}
+	recorder.recorder = NewRecorder(path, dir.RunID())
+	return recorder.recorder, nil
+}
@@ -0,0 +1,190 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// RunSummary counts the outcomes of the tests of a run
+type RunSummary struct {
+	ID      string
+	Started time.Time
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+// PassRate returns the share of the executed tests that passed, from 0 to 1; skipped tests
+// are not executed. A run without executed tests has a pass rate of 0.
+func (s RunSummary) PassRate() float64 {
+	if s.Passed+s.Failed == 0 {
+		return 0
+	}
+	return float64(s.Passed) / float64(s.Passed+s.Failed)
+}
+
+// ScenarioTrend is the duration of a test in each run of the trends, zero for runs that
+// didn't execute it
+type ScenarioTrend struct {
+	Test      string
+	Durations []time.Duration
+}
+
+// Trends are the outcomes of consecutive runs, and the changes of the latest run since a
+// baseline run
+type Trends struct {
+	// Runs summarizes the runs from the oldest
+	Runs []RunSummary
+	// Scenarios are the durations of the tests across the runs, by test name
+	Scenarios []ScenarioTrend
+	// Baseline is the ID of the run the latest one is compared with, empty without one
+	Baseline string
+	// NewFailures are the tests failing in the latest run that didn't fail in the baseline
+	NewFailures []Test
+	// Fixed names the tests failing in the baseline that passed in the latest run
+	Fixed []string
+}
+
+// Compute computes the trends of the last runs, all of them if last is 0 or less, and
+// compares the latest run with the baseline run, the run before it when baseline is empty
+func Compute(runs []Run, last int, baseline string) (Trends, error) {
+	if len(runs) == 0 {
+		return Trends{}, nil
+	}
+
+	var base *Run
+	switch {
+	case baseline != "":
+		i := slices.IndexFunc(runs, func(run Run) bool { return run.ID == baseline })
+		if i < 0 {
+			return Trends{}, fmt.Errorf("unknown baseline run '%s'", baseline)
+		}
+		base = &runs[i]
+	case len(runs) > 1:
+		base = &runs[len(runs)-2]
+	}
+
+	if last > 0 && len(runs) > last {
+		runs = runs[len(runs)-last:]
+	}
+
+	var trends Trends
+	scenarios := make(map[string]int)
+	for i, run := range runs {
+		summary := RunSummary{ID: run.ID, Started: run.Started}
+		for _, test := range run.Tests {
+			switch test.Status {
+			case Failed:
+				summary.Failed++
+			case Skipped:
+				summary.Skipped++
+				continue
+			default:
+				summary.Passed++
+			}
+			j, ok := scenarios[test.Name]
+			if !ok {
+				j = len(trends.Scenarios)
+				scenarios[test.Name] = j
+				trends.Scenarios = append(trends.Scenarios, ScenarioTrend{Test: test.Name, Durations: make([]time.Duration, len(runs))})
+			}
+			trends.Scenarios[j].Durations[i] = test.Duration
+		}
+		trends.Runs = append(trends.Runs, summary)
+	}
+	slices.SortFunc(trends.Scenarios, func(a, b ScenarioTrend) int { return strings.Compare(a.Test, b.Test) })
+
+	if base != nil {
+		trends.Baseline = base.ID
+		latest := statuses(runs[len(runs)-1])
+		before := statuses(*base)
+		for _, test := range runs[len(runs)-1].Tests {
+			if test.Status == Failed && before[test.Name] != Failed && !slices.ContainsFunc(trends.NewFailures, func(t Test) bool { return t.Name == test.Name }) {
+				trends.NewFailures = append(trends.NewFailures, test)
+			}
+		}
+		for _, test := range base.Tests {
+			if test.Status == Failed && latest[test.Name] == Passed && !slices.Contains(trends.Fixed, test.Name) {
+				trends.Fixed = append(trends.Fixed, test.Name)
+			}
+		}
+	}
+	return trends, nil
+}
+
+// statuses returns the status of each test of the run; a test that failed in any package
+// or repetition of the run failed
+func statuses(run Run) map[string]string {
+	result := make(map[string]string)
+	for _, test := range run.Tests {
+		if result[test.Name] != Failed {
+			result[test.Name] = test.Status
+		}
+	}
+	return result
+}
+
+// Render writes the trends as text: the pass rate of each run, the duration of each
+// scenario across the runs, and the changes since the baseline
+func (t Trends) Render(w io.Writer) error {
+	if len(t.Runs) == 0 {
+		_, err := fmt.Fprintln(w, "No runs recorded")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Pass rate")
+	for _, run := range t.Runs {
+		fmt.Fprintf(tw, "  %s\t%.1f%%\t(%d/%d, %d skipped)\n",
+			run.ID, 100*run.PassRate(), run.Passed, run.Passed+run.Failed, run.Skipped)
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Duration per scenario")
+	for _, scenario := range t.Scenarios {
+		cells := make([]string, len(scenario.Durations))
+		for i, duration := range scenario.Durations {
+			cells[i] = "-"
+			if duration > 0 {
+				cells[i] = reporting.FormatDuration(duration)
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", scenario.Test, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to render the trends: %w", err)
+	}
+
+	if t.Baseline == "" {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nNew failures since %s\n", t.Baseline)
+	if len(t.NewFailures) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, failure := range t.NewFailures {
+		line := "  " + failure.Name
+		if failure.Error != "" {
+			first, _, _ := strings.Cut(failure.Error, "\n")
+			line += ": " + first
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(t.Fixed) > 0 {
+		fmt.Fprintf(&b, "\nFixed since %s\n", t.Baseline)
+		for _, name := range t.Fixed {
+			b.WriteString("  " + name + "\n")
+		}
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to render the trends: %w", err)
+	}
+	return nil
+}
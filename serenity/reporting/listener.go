@@ -0,0 +1,123 @@
+package reporting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/events"
+)
+
+// reporterListener feeds the events of an event bus to a reporter
+type reporterListener struct {
+	reporter Reporter
+	// steps are the steps being performed, from the outermost one
+	steps []*pendingStep
+	// test collects the results and attachments of the top-level steps
+	test  pendingStep
+	mutex sync.Mutex
+}
+
+// pendingStep collects the results of nested steps and the attachments of a running step
+type pendingStep struct {
+	start       time.Time
+	children    []TestResult
+	attachments []Attachment
+}
+
+// NewListener creates an event listener that forwards test and activity events to the reporter.
+// Answers are forwarded as well when the reporter implements AnswerReporter, and the start of
+// a test is forwarded with its contexts when it implements HierarchicalReporter. Results carry
+// the results of their nested steps and the attachments added while they were performed.
+func NewListener(reporter Reporter) events.Listener {
+	return &reporterListener{reporter: reporter}
+}
+
+// Notify translates the event into the corresponding reporter call
+func (rl *reporterListener) Notify(event events.Event) {
+	switch e := event.(type) {
+	case events.TestStarted:
+		rl.mutex.Lock()
+		rl.steps = nil
+		rl.test = pendingStep{start: time.Now()}
+		rl.mutex.Unlock()
+
+		if hierarchical, ok := rl.reporter.(HierarchicalReporter); ok {
+			hierarchical.OnTestStartIn(e.Test, e.Contexts)
+		} else {
+			rl.reporter.OnTestStart(e.Test)
+		}
+	case events.TestFinished:
+		rl.mutex.Lock()
+		test := rl.test
+		rl.test = pendingStep{}
+		rl.mutex.Unlock()
+
+		rl.reporter.OnTestFinish(NewResult(e.Test, statusOf(e.Outcome)).
+			WithTimings(test.start, e.Duration).
+			WithError(e.Err).
+			WithRequirements(e.Requirements...).
+			WithCases(e.Cases...).
+			WithContexts(e.Contexts...).
+			WithAttachments(test.attachments...).
+			WithChildren(test.children...))
+	case events.ActivityStarted:
+		rl.mutex.Lock()
+		rl.steps = append(rl.steps, &pendingStep{start: time.Now()})
+		rl.mutex.Unlock()
+
+		rl.reporter.OnStepStart(stepDescription(e.Activity, e.Actor))
+	case events.ActivityFinished:
+		rl.mutex.Lock()
+		step := &pendingStep{}
+		if len(rl.steps) > 0 {
+			step = rl.steps[len(rl.steps)-1]
+			rl.steps = rl.steps[:len(rl.steps)-1]
+		}
+		result := NewResult(stepDescription(e.Activity, e.Actor), statusOf(e.Outcome)).
+			WithTimings(step.start, e.Duration).
+			WithError(e.Err).
+			WithLocation(e.Location).
+			WithMetadata(e.Metadata).
+			WithAttachments(step.attachments...).
+			WithChildren(step.children...)
+		parent := rl.current()
+		parent.children = append(parent.children, result)
+		rl.mutex.Unlock()
+
+		rl.reporter.OnStepFinish(result)
+	case events.AttachmentAdded:
+		rl.mutex.Lock()
+		current := rl.current()
+		current.attachments = append(current.attachments, Attachment{Name: e.Name, MediaType: e.MediaType, Content: e.Content})
+		rl.mutex.Unlock()
+	case events.QuestionAnswered:
+		if answerReporter, ok := rl.reporter.(AnswerReporter); ok {
+			answerReporter.OnQuestionAnswered(e.Question, e.Answer)
+		}
+	case events.RequestSent:
+		if requestReporter, ok := rl.reporter.(RequestReporter); ok {
+			requestReporter.OnRequestSent(e.Method, e.URL, e.Status)
+		}
+	}
+}
+
+// current returns the innermost running step, or the test when no step is running.
+// The caller must hold the mutex.
+func (rl *reporterListener) current() *pendingStep {
+	if len(rl.steps) == 0 {
+		return &rl.test
+	}
+	return rl.steps[len(rl.steps)-1]
+}
+
+// statusOf converts an event outcome into a reporting status
+func statusOf(outcome events.Outcome) Status {
+	switch outcome {
+	case events.Failed:
+		return StatusFailed
+	case events.Skipped:
+		return StatusSkipped
+	default:
+		return StatusPassed
+	}
+}
@@ -12,7 +12,9 @@ package mocks
 import (
 	io "io"
 	reflect "reflect"
+	time "time"
 
+	events "github.com/nchursin/serenity-go/serenity/events"
 	reporting "github.com/nchursin/serenity-go/serenity/reporting"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -101,6 +103,78 @@ func (mr *MockReporterMockRecorder) SetOutput(w any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutput", reflect.TypeOf((*MockReporter)(nil).SetOutput), w)
 }
 
+// MockAnswerReporter is a mock of AnswerReporter interface.
+type MockAnswerReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnswerReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockAnswerReporterMockRecorder is the mock recorder for MockAnswerReporter.
+type MockAnswerReporterMockRecorder struct {
+	mock *MockAnswerReporter
+}
+
+// NewMockAnswerReporter creates a new mock instance.
+func NewMockAnswerReporter(ctrl *gomock.Controller) *MockAnswerReporter {
+	mock := &MockAnswerReporter{ctrl: ctrl}
+	mock.recorder = &MockAnswerReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnswerReporter) EXPECT() *MockAnswerReporterMockRecorder {
+	return m.recorder
+}
+
+// OnQuestionAnswered mocks base method.
+func (m *MockAnswerReporter) OnQuestionAnswered(question string, answer any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnQuestionAnswered", question, answer)
+}
+
+// OnQuestionAnswered indicates an expected call of OnQuestionAnswered.
+func (mr *MockAnswerReporterMockRecorder) OnQuestionAnswered(question, answer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnQuestionAnswered", reflect.TypeOf((*MockAnswerReporter)(nil).OnQuestionAnswered), question, answer)
+}
+
+// MockHierarchicalReporter is a mock of HierarchicalReporter interface.
+type MockHierarchicalReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHierarchicalReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockHierarchicalReporterMockRecorder is the mock recorder for MockHierarchicalReporter.
+type MockHierarchicalReporterMockRecorder struct {
+	mock *MockHierarchicalReporter
+}
+
+// NewMockHierarchicalReporter creates a new mock instance.
+func NewMockHierarchicalReporter(ctrl *gomock.Controller) *MockHierarchicalReporter {
+	mock := &MockHierarchicalReporter{ctrl: ctrl}
+	mock.recorder = &MockHierarchicalReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHierarchicalReporter) EXPECT() *MockHierarchicalReporterMockRecorder {
+	return m.recorder
+}
+
+// OnTestStartIn mocks base method.
+func (m *MockHierarchicalReporter) OnTestStartIn(testName string, contexts []events.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnTestStartIn", testName, contexts)
+}
+
+// OnTestStartIn indicates an expected call of OnTestStartIn.
+func (mr *MockHierarchicalReporterMockRecorder) OnTestStartIn(testName, contexts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnTestStartIn", reflect.TypeOf((*MockHierarchicalReporter)(nil).OnTestStartIn), testName, contexts)
+}
+
 // MockTestResult is a mock of TestResult interface.
 type MockTestResult struct {
 	ctrl     *gomock.Controller
@@ -125,11 +199,39 @@ func (m *MockTestResult) EXPECT() *MockTestResultMockRecorder {
 	return m.recorder
 }
 
+// Attachments mocks base method.
+func (m *MockTestResult) Attachments() []reporting.Attachment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attachments")
+	ret0, _ := ret[0].([]reporting.Attachment)
+	return ret0
+}
+
+// Attachments indicates an expected call of Attachments.
+func (mr *MockTestResultMockRecorder) Attachments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attachments", reflect.TypeOf((*MockTestResult)(nil).Attachments))
+}
+
+// Children mocks base method.
+func (m *MockTestResult) Children() []reporting.TestResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Children")
+	ret0, _ := ret[0].([]reporting.TestResult)
+	return ret0
+}
+
+// Children indicates an expected call of Children.
+func (mr *MockTestResultMockRecorder) Children() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Children", reflect.TypeOf((*MockTestResult)(nil).Children))
+}
+
 // Duration mocks base method.
-func (m *MockTestResult) Duration() float64 {
+func (m *MockTestResult) Duration() time.Duration {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Duration")
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(time.Duration)
 	return ret0
 }
 
@@ -167,6 +269,20 @@ func (mr *MockTestResultMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockTestResult)(nil).Name))
 }
 
+// StartTime mocks base method.
+func (m *MockTestResult) StartTime() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTime")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// StartTime indicates an expected call of StartTime.
+func (mr *MockTestResultMockRecorder) StartTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTime", reflect.TypeOf((*MockTestResult)(nil).StartTime))
+}
+
 // Status mocks base method.
 func (m *MockTestResult) Status() reporting.Status {
 	m.ctrl.T.Helper()
@@ -180,3 +296,155 @@ func (mr *MockTestResultMockRecorder) Status() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockTestResult)(nil).Status))
 }
+
+// MockLocatedResult is a mock of LocatedResult interface.
+type MockLocatedResult struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocatedResultMockRecorder
+	isgomock struct{}
+}
+
+// MockLocatedResultMockRecorder is the mock recorder for MockLocatedResult.
+type MockLocatedResultMockRecorder struct {
+	mock *MockLocatedResult
+}
+
+// NewMockLocatedResult creates a new mock instance.
+func NewMockLocatedResult(ctrl *gomock.Controller) *MockLocatedResult {
+	mock := &MockLocatedResult{ctrl: ctrl}
+	mock.recorder = &MockLocatedResultMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocatedResult) EXPECT() *MockLocatedResultMockRecorder {
+	return m.recorder
+}
+
+// Location mocks base method.
+func (m *MockLocatedResult) Location() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Location")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Location indicates an expected call of Location.
+func (mr *MockLocatedResultMockRecorder) Location() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockLocatedResult)(nil).Location))
+}
+
+// MockAnnotatedResult is a mock of AnnotatedResult interface.
+type MockAnnotatedResult struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnnotatedResultMockRecorder
+	isgomock struct{}
+}
+
+// MockAnnotatedResultMockRecorder is the mock recorder for MockAnnotatedResult.
+type MockAnnotatedResultMockRecorder struct {
+	mock *MockAnnotatedResult
+}
+
+// NewMockAnnotatedResult creates a new mock instance.
+func NewMockAnnotatedResult(ctrl *gomock.Controller) *MockAnnotatedResult {
+	mock := &MockAnnotatedResult{ctrl: ctrl}
+	mock.recorder = &MockAnnotatedResultMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnnotatedResult) EXPECT() *MockAnnotatedResultMockRecorder {
+	return m.recorder
+}
+
+// Metadata mocks base method.
+func (m *MockAnnotatedResult) Metadata() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Metadata indicates an expected call of Metadata.
+func (mr *MockAnnotatedResultMockRecorder) Metadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockAnnotatedResult)(nil).Metadata))
+}
+
+// MockTracedResult is a mock of TracedResult interface.
+type MockTracedResult struct {
+	ctrl     *gomock.Controller
+	recorder *MockTracedResultMockRecorder
+	isgomock struct{}
+}
+
+// MockTracedResultMockRecorder is the mock recorder for MockTracedResult.
+type MockTracedResultMockRecorder struct {
+	mock *MockTracedResult
+}
+
+// NewMockTracedResult creates a new mock instance.
+func NewMockTracedResult(ctrl *gomock.Controller) *MockTracedResult {
+	mock := &MockTracedResult{ctrl: ctrl}
+	mock.recorder = &MockTracedResultMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTracedResult) EXPECT() *MockTracedResultMockRecorder {
+	return m.recorder
+}
+
+// Requirements mocks base method.
+func (m *MockTracedResult) Requirements() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requirements")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Requirements indicates an expected call of Requirements.
+func (mr *MockTracedResultMockRecorder) Requirements() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requirements", reflect.TypeOf((*MockTracedResult)(nil).Requirements))
+}
+
+// MockContextualResult is a mock of ContextualResult interface.
+type MockContextualResult struct {
+	ctrl     *gomock.Controller
+	recorder *MockContextualResultMockRecorder
+	isgomock struct{}
+}
+
+// MockContextualResultMockRecorder is the mock recorder for MockContextualResult.
+type MockContextualResultMockRecorder struct {
+	mock *MockContextualResult
+}
+
+// NewMockContextualResult creates a new mock instance.
+func NewMockContextualResult(ctrl *gomock.Controller) *MockContextualResult {
+	mock := &MockContextualResult{ctrl: ctrl}
+	mock.recorder = &MockContextualResultMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContextualResult) EXPECT() *MockContextualResultMockRecorder {
+	return m.recorder
+}
+
+// Contexts mocks base method.
+func (m *MockContextualResult) Contexts() []events.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contexts")
+	ret0, _ := ret[0].([]events.Context)
+	return ret0
+}
+
+// Contexts indicates an expected call of Contexts.
+func (mr *MockContextualResultMockRecorder) Contexts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contexts", reflect.TypeOf((*MockContextualResult)(nil).Contexts))
+}
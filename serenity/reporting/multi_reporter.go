@@ -0,0 +1,82 @@
+package reporting
+
+import (
+	"io"
+
+	"github.com/nchursin/serenity-go/serenity/events"
+)
+
+// MultiReporter forwards every event to several reporters, e.g. the console and a transcript recorder
+type MultiReporter struct {
+	reporters []Reporter
+}
+
+// NewMultiReporter creates a reporter that forwards events to all given reporters in order
+func NewMultiReporter(reporters ...Reporter) *MultiReporter {
+	return &MultiReporter{reporters: reporters}
+}
+
+// OnTestStart forwards the event to all reporters
+func (mr *MultiReporter) OnTestStart(testName string) {
+	for _, reporter := range mr.reporters {
+		reporter.OnTestStart(testName)
+	}
+}
+
+// OnTestStartIn forwards the event with the contexts to the hierarchical reporters, and
+// without them to the others
+func (mr *MultiReporter) OnTestStartIn(testName string, contexts []events.Context) {
+	for _, reporter := range mr.reporters {
+		if hierarchical, ok := reporter.(HierarchicalReporter); ok {
+			hierarchical.OnTestStartIn(testName, contexts)
+		} else {
+			reporter.OnTestStart(testName)
+		}
+	}
+}
+
+// OnTestFinish forwards the event to all reporters
+func (mr *MultiReporter) OnTestFinish(result TestResult) {
+	for _, reporter := range mr.reporters {
+		reporter.OnTestFinish(result)
+	}
+}
+
+// OnStepStart forwards the event to all reporters
+func (mr *MultiReporter) OnStepStart(stepDescription string) {
+	for _, reporter := range mr.reporters {
+		reporter.OnStepStart(stepDescription)
+	}
+}
+
+// OnStepFinish forwards the event to all reporters
+func (mr *MultiReporter) OnStepFinish(stepResult TestResult) {
+	for _, reporter := range mr.reporters {
+		reporter.OnStepFinish(stepResult)
+	}
+}
+
+// OnQuestionAnswered forwards the event to the reporters that record answers
+func (mr *MultiReporter) OnQuestionAnswered(question string, answer any) {
+	for _, reporter := range mr.reporters {
+		if answerReporter, ok := reporter.(AnswerReporter); ok {
+			answerReporter.OnQuestionAnswered(question, answer)
+		}
+	}
+}
+
+// OnRequestSent forwards the event to the reporters that record requests
+func (mr *MultiReporter) OnRequestSent(method, url string, status int) {
+	for _, reporter := range mr.reporters {
+		if requestReporter, ok := reporter.(RequestReporter); ok {
+			requestReporter.OnRequestSent(method, url, status)
+		}
+	}
+}
+
+// SetOutput sets the output destination of all reporters
+func (mr *MultiReporter) SetOutput(w io.Writer) {
+	for _, reporter := range mr.reporters {
+		reporter.SetOutput(w)
+	}
+}
@@ -0,0 +1,183 @@
+package reporting
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultOutputRoot is the directory holding the output of the runs unless configured otherwise
+	DefaultOutputRoot = "target/serenity"
+
+	// RunIDEnvVar names the environment variable naming the output folder of the run, e.g. so
+	// the packages tested by one CI job write to the same folder
+	RunIDEnvVar = "SERENITY_RUN_ID"
+
+	// DefaultKeptRuns is the number of run folders kept in the output root by default
+	DefaultKeptRuns = 10
+
+	// runLayout is the layout of the timestamps naming run folders
+	runLayout = "20060102-150405"
+
+	// activeRunAge is the age below which run folders are never removed, since the packages
+	// of one go test invocation write to their own run folders at the same time
+	activeRunAge = time.Hour
+)
+
+// OutputDir is where reporters and attachment writers save their files. Each run writes to
+// its own subfolder of the root, named after the time the run started or SERENITY_RUN_ID,
+// which is created on first use. Creating it removes the oldest timestamped run folders
+// beyond the number of kept runs, except those changed within the last hour as other
+// packages may still be writing to them, so the root doesn't grow without bounds:
+//
+//	output := reporting.OutputDirAt("target/serenity")
+//	file, err := output.Create("console.txt") // target/serenity/20260114-093012/console.txt
+type OutputDir struct {
+	root    string
+	run     string
+	keep    int
+	created bool
+	mutex   sync.Mutex
+}
+
+// outputDirs holds the output directories of the process, by root, so reporters writing to
+// the same root share the run folder
+var outputDirs = struct {
+	dirs  map[string]*OutputDir
+	mutex sync.Mutex
+}{dirs: make(map[string]*OutputDir)}
+
+// OutputDirAt returns the output directory of the run in the root, the same for every call
+// with the root. An empty root is the DefaultOutputRoot.
+func OutputDirAt(root string) *OutputDir {
+	if root == "" {
+		root = DefaultOutputRoot
+	}
+	outputDirs.mutex.Lock()
+	defer outputDirs.mutex.Unlock()
+
+	if dir, ok := outputDirs.dirs[root]; ok {
+		return dir
+	}
+	dir := NewOutputDir(root)
+	outputDirs.dirs[root] = dir
+	return dir
+}
+
+// NewOutputDir creates an output directory with a new run folder in the root. Reporters
+// use OutputDirAt instead, to share the run folder with the other reporters of the process.
+func NewOutputDir(root string) *OutputDir {
+	run := os.Getenv(RunIDEnvVar)
+	if run == "" {
+		run = time.Now().Format(runLayout)
+	}
+	return &OutputDir{root: root, run: run, keep: DefaultKeptRuns}
+}
+
+// KeepRuns sets the number of run folders kept in the root, including the current one, and
+// returns the directory for chaining. Zero or less keeps all of them.
+func (d *OutputDir) KeepRuns(runs int) *OutputDir {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.keep = runs
+	return d
+}
+
+// Root returns the directory holding the run folders
+func (d *OutputDir) Root() string {
+	return d.root
+}
+
+// RunID returns the name of the run folder, which identifies the run
+func (d *OutputDir) RunID() string {
+	return d.run
+}
+
+// Path returns the path of the named file or directory in the run folder, creating the run
+// folder and the parents of the file
+func (d *OutputDir) Path(name string) (string, error) {
+	if err := d.ensureRun(); err != nil {
+		return "", err
+	}
+	path := filepath.Join(d.root, d.run, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create output directory %s: %w", filepath.Dir(path), err)
+	}
+	return path, nil
+}
+
+// Create creates or truncates the named file in the run folder
+func (d *OutputDir) Create(name string) (*os.File, error) {
+	path, err := d.Path(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path) // #nosec G304 -- path is in the output directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return file, nil
+}
+
+// WriteFile writes the named file in the run folder and returns its path
+func (d *OutputDir) WriteFile(name string, content []byte) (string, error) {
+	path, err := d.Path(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write output file: %w", err)
+	}
+	return path, nil
+}
+
+// ensureRun creates the run folder and removes the oldest run folders on first use
+func (d *OutputDir) ensureRun() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.created {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(d.root, d.run), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", filepath.Join(d.root, d.run), err)
+	}
+	d.created = true
+	return d.pruneRuns()
+}
+
+// pruneRuns removes the oldest inactive timestamped run folders beyond the number of kept
+// runs. Other entries of the root, e.g. folders named by SERENITY_RUN_ID, are left alone.
+func (d *OutputDir) pruneRuns() error {
+	if d.keep <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(d.root)
+	if err != nil {
+		return fmt.Errorf("failed to list output directory %s: %w", d.root, err)
+	}
+
+	var runs []string
+	for _, entry := range entries {
+		if _, err := time.Parse(runLayout, entry.Name()); err != nil || !entry.IsDir() || entry.Name() == d.run {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && time.Since(info.ModTime()) > activeRunAge {
+			runs = append(runs, entry.Name())
+		}
+	}
+	// Timestamps sort chronologically; the current run is always kept
+	slices.Sort(runs)
+	for len(runs) > d.keep-1 {
+		if err := os.RemoveAll(filepath.Join(d.root, runs[0])); err != nil {
+			return fmt.Errorf("failed to remove old run %s: %w", runs[0], err)
+		}
+		runs = runs[1:]
+	}
+	return nil
+}
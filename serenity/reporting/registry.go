@@ -0,0 +1,60 @@
+package reporting
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+)
+
+// ReporterFactory creates the reporter of a test from the run settings. Factories of
+// reporters that collect the whole run return the same reporter for every test.
+type ReporterFactory func(settings config.RunSettings) (Reporter, error)
+
+// registry holds the reporters that can be named in the run settings
+var registry = struct {
+	factories map[string]ReporterFactory
+	mutex     sync.RWMutex
+}{factories: map[string]ReporterFactory{
+	"attachments": func(settings config.RunSettings) (Reporter, error) {
+		return NewAttachmentWriter(OutputDirOf(settings)), nil
+	},
+}}
+
+// RegisterReporter makes a reporter available to the run settings under the name, from the
+// init function of the package defining it, so that importing the package is enough to
+// select the reporter by name. It panics if the name is registered twice.
+func RegisterReporter(name string, factory ReporterFactory) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, exists := registry.factories[name]; exists {
+		panic(fmt.Sprintf("serenity: reporter %s registered twice", name))
+	}
+	registry.factories[name] = factory
+}
+
+// ReporterFactoryFor returns the factory of the reporter registered under the name, and
+// whether there is one
+func ReporterFactoryFor(name string) (ReporterFactory, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	factory, ok := registry.factories[name]
+	return factory, ok
+}
+
+// RegisteredReporters returns the sorted names of the registered reporters
+func RegisteredReporters() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	return slices.Sorted(maps.Keys(registry.factories))
+}
+
+// OutputDirOf returns the output directory of the run in the settings
+func OutputDirOf(settings config.RunSettings) *OutputDir {
+	return OutputDirAt(settings.OutputDir).KeepRuns(settings.KeepRuns)
+}
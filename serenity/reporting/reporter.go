@@ -1,6 +1,11 @@
 package reporting
 
-import "io"
+import (
+	"io"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/events"
+)
 
 // Reporter handles test execution reporting
 type Reporter interface {
@@ -20,12 +25,86 @@ type Reporter interface {
 	SetOutput(w io.Writer)
 }
 
-// TestResult represents the result of a test or step execution
+// AnswerReporter is an optional extension of Reporter for reporters that record
+// the answers actors receive to their questions
+type AnswerReporter interface {
+	// OnQuestionAnswered is called when an actor receives an answer to a question
+	OnQuestionAnswered(question string, answer any)
+}
+
+// RequestReporter is an optional extension of Reporter for reporters that record the HTTP
+// requests actors send, e.g. to measure the coverage of API endpoints
+type RequestReporter interface {
+	// OnRequestSent is called when an actor sent an HTTP request; status is 0 when no
+	// response was received
+	OnRequestSent(method, url string, status int)
+}
+
+// HierarchicalReporter is an optional extension of Reporter for reporters that render
+// tests within their feature, scenario and example
+type HierarchicalReporter interface {
+	// OnTestStartIn is called instead of OnTestStart when a test begins, with the contexts
+	// of the test from the outermost level
+	OnTestStartIn(testName string, contexts []events.Context)
+}
+
+// RunReporter is an optional extension of Reporter for reporters that summarize the whole
+// run, such as a dashboard or a notifier. Runs finish with serenity.Main, called from TestMain.
+type RunReporter interface {
+	// OnRunFinish is called once all tests of the run have finished
+	OnRunFinish()
+}
+
+// TestResult represents the result of a test or step execution. Results are immutable;
+// Result is the implementation used by the framework, and also implements LocatedResult,
+// AnnotatedResult, TracedResult and ContextualResult.
 type TestResult interface {
 	Name() string
 	Status() Status
-	Duration() float64
+	// Duration returns how long the test or step took, measured with the monotonic clock
+	Duration() time.Duration
 	Error() error
+	// StartTime returns when the test or step started, or the zero time if unknown
+	StartTime() time.Time
+	// Attachments returns the files attached to the test or step
+	Attachments() []Attachment
+	// Children returns the results of the steps performed within the test or step
+	Children() []TestResult
+}
+
+// LocatedResult is an optional extension of TestResult for step results that know where the
+// step was constructed
+type LocatedResult interface {
+	// Location returns the "file:line" of the step, or an empty string if unknown
+	Location() string
+}
+
+// AnnotatedResult is an optional extension of TestResult for step results that carry the
+// metadata attached to the step, such as ticket IDs or links to requirements
+type AnnotatedResult interface {
+	// Metadata returns the metadata of the step, or nil if it has none
+	Metadata() map[string]string
+}
+
+// TracedResult is an optional extension of TestResult for test results that list the
+// requirements the test covers
+type TracedResult interface {
+	// Requirements returns the IDs of the covered requirements, or nil if none were declared
+	Requirements() []string
+}
+
+// CaseResult is an optional extension of TestResult for test results that list the cases
+// of a test-management tool, such as TestRail, the test covers
+type CaseResult interface {
+	// Cases returns the IDs of the covered cases, or nil if none were declared
+	Cases() []string
+}
+
+// ContextualResult is an optional extension of TestResult for test results that know the
+// feature, scenario and example of the test
+type ContextualResult interface {
+	// Contexts returns the contexts of the test from the outermost level
+	Contexts() []events.Context
 }
 
 // Status represents the status of a test or step
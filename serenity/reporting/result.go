@@ -0,0 +1,183 @@
+package reporting
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/events"
+)
+
+// Attachment is a file attached to a test or step result, e.g. a screenshot or a response body
+type Attachment struct {
+	// Name is the file name of the attachment
+	Name string
+	// MediaType is the MIME type of the content, e.g. "image/png"
+	MediaType string
+	// Content is the attached data
+	Content []byte
+}
+
+// Result is the result of a test or step. It is immutable: the With methods return a copy,
+// so a result handed to several reporters cannot be changed by any of them.
+//
+//	result := reporting.NewResult("Ada pays the order", reporting.StatusPassed).
+//		WithTimings(start, time.Since(start)).
+//		WithChildren(stepResults...)
+type Result struct {
+	name         string
+	status       Status
+	startTime    time.Time
+	duration     time.Duration
+	err          error
+	location     string
+	metadata     map[string]string
+	requirements []string
+	cases        []string
+	contexts     []events.Context
+	attachments  []Attachment
+	children     []TestResult
+}
+
+// NewResult creates the result of the named test or step
+func NewResult(name string, status Status) *Result {
+	return &Result{name: name, status: status}
+}
+
+// Name returns the name of the test or the description of the step
+func (r *Result) Name() string { return r.name }
+
+// Status returns whether the test or step passed, failed or was skipped
+func (r *Result) Status() Status { return r.status }
+
+// Duration returns how long the test or step took
+func (r *Result) Duration() time.Duration { return r.duration }
+
+// StartTime returns when the test or step started, or the zero time if unknown
+func (r *Result) StartTime() time.Time { return r.startTime }
+
+// Error returns the failure, or the reason a skipped step was skipped
+func (r *Result) Error() error { return r.err }
+
+// Location returns the "file:line" of the step, or an empty string if unknown
+func (r *Result) Location() string { return r.location }
+
+// Metadata returns the metadata of the step, or nil if it has none
+func (r *Result) Metadata() map[string]string { return maps.Clone(r.metadata) }
+
+// Requirements returns the requirements the test covers
+func (r *Result) Requirements() []string { return slices.Clone(r.requirements) }
+
+// Cases returns the test-management cases the test covers
+func (r *Result) Cases() []string { return slices.Clone(r.cases) }
+
+// Contexts returns the feature, scenario and example of the test
+func (r *Result) Contexts() []events.Context { return slices.Clone(r.contexts) }
+
+// Attachments returns the files attached to the test or step
+func (r *Result) Attachments() []Attachment { return slices.Clone(r.attachments) }
+
+// Children returns the results of the steps performed within the test or step
+func (r *Result) Children() []TestResult { return slices.Clone(r.children) }
+
+// WithTimings returns a copy of the result with the start time and duration. Durations
+// should be measured with time.Since on a time.Now reading, which uses the monotonic clock
+// and so is not affected by changes of the wall clock; negative durations are stored as 0.
+func (r *Result) WithTimings(start time.Time, duration time.Duration) *Result {
+	c := r.clone()
+	c.startTime, c.duration = start, max(duration, 0)
+	return c
+}
+
+// WithError returns a copy of the result with the error
+func (r *Result) WithError(err error) *Result {
+	c := r.clone()
+	c.err = err
+	return c
+}
+
+// WithLocation returns a copy of the result with the "file:line" of the step
+func (r *Result) WithLocation(location string) *Result {
+	c := r.clone()
+	c.location = location
+	return c
+}
+
+// WithMetadata returns a copy of the result with the metadata
+func (r *Result) WithMetadata(metadata map[string]string) *Result {
+	c := r.clone()
+	c.metadata = maps.Clone(metadata)
+	return c
+}
+
+// WithRequirements returns a copy of the result with the covered requirements
+func (r *Result) WithRequirements(requirements ...string) *Result {
+	c := r.clone()
+	c.requirements = slices.Clone(requirements)
+	return c
+}
+
+// WithCases returns a copy of the result with the covered test-management cases
+func (r *Result) WithCases(cases ...string) *Result {
+	c := r.clone()
+	c.cases = slices.Clone(cases)
+	return c
+}
+
+// WithContexts returns a copy of the result with the feature, scenario and example
+func (r *Result) WithContexts(contexts ...events.Context) *Result {
+	c := r.clone()
+	c.contexts = slices.Clone(contexts)
+	return c
+}
+
+// WithAttachments returns a copy of the result with the attachments added
+func (r *Result) WithAttachments(attachments ...Attachment) *Result {
+	c := r.clone()
+	c.attachments = append(slices.Clone(r.attachments), attachments...)
+	return c
+}
+
+// WithChildren returns a copy of the result with the results of nested steps added
+func (r *Result) WithChildren(children ...TestResult) *Result {
+	c := r.clone()
+	c.children = append(slices.Clone(r.children), children...)
+	return c
+}
+
+// clone returns a shallow copy of the result; the With methods copy the field they change
+func (r *Result) clone() *Result {
+	c := *r
+	return &c
+}
+
+// FormatDuration renders a duration with a unit adapted to its magnitude, keeping short
+// steps distinguishable: "850ns", "12.3µs", "4.5ms", "1.25s", "2m5s"
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d < time.Microsecond:
+		return fmt.Sprintf("%dns", max(d, 0).Nanoseconds())
+	case d < time.Millisecond:
+		return fmt.Sprintf("%.1fµs", float64(d)/float64(time.Microsecond))
+	case d < time.Second:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	case d < time.Minute:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	default:
+		return d.Round(time.Second).String()
+	}
+}
+
+// FailedStepError returns the error of the first failed step of the result, which explains
+// the failure better than the error of the test
+func FailedStepError(result TestResult) error {
+	for _, child := range result.Children() {
+		if child.Status() == StatusFailed {
+			if err := FailedStepError(child); err != nil {
+				return err
+			}
+		}
+	}
+	return result.Error()
+}
@@ -0,0 +1,148 @@
+package testmanagement
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// Settings configures where the "testmanagement" reporter uploads the statuses of the cases
+// covered by the tests. They are read from the testmanagement entry of the reporting settings:
+//
+//	reporting:
+//	  testmanagement:
+//	    tool: testrail
+//	    url: https://example.testrail.io
+//	    user: qa@example.com
+//	    run: "42"
+//
+// and overridden by SERENITY_TEST_MANAGEMENT_TOOL, SERENITY_TEST_MANAGEMENT_URL,
+// SERENITY_TEST_MANAGEMENT_USER, SERENITY_TEST_MANAGEMENT_TOKEN,
+// SERENITY_TEST_MANAGEMENT_PROJECT and SERENITY_TEST_MANAGEMENT_RUN.
+type Settings struct {
+	// Tool is the test-management tool: "testrail", "xray" or "zephyr"
+	Tool string `yaml:"tool"`
+	// URL is the address of the tool; Xray and Zephyr Scale default to their cloud APIs
+	URL string `yaml:"url"`
+	// User is the TestRail user, or the Xray client ID
+	User string `yaml:"user"`
+	// Token is the TestRail API key, the Xray client secret or the Zephyr Scale API token;
+	// it is masked in reports
+	Token string `yaml:"token"`
+	// Project is the Jira project key, for Xray and Zephyr Scale
+	Project string `yaml:"project"`
+	// Run is the TestRail run ID, the Xray test execution key or the Zephyr Scale test
+	// cycle key the statuses are uploaded to
+	Run string `yaml:"run"`
+}
+
+func init() {
+	reporting.RegisterReporter("testmanagement", func(run config.RunSettings) (reporting.Reporter, error) {
+		settings, err := settingsOf(run)
+		if err != nil {
+			return nil, err
+		}
+		return runPublisher(settings)
+	})
+}
+
+// settingsOf returns the test-management settings of the run, with the environment
+// overrides applied
+func settingsOf(run config.RunSettings) (Settings, error) {
+	var settings Settings
+	if err := run.ReporterSettings("testmanagement", &settings); err != nil {
+		return Settings{}, err
+	}
+	err := config.ApplyOverrides(map[string]any{
+		"TEST_MANAGEMENT_TOOL":    &settings.Tool,
+		"TEST_MANAGEMENT_URL":     &settings.URL,
+		"TEST_MANAGEMENT_USER":    &settings.User,
+		"TEST_MANAGEMENT_TOKEN":   &settings.Token,
+		"TEST_MANAGEMENT_PROJECT": &settings.Project,
+		"TEST_MANAGEMENT_RUN":     &settings.Run,
+	})
+	return settings, err
+}
+
+// publisher holds the test-management publisher shared by the tests of the process
+var publisher struct {
+	publisher *Publisher
+	mutex     sync.Mutex
+}
+
+// runPublisher returns the publisher uploading the case statuses of the run to the
+// test-management tool
+func runPublisher(settings Settings) (reporting.Reporter, error) {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	if publisher.publisher != nil {
+		return publisher.publisher, nil
+	}
+	target, err := targetOf(settings)
+	if err != nil {
+		return nil, err
+	}
+	publisher.publisher = NewPublisher(target)
+	return publisher.publisher, nil
+}
+
+// targetOf creates the target of the test-management tool in the settings
+func targetOf(settings Settings) (Target, error) {
+	required := func(values map[string]string) error {
+		var missing []string
+		for name, value := range values {
+			if value == "" {
+				missing = append(missing, "reporting.testmanagement."+name)
+			}
+		}
+		if len(missing) > 0 {
+			slices.Sort(missing)
+			return fmt.Errorf("the %s test management needs %s", settings.Tool, strings.Join(missing, ", "))
+		}
+		return nil
+	}
+
+	switch strings.ToLower(settings.Tool) {
+	case "testrail":
+		if err := required(map[string]string{"url": settings.URL, "user": settings.User, "token": settings.Token, "run": settings.Run}); err != nil {
+			return nil, err
+		}
+		run, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(settings.Run), "R"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid TestRail run ID '%s', expected e.g. R42", settings.Run)
+		}
+		return NewTestRail(settings.URL, settings.User, settings.Token, run), nil
+	case "xray":
+		if err := required(map[string]string{"user": settings.User, "token": settings.Token}); err != nil {
+			return nil, err
+		}
+		if settings.Project == "" && settings.Run == "" {
+			return nil, errors.New("the xray test management needs reporting.testmanagement.project or reporting.testmanagement.run")
+		}
+		xray := NewXray(settings.User, settings.Token, settings.Project).WithTestExecution(settings.Run)
+		if settings.URL != "" {
+			xray.WithURL(settings.URL)
+		}
+		return xray, nil
+	case "zephyr":
+		if err := required(map[string]string{"token": settings.Token, "project": settings.Project, "run": settings.Run}); err != nil {
+			return nil, err
+		}
+		zephyr := NewZephyr(settings.Token, settings.Project, settings.Run)
+		if settings.URL != "" {
+			zephyr.WithURL(settings.URL)
+		}
+		return zephyr, nil
+	case "":
+		return nil, errors.New("the testmanagement reporter needs reporting.testmanagement.tool or SERENITY_TEST_MANAGEMENT_TOOL")
+	default:
+		return nil, fmt.Errorf("unknown test management tool '%s' (available: testrail, xray, zephyr)", settings.Tool)
+	}
+}
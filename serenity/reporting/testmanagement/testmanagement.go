@@ -0,0 +1,227 @@
+// Package testmanagement provides a reporter that uploads the statuses of the cases of a
+// test-management tool, such as TestRail, Xray or Zephyr Scale, when the run finishes. Tests
+// declare the cases they cover:
+//
+//	test := serenity.NewSerenityTest(t).CoversCase("C1234")
+//
+// A case covered by several tests fails if any of them failed, passes if the others passed,
+// and is reported as not executed if they were all skipped. The publisher is configured
+// with the testmanagement entry of the reporting settings (see Settings) and selected with the
+// "testmanagement" reporter, available once the package is imported. Runs finish with serenity.Main, called from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(serenity.Main(m))
+//	}
+package testmanagement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// publishTimeout caps the time uploading the statuses may take
+const publishTimeout = time.Minute
+
+// Outcome is the status of a case, aggregated over the tests covering it
+type Outcome struct {
+	// Case is the ID of the case in the tool, e.g. "C1234" or "SHOP-12"
+	Case string
+	// Status is failed if any test failed, passed if the others passed, and skipped otherwise
+	Status reporting.Status
+	// Tests names the tests covering the case
+	Tests []string
+	// Duration is the total duration of the tests
+	Duration time.Duration
+	// Comment lists the tests with their statuses and failures
+	Comment string
+}
+
+// Target uploads the outcomes of cases to a test-management tool
+type Target interface {
+	// Name names the tool in messages, e.g. "TestRail"
+	Name() string
+
+	// Publish uploads the outcomes of the cases
+	Publish(ctx context.Context, outcomes []Outcome) error
+}
+
+// Publisher is a reporter collecting the outcomes of the cases covered by the tests of a run
+// and uploading them to a test-management tool when the run finishes
+type Publisher struct {
+	target   Target
+	outcomes map[string]*Outcome
+	order    []string
+	output   io.Writer
+	mutex    sync.Mutex
+}
+
+// NewPublisher creates a publisher uploading to the target. Failures to upload are reported
+// to the output, os.Stderr by default.
+func NewPublisher(target Target) *Publisher {
+	return &Publisher{
+		target:   target,
+		outcomes: make(map[string]*Outcome),
+		output:   os.Stderr,
+	}
+}
+
+// OnTestStart is a no-op
+func (p *Publisher) OnTestStart(testName string) {}
+
+// OnTestFinish records the outcome of the test for each case it covers
+func (p *Publisher) OnTestFinish(result reporting.TestResult) {
+	cased, ok := result.(reporting.CaseResult)
+	if !ok || len(cased.Cases()) == 0 {
+		return
+	}
+
+	line := fmt.Sprintf("%s: %s (%s)", result.Name(), statusText(result.Status()), reporting.FormatDuration(result.Duration()))
+	if result.Status() == reporting.StatusFailed {
+		if err := reporting.FailedStepError(result); err != nil {
+			line += "\n" + secrets.Mask(err.Error())
+		}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, id := range cased.Cases() {
+		outcome, ok := p.outcomes[id]
+		if !ok {
+			outcome = &Outcome{Case: id, Status: reporting.StatusSkipped}
+			p.outcomes[id] = outcome
+			p.order = append(p.order, id)
+		}
+		outcome.Status = worse(outcome.Status, result.Status())
+		outcome.Tests = append(outcome.Tests, result.Name())
+		outcome.Duration += result.Duration()
+		if outcome.Comment != "" {
+			outcome.Comment += "\n"
+		}
+		outcome.Comment += line
+	}
+}
+
+// OnStepStart is a no-op
+func (p *Publisher) OnStepStart(stepDescription string) {}
+
+// OnStepFinish is a no-op
+func (p *Publisher) OnStepFinish(stepResult reporting.TestResult) {}
+
+// SetOutput sets where failures to upload the statuses are reported, os.Stderr by default
+func (p *Publisher) SetOutput(w io.Writer) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.output = w
+}
+
+// OnRunFinish uploads the outcomes, reporting a failure to upload them to the output
+func (p *Publisher) OnRunFinish() {
+	if err := p.Publish(context.Background()); err != nil {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		_, _ = fmt.Fprintf(p.output, "⚠️ %s\n", secrets.Mask(err.Error()))
+	}
+}
+
+// Outcomes returns the outcomes of the cases covered by the tests finished so far, in the
+// order the cases were first covered
+func (p *Publisher) Outcomes() []Outcome {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	outcomes := make([]Outcome, 0, len(p.order))
+	for _, id := range p.order {
+		outcome := *p.outcomes[id]
+		outcome.Tests = append([]string{}, outcome.Tests...)
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// Publish uploads the outcomes of the cases covered by the tests finished so far
+func (p *Publisher) Publish(ctx context.Context) error {
+	outcomes := p.Outcomes()
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if err := p.target.Publish(ctx, outcomes); err != nil {
+		return fmt.Errorf("failed to publish the case statuses to %s: %w", p.target.Name(), err)
+	}
+	return nil
+}
+
+// worse returns the status of a case covered by tests with the two statuses
+func worse(a, b reporting.Status) reporting.Status {
+	switch {
+	case a == reporting.StatusFailed || b == reporting.StatusFailed:
+		return reporting.StatusFailed
+	case a == reporting.StatusPassed || b == reporting.StatusPassed:
+		return reporting.StatusPassed
+	default:
+		return reporting.StatusSkipped
+	}
+}
+
+// statusText names the status in comments
+func statusText(status reporting.Status) string {
+	switch status {
+	case reporting.StatusFailed:
+		return "failed"
+	case reporting.StatusSkipped:
+		return "skipped"
+	default:
+		return "passed"
+	}
+}
+
+// postJSON posts the payload to the URL with the headers and decodes the answer into
+// answer, unless it is nil
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, payload, answer any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode the request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to %s: %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s answered %s: %s", url, resp.Status, strings.TrimSpace(string(text)))
+	}
+	if answer == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(answer); err != nil {
+		return fmt.Errorf("failed to decode the answer of %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package testmanagement
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// TestRail status IDs of the default statuses
+const (
+	testRailPassed  = 1
+	testRailBlocked = 2
+	testRailFailed  = 5
+)
+
+// TestRail is a target adding the outcomes as results of the cases of a TestRail run. Case
+// IDs are the IDs shown by TestRail, e.g. "C1234". Skipped cases are reported as blocked.
+type TestRail struct {
+	url    string
+	user   string
+	apiKey string
+	run    int
+	client *http.Client
+}
+
+// NewTestRail creates a target adding results to the run of the TestRail instance at url,
+// e.g. https://example.testrail.io, authenticating with the user and API key. The API key
+// is registered as a secret.
+func NewTestRail(url, user, apiKey string, run int) *TestRail {
+	secrets.Register(apiKey)
+	return &TestRail{
+		url:    strings.TrimSuffix(url, "/"),
+		user:   user,
+		apiKey: apiKey,
+		run:    run,
+		client: http.DefaultClient,
+	}
+}
+
+// WithClient sets the HTTP client of the API and returns the target for chaining
+func (tr *TestRail) WithClient(client *http.Client) *TestRail {
+	tr.client = client
+	return tr
+}
+
+// Name returns "TestRail"
+func (tr *TestRail) Name() string { return "TestRail" }
+
+// Publish adds the outcomes to the run with a single request
+func (tr *TestRail) Publish(ctx context.Context, outcomes []Outcome) error {
+	type result struct {
+		CaseID   int    `json:"case_id"`
+		StatusID int    `json:"status_id"`
+		Comment  string `json:"comment,omitempty"`
+		Elapsed  string `json:"elapsed,omitempty"`
+	}
+	results := make([]result, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(outcome.Case), "C"))
+		if err != nil {
+			return fmt.Errorf("invalid TestRail case ID '%s', expected e.g. C1234", outcome.Case)
+		}
+		results = append(results, result{
+			CaseID:   id,
+			StatusID: testRailStatus(outcome.Status),
+			Comment:  outcome.Comment,
+			Elapsed:  timespan(outcome.Duration),
+		})
+	}
+
+	url := fmt.Sprintf("%s/index.php?/api/v2/add_results_for_cases/%d", tr.url, tr.run)
+	credentials := base64.StdEncoding.EncodeToString([]byte(tr.user + ":" + tr.apiKey))
+	headers := map[string]string{"Authorization": "Basic " + credentials}
+	return postJSON(ctx, tr.client, url, headers, map[string]any{"results": results}, nil)
+}
+
+// testRailStatus returns the TestRail status ID of the status
+func testRailStatus(status reporting.Status) int {
+	switch status {
+	case reporting.StatusFailed:
+		return testRailFailed
+	case reporting.StatusSkipped:
+		return testRailBlocked
+	default:
+		return testRailPassed
+	}
+}
+
+// timespan renders the duration as a TestRail timespan, e.g. "1m 5s", rounded up to whole
+// seconds since TestRail rejects shorter ones
+func timespan(d time.Duration) string {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds <= 0 {
+		return ""
+	}
+	var parts []string
+	if hours := seconds / 3600; hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes := seconds % 3600 / 60; minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	if seconds%60 > 0 {
+		parts = append(parts, fmt.Sprintf("%ds", seconds%60))
+	}
+	return strings.Join(parts, " ")
+}
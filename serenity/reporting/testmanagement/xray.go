@@ -0,0 +1,107 @@
+package testmanagement
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// XrayCloudURL is the address of the Xray Cloud API
+const XrayCloudURL = "https://xray.cloud.getxray.app"
+
+// Xray is a target importing the outcomes into an Xray test execution. Case IDs are the
+// Jira keys of the tests, e.g. "SHOP-12". Skipped cases are reported as TODO.
+type Xray struct {
+	url          string
+	clientID     string
+	clientSecret string
+	project      string
+	execution    string
+	client       *http.Client
+}
+
+// NewXray creates a target importing into Xray Cloud, authenticating with the API key of
+// the client. Without a test execution, each run creates one in the project. The client
+// secret is registered as a secret.
+func NewXray(clientID, clientSecret, project string) *Xray {
+	secrets.Register(clientSecret)
+	return &Xray{
+		url:          XrayCloudURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		project:      project,
+		client:       http.DefaultClient,
+	}
+}
+
+// WithURL sets the address of the Xray API and returns the target for chaining
+func (x *Xray) WithURL(url string) *Xray {
+	x.url = strings.TrimSuffix(url, "/")
+	return x
+}
+
+// WithTestExecution imports into the existing test execution with the Jira key and returns
+// the target for chaining
+func (x *Xray) WithTestExecution(key string) *Xray {
+	x.execution = key
+	return x
+}
+
+// WithClient sets the HTTP client of the API and returns the target for chaining
+func (x *Xray) WithClient(client *http.Client) *Xray {
+	x.client = client
+	return x
+}
+
+// Name returns "Xray"
+func (x *Xray) Name() string { return "Xray" }
+
+// Publish authenticates and imports the outcomes as a single execution
+func (x *Xray) Publish(ctx context.Context, outcomes []Outcome) error {
+	var token string
+	credentials := map[string]string{"client_id": x.clientID, "client_secret": x.clientSecret}
+	if err := postJSON(ctx, x.client, x.url+"/api/v2/authenticate", nil, credentials, &token); err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+	secrets.Register(token)
+
+	type test struct {
+		TestKey string `json:"testKey"`
+		Status  string `json:"status"`
+		Comment string `json:"comment,omitempty"`
+	}
+	execution := struct {
+		TestExecutionKey string            `json:"testExecutionKey,omitempty"`
+		Info             map[string]string `json:"info,omitempty"`
+		Tests            []test            `json:"tests"`
+	}{TestExecutionKey: x.execution}
+	if x.execution == "" {
+		execution.Info = map[string]string{"project": x.project, "summary": "Serenity run"}
+	}
+	for _, outcome := range outcomes {
+		execution.Tests = append(execution.Tests, test{
+			TestKey: outcome.Case,
+			Status:  xrayStatus(outcome.Status),
+			Comment: outcome.Comment,
+		})
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + token}
+	return postJSON(ctx, x.client, x.url+"/api/v2/import/execution", headers, execution, nil)
+}
+
+// xrayStatus returns the Xray status of the status
+func xrayStatus(status reporting.Status) string {
+	switch status {
+	case reporting.StatusFailed:
+		return "FAILED"
+	case reporting.StatusSkipped:
+		return "TODO"
+	default:
+		return "PASSED"
+	}
+}
@@ -0,0 +1,86 @@
+package testmanagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// ZephyrCloudURL is the address of the Zephyr Scale Cloud API
+const ZephyrCloudURL = "https://api.zephyrscale.smartbear.com/v2"
+
+// Zephyr is a target creating Zephyr Scale test executions in a test cycle. Case IDs are the
+// keys of the test cases, e.g. "SHOP-T12". Skipped cases are reported as not executed.
+type Zephyr struct {
+	url     string
+	token   string
+	project string
+	cycle   string
+	client  *http.Client
+}
+
+// NewZephyr creates a target adding executions to the test cycle of the project in Zephyr
+// Scale Cloud, authenticating with the API token. The token is registered as a secret.
+func NewZephyr(token, project, cycle string) *Zephyr {
+	secrets.Register(token)
+	return &Zephyr{
+		url:     ZephyrCloudURL,
+		token:   token,
+		project: project,
+		cycle:   cycle,
+		client:  http.DefaultClient,
+	}
+}
+
+// WithURL sets the address of the Zephyr Scale API and returns the target for chaining
+func (z *Zephyr) WithURL(url string) *Zephyr {
+	z.url = strings.TrimSuffix(url, "/")
+	return z
+}
+
+// WithClient sets the HTTP client of the API and returns the target for chaining
+func (z *Zephyr) WithClient(client *http.Client) *Zephyr {
+	z.client = client
+	return z
+}
+
+// Name returns "Zephyr Scale"
+func (z *Zephyr) Name() string { return "Zephyr Scale" }
+
+// Publish creates an execution per case, going on after failures to report them all
+func (z *Zephyr) Publish(ctx context.Context, outcomes []Outcome) error {
+	headers := map[string]string{"Authorization": "Bearer " + z.token}
+
+	var errs []error
+	for _, outcome := range outcomes {
+		execution := map[string]any{
+			"projectKey":    z.project,
+			"testCaseKey":   outcome.Case,
+			"testCycleKey":  z.cycle,
+			"statusName":    zephyrStatus(outcome.Status),
+			"executionTime": outcome.Duration.Milliseconds(),
+			"comment":       strings.ReplaceAll(outcome.Comment, "\n", "<br>"),
+		}
+		if err := postJSON(ctx, z.client, z.url+"/testexecutions", headers, execution, nil); err != nil {
+			errs = append(errs, fmt.Errorf("case %s: %w", outcome.Case, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// zephyrStatus returns the Zephyr Scale status name of the status
+func zephyrStatus(status reporting.Status) string {
+	switch status {
+	case reporting.StatusFailed:
+		return "Fail"
+	case reporting.StatusSkipped:
+		return "Not Executed"
+	default:
+		return "Pass"
+	}
+}
@@ -0,0 +1,214 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// Drift describes a test whose behavior differs from the baseline transcript
+type Drift struct {
+	Test string
+	Diff string
+}
+
+// Recorder is a reporter that records tests into a transcript.
+// Secret values registered with the secrets package are masked in the transcript.
+type Recorder struct {
+	mutex      sync.Mutex
+	path       string
+	output     io.Writer
+	transcript Transcript
+	current    *Test
+	stack      []*Step
+	baseline   *Transcript
+	drifts     []Drift
+}
+
+// NewRecorder creates a recorder that saves the transcript to path after every test.
+// An empty path keeps the transcript in memory only.
+func NewRecorder(path string) *Recorder {
+	return &Recorder{
+		path:   path,
+		output: os.Stdout,
+	}
+}
+
+// CompareWith loads a baseline transcript; every finished test is compared with its baseline
+// recording and behavioral drift is written to the recorder output
+func (r *Recorder) CompareWith(baselinePath string) error {
+	baseline, err := Load(baselinePath)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	r.baseline = baseline
+	r.mutex.Unlock()
+	return nil
+}
+
+// Transcript returns the transcript recorded so far
+func (r *Recorder) Transcript() *Transcript {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tests := make([]*Test, len(r.transcript.Tests))
+	copy(tests, r.transcript.Tests)
+	return &Transcript{Tests: tests}
+}
+
+// Drifts returns the tests whose behavior differs from the baseline
+func (r *Recorder) Drifts() []Drift {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	drifts := make([]Drift, len(r.drifts))
+	copy(drifts, r.drifts)
+	return drifts
+}
+
+// SetOutput sets the destination for drift reports
+func (r *Recorder) SetOutput(w io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.output = w
+}
+
+// OnTestStart starts recording a new test
+func (r *Recorder) OnTestStart(testName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.current = &Test{Name: testName}
+	r.stack = nil
+}
+
+// OnTestFinish records the test outcome, saves the transcript and reports drift from the baseline
+func (r *Recorder) OnTestFinish(result reporting.TestResult) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	test := r.currentTest()
+	test.Name = result.Name()
+	test.Status = statusName(result.Status())
+	if result.Error() != nil {
+		test.Error = secrets.Mask(result.Error().Error())
+	}
+	if traced, ok := result.(reporting.TracedResult); ok {
+		test.Requirements = traced.Requirements()
+	}
+	if cased, ok := result.(reporting.CaseResult); ok {
+		test.Cases = cased.Cases()
+	}
+	if contextual, ok := result.(reporting.ContextualResult); ok {
+		test.Contexts = contextual.Contexts()
+	}
+
+	r.transcript.Tests = append(r.transcript.Tests, test)
+	r.current = nil
+	r.stack = nil
+
+	if r.path != "" {
+		if err := r.transcript.Save(r.path); err != nil {
+			r.write("⚠️ %v\n", err)
+		}
+	}
+
+	if r.baseline == nil {
+		return
+	}
+
+	baselineTest := r.baseline.Test(test.Name)
+	if baselineTest == nil {
+		r.write("⚠️ %s is not part of the baseline transcript\n", test.Name)
+		return
+	}
+
+	if diff := Diff(baselineTest, test); diff != "" {
+		r.drifts = append(r.drifts, Drift{Test: test.Name, Diff: diff})
+		r.write("⚠️ Behavioral drift in %s (- baseline, + current):\n%s\n", test.Name, diff)
+	}
+}
+
+// OnStepStart records a new step nested in the currently running step
+func (r *Recorder) OnStepStart(stepDescription string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	step := &Step{Description: secrets.Mask(stepDescription)}
+	if len(r.stack) == 0 {
+		test := r.currentTest()
+		test.Steps = append(test.Steps, step)
+	} else {
+		parent := r.stack[len(r.stack)-1]
+		parent.Steps = append(parent.Steps, step)
+	}
+	r.stack = append(r.stack, step)
+}
+
+// OnStepFinish records the outcome of the currently running step
+func (r *Recorder) OnStepFinish(stepResult reporting.TestResult) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.stack) == 0 {
+		return
+	}
+
+	step := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+
+	step.Status = statusName(stepResult.Status())
+	if stepResult.Error() != nil {
+		step.Error = secrets.Mask(stepResult.Error().Error())
+	}
+	if annotated, ok := stepResult.(reporting.AnnotatedResult); ok {
+		step.Metadata = annotated.Metadata()
+	}
+}
+
+// OnQuestionAnswered records the answer in the currently running step
+func (r *Recorder) OnQuestionAnswered(question string, answer any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.stack) == 0 {
+		return
+	}
+
+	step := r.stack[len(r.stack)-1]
+	step.Answers = append(step.Answers, Answer{
+		Question: secrets.Mask(question),
+		Value:    secrets.Mask(encodeAnswer(answer)),
+	})
+}
+
+// currentTest returns the running test, starting an unnamed one for steps reported outside a test
+func (r *Recorder) currentTest() *Test {
+	if r.current == nil {
+		r.current = &Test{}
+	}
+	return r.current
+}
+
+// write writes a message to the output; the caller must hold the mutex
+func (r *Recorder) write(format string, args ...interface{}) {
+	if r.output != nil {
+		_, _ = fmt.Fprintf(r.output, format, args...)
+	}
+}
+
+// encodeAnswer encodes an answer as compact JSON, falling back to its default formatting
+func encodeAnswer(answer any) string {
+	data, err := json.Marshal(answer)
+	if err != nil {
+		return fmt.Sprintf("%v", answer)
+	}
+	return string(data)
+}
@@ -0,0 +1,37 @@
+package transcript
+
+import (
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// transcripts holds the recorders shared by the tests of the process, by output directory
+var transcripts = struct {
+	recorders map[*reporting.OutputDir]*Recorder
+	mutex     sync.Mutex
+}{recorders: make(map[*reporting.OutputDir]*Recorder)}
+
+func init() {
+	reporting.RegisterReporter("transcript", func(settings config.RunSettings) (reporting.Reporter, error) {
+		return runTranscript(reporting.OutputDirOf(settings))
+	})
+}
+
+// runTranscript returns the recorder saving the transcript of the run to the output directory
+func runTranscript(dir *reporting.OutputDir) (reporting.Reporter, error) {
+	transcripts.mutex.Lock()
+	defer transcripts.mutex.Unlock()
+
+	if recorder, ok := transcripts.recorders[dir]; ok {
+		return recorder, nil
+	}
+	path, err := dir.Path("transcript.json")
+	if err != nil {
+		return nil, err
+	}
+	recorder := NewRecorder(path)
+	transcripts.recorders[dir] = recorder
+	return recorder, nil
+}
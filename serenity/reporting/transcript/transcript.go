@@ -0,0 +1,178 @@
+// Package transcript records test sessions into durable, replayable transcripts.
+//
+// A transcript captures the full sequence of activities each test performed, the answers
+// actors received to their questions, and the outcome of every step. Transcripts are stored
+// as JSON files and contain no timings, so a transcript recorded for one release can be
+// compared with a later run to highlight behavioral drift:
+//
+//	recorder := transcript.NewRecorder("testdata/transcripts/current.json")
+//	if err := recorder.CompareWith("testdata/transcripts/v1.2.json"); err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	test := serenity.NewSerenityTestWithReporter(ctx, t, reporting.NewMultiReporter(
+//		console_reporter.NewConsoleReporter(),
+//		recorder,
+//	))
+//
+// With the run settings, the "transcript" reporter saves the transcript of the run to the
+// output directory once the package is imported. Steps are attributed to the most recently
+// started test, so a recorder shared between tests should not be used with t.Parallel().
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/expectations/utils"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// Transcript is the recorded sequence of tests of a session
+type Transcript struct {
+	Tests []*Test `json:"tests"`
+}
+
+// Test is a recorded test with its top-level steps
+type Test struct {
+	Name         string           `json:"name"`
+	Status       string           `json:"status"`
+	Error        string           `json:"error,omitempty"`
+	Contexts     []events.Context `json:"contexts,omitempty"`
+	Requirements []string         `json:"requirements,omitempty"`
+	Cases        []string         `json:"cases,omitempty"`
+	Steps        []*Step          `json:"steps,omitempty"`
+}
+
+// Step is a recorded activity with the answers received while performing it and its nested steps
+type Step struct {
+	Description string            `json:"description"`
+	Status      string            `json:"status"`
+	Error       string            `json:"error,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Answers     []Answer          `json:"answers,omitempty"`
+	Steps       []*Step           `json:"steps,omitempty"`
+}
+
+// Answer is a recorded answer to a question, encoded as JSON
+type Answer struct {
+	Question string `json:"question"`
+	Value    string `json:"value"`
+}
+
+// Load reads a transcript from a file
+func Load(path string) (*Transcript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	var transcript Transcript
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript %s: %w", path, err)
+	}
+	return &transcript, nil
+}
+
+// Save writes the transcript to a file as indented JSON
+func (t *Transcript) Save(path string) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transcript: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return nil
+}
+
+// Test returns the recorded test with the given name, or nil if there is none
+func (t *Transcript) Test(name string) *Test {
+	for _, test := range t.Tests {
+		if test.Name == name {
+			return test
+		}
+	}
+	return nil
+}
+
+// Coverage maps every requirement to the names of the tests covering it: the tests that
+// declared it with CoversRequirement and the tests with a step linked to it as an issue
+func (t *Transcript) Coverage() map[string][]string {
+	coverage := make(map[string][]string)
+	for _, test := range t.Tests {
+		for _, requirement := range test.requirements() {
+			coverage[requirement] = append(coverage[requirement], test.Name)
+		}
+	}
+	return coverage
+}
+
+// requirements returns the requirements covered by the test and its steps, without duplicates
+func (t *Test) requirements() []string {
+	requirements := slices.Clone(t.Requirements)
+	var collect func(steps []*Step)
+	collect = func(steps []*Step) {
+		for _, step := range steps {
+			for _, issue := range core.Issues(step.Metadata) {
+				if !slices.Contains(requirements, issue) {
+					requirements = append(requirements, issue)
+				}
+			}
+			collect(step.Steps)
+		}
+	}
+	collect(t.Steps)
+	return requirements
+}
+
+// Render returns a readable, line-oriented representation of the test used for diffs
+func (t *Test) Render() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s [%s]\n", t.Name, t.Status))
+	if t.Error != "" {
+		builder.WriteString(fmt.Sprintf("  ! %s\n", t.Error))
+	}
+	for _, step := range t.Steps {
+		step.render(&builder, 1)
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func (s *Step) render(builder *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	builder.WriteString(fmt.Sprintf("%s%s [%s]\n", indent, s.Description, s.Status))
+	for _, answer := range s.Answers {
+		builder.WriteString(fmt.Sprintf("%s  ? %s = %s\n", indent, answer.Question, answer.Value))
+	}
+	if s.Error != "" {
+		builder.WriteString(fmt.Sprintf("%s  ! %s\n", indent, s.Error))
+	}
+	for _, step := range s.Steps {
+		step.render(builder, depth+1)
+	}
+}
+
+// Diff compares a test with its baseline recording. It returns an empty string when the
+// behavior is unchanged, or a line diff prefixed with "- " (baseline) and "+ " (current).
+func Diff(baseline, current *Test) string {
+	return utils.LineDiff(baseline.Render(), current.Render())
+}
+
+// statusName converts a reporting status into its transcript representation
+func statusName(status reporting.Status) string {
+	switch status {
+	case reporting.StatusFailed:
+		return "failed"
+	case reporting.StatusSkipped:
+		return "skipped"
+	default:
+		return "passed"
+	}
+}
@@ -0,0 +1,438 @@
+// Package triage exports a triage bundle for each failed test: a single JSON attachment
+// holding the failing step with its expected and actual values, the last HTTP exchanges
+// of the actors, the notes they took and the environment of the run, so that a bug can be
+// filed with the complete context of the failure:
+//
+//	{
+//	  "test": "TestCheckout",
+//	  "failure": {
+//	    "actor": "Buyer",
+//	    "step": "Buyer ensures that last response status equals 201",
+//	    "steps": ["Buyer places an order", "Buyer ensures that last response status equals 201"],
+//	    "location": "checkout_test.go:42",
+//	    "error": "expected 201, but got 402",
+//	    "expected": "201",
+//	    "actual": "402"
+//	  },
+//	  "httpExchanges": [{"actor": "Buyer", "method": "POST", "url": "https://shop.example.com/orders", "status": 402, ...}],
+//	  "notes": {"Buyer": {"cart id": "c-7"}},
+//	  "environment": {"goVersion": "go1.23.4", "os": "linux", "arch": "amd64", "runId": "20261016-101500", ...}
+//	}
+//
+// Bundles are enabled with the triage entry of the reporting settings (see Settings), and
+// are attached to the failed tests as triage.json. Credential headers are redacted and registered secrets are
+// masked.
+package triage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+const (
+	// AttachmentName is the name of the triage bundle attached to failed tests
+	AttachmentName = "triage.json"
+
+	// DefaultExchanges is the number of HTTP exchanges in a bundle unless configured otherwise
+	DefaultExchanges = 5
+
+	// maxBodySize is the number of bytes of a body kept in a bundle
+	maxBodySize = 64 << 10
+
+	// redacted replaces the values of credential headers
+	redacted = "[redacted]"
+)
+
+// credentialHeaders are the headers whose values are redacted from the bundles
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// ciVariables are the environment variables identifying the CI build, recorded when set
+var ciVariables = []string{
+	"CI",
+	"GITHUB_REPOSITORY", "GITHUB_REF", "GITHUB_SHA", "GITHUB_RUN_ID", "GITHUB_SERVER_URL",
+	"CI_PROJECT_PATH", "CI_COMMIT_REF_NAME", "CI_COMMIT_SHA", "CI_PIPELINE_URL", "CI_JOB_URL",
+	"BUILD_URL", "BUILD_NUMBER", "GIT_BRANCH", "GIT_COMMIT",
+}
+
+// Bundle is the context of a failed test
+type Bundle struct {
+	Test        string                       `json:"test"`
+	Failed      time.Time                    `json:"failedAt"`
+	Failure     *Failure                     `json:"failure,omitempty"`
+	Exchanges   []Exchange                   `json:"httpExchanges,omitempty"`
+	Notes       map[string]map[string]string `json:"notes,omitempty"`
+	Environment Environment                  `json:"environment"`
+}
+
+// Failure is the innermost step that failed
+type Failure struct {
+	Actor string `json:"actor"`
+	Step  string `json:"step"`
+	// Steps are the activities enclosing the failing step, from the outermost, followed by the step
+	Steps    []string `json:"steps,omitempty"`
+	Location string   `json:"location,omitempty"`
+	Error    string   `json:"error"`
+	// Expected and Actual are the values of a failed assertion
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// Exchange is an HTTP request sent by an actor, with its response
+type Exchange struct {
+	Actor           string              `json:"actor"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	Status          int                 `json:"status,omitempty"`
+	Duration        time.Duration       `json:"durationNanos"`
+	Error           string              `json:"error,omitempty"`
+	RequestHeaders  map[string][]string `json:"requestHeaders,omitempty"`
+	RequestBody     string              `json:"requestBody,omitempty"`
+	ResponseHeaders map[string][]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string              `json:"responseBody,omitempty"`
+}
+
+// Environment describes where the test ran
+type Environment struct {
+	GoVersion string            `json:"goVersion"`
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	Hostname  string            `json:"hostname,omitempty"`
+	RunID     string            `json:"runId,omitempty"`
+	Profile   string            `json:"profile,omitempty"`
+	CI        map[string]string `json:"ci,omitempty"`
+}
+
+// CurrentEnvironment describes the environment of the process running the run with the ID
+func CurrentEnvironment(runID string) Environment {
+	env := Environment{
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		RunID:     runID,
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		env.Hostname = hostname
+	}
+	if cfg, err := config.Active(); err == nil {
+		env.Profile = cfg.Profile()
+	}
+	for _, name := range ciVariables {
+		if value, ok := os.LookupEnv(name); ok {
+			if env.CI == nil {
+				env.CI = make(map[string]string)
+			}
+			env.CI[name] = value
+		}
+	}
+	return env
+}
+
+// Collector listens to the events of a test and keeps what a bundle needs: the step that
+// failed the test, the activities enclosing it and the last HTTP exchanges
+type Collector struct {
+	exchanges int
+	sent      []events.RequestSent
+	running   map[string][]runningStep
+	started   int
+	failures  []failedStep
+	mutex     sync.Mutex
+}
+
+// failedStep is the innermost failure of an activity that failed
+type failedStep struct {
+	failure *Failure
+	err     error
+	// started is the order in which the activity started among the activities of the test
+	started int
+}
+
+// runningStep is an activity that started and hasn't finished yet
+type runningStep struct {
+	description string
+	// started is the order in which the activity started among the activities of the test
+	started int
+}
+
+// Settings configures the triage bundles. They are read from the triage entry of the
+// reporting settings:
+//
+//	reporting:
+//	  triage:
+//	    enabled: true
+//	    exchanges: 10
+//
+// and overridden by SERENITY_TRIAGE and SERENITY_TRIAGE_EXCHANGES.
+type Settings struct {
+	// Enabled attaches the triage bundles to the failed tests
+	Enabled bool `yaml:"enabled"`
+	// Exchanges is the number of last HTTP exchanges in a bundle
+	Exchanges int `yaml:"exchanges"`
+}
+
+// SettingsOf returns the triage settings of the run, with the environment overrides applied
+func SettingsOf(run config.RunSettings) (Settings, error) {
+	settings := Settings{Exchanges: DefaultExchanges}
+	if err := run.ReporterSettings("triage", &settings); err != nil {
+		return Settings{}, err
+	}
+	err := config.ApplyOverrides(map[string]any{
+		"TRIAGE":           &settings.Enabled,
+		"TRIAGE_EXCHANGES": &settings.Exchanges,
+	})
+	if err != nil {
+		return Settings{}, err
+	}
+	if settings.Exchanges < 0 {
+		return Settings{}, fmt.Errorf("invalid reporting.triage.exchanges %d: use 0 to leave the exchanges out", settings.Exchanges)
+	}
+	return settings, nil
+}
+
+// NewCollector creates a collector keeping the last exchanges HTTP exchanges
+func NewCollector(exchanges int) *Collector {
+	return &Collector{exchanges: exchanges, running: make(map[string][]runningStep)}
+}
+
+// Notify records the activities and the requests of the actors. The failure of the test is
+// that of the last activity that failed, traced down to the innermost step whose error it
+// wraps: failures that an activity tolerated, such as ignored steps or attempts that were
+// retried, are dropped when it finishes.
+func (c *Collector) Notify(event events.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch e := event.(type) {
+	case events.ActivityStarted:
+		c.started++
+		c.running[e.Actor] = append(c.running[e.Actor], runningStep{describe(e.Actor, e.Activity), c.started})
+	case events.ActivityFinished:
+		steps := c.running[e.Actor]
+		if len(steps) == 0 {
+			return
+		}
+		step := steps[len(steps)-1]
+		c.running[e.Actor] = steps[:len(steps)-1]
+
+		// Inner steps finish before the activities enclosing them, so the failures kept
+		// since the activity started are those of its steps
+		kept := len(c.failures)
+		for kept > 0 && c.failures[kept-1].started > step.started {
+			kept--
+		}
+		inner := c.failures[kept:]
+		c.failures = c.failures[:kept]
+		if e.Outcome != events.Failed {
+			return
+		}
+		failed := failedStep{failure: failureOf(e, descriptions(steps)), err: e.Err}
+		if len(inner) > 0 && e.Err != nil && errors.Is(e.Err, inner[len(inner)-1].err) {
+			failed = inner[len(inner)-1]
+		}
+		failed.started = step.started
+		c.failures = append(c.failures, failed)
+	case events.RequestSent:
+		if c.exchanges <= 0 {
+			return
+		}
+		c.sent = append(c.sent, e)
+		if len(c.sent) > c.exchanges {
+			c.sent = slices.Delete(c.sent, 0, len(c.sent)-c.exchanges)
+		}
+	}
+}
+
+// descriptions returns the descriptions of the steps
+func descriptions(steps []runningStep) []string {
+	described := make([]string, len(steps))
+	for i, step := range steps {
+		described[i] = step.description
+	}
+	return described
+}
+
+// describe replaces the leading #actor placeholder of an activity description
+func describe(actor, activity string) string {
+	if rest, ok := strings.CutPrefix(activity, "#actor "); ok {
+		return actor + " " + rest
+	}
+	return activity
+}
+
+// failureOf describes the failed activity, taking the expected and actual values from a
+// failed assertion
+func failureOf(e events.ActivityFinished, steps []string) *Failure {
+	failure := &Failure{
+		Actor:    e.Actor,
+		Step:     describe(e.Actor, e.Activity),
+		Steps:    steps,
+		Location: e.Location,
+	}
+	if len(failure.Steps) == 0 {
+		failure.Steps = []string{failure.Step}
+	}
+	if e.Err != nil {
+		failure.Error = e.Err.Error()
+	}
+	var assertion *core.AssertionError
+	if errors.As(e.Err, &assertion) {
+		failure.Expected = fmt.Sprintf("%v", assertion.Expected)
+		failure.Actual = fmt.Sprintf("%v", assertion.Actual)
+	}
+	return failure
+}
+
+// Bundle returns the bundle of the failed test, with the notes of the actors. It reads the
+// bodies of the recorded exchanges, so it is called once the actors are done with them.
+func (c *Collector) Bundle(test string, actors []core.Actor, env Environment) Bundle {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	bundle := Bundle{Test: test, Failed: time.Now(), Environment: env}
+	if len(c.failures) > 0 {
+		failure := *c.failures[len(c.failures)-1].failure
+		bundle.Failure = &failure
+	}
+	for _, sent := range c.sent {
+		bundle.Exchanges = append(bundle.Exchanges, exchangeOf(sent))
+	}
+	for _, actor := range actors {
+		snapshot := notes.Snapshot(actor)
+		if len(snapshot) == 0 {
+			continue
+		}
+		if bundle.Notes == nil {
+			bundle.Notes = make(map[string]map[string]string)
+		}
+		texts := make(map[string]string, len(snapshot))
+		for subject, value := range snapshot {
+			texts[subject] = fmt.Sprintf("%v", value)
+		}
+		bundle.Notes[actor.Name()] = texts
+	}
+	return bundle
+}
+
+// exchangeOf describes the exchange of the event
+func exchangeOf(sent events.RequestSent) Exchange {
+	exchange := Exchange{
+		Actor:    sent.Actor,
+		Method:   sent.Method,
+		URL:      sent.URL,
+		Status:   sent.Status,
+		Duration: sent.Duration,
+	}
+	if sent.Err != nil {
+		exchange.Error = sent.Err.Error()
+	}
+	if req := sent.Request; req != nil {
+		exchange.RequestHeaders = headersOf(req.Header)
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				exchange.RequestBody = textOf(body)
+				_ = body.Close()
+			}
+		}
+	}
+	if resp := sent.Response; resp != nil {
+		exchange.ResponseHeaders = headersOf(resp.Header)
+		exchange.ResponseBody = responseBodyOf(resp)
+	}
+	return exchange
+}
+
+// headersOf copies the header, redacting the credentials
+func headersOf(header http.Header) map[string][]string {
+	if len(header) == 0 {
+		return nil
+	}
+	headers := make(map[string][]string, len(header))
+	for _, name := range slices.Sorted(maps.Keys(header)) {
+		values := slices.Clone(header[name])
+		if slices.ContainsFunc(credentialHeaders, func(credential string) bool { return strings.EqualFold(credential, name) }) {
+			values = []string{redacted}
+		}
+		headers[name] = values
+	}
+	return headers
+}
+
+// responseBodyOf reads the response body from the start if the questions of the actor
+// buffered it, or reads it if they didn't read it. A body streamed by a question is gone.
+func responseBodyOf(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+	if seeker, ok := resp.Body.(io.Seeker); ok {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return ""
+		}
+		return textOf(resp.Body)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		return ""
+	}
+	// Put the content back in front of the rest of the body
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(content), resp.Body), resp.Body}
+	return truncated(content)
+}
+
+// textOf reads the body up to the size kept in a bundle
+func textOf(body io.Reader) string {
+	content, err := io.ReadAll(io.LimitReader(body, maxBodySize+1))
+	if err != nil {
+		return ""
+	}
+	return truncated(content)
+}
+
+// truncated renders the body as text, cut to the size kept in a bundle
+func truncated(content []byte) string {
+	cut := len(content) > maxBodySize
+	text := string(content)
+	if cut {
+		text = text[:maxBodySize]
+		// Drop a rune split by the cut
+		for i := 0; i < utf8.UTFMax && !utf8.ValidString(text); i++ {
+			text = text[:len(text)-1]
+		}
+	}
+	if !utf8.ValidString(text) {
+		return fmt.Sprintf("(binary, %d bytes)", len(content))
+	}
+	if cut {
+		text += "… (truncated)"
+	}
+	return text
+}
+
+// Marshal renders the bundle as indented JSON, masking the registered secrets
+func (b Bundle) Marshal() ([]byte, error) {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to export the triage bundle of %s: %w", b.Test, err)
+	}
+	return []byte(secrets.Mask(string(content))), nil
+}
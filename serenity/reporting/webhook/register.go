@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+)
+
+// notifier holds the notifier shared by the tests of the process
+var notifier struct {
+	notifier *Notifier
+	mutex    sync.Mutex
+}
+
+// Settings configures where and how the "webhook" reporter posts the summary of a run. They
+// are read from the webhook entry of the reporting settings:
+//
+//	reporting:
+//	  webhook:
+//	    url: https://hooks.slack.com/services/T000/B000/XXXX
+//	    format: slack
+//	    title: Nightly acceptance
+//	    reportUrl: https://ci.example.com/nightly/serenity/index.html
+//	    onlyOnFailure: true
+//
+// and overridden by SERENITY_WEBHOOK_URL, SERENITY_WEBHOOK_FORMAT, SERENITY_WEBHOOK_TITLE,
+// SERENITY_REPORT_URL and SERENITY_WEBHOOK_ONLY_ON_FAILURE.
+type Settings struct {
+	// URL is the address of the webhook; it is masked in reports
+	URL string `yaml:"url"`
+	// Format is the payload format: "slack", "teams" or "json"
+	Format string `yaml:"format"`
+	// Title names the run in the notification, e.g. "Nightly acceptance"
+	Title string `yaml:"title"`
+	// ReportURL links the notification to the report of the run
+	ReportURL string `yaml:"reportUrl"`
+	// OnlyOnFailure skips the notification of runs without failures
+	OnlyOnFailure bool `yaml:"onlyOnFailure"`
+}
+
+func init() {
+	reporting.RegisterReporter("webhook", func(run config.RunSettings) (reporting.Reporter, error) {
+		settings, err := settingsOf(run)
+		if err != nil {
+			return nil, err
+		}
+		return runNotifier(settings)
+	})
+}
+
+// settingsOf returns the webhook settings of the run, with the environment overrides applied
+func settingsOf(run config.RunSettings) (Settings, error) {
+	var settings Settings
+	if err := run.ReporterSettings("webhook", &settings); err != nil {
+		return Settings{}, err
+	}
+	err := config.ApplyOverrides(map[string]any{
+		"WEBHOOK_URL":             &settings.URL,
+		"WEBHOOK_FORMAT":          &settings.Format,
+		"WEBHOOK_TITLE":           &settings.Title,
+		"REPORT_URL":              &settings.ReportURL,
+		"WEBHOOK_ONLY_ON_FAILURE": &settings.OnlyOnFailure,
+	})
+	return settings, err
+}
+
+// runNotifier returns the notifier posting the summary of the run to the webhook
+func runNotifier(settings Settings) (reporting.Reporter, error) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	if notifier.notifier != nil {
+		return notifier.notifier, nil
+	}
+	if settings.URL == "" {
+		return nil, errors.New("the webhook reporter needs reporting.webhook.url or SERENITY_WEBHOOK_URL")
+	}
+	n := NewNotifier(settings.URL).WithReportURL(settings.ReportURL)
+	if settings.Format != "" {
+		n.WithFormat(Format(settings.Format))
+	}
+	if settings.Title != "" {
+		n.WithTitle(settings.Title)
+	}
+	if settings.OnlyOnFailure {
+		n.OnlyOnFailure()
+	}
+	notifier.notifier = n
+	return n, nil
+}
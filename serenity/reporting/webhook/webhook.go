@@ -0,0 +1,299 @@
+// Package webhook provides a reporter that posts the summary of a run, with its failures,
+// to a Slack, Microsoft Teams or generic webhook when the run finishes, e.g. for nightly
+// acceptance pipelines:
+//
+//	❌ Nightly acceptance: 41 passed, 2 failed, 1 skipped in 12m3s
+//	• TestCheckout/pay_by_card: expected status 201, but got 402
+//	• TestRefunds/partial: order 42 was not refunded
+//	Open the report: https://ci.example.com/nightly/serenity/index.html
+//
+// The notifier is configured with the webhook entry of the reporting settings (see Settings)
+// and selected with the "webhook" reporter, available once the package is imported. Runs finish with serenity.Main, called from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(serenity.Main(m))
+//	}
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// Format is the payload format of a webhook
+type Format string
+
+const (
+	// Slack posts a message to a Slack incoming webhook
+	Slack Format = "slack"
+	// Teams posts a message card to a Microsoft Teams incoming webhook
+	Teams Format = "teams"
+	// JSON posts the Summary as JSON, for other services
+	JSON Format = "json"
+)
+
+const (
+	// maxListedFailures caps the failures listed in a message
+	maxListedFailures = 10
+	// sendTimeout caps the time posting the summary may take
+	sendTimeout = 30 * time.Second
+)
+
+// Failure is a failed test of the run
+type Failure struct {
+	Test  string `json:"test"`
+	Error string `json:"error,omitempty"`
+}
+
+// Summary is the outcome of a run, as posted in the JSON format
+type Summary struct {
+	Title     string        `json:"title"`
+	Passed    int           `json:"passed"`
+	Failed    int           `json:"failed"`
+	Skipped   int           `json:"skipped"`
+	Duration  time.Duration `json:"durationNanos"`
+	ReportURL string        `json:"reportUrl,omitempty"`
+	Failures  []Failure     `json:"failures"`
+}
+
+// Notifier is a reporter collecting the results of the tests of a run and posting their
+// summary to a webhook when the run finishes
+type Notifier struct {
+	url           string
+	format        Format
+	title         string
+	reportURL     string
+	onlyOnFailure bool
+	client        *http.Client
+	output        io.Writer
+	summary       Summary
+	started       time.Time
+	mutex         sync.Mutex
+}
+
+// NewNotifier creates a notifier posting to the webhook URL in the Slack format. The URL
+// is registered as a secret, since it grants access to the channel.
+func NewNotifier(url string) *Notifier {
+	secrets.Register(url)
+	return &Notifier{
+		url:    url,
+		format: Slack,
+		title:  "Serenity run",
+		client: http.DefaultClient,
+		output: os.Stderr,
+	}
+}
+
+// WithFormat sets the payload format and returns the notifier for chaining
+func (n *Notifier) WithFormat(format Format) *Notifier {
+	n.format = format
+	return n
+}
+
+// WithTitle sets the name of the run in the message and returns the notifier for chaining
+func (n *Notifier) WithTitle(title string) *Notifier {
+	n.title = title
+	return n
+}
+
+// WithReportURL links the message to the report of the run and returns the notifier for
+// chaining
+func (n *Notifier) WithReportURL(url string) *Notifier {
+	n.reportURL = url
+	return n
+}
+
+// OnlyOnFailure skips the message for runs without failures and returns the notifier for
+// chaining
+func (n *Notifier) OnlyOnFailure() *Notifier {
+	n.onlyOnFailure = true
+	return n
+}
+
+// WithClient sets the HTTP client posting the message and returns the notifier for chaining
+func (n *Notifier) WithClient(client *http.Client) *Notifier {
+	n.client = client
+	return n
+}
+
+// OnTestStart starts the run clock with the first test
+func (n *Notifier) OnTestStart(testName string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.started.IsZero() {
+		n.started = time.Now()
+	}
+}
+
+// OnTestFinish counts the outcome of the test and records its failure
+func (n *Notifier) OnTestFinish(result reporting.TestResult) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	switch result.Status() {
+	case reporting.StatusFailed:
+		n.summary.Failed++
+		failure := Failure{Test: result.Name()}
+		if err := reporting.FailedStepError(result); err != nil {
+			failure.Error = secrets.Mask(err.Error())
+		}
+		n.summary.Failures = append(n.summary.Failures, failure)
+	case reporting.StatusSkipped:
+		n.summary.Skipped++
+	default:
+		n.summary.Passed++
+	}
+}
+
+// OnStepStart is a no-op
+func (n *Notifier) OnStepStart(stepDescription string) {}
+
+// OnStepFinish is a no-op
+func (n *Notifier) OnStepFinish(stepResult reporting.TestResult) {}
+
+// SetOutput sets where failures to post the message are reported, os.Stderr by default
+func (n *Notifier) SetOutput(w io.Writer) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.output = w
+}
+
+// OnRunFinish posts the summary, reporting a failure to post it to the output
+func (n *Notifier) OnRunFinish() {
+	if err := n.Send(context.Background()); err != nil {
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		_, _ = fmt.Fprintf(n.output, "⚠️ %s\n", secrets.Mask(err.Error()))
+	}
+}
+
+// Summary returns the summary of the tests finished so far
+func (n *Notifier) Summary() Summary {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	summary := n.summary
+	summary.Title = n.title
+	summary.ReportURL = n.reportURL
+	summary.Failures = append([]Failure{}, n.summary.Failures...)
+	if !n.started.IsZero() {
+		summary.Duration = time.Since(n.started)
+	}
+	return summary
+}
+
+// Send posts the summary of the tests finished so far to the webhook
+func (n *Notifier) Send(ctx context.Context) error {
+	summary := n.Summary()
+	if n.onlyOnFailure && summary.Failed == 0 {
+		return nil
+	}
+
+	payload, err := n.payload(summary)
+	if err != nil {
+		return fmt.Errorf("failed to encode the run summary: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post the run summary: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the run summary: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to post the run summary: the webhook answered %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// payload encodes the summary in the format of the webhook
+func (n *Notifier) payload(summary Summary) ([]byte, error) {
+	switch n.format {
+	case Slack:
+		return json.Marshal(map[string]string{"text": message(summary, slackLink)})
+	case Teams:
+		return json.Marshal(map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  headline(summary),
+			"text":     strings.ReplaceAll(message(summary, markdownLink), "\n", "\n\n"),
+		})
+	case JSON:
+		return json.Marshal(summary)
+	default:
+		return nil, fmt.Errorf("unknown webhook format '%s' (available: slack, teams, json)", n.format)
+	}
+}
+
+// headline returns the outcome of the run in a sentence
+func headline(summary Summary) string {
+	emoji := "✅"
+	if summary.Failed > 0 {
+		emoji = "❌"
+	}
+	return fmt.Sprintf("%s %s: %d passed, %d failed, %d skipped in %s",
+		emoji, summary.Title, summary.Passed, summary.Failed, summary.Skipped, reporting.FormatDuration(summary.Duration))
+}
+
+// message renders the summary as text, listing the failures with links to the report
+func message(summary Summary, link func(url, text string) string) string {
+	lines := []string{headline(summary)}
+	for i, failure := range summary.Failures {
+		if i == maxListedFailures {
+			lines = append(lines, fmt.Sprintf("• and %d more", len(summary.Failures)-maxListedFailures))
+			break
+		}
+		test := failure.Test
+		if summary.ReportURL != "" {
+			test = link(summary.ReportURL, failure.Test)
+		}
+		line := "• " + test
+		if failure.Error != "" {
+			line += ": " + firstLine(failure.Error)
+		}
+		lines = append(lines, line)
+	}
+	if summary.ReportURL != "" {
+		lines = append(lines, link(summary.ReportURL, "Open the report"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// slackLink renders a link in Slack's mrkdwn
+func slackLink(url, text string) string {
+	return fmt.Sprintf("<%s|%s>", url, text)
+}
+
+// markdownLink renders a link in Markdown
+func markdownLink(url, text string) string {
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+// firstLine returns the first line of a possibly multi-line error message
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
@@ -0,0 +1,192 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envProvider reads secrets from environment variables
+type envProvider struct {
+	prefix string
+}
+
+// FromEnv creates a provider that reads secrets from environment variables.
+// The variable name is the prefix followed by the secret name in upper snake case:
+// with prefix "APP_", secret "db-password" is read from APP_DB_PASSWORD.
+func FromEnv(prefix string) Provider {
+	return &envProvider{prefix: prefix}
+}
+
+// Secret returns the value of the environment variable for the named secret
+func (p *envProvider) Secret(ctx context.Context, name string) (string, error) {
+	variable := p.variable(name)
+	value, ok := os.LookupEnv(variable)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", variable)
+	}
+	return value, nil
+}
+
+// Description returns the provider description
+func (p *envProvider) Description() string {
+	if p.prefix == "" {
+		return "environment"
+	}
+	return fmt.Sprintf("environment (prefix %s)", p.prefix)
+}
+
+func (p *envProvider) variable(name string) string {
+	replacer := strings.NewReplacer("-", "_", ".", "_", "/", "_", " ", "_")
+	return p.prefix + strings.ToUpper(replacer.Replace(name))
+}
+
+// fileProvider reads secrets from a directory or a key/value file
+type fileProvider struct {
+	path string
+}
+
+// FromFile creates a provider that reads secrets from the file system.
+//
+// When path is a directory, every secret is stored in its own file named after the
+// secret (the layout used by Docker and Kubernetes secret mounts); surrounding
+// whitespace is trimmed. Otherwise path must be a JSON or YAML file with a flat
+// name-to-value mapping.
+func FromFile(path string) Provider {
+	return &fileProvider{path: path}
+}
+
+// Secret returns the value of the named secret from the file system
+func (p *fileProvider) Secret(ctx context.Context, name string) (string, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to access secrets file: %w", err)
+	}
+
+	if info.IsDir() {
+		if filepath.Base(name) != name {
+			return "", fmt.Errorf("invalid secret name '%s'", name)
+		}
+
+		data, err := os.ReadFile(filepath.Join(p.path, name))
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	values := make(map[string]string)
+	if strings.EqualFold(filepath.Ext(p.path), ".json") {
+		err = json.Unmarshal(data, &values)
+	} else {
+		err = yaml.Unmarshal(data, &values)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+
+	value, ok := values[name]
+	if !ok {
+		return "", fmt.Errorf("secret is not defined in %s", p.path)
+	}
+	return value, nil
+}
+
+// Description returns the provider description
+func (p *fileProvider) Description() string {
+	return fmt.Sprintf("file %s", p.path)
+}
+
+// vaultProvider reads secrets from a HashiCorp Vault key/value engine
+type vaultProvider struct {
+	address string
+	token   string
+	path    string
+	client  *http.Client
+}
+
+// FromVault creates a provider that reads the fields of a single Vault secret over
+// the HTTP API. Both KV version 1 ("secret/orders") and version 2 ("secret/data/orders")
+// paths are supported; each secret name refers to a field of the stored secret.
+func FromVault(address, token, path string) Provider {
+	return FromVaultUsing(http.DefaultClient, address, token, path)
+}
+
+// FromVaultUsing is like FromVault but sends requests with the given HTTP client
+func FromVaultUsing(client *http.Client, address, token, path string) Provider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &vaultProvider{
+		address: strings.TrimRight(address, "/"),
+		token:   token,
+		path:    strings.Trim(path, "/"),
+		client:  client,
+	}
+}
+
+// Secret returns the named field of the Vault secret
+func (p *vaultProvider) Secret(ctx context.Context, name string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+"/v1/"+p.path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded with status %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+
+	fields := payload.Data
+	// KV version 2 nests the secret fields in data.data
+	if nested, ok := fields["data"].(map[string]interface{}); ok {
+		if _, versioned := fields["metadata"]; versioned {
+			fields = nested
+		}
+	}
+
+	value, ok := fields[name]
+	if !ok {
+		return "", fmt.Errorf("field is not defined in Vault secret %s", p.path)
+	}
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// Description returns the provider description
+func (p *vaultProvider) Description() string {
+	return fmt.Sprintf("Vault %s", p.path)
+}
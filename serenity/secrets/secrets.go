@@ -0,0 +1,128 @@
+// Package secrets provides access to sensitive test data such as passwords and tokens,
+// and masks every known secret value in reporter output and error messages.
+//
+// Secrets are read through a Provider (environment variables, files, or HashiCorp Vault).
+// Every value retrieved with Get or Secret.Value is registered for masking, so it is
+// replaced with "******" wherever Serenity-Go prints text:
+//
+//	vault := secrets.FromVault("https://vault.example.com", os.Getenv("VAULT_TOKEN"), "secret/data/orders")
+//
+//	actor.AttemptsTo(
+//		api.SendGetRequest("/orders").
+//			WithBearerToken(secrets.Ref(vault, "apiToken")),
+//	)
+//
+// Values obtained elsewhere can be registered manually with Register.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Masked is the replacement text used for secret values in output
+const Masked = "******"
+
+// Provider retrieves secret values by name
+type Provider interface {
+	// Secret returns the value of the named secret
+	Secret(ctx context.Context, name string) (string, error)
+	// Description returns a human-readable description of the provider
+	Description() string
+}
+
+// Get retrieves a secret from the provider and registers its value for masking
+func Get(ctx context.Context, provider Provider, name string) (string, error) {
+	if provider == nil {
+		return "", fmt.Errorf("secret '%s': provider is nil", name)
+	}
+
+	value, err := provider.Secret(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret '%s' from %s: %w", name, provider.Description(), err)
+	}
+
+	Register(value)
+	return value, nil
+}
+
+// Secret is a reference to a secret that is resolved when it is needed,
+// so that activities can be declared before the secret is read
+type Secret struct {
+	provider Provider
+	name     string
+}
+
+// Ref creates a reference to the named secret of the provider
+func Ref(provider Provider, name string) Secret {
+	return Secret{provider: provider, name: name}
+}
+
+// Name returns the secret name
+func (s Secret) Name() string {
+	return s.name
+}
+
+// Value resolves the secret and registers its value for masking
+func (s Secret) Value(ctx context.Context) (string, error) {
+	return Get(ctx, s.provider, s.name)
+}
+
+// String never reveals the secret value, so references are safe to print
+func (s Secret) String() string {
+	return Masked
+}
+
+// registry holds all known secret values
+var registry = struct {
+	mutex  sync.RWMutex
+	values map[string]struct{}
+	sorted []string
+}{values: make(map[string]struct{})}
+
+// Register adds values that must be masked in output. Empty values are ignored.
+func Register(values ...string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	changed := false
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if _, exists := registry.values[value]; exists {
+			continue
+		}
+		registry.values[value] = struct{}{}
+		changed = true
+	}
+
+	if !changed {
+		return
+	}
+
+	// Longest values first so that a secret containing another one is masked as a whole
+	registry.sorted = registry.sorted[:0]
+	for value := range registry.values {
+		registry.sorted = append(registry.sorted, value)
+	}
+	sort.Slice(registry.sorted, func(i, j int) bool {
+		return len(registry.sorted[i]) > len(registry.sorted[j])
+	})
+}
+
+// Mask replaces every registered secret value in text with Masked
+func Mask(text string) string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	for _, value := range registry.sorted {
+		if strings.Contains(text, value) {
+			text = strings.ReplaceAll(text, value, Masked)
+		}
+	}
+	return text
+}
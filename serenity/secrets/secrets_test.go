@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMask(t *testing.T) {
+	Register("", "mask-test-token", "mask-test-token-extended")
+
+	require.Equal(t, "token=******", Mask("token=mask-test-token"))
+	require.Equal(t, "token=******", Mask("token=mask-test-token-extended"))
+	require.Equal(t, "nothing to hide", Mask("nothing to hide"))
+}
+
+func TestGetRegistersValueForMasking(t *testing.T) {
+	t.Setenv("SECRETS_TEST_API_TOKEN", "env-secret-value")
+
+	value, err := Get(context.Background(), FromEnv("SECRETS_TEST_"), "api-token")
+	require.NoError(t, err)
+	require.Equal(t, "env-secret-value", value)
+	require.Equal(t, "Bearer ******", Mask("Bearer env-secret-value"))
+
+	_, err = Get(context.Background(), FromEnv("SECRETS_TEST_"), "missing")
+	require.EqualError(t, err, "failed to read secret 'missing' from environment (prefix SECRETS_TEST_): "+
+		"environment variable SECRETS_TEST_MISSING is not set")
+}
+
+func TestRef(t *testing.T) {
+	t.Setenv("SECRETS_TEST_PASSWORD", "ref-secret-value")
+
+	secret := Ref(FromEnv("SECRETS_TEST_"), "password")
+	require.Equal(t, "password", secret.Name())
+	require.Equal(t, Masked, secret.String())
+
+	value, err := secret.Value(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "ref-secret-value", value)
+}
+
+func TestFromFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db-password"), []byte("dir-secret\n"), 0600))
+
+	value, err := FromFile(dir).Secret(context.Background(), "db-password")
+	require.NoError(t, err)
+	require.Equal(t, "dir-secret", value)
+
+	_, err = FromFile(dir).Secret(context.Background(), "../db-password")
+	require.EqualError(t, err, "invalid secret name '../db-password'")
+
+	yamlFile := filepath.Join(dir, "secrets.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte("apiToken: yaml-secret\n"), 0600))
+
+	value, err = FromFile(yamlFile).Secret(context.Background(), "apiToken")
+	require.NoError(t, err)
+	require.Equal(t, "yaml-secret", value)
+
+	jsonFile := filepath.Join(dir, "secrets.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"apiToken": "json-secret"}`), 0600))
+
+	value, err = FromFile(jsonFile).Secret(context.Background(), "apiToken")
+	require.NoError(t, err)
+	require.Equal(t, "json-secret", value)
+
+	_, err = FromFile(jsonFile).Secret(context.Background(), "other")
+	require.ErrorContains(t, err, "secret is not defined in")
+}
+
+func TestFromVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/orders":
+			_, _ = w.Write([]byte(`{"data": {"data": {"apiToken": "kv2-secret"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/orders":
+			_, _ = w.Write([]byte(`{"data": {"apiToken": "kv1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	value, err := FromVault(server.URL, "vault-token", "secret/data/orders").Secret(context.Background(), "apiToken")
+	require.NoError(t, err)
+	require.Equal(t, "kv2-secret", value)
+
+	value, err = FromVault(server.URL+"/", "vault-token", "/kv/orders").Secret(context.Background(), "apiToken")
+	require.NoError(t, err)
+	require.Equal(t, "kv1-secret", value)
+
+	_, err = FromVault(server.URL, "wrong-token", "kv/orders").Secret(context.Background(), "apiToken")
+	require.EqualError(t, err, "vault responded with status 403")
+
+	_, err = FromVault(server.URL, "vault-token", "kv/orders").Secret(context.Background(), "password")
+	require.EqualError(t, err, "field is not defined in Vault secret kv/orders")
+}
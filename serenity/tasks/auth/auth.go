@@ -0,0 +1,123 @@
+// Package auth provides reusable tasks that log actors into the system under test.
+//
+// Each task obtains credentials, records them in the actor's notes and configures the
+// actor's API ability to send them with every subsequent request:
+//
+//	actor := test.ActorCalled("Alice").WhoCan(api.CallAnApiAt("https://shop.example.com"))
+//
+//	actor.AttemptsTo(
+//		auth.LogInWithPasswordGrant(auth.PasswordGrant{
+//			TokenURL: "https://login.example.com/oauth/token",
+//			ClientID: "shop-tests",
+//			Username: "alice",
+//			Password: secrets.Ref(vault, "alicePassword"),
+//		}),
+//		api.SendGetRequest("/orders"), // sent with "Authorization: Bearer <token>"
+//		ensure.That(api.LastResponseStatus{}, expectations.Equals(200)),
+//	)
+//
+// Obtained tokens and cookies are registered with the secrets package, so they are
+// masked in reports.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// Subjects of the notes recorded by the tasks
+const (
+	// AccessTokenNote is the subject of the OAuth2 access token
+	AccessTokenNote = "access token"
+	// APIKeyNote is the subject of the API key
+	APIKeyNote = "API key"
+	// SessionCookieNote is the subject of the Cookie header carrying the session
+	SessionCookieNote = "session cookie"
+	// RoleNote is the subject of the name of the role the actor switched to last
+	RoleNote = "role"
+)
+
+// loginClient sends the requests of the tasks. It does not follow redirects, so that
+// cookies set by a login response that redirects are not lost.
+var loginClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// postForm posts the form to the target, resolved against the base URL of the actor's
+// API ability when it is relative. The request bypasses the ability, so the login does
+// not replace its last response.
+func postForm(ctx context.Context, actor core.Actor, target string, form url.Values) (*http.Response, error) {
+	target, err := resolve(actor, target)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := loginClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", target, err)
+	}
+	return resp, nil
+}
+
+// resolve resolves a relative target against the base URL of the actor's API ability
+func resolve(actor core.Actor, target string) (string, error) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %s: %w", target, err)
+	}
+	if parsed.IsAbs() {
+		return target, nil
+	}
+
+	ability, err := core.AbilityOf[api.CallAnAPI](actor)
+	if err != nil || ability.GetBaseURL() == "" {
+		return "", fmt.Errorf("cannot resolve relative URL %s without an API ability with a base URL", target)
+	}
+	base, err := url.Parse(ability.GetBaseURL())
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	return base.ResolveReference(parsed).String(), nil
+}
+
+// remember registers the credential for masking, records it in the actor's notes and
+// sets the header to headerValue on the actor's API ability, if it supports default headers
+func remember(actor core.Actor, subject, value, header, headerValue string) {
+	secrets.Register(value, headerValue)
+	notes.Of(actor).Record(subject, value)
+
+	if headers, err := core.AbilityOf[api.DefaultHeaders](actor); err == nil {
+		headers.SetDefaultHeader(header, headerValue)
+	}
+}
+
+// sessionCookie returns the Cookie header for the cookies the response sets
+func sessionCookie(resp *http.Response) (string, error) {
+	cookies := resp.Cookies()
+	if len(cookies) == 0 {
+		return "", fmt.Errorf("login did not set a session cookie (status %d)", resp.StatusCode)
+	}
+
+	pairs := make([]string, len(cookies))
+	for i, cookie := range cookies {
+		pairs[i] = cookie.Name + "=" + cookie.Value
+	}
+	return strings.Join(pairs, "; "), nil
+}
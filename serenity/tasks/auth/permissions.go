@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+// Endpoint is a request whose authorization a PermissionMatrix checks
+type Endpoint struct {
+	// Name identifies the endpoint in scenario names; it defaults to the description of
+	// the request, e.g. "sends DELETE request to /orders/42"
+	Name string
+	// Request calls the endpoint, e.g. api.SendDeleteRequest("/orders/42")
+	Request core.Activity
+}
+
+// Permissions lists the statuses a role expects from the endpoints of a PermissionMatrix,
+// in the order of the endpoints
+type Permissions struct {
+	Role     Role
+	Statuses []int
+}
+
+// PermissionMatrix expands roles, endpoints and expected statuses into one scenario per
+// role and endpoint, so each combination is reported on its own:
+//
+//	auth.PermissionMatrix{
+//		Endpoints: []auth.Endpoint{
+//			{Name: "list orders", Request: api.SendGetRequest("/orders")},
+//			{Name: "delete an order", Request: api.SendDeleteRequest("/orders/42")},
+//		},
+//		Permissions: []auth.Permissions{
+//			{Role: admin, Statuses: []int{200, 204}},
+//			{Role: auditor, Statuses: []int{200, 403}},
+//			{Role: auth.Role{Name: "anonymous"}, Statuses: []int{401, 401}},
+//		},
+//	}.Run(t, func(test serenity.SerenityTest) core.Actor {
+//		return test.ActorCalled("Tester").WhoCan(api.CallAnApiAt(shopURL))
+//	})
+type PermissionMatrix struct {
+	Endpoints   []Endpoint
+	Permissions []Permissions
+}
+
+// Run runs a subtest per role and endpoint, named e.g. "auditor delete an order expects 403".
+// Each subtest is its own SerenityTest: the actor created by newActor switches to the role,
+// calls the endpoint and expects the status. Each role logs in once per run; later
+// scenarios of the role reuse its credentials.
+func (pm PermissionMatrix) Run(t *testing.T, newActor func(test serenity.SerenityTest) core.Actor) {
+	t.Helper()
+
+	roles := make([]Role, len(pm.Permissions))
+	for i, permissions := range pm.Permissions {
+		if len(permissions.Statuses) != len(pm.Endpoints) {
+			t.Fatalf("the %s role expects %d statuses for %d endpoints", permissions.Role.Name, len(permissions.Statuses), len(pm.Endpoints))
+		}
+		roles[i] = permissions.Role
+	}
+	switching := SwitchBetween(roles...)
+
+	for _, permissions := range pm.Permissions {
+		for i, endpoint := range pm.Endpoints {
+			status := permissions.Statuses[i]
+			name := fmt.Sprintf("%s %s expects %d", permissions.Role.Name, endpoint.name(), status)
+
+			t.Run(name, func(t *testing.T) {
+				test := serenity.NewSerenityTest(t)
+				defer test.Shutdown()
+
+				newActor(test).WhoCan(switching).AttemptsTo(
+					SwitchToRole(permissions.Role.Name),
+					endpoint.Request,
+					ensure.That(api.LastResponseStatus{}, expectations.Equals(status)),
+				)
+			})
+		}
+	}
+}
+
+// name returns the name of the endpoint, or the description of its request
+func (e Endpoint) name() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return strings.TrimPrefix(e.Request.Description(), "#actor ")
+}
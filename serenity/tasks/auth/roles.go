@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/api"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+// RoleAttribute is the actor attribute holding the name of the role the actor acts in, so
+// reusable tasks can read it with core.AttributeOf. Actors that don't implement
+// core.TraitBearer switch roles without it.
+const RoleAttribute = "role"
+
+// Role is a set of credentials an actor can switch to mid-scenario
+type Role struct {
+	// Name identifies the role, e.g. "auditor"
+	Name string
+	// LogIn obtains the credentials of the role, e.g. with LogInWithPasswordGrant or
+	// UseAPIKey. It is performed the first time the actor switches to the role; a role
+	// without LogIn sends no credentials, e.g. to test anonymous access.
+	LogIn core.Activity
+}
+
+// SwitchRoles enables an actor to switch between roles, such as in permission-matrix
+// tests. It remembers the credentials each role's login set up on the actor's API
+// ability, so switching back to a role does not log in again.
+type SwitchRoles struct {
+	roles       map[string]Role
+	credentials map[string]http.Header
+	current     string
+	mutex       sync.Mutex
+}
+
+// SwitchBetween creates the ability to switch between the roles:
+//
+//	actor := test.ActorCalled("Tester").WhoCan(
+//		api.CallAnApiAt("https://shop.example.com"),
+//		auth.SwitchBetween(
+//			auth.Role{Name: "admin", LogIn: auth.LogInWithPasswordGrant(adminGrant)},
+//			auth.Role{Name: "auditor", LogIn: auth.UseAPIKey("X-API-Key", auditorKey)},
+//		),
+//	)
+func SwitchBetween(roles ...Role) *SwitchRoles {
+	switching := &SwitchRoles{
+		roles:       make(map[string]Role, len(roles)),
+		credentials: make(map[string]http.Header),
+	}
+	for _, role := range roles {
+		switching.roles[role.Name] = role
+	}
+	return switching
+}
+
+// CurrentRole returns the name of the role the actor acts in, or "" before the first switch
+func (sr *SwitchRoles) CurrentRole() string {
+	sr.mutex.Lock()
+	defer sr.mutex.Unlock()
+
+	return sr.current
+}
+
+// Inspect describes the current role
+func (sr *SwitchRoles) Inspect() string {
+	if current := sr.CurrentRole(); current != "" {
+		return fmt.Sprintf("acting as %s", current)
+	}
+	return "acting in no role yet"
+}
+
+// SwitchToRole creates an interaction that replaces the credentials the actor's API ability
+// sends with those of the role, logging in the first time. The role is noted under RoleNote
+// and set as the actor's RoleAttribute.
+func SwitchToRole(name string) core.Activity {
+	return core.Do(fmt.Sprintf("#actor switches to the %s role", name), func(actor core.Actor, ctx context.Context) error {
+		switching, err := core.AbilityOf[*SwitchRoles](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have the ability to switch roles: %w", err)
+		}
+		headers, err := core.AbilityOf[api.DefaultHeaders](actor)
+		if err != nil {
+			return fmt.Errorf("actor does not have an API ability with default headers: %w", err)
+		}
+
+		if err := switching.switchTo(ctx, actor, headers, name); err != nil {
+			return err
+		}
+
+		notes.Of(actor).Record(RoleNote, name)
+		core.With(actor, RoleAttribute, name)
+		return nil
+	})
+}
+
+// switchTo removes the credentials of the current role and sets those of the named one
+func (sr *SwitchRoles) switchTo(ctx context.Context, actor core.Actor, headers api.DefaultHeaders, name string) error {
+	sr.mutex.Lock()
+	defer sr.mutex.Unlock()
+
+	role, ok := sr.roles[name]
+	if !ok {
+		return fmt.Errorf("unknown role %s, the actor can switch to %s", name, strings.Join(sr.names(), ", "))
+	}
+
+	for header := range sr.credentials[sr.current] {
+		headers.RemoveDefaultHeader(header)
+	}
+	sr.current = ""
+
+	if credentials, ok := sr.credentials[name]; ok {
+		for header, values := range credentials {
+			headers.SetDefaultHeader(header, values[0])
+		}
+		sr.current = name
+		return nil
+	}
+
+	before := headers.DefaultHeaderValues()
+	if role.LogIn != nil {
+		if err := role.LogIn.PerformAs(actor, ctx); err != nil {
+			return fmt.Errorf("failed to log in as %s: %w", name, err)
+		}
+	}
+
+	// The credentials of the role are the headers its login set or changed
+	credentials := make(http.Header)
+	for header, values := range headers.DefaultHeaderValues() {
+		if len(values) > 0 && !slices.Equal(values, before[header]) {
+			credentials[header] = values
+		}
+	}
+	sr.credentials[name] = credentials
+	sr.current = name
+	return nil
+}
+
+// names returns the names of the roles, sorted. The caller must hold the mutex.
+func (sr *SwitchRoles) names() []string {
+	names := make([]string, 0, len(sr.roles))
+	for name := range sr.roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CurrentRole is a question about the name of the role the actor switched to last
+type CurrentRole struct{}
+
+// AnsweredBy returns the name of the actor's current role
+func (CurrentRole) AnsweredBy(actor core.Actor, ctx context.Context) (string, error) {
+	switching, err := core.AbilityOf[*SwitchRoles](actor)
+	if err != nil {
+		return "", fmt.Errorf("actor does not have the ability to switch roles: %w", err)
+	}
+	return switching.CurrentRole(), nil
+}
+
+// Description returns the question description
+func (CurrentRole) Description() string {
+	return "the current role"
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/secrets"
+)
+
+// PasswordGrant describes a login with the OAuth2 resource owner password credentials grant
+type PasswordGrant struct {
+	// TokenURL is the token endpoint of the authorization server
+	TokenURL string
+	// ClientID identifies the client
+	ClientID string
+	// ClientSecret authenticates confidential clients; leave it nil for public clients
+	ClientSecret *secrets.Secret
+	// Username and Password are the credentials of the user
+	Username string
+	Password secrets.Secret
+	// Scopes are the requested scopes, if any
+	Scopes []string
+}
+
+// LogInWithPasswordGrant creates a task that requests an access token with the OAuth2
+// password grant, notes it under AccessTokenNote and sends it as a bearer token with
+// subsequent API requests
+func LogInWithPasswordGrant(grant PasswordGrant) core.Activity {
+	return core.Do(fmt.Sprintf("#actor logs in as %s with the OAuth2 password grant", grant.Username), func(actor core.Actor, ctx context.Context) error {
+		password, err := grant.Password.Value(ctx)
+		if err != nil {
+			return err
+		}
+
+		form := url.Values{
+			"grant_type": {"password"},
+			"client_id":  {grant.ClientID},
+			"username":   {grant.Username},
+			"password":   {password},
+		}
+		if grant.ClientSecret != nil {
+			clientSecret, err := grant.ClientSecret.Value(ctx)
+			if err != nil {
+				return err
+			}
+			form.Set("client_secret", clientSecret)
+		}
+		if len(grant.Scopes) > 0 {
+			form.Set("scope", strings.Join(grant.Scopes, " "))
+		}
+
+		resp, err := postForm(ctx, actor, grant.TokenURL, form)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = resp.Body.Close() // Ignore cleanup error
+		}()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read token response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, secrets.Mask(string(body)))
+		}
+
+		var token struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+		}
+		if err := json.Unmarshal(body, &token); err != nil {
+			return fmt.Errorf("failed to decode token response: %w", err)
+		}
+		if token.AccessToken == "" {
+			return fmt.Errorf("token response has no access_token")
+		}
+
+		scheme := "Bearer"
+		if token.TokenType != "" && !strings.EqualFold(token.TokenType, scheme) {
+			scheme = token.TokenType
+		}
+		remember(actor, AccessTokenNote, token.AccessToken, "Authorization", scheme+" "+token.AccessToken)
+		return nil
+	})
+}
+
+// UseAPIKey creates a task that notes the API key under APIKeyNote and sends it in the
+// header, such as X-API-Key, with subsequent API requests
+func UseAPIKey(header string, key secrets.Secret) core.Activity {
+	return core.Do(fmt.Sprintf("#actor uses an API key in the %s header", header), func(actor core.Actor, ctx context.Context) error {
+		value, err := key.Value(ctx)
+		if err != nil {
+			return err
+		}
+
+		remember(actor, APIKeyNote, value, header, value)
+		return nil
+	})
+}
+
+// FormLogin describes a login through an HTML form that starts a cookie-based session
+type FormLogin struct {
+	// URL receives the form, e.g. "/login"; relative URLs are resolved against the base
+	// URL of the actor's API ability
+	URL string
+	// UsernameField and PasswordField name the form fields, "username" and "password"
+	// by default
+	UsernameField string
+	PasswordField string
+	// Username and Password are the credentials of the user
+	Username string
+	Password secrets.Secret
+}
+
+// LogInWithSessionCookie creates a task that submits the login form, notes the Cookie
+// header for the cookies it sets under SessionCookieNote and sends it with subsequent
+// API requests
+func LogInWithSessionCookie(login FormLogin) core.Activity {
+	return core.Do(fmt.Sprintf("#actor logs in as %s with a session cookie", login.Username), func(actor core.Actor, ctx context.Context) error {
+		password, err := login.Password.Value(ctx)
+		if err != nil {
+			return err
+		}
+
+		usernameField, passwordField := login.UsernameField, login.PasswordField
+		if usernameField == "" {
+			usernameField = "username"
+		}
+		if passwordField == "" {
+			passwordField = "password"
+		}
+
+		return startSession(ctx, actor, login.URL, url.Values{
+			usernameField: {login.Username},
+			passwordField: {password},
+		})
+	})
+}
+
+// SAMLStubLogin describes a login through a SAML service provider whose test
+// configuration accepts unsigned assertions, standing in for the real identity provider
+type SAMLStubLogin struct {
+	// ACSURL is the assertion consumer service of the service provider
+	ACSURL string
+	// NameID identifies the user in the assertion
+	NameID string
+	// Issuer is the entity ID of the stubbed identity provider, "serenity-saml-stub" by default
+	Issuer string
+	// Attributes are added to the assertion's attribute statement
+	Attributes map[string]string
+	// RelayState is passed back to the service provider unchanged, if set
+	RelayState string
+}
+
+// LogInWithSAMLStub creates a task that posts an unsigned SAML response for the user to
+// the assertion consumer service, notes the Cookie header for the session it starts
+// under SessionCookieNote and sends it with subsequent API requests
+func LogInWithSAMLStub(login SAMLStubLogin) core.Activity {
+	return core.Do(fmt.Sprintf("#actor logs in as %s through the SAML stub", login.NameID), func(actor core.Actor, ctx context.Context) error {
+		form := url.Values{"SAMLResponse": {base64.StdEncoding.EncodeToString([]byte(samlResponse(login, time.Now().UTC())))}}
+		if login.RelayState != "" {
+			form.Set("RelayState", login.RelayState)
+		}
+		return startSession(ctx, actor, login.ACSURL, form)
+	})
+}
+
+// startSession posts the login form and remembers the session cookies it sets
+func startSession(ctx context.Context, actor core.Actor, target string, form url.Values) error {
+	resp, err := postForm(ctx, actor, target, form)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close() // Ignore cleanup error
+	}()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("login failed with status %d", resp.StatusCode)
+	}
+
+	cookie, err := sessionCookie(resp)
+	if err != nil {
+		return err
+	}
+	remember(actor, SessionCookieNote, cookie, "Cookie", cookie)
+	return nil
+}
+
+// samlResponse renders a minimal unsigned SAML 2.0 response asserting the user's identity
+func samlResponse(login SAMLStubLogin, now time.Time) string {
+	issuer := login.Issuer
+	if issuer == "" {
+		issuer = "serenity-saml-stub"
+	}
+	issued := now.Format(time.RFC3339)
+	expires := now.Add(5 * time.Minute).Format(time.RFC3339)
+	id := fmt.Sprintf("_serenity%d", now.UnixNano())
+
+	names := make([]string, 0, len(login.Attributes))
+	for name := range login.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var attributes strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&attributes, `<saml:Attribute Name="%s"><saml:AttributeValue>%s</saml:AttributeValue></saml:Attribute>`,
+			html.EscapeString(name), html.EscapeString(login.Attributes[name]))
+	}
+
+	return fmt.Sprintf(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="%[1]s" Version="2.0" IssueInstant="%[2]s" Destination="%[3]s">`+
+		`<saml:Issuer>%[4]s</saml:Issuer>`+
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>`+
+		`<saml:Assertion ID="%[1]s-assertion" Version="2.0" IssueInstant="%[2]s">`+
+		`<saml:Issuer>%[4]s</saml:Issuer>`+
+		`<saml:Subject><saml:NameID>%[5]s</saml:NameID>`+
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer"><saml:SubjectConfirmationData NotOnOrAfter="%[6]s" Recipient="%[3]s"/></saml:SubjectConfirmation></saml:Subject>`+
+		`<saml:Conditions NotBefore="%[2]s" NotOnOrAfter="%[6]s"/>`+
+		`<saml:AuthnStatement AuthnInstant="%[2]s"><saml:AuthnContext><saml:AuthnContextClassRef>urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport</saml:AuthnContextClassRef></saml:AuthnContext></saml:AuthnStatement>`+
+		`<saml:AttributeStatement>%[7]s</saml:AttributeStatement>`+
+		`</saml:Assertion></samlp:Response>`,
+		id, issued, html.EscapeString(login.ACSURL), html.EscapeString(issuer), html.EscapeString(login.NameID), expires, attributes.String())
+}
@@ -0,0 +1,109 @@
+// Package consistency provides tasks that read the same fact from two sources, such as
+// an API and the database behind it, and verify that the sources agree:
+//
+//	actor.AttemptsTo(
+//		api.SendGetRequest("/orders/42"),
+//		consistency.VerifyApiAndDatabaseAgree(
+//			api.NewResponseBodyAsJSON[Order](),
+//			db.QueryOneAs[Order]("SELECT id, state FROM orders WHERE id = ?", 42),
+//			consistency.Equal[Order],
+//		),
+//	)
+//
+// Reading each source and comparing the answers are reported as nested steps, so a
+// report shows which source answered what when they disagree.
+package consistency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+)
+
+// Source is a named source of a fact, such as "the API", and the question reading it
+type Source[T any] struct {
+	// Name identifies the source in reports, e.g. "the API" or "the database"
+	Name string
+	// Question reads the fact from the source
+	Question core.Question[T]
+}
+
+// VerifyApiAndDatabaseAgree creates a task that answers the API question and the database
+// question and checks the answers with the comparator, which returns why they disagree
+func VerifyApiAndDatabaseAgree[A, D any](apiQuestion core.Question[A], dbQuestion core.Question[D], comparator func(api A, db D) error) core.Activity {
+	return VerifySourcesAgree(
+		Source[A]{Name: "the API", Question: apiQuestion},
+		Source[D]{Name: "the database", Question: dbQuestion},
+		comparator,
+	)
+}
+
+// VerifySourcesAgree creates a task that answers the question of each source, in order,
+// and checks the answers with the comparator, which returns why they disagree
+func VerifySourcesAgree[A, B any](first Source[A], second Source[B], comparator func(first A, second B) error) core.Activity {
+	description := fmt.Sprintf("#actor verifies that %s and %s agree", first.Name, second.Name)
+	return core.Do(description, func(actor core.Actor, ctx context.Context) error {
+		firstAnswer, err := read(actor, ctx, first)
+		if err != nil {
+			return err
+		}
+		secondAnswer, err := read(actor, ctx, second)
+		if err != nil {
+			return err
+		}
+
+		return step(actor, fmt.Sprintf("#actor compares the answers of %s and %s", first.Name, second.Name), func() error {
+			if err := comparator(firstAnswer, secondAnswer); err != nil {
+				return fmt.Errorf("%s and %s disagree: %w", first.Name, second.Name, err)
+			}
+			return nil
+		})
+	})
+}
+
+// Equal is a comparator for answers that must be equal
+func Equal[T comparable](first, second T) error {
+	if first != second {
+		return core.NewAssertionError(first, second, "expected %v, but got %v", first, second)
+	}
+	return nil
+}
+
+// read answers the question of the source in a nested step and records the answer
+func read[T any](actor core.Actor, ctx context.Context, source Source[T]) (T, error) {
+	var answer T
+	err := step(actor, fmt.Sprintf("#actor reads %s from %s", source.Question.Description(), source.Name), func() error {
+		var err error
+		answer, err = source.Question.AnsweredBy(actor, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from %s: %w", source.Question.Description(), source.Name, err)
+		}
+		core.RecordAnswer(actor, fmt.Sprintf("%s according to %s", source.Question.Description(), source.Name), answer)
+		return nil
+	})
+	return answer, err
+}
+
+// step performs a nested step, publishing its start and outcome so reports show it
+// within the task
+func step(actor core.Actor, description string, perform func() error) error {
+	core.Publish(actor, events.ActivityStarted{Actor: actor.Name(), Activity: description})
+	start := time.Now()
+
+	err := perform()
+	outcome := events.Passed
+	if err != nil {
+		outcome = events.Failed
+	}
+	core.Publish(actor, events.ActivityFinished{
+		Actor:    actor.Name(),
+		Activity: description,
+		Outcome:  outcome,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+	return err
+}
@@ -0,0 +1,175 @@
+// Package health provides tasks that wait for the system under test and its
+// dependencies to become ready, standardizing how suites synchronize on startup.
+//
+// Targets are HTTP(S) URLs, ready once they answer with a 2xx status, or TCP addresses
+// such as "tcp://localhost:5432" or "localhost:5432", ready once they accept a connection:
+//
+//	actor.AttemptsTo(
+//		health.VerifyDependenciesHealthy(time.Minute,
+//			health.Dependency{Name: "database", Target: "tcp://localhost:5432"},
+//			health.Dependency{Name: "payments", Target: "http://localhost:8081/healthz"},
+//		),
+//		health.WaitForServiceReady("http://localhost:8080/ready", 30*time.Second),
+//	)
+//
+// The tasks poll with exponential backoff on the actor's clock and report the time
+// spent waiting for each target as an answer in the test report.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/abilities/clock"
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/events"
+	serenity "github.com/nchursin/serenity-go/serenity/testing"
+)
+
+const (
+	// initialInterval is the wait after the first failed probe
+	initialInterval = 100 * time.Millisecond
+	// maxInterval caps the wait between probes
+	maxInterval = 5 * time.Second
+	// probeTimeout caps the time a single probe may take
+	probeTimeout = 5 * time.Second
+)
+
+// probeClient sends the HTTP probes. It does not follow redirects, so a redirect to a
+// login page does not count as ready.
+var probeClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// Dependency is a service the system under test depends on
+type Dependency struct {
+	// Name identifies the dependency in reports
+	Name string
+	// Target is the URL or TCP address probed for readiness
+	Target string
+}
+
+// WaitForServiceReady creates a task that probes the target until it is ready, failing
+// if it is not ready within the timeout
+func WaitForServiceReady(target string, timeout time.Duration) core.Activity {
+	return core.Do(fmt.Sprintf("#actor waits up to %s for %s to be ready", timeout, target), func(actor core.Actor, ctx context.Context) error {
+		c := clock.Of(actor)
+		return waitFor(ctx, actor, c, target, target, c.Now().Add(timeout))
+	})
+}
+
+// VerifyDependenciesHealthy creates a task that waits for every dependency to be ready,
+// in order, within a shared timeout. It fails listing every dependency that is not.
+func VerifyDependenciesHealthy(timeout time.Duration, dependencies ...Dependency) core.Activity {
+	names := make([]string, len(dependencies))
+	for i, dependency := range dependencies {
+		names[i] = dependency.Name
+	}
+
+	return core.Do(fmt.Sprintf("#actor verifies the health of %s", strings.Join(names, ", ")), func(actor core.Actor, ctx context.Context) error {
+		c := clock.Of(actor)
+		deadline := c.Now().Add(timeout)
+
+		var errs []error
+		for _, dependency := range dependencies {
+			if err := waitFor(ctx, actor, c, dependency.Name, dependency.Target, deadline); err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// Reachable creates an environment check, for SerenityTest.RequireEnvironment, that probes
+// the target once
+//
+//	test := serenity.NewSerenityTest(t).RequireEnvironment(
+//		health.Reachable("database", "tcp://localhost:5432"),
+//	)
+func Reachable(name, target string) serenity.EnvironmentCheck {
+	return serenity.EnvironmentCheck{
+		Name: name,
+		Verify: func(ctx context.Context) error {
+			if err := probe(ctx, target); err != nil {
+				return fmt.Errorf("%s is not reachable: %w", target, err)
+			}
+			return nil
+		},
+	}
+}
+
+// waitFor probes the target with backoff until it is ready or the deadline passes, and
+// reports the time waited. A target that is not ready at the deadline is probed once.
+func waitFor(ctx context.Context, actor core.Actor, c clock.Clock, name, target string, deadline time.Time) error {
+	start := c.Now()
+	interval := initialInterval
+	attempts := 0
+
+	for {
+		attempts++
+		err := probe(ctx, target)
+		if err == nil {
+			core.Publish(actor, events.QuestionAnswered{
+				Actor:    actor.Name(),
+				Question: fmt.Sprintf("the time waited for %s to be ready", name),
+				Answer:   c.Now().Sub(start),
+			})
+			return nil
+		}
+
+		remaining := deadline.Sub(c.Now())
+		if remaining <= 0 {
+			return fmt.Errorf("%s is not ready after %s and %d attempts: %w", name, c.Now().Sub(start), attempts, err)
+		}
+		if err := c.Sleep(ctx, min(interval, remaining)); err != nil {
+			return err
+		}
+		interval = min(2*interval, maxInterval)
+	}
+}
+
+// probe checks once whether the target is ready
+func probe(ctx context.Context, target string) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		return probeHTTP(ctx, target)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", strings.TrimPrefix(target, "tcp://"))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// probeHTTP requests the URL and checks for a 2xx status
+func probeHTTP(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close() // Ignore cleanup error
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -2,12 +2,20 @@ package testing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/abilities/notes"
+	"github.com/nchursin/serenity-go/serenity/config"
 	"github.com/nchursin/serenity-go/serenity/core"
-	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/debug"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/reporting/failures"
+	"github.com/nchursin/serenity-go/serenity/secrets"
 )
 
 // testActor implements the Actor interface with TestContext integration.
@@ -17,15 +25,23 @@ import (
 // Key Features:
 //   - Automatic error propagation to test framework
 //   - Thread-safe operations
-//   - Integrated reporting capabilities
+//   - Activity and answer events published on the test's event bus
 //   - Support for all standard Actor methods
 type testActor struct {
-	name        string                       // Actor name for reporting
-	abilities   []abilities.Ability          // Actor abilities
-	testContext TestContext                  // Embedded test context for error handling
-	reporter    *reporting.TestRunnerAdapter // Integrated reporter for activity tracking
-	ctx         context.Context              // Context for cancellation and timeout
-	mutex       sync.RWMutex                 // Mutex for thread-safe operations
+	name        string              // Actor name for reporting
+	abilities   []abilities.Ability // Actor abilities
+	shared      *sharedAbilities    // Abilities shared by the actors of the test
+	sharing     []*sharedAbility    // Shared abilities the actor uses
+	testContext TestContext         // Embedded test context for error handling
+	bus         *events.Bus         // Event bus announcing activities to reporters and listeners
+	ctx         context.Context     // Context for cancellation and timeout
+	pacer       *core.Pacer         // Limits the rate of activities, nil when unpaced
+	budget      *core.WorkBudget    // Time budget of the test run, nil when unlimited
+	stepper     *debug.Stepper      // Pauses before each activity in debug mode, nil otherwise
+	traits      core.Traits         // Traits and attributes activities can adapt to
+	retries     config.RetryPolicy  // Attempts of failing activities, from the run settings
+	timeout     time.Duration       // Time limit of each activity, zero when unlimited
+	mutex       sync.RWMutex        // Mutex for thread-safe operations
 }
 
 // Name returns the actor's name
@@ -62,18 +78,169 @@ func (ta *testActor) WhoCan(abilities ...abilities.Ability) core.Actor {
 	return ta
 }
 
+// WhoCanUseShared gives the actor the shared abilities of the types, or all shared
+// abilities if no types are given. A type that matches no shared ability fails the test.
+func (ta *testActor) WhoCanUseShared(abilityTypes ...abilities.Ability) core.Actor {
+	ta.mutex.Lock()
+	missing := abilityTypes
+	if ta.shared != nil {
+		var used []*sharedAbility
+		used, missing = ta.shared.use(ta.sharing, abilityTypes)
+		ta.sharing = append(ta.sharing, used...)
+	}
+	ta.mutex.Unlock()
+
+	for _, abilityType := range missing {
+		ta.testContext.Errorf("%s", masked("Actor '%s' cannot use shared %s: the test shares no such ability", ta.name, core.AbilityName(abilityType)))
+	}
+	if len(missing) > 0 {
+		ta.testContext.FailNow()
+	}
+	return ta
+}
+
+// Abilities returns a snapshot of the actor's own abilities in the order they were added,
+// followed by the shared abilities it uses
+func (ta *testActor) Abilities() []abilities.Ability {
+	ta.mutex.RLock()
+	defer ta.mutex.RUnlock()
+
+	return ta.allAbilities()
+}
+
+// ownAbilities returns a snapshot of the abilities the actor was given with WhoCan
+func (ta *testActor) ownAbilities() []abilities.Ability {
+	ta.mutex.RLock()
+	defer ta.mutex.RUnlock()
+
+	return slices.Clone(ta.abilities)
+}
+
+// releaseShared stops the actor using shared abilities and returns those it was the
+// last user of
+func (ta *testActor) releaseShared() []abilities.Ability {
+	ta.mutex.Lock()
+	defer ta.mutex.Unlock()
+
+	if len(ta.sharing) == 0 {
+		return nil
+	}
+	unused := ta.shared.release(ta.sharing)
+	ta.sharing = nil
+	return unused
+}
+
+// allAbilities returns the actor's own and shared abilities. The caller must hold the mutex.
+func (ta *testActor) allAbilities() []abilities.Ability {
+	all := slices.Clone(ta.abilities)
+	for _, entry := range ta.sharing {
+		all = append(all, entry.ability)
+	}
+	return all
+}
+
+// WithPacing limits the actor to the given number of activities per second
+func (ta *testActor) WithPacing(activitiesPerSecond float64) core.Actor {
+	ta.mutex.Lock()
+	defer ta.mutex.Unlock()
+
+	ta.pacer = core.NewPacer(activitiesPerSecond)
+	return ta
+}
+
+// Is gives the actor traits that activities and questions can adapt to
+func (ta *testActor) Is(traits ...core.Trait) core.Actor {
+	ta.traits.Add(traits...)
+	return ta
+}
+
+// With sets an attribute that activities and questions can read
+func (ta *testActor) With(key, value string) core.Actor {
+	ta.traits.Set(key, value)
+	return ta
+}
+
+// HasTrait reports whether the actor was given the trait
+func (ta *testActor) HasTrait(trait core.Trait) bool {
+	return ta.traits.Has(trait)
+}
+
+// Attribute returns the value of the attribute, and whether it is set
+func (ta *testActor) Attribute(key string) (string, bool) {
+	return ta.traits.Attribute(key)
+}
+
+// useWorkBudget sets the time budget that decides whether non-critical activities still run
+func (ta *testActor) useWorkBudget(budget *core.WorkBudget) {
+	ta.mutex.Lock()
+	defer ta.mutex.Unlock()
+
+	ta.budget = budget
+}
+
+// perform performs the activity within the activity timeout, attempting it again after the
+// delay of the retry policy while it fails. Steps of Repeat and ForEach are performed
+// within the context of the enclosing activity and retried on their own, so the enclosing
+// activity is not retried when one of them failed.
+func (ta *testActor) perform(activity core.Activity, description string) error {
+	ctx := ta.ctx
+	if enclosing, ok := core.SubStepContext(activity); ok {
+		ctx = enclosing
+	}
+	for attempt := 1; ; attempt++ {
+		err := ta.attempt(ctx, activity)
+		if err == nil || errors.Is(err, core.ErrSkipped) || core.IsSubStepFailure(err) || attempt >= ta.retries.Attempts {
+			return err
+		}
+
+		ta.testContext.Logf("%s", masked("Attempt %d of %d of '%s' failed, retrying in %s: %v", attempt, ta.retries.Attempts, description, ta.retries.Delay, err))
+		select {
+		case <-time.After(ta.retries.Delay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// attempt performs the activity once within the context, bounded by the activity timeout
+func (ta *testActor) attempt(ctx context.Context, activity core.Activity) error {
+	if ta.timeout <= 0 {
+		return activity.PerformAs(ta, ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, ta.timeout)
+	defer cancel()
+	return activity.PerformAs(ta, ctx)
+}
+
+// skip reports an activity as skipped instead of performing it
+func (ta *testActor) skip(activity core.Activity, reason string) {
+	core.MarkSkipped(activity)
+	description := notes.Fill(ta, activity.Description())
+	metadata := core.MetadataOf(activity)
+	ta.Publish(events.ActivityStarted{Actor: ta.name, Activity: description, Metadata: metadata})
+	ta.Publish(events.ActivityFinished{
+		Actor:    ta.name,
+		Activity: description,
+		Outcome:  events.Skipped,
+		Err:      errors.New(reason),
+		Metadata: metadata,
+	})
+	ta.testContext.Logf("%s", masked("Skipped activity '%s': %s", description, reason))
+}
+
 // AbilityTo returns the specified ability
 func (ta *testActor) AbilityTo(abilityType abilities.Ability) (abilities.Ability, error) {
 	ta.mutex.RLock()
 	defer ta.mutex.RUnlock()
 
-	for _, ability := range ta.abilities {
+	all := ta.allAbilities()
+	for _, ability := range all {
 		if abilityMatchesType(ability, abilityType) {
 			return ability, nil
 		}
 	}
 
-	return nil, fmt.Errorf("actor '%s' does not have the required ability", ta.name)
+	return nil, core.NewMissingAbilityError(ta.name, core.AbilityName(abilityType), all)
 }
 
 // AttemptsTo executes activities and automatically handles any errors through TestContext.
@@ -103,42 +270,150 @@ func (ta *testActor) AbilityTo(abilityType abilities.Ability) (abilities.Ability
 //   - FailFast: Stops test execution immediately on error
 //   - ErrorButContinue: Logs error but continues with remaining activities
 //   - Ignore: Silently ignores the error and continues
+//
+// An activity returning an error that matches core.ErrSkipped is reported as skipped and
+// does not fail the test. One matching core.ErrTestSkipped, e.g. from core.SkipIf, also
+// reports the remaining activities as skipped and skips the test.
 func (ta *testActor) AttemptsTo(activities ...core.Activity) {
-	for _, activity := range activities {
-		var tracker *reporting.ActivityTracker
-		if ta.reporter != nil {
-			tracker = reporting.NewActivityTrackerWithActor(ta.reporter.GetReporter(), activity.Description(), ta.name)
-			tracker.Start()
+	for i, activity := range activities {
+		ta.mutex.RLock()
+		pacer := ta.pacer
+		budget := ta.budget
+		ta.mutex.RUnlock()
+
+		if !budget.Allows(activity) {
+			ta.skip(activity, budget.SkipReason())
+			continue
+		}
+
+		switch ta.stepper.Pause(ta, activity) {
+		case debug.Skip:
+			ta.skip(activity, "skipped in debug mode")
+			continue
+		case debug.Abort:
+			ta.testContext.Errorf("%s", masked("Aborted in debug mode before '%s'", activity.Description()))
+			ta.testContext.FailNow()
+			return
 		}
 
-		err := activity.PerformAs(ta, ta.ctx)
+		paceErr := pacer.Wait(ta.ctx)
 
-		if tracker != nil {
-			tracker.Finish(err)
+		// Placeholders are filled from the notes taken by earlier activities
+		description := notes.Fill(ta, activity.Description())
+		metadata := core.MetadataOf(activity)
+		ta.Publish(events.ActivityStarted{Actor: ta.name, Activity: description, Metadata: metadata})
+		start := time.Now()
+
+		err := paceErr
+		if err == nil {
+			err = ta.perform(activity, description)
+		}
+
+		outcome := events.Passed
+		switch {
+		case errors.Is(err, core.ErrSkipped):
+			outcome = events.Skipped
+		case err != nil:
+			outcome = events.Failed
+		}
+		location := core.FailureLocation(activity, err)
+		ta.Publish(events.ActivityFinished{
+			Actor:    ta.name,
+			Activity: description,
+			Outcome:  outcome,
+			Duration: time.Since(start),
+			Err:      err,
+			Location: location.String(),
+			Metadata: metadata,
+		})
+
+		if outcome == events.Skipped {
+			ta.testContext.Logf("%s", masked("Skipped activity '%s': %v", description, err))
+			if errors.Is(err, core.ErrTestSkipped) {
+				for _, remaining := range activities[i+1:] {
+					ta.skip(remaining, err.Error())
+				}
+				ta.testContext.Skip(masked("%v", err))
+				return
+			}
+			continue
 		}
 
 		if err != nil {
-			failureMode := activity.FailureMode()
+			failureMode := core.FailureModeFor(activity, err)
 			switch failureMode {
 			case core.FailFast:
-				ta.testContext.Errorf("Critical activity error '%s' failed: %v", activity.Description(), err)
+				ta.testContext.Errorf("%s", masked("Critical activity error '%s' failed: %s%s", description, formatFailure(err), at(location)))
 				ta.testContext.FailNow()
 				return
 			case core.ErrorButContinue:
-				ta.testContext.Errorf("Non-critical activity error '%s' failed: %v", activity.Description(), err)
+				ta.testContext.Errorf("%s", masked("Non-critical activity error '%s' failed: %s%s", description, formatFailure(err), at(location)))
 			case core.Ignore:
-				ta.testContext.Logf("Ignore activity error '%s' failed: %v", activity.Description(), err)
+				ta.testContext.Logf("%s", masked("Ignore activity error '%s' failed: %v%s", description, err, at(location)))
 			}
 		}
 	}
 }
 
 // AnswersTo answers questions with boolean success flag
+//
+// Deprecated: Use core.Answer or MustAnswer.
 func (ta *testActor) AnswersTo(question core.Question[any]) (any, bool) {
-	result, err := question.AnsweredBy(ta, ta.ctx)
+	result, err := core.Answer(ta, question)
 	if err != nil {
-		ta.testContext.Errorf("Failed to answer question '%s': %v", question.Description(), err)
+		ta.testContext.Errorf("%s", masked("%v", err))
 		return nil, false
 	}
 	return result, true
 }
+
+// RecordAnswer publishes the answer as a QuestionAnswered event
+func (ta *testActor) RecordAnswer(question string, answer any) {
+	ta.Publish(events.QuestionAnswered{Actor: ta.name, Question: question, Answer: answer})
+}
+
+// Publish notifies the listeners of the test's event bus about the event
+func (ta *testActor) Publish(event events.Event) {
+	ta.bus.Publish(event)
+}
+
+// formatFailure renders an error for the test log, expanding assertion failures
+// into the expected and actual values and their diff
+func formatFailure(err error) string {
+	return failures.NewFormatter().Format(err)
+}
+
+// at describes where a failing activity was constructed, or returns an empty string if unknown
+func at(location core.Location) string {
+	if location.IsZero() {
+		return ""
+	}
+	return "\n    at " + location.String()
+}
+
+// masked formats a message and hides any registered secret values in it
+func masked(format string, args ...interface{}) string {
+	return secrets.Mask(fmt.Sprintf(format, args...))
+}
+
+// MustAnswer asks the actor the question and returns the typed answer. If the question
+// cannot be answered, the test fails immediately, as for a failed activity; actors not
+// created by a SerenityTest panic instead.
+//
+// Example:
+//
+//	orderID := serenity.MustAnswer(actor, api.NewJSONPath("id"))
+func MustAnswer[T any](actor core.Actor, question core.Question[T]) T {
+	answer, err := core.Answer(actor, question)
+	if err == nil {
+		return answer
+	}
+
+	ta, ok := actor.(*testActor)
+	if !ok {
+		panic(err)
+	}
+	ta.testContext.Errorf("%s", masked("%v", err))
+	ta.testContext.FailNow()
+	return answer
+}
@@ -1,13 +1,25 @@
 package testing
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/nchursin/serenity-go/serenity/config"
 	"github.com/nchursin/serenity-go/serenity/core"
 	coreMocks "github.com/nchursin/serenity-go/serenity/core/testing/mocks"
+	"github.com/nchursin/serenity-go/serenity/debug"
+	"github.com/nchursin/serenity-go/serenity/events"
+	"github.com/nchursin/serenity-go/serenity/expectations"
+	"github.com/nchursin/serenity-go/serenity/expectations/ensure"
 	"github.com/nchursin/serenity-go/serenity/reporting"
 	reportingMocks "github.com/nchursin/serenity-go/serenity/reporting/mocks"
 	testingMocks "github.com/nchursin/serenity-go/serenity/testing/mocks"
@@ -49,3 +61,521 @@ func TestTestActorAttemptsToWithReporting(t *testing.T) {
 	// Execute activity
 	actor.AttemptsTo(mockActivity)
 }
+
+type manageFiles struct{}
+
+type connectToDatabase struct{}
+
+func TestTestActorAbilityToListsAvailableAbilities(t *testing.T) {
+	actor := &testActor{name: "Bob"}
+	actor.WhoCan(&manageFiles{}, &connectToDatabase{})
+
+	_, err := actor.AbilityTo(&struct{}{})
+	require.EqualError(t, err, "actor 'Bob' lacks struct {}; has: testing.manageFiles, testing.connectToDatabase")
+
+	// A value is requested while the actor has a pointer: the closest match is suggested
+	_, err = actor.AbilityTo(connectToDatabase{})
+	require.EqualError(t, err, "actor 'Bob' lacks testing.connectToDatabase; "+
+		"has: testing.manageFiles, testing.connectToDatabase (did you mean *testing.connectToDatabase?)")
+
+	ability, err := actor.AbilityTo(&connectToDatabase{})
+	require.NoError(t, err)
+	require.IsType(t, &connectToDatabase{}, ability)
+}
+
+type databaseAbility interface {
+	Query(sql string) error
+}
+
+type fakeDatabase struct{}
+
+func (f *fakeDatabase) Query(sql string) error { return nil }
+
+func TestTestActorAbilityToMatchesInterface(t *testing.T) {
+	fake := &fakeDatabase{}
+	actor := &testActor{name: "Bob"}
+	actor.WhoCan(&manageFiles{}, fake)
+
+	ability, err := actor.AbilityTo((*databaseAbility)(nil))
+	require.NoError(t, err)
+	require.Same(t, fake, ability)
+
+	_, err = actor.AbilityTo((*fmt.Stringer)(nil))
+	require.Error(t, err)
+}
+
+func TestTestActorWithPacingSpacesActivities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := &testActor{name: "Pacer", testContext: mockTestContext, ctx: context.Background()}
+	actor.WithPacing(20)
+
+	var starts []time.Time
+	record := core.Do("#actor calls the partner API", func(actor core.Actor, ctx context.Context) error {
+		starts = append(starts, time.Now())
+		return nil
+	})
+
+	actor.AttemptsTo(record, record, record)
+
+	require.Len(t, starts, 3)
+	for i := 1; i < len(starts); i++ {
+		require.GreaterOrEqual(t, starts[i].Sub(starts[i-1]), 45*time.Millisecond)
+	}
+}
+
+func TestWithPacingLeavesActorsThatCannotBePacedUnchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	actor := coreMocks.NewMockActor(ctrl)
+
+	require.NotPanics(t, func() {
+		require.Same(t, actor, core.WithPacing(actor, 10))
+	})
+}
+
+func TestTestActorWithoutPacingDoesNotWait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := &testActor{name: "Unpaced", testContext: mockTestContext, ctx: context.Background()}
+	core.WithPacing(core.WithPacing(actor, 1), 0)
+
+	start := time.Now()
+	noop := core.Do("#actor does nothing", func(actor core.Actor, ctx context.Context) error { return nil })
+	actor.AttemptsTo(noop, noop, noop)
+
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestTestActorSkipsNonCriticalActivitiesWhenBudgetIsExhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockReporter := reportingMocks.NewMockReporter(ctrl)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	test := &serenityTest{
+		testCtx: mockTestContext,
+		ctx:     context.Background(),
+		actors:  make(map[string]core.Actor),
+		adapter: reporting.NewTestRunnerAdapter(mockReporter),
+	}
+	actor := test.ActorCalled("Hurried")
+	test.WithWorkBudget(core.NewWorkBudget(0))
+
+	metrics := coreMocks.NewMockActivity(ctrl)
+	metrics.EXPECT().Description().Return("#actor collects metrics").AnyTimes()
+	metrics.EXPECT().FailureMode().Return(core.ErrorButContinue).AnyTimes()
+
+	verification := coreMocks.NewMockActivity(ctrl)
+	verification.EXPECT().Description().Return("#actor verifies the order").AnyTimes()
+	verification.EXPECT().FailureMode().Return(core.FailFast).AnyTimes()
+	verification.EXPECT().PerformAs(gomock.Any(), gomock.Any()).Return(nil)
+
+	gomock.InOrder(
+		mockReporter.EXPECT().OnStepStart("Hurried collects metrics"),
+		mockReporter.EXPECT().OnStepFinish(gomock.Any()).Do(func(result reporting.TestResult) {
+			require.Equal(t, reporting.StatusSkipped, result.Status())
+			require.EqualError(t, result.Error(), "work budget of 0s exhausted")
+		}),
+		mockReporter.EXPECT().OnStepStart("Hurried verifies the order"),
+		mockReporter.EXPECT().OnStepFinish(gomock.Any()).Do(func(result reporting.TestResult) {
+			require.Equal(t, reporting.StatusPassed, result.Status())
+		}),
+	)
+	mockTestContext.EXPECT().Logf("%s", "Skipped activity '#actor collects metrics': work budget of 0s exhausted")
+
+	actor.AttemptsTo(metrics, verification)
+}
+
+func TestTestActorPerformsCriticalIterationsWhenBudgetIsExhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+	mockTestContext.EXPECT().Logf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	var outcomes []string
+	bus := events.NewBus()
+	bus.Subscribe(events.ListenerFunc(func(event events.Event) {
+		if finished, ok := event.(events.ActivityFinished); ok {
+			outcomes = append(outcomes, finished.Activity+": "+finished.Outcome.String())
+		}
+	}))
+	actor := &testActor{name: "Hurried", testContext: mockTestContext, ctx: context.Background(), bus: bus}
+	actor.useWorkBudget(core.NewWorkBudget(0))
+
+	created, collected := 0, 0
+	actor.AttemptsTo(
+		core.Repeat(2, func(i int) core.Activity {
+			return core.Do("#actor creates a user", func(actor core.Actor, ctx context.Context) error {
+				created++
+				return nil
+			})
+		}),
+		core.Repeat(2, func(i int) core.Activity {
+			return metricsCollection(func() { collected++ })
+		}),
+	)
+
+	require.Equal(t, 2, created)
+	require.Zero(t, collected, "skipped iterations must not be performed")
+	require.Equal(t, []string{
+		"#actor creates a user: passed",
+		"#actor creates a user: passed",
+		"#actor repeats an activity 2 times: passed",
+		"#actor collects metrics: skipped",
+		"#actor collects metrics: skipped",
+		"#actor repeats an activity 2 times: passed",
+	}, outcomes)
+}
+
+// metricsCollection is a non-critical activity calling collect when performed
+type metricsCollection func()
+
+func (m metricsCollection) Description() string { return "#actor collects metrics" }
+
+func (m metricsCollection) PerformAs(actor core.Actor, ctx context.Context) error {
+	m()
+	return nil
+}
+
+func (m metricsCollection) FailureMode() core.FailureMode { return core.ErrorButContinue }
+
+func TestTestActorPerformsActivitiesWithinBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := &testActor{name: "Relaxed", testContext: mockTestContext, ctx: context.Background()}
+	actor.useWorkBudget(core.NewWorkBudget(time.Hour))
+
+	metrics := coreMocks.NewMockActivity(ctrl)
+	metrics.EXPECT().Description().Return("#actor collects metrics").AnyTimes()
+	metrics.EXPECT().FailureMode().Return(core.ErrorButContinue).AnyTimes()
+	metrics.EXPECT().PerformAs(gomock.Any(), gomock.Any()).Return(nil)
+
+	actor.AttemptsTo(metrics)
+}
+
+func TestTestActorRetriesTheFailingIterationOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := &testActor{
+		name:        "Retrier",
+		testContext: mockTestContext,
+		ctx:         context.Background(),
+		retries:     config.RetryPolicy{Attempts: 3},
+	}
+
+	var retried []string
+	mockTestContext.EXPECT().Logf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+		if message := args[0].(string); strings.HasPrefix(message, "Attempt ") {
+			retried = append(retried, message)
+		}
+	}).AnyTimes()
+
+	calls := map[int]int{}
+	actor.AttemptsTo(core.Repeat(3, func(iteration int) core.Activity {
+		return core.Do(fmt.Sprintf("#actor polls the queue %d", iteration), func(actor core.Actor, ctx context.Context) error {
+			calls[iteration]++
+			if iteration == 1 && calls[iteration] == 1 {
+				return errors.New("queue is empty")
+			}
+			return nil
+		})
+	}))
+
+	require.Equal(t, map[int]int{0: 1, 1: 2, 2: 1}, calls, "only the failing iteration is performed again")
+	require.Len(t, retried, 1)
+	require.Contains(t, retried[0], "'#actor polls the queue 1'")
+}
+
+func TestTestActorDoesNotRetryLoopsWhoseIterationFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := &testActor{
+		name:        "Retrier",
+		testContext: mockTestContext,
+		ctx:         context.Background(),
+		retries:     config.RetryPolicy{Attempts: 3},
+	}
+
+	mockTestContext.EXPECT().Logf("%s", gomock.Any()).AnyTimes()
+	mockTestContext.EXPECT().Errorf("%s", gomock.Any())
+	mockTestContext.EXPECT().FailNow()
+
+	calls := map[int]int{}
+	actor.AttemptsTo(core.Repeat(2, func(iteration int) core.Activity {
+		return core.Do("#actor polls the queue", func(actor core.Actor, ctx context.Context) error {
+			calls[iteration]++
+			if iteration == 1 {
+				return errors.New("queue is empty")
+			}
+			return nil
+		})
+	}))
+
+	require.Equal(t, map[int]int{0: 1, 1: 3}, calls, "the succeeding iteration is not repeated")
+}
+
+func TestTestActorPerformsIterationsWithinTheContextOfTheLoop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+	mockTestContext.EXPECT().Logf("%s", gomock.Any()).AnyTimes()
+
+	actor := &testActor{name: "Poller", testContext: mockTestContext, ctx: context.Background()}
+
+	var iterationErr error
+	actor.AttemptsTo(core.Do("#actor polls until cancelled", func(actor core.Actor, ctx context.Context) error {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		return core.Repeat(1, func(iteration int) core.Activity {
+			return core.Do("#actor polls the queue", func(actor core.Actor, ctx context.Context) error {
+				iterationErr = ctx.Err()
+				return nil
+			})
+		}).PerformAs(actor, ctx)
+	}))
+
+	require.ErrorIs(t, iterationErr, context.Canceled)
+}
+
+func TestTestActorReportsWhereFailingStepWasConstructed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	var finished events.ActivityFinished
+	bus := events.NewBus()
+	bus.Subscribe(events.ListenerFunc(func(event events.Event) {
+		if e, ok := event.(events.ActivityFinished); ok {
+			finished = e
+		}
+	}))
+	actor := &testActor{name: "Locator", testContext: mockTestContext, ctx: context.Background(), bus: bus}
+
+	_, file, line, _ := runtime.Caller(0)
+	task := core.TaskWhere("#actor checks the order",
+		core.Do("#actor loads the order", func(actor core.Actor, ctx context.Context) error { return nil }),
+		core.Do("#actor checks the status", func(actor core.Actor, ctx context.Context) error {
+			return errors.New("status is pending")
+		}),
+	)
+	stepLocation := fmt.Sprintf("%s:%d", file, line+3)
+
+	var message string
+	mockTestContext.EXPECT().Errorf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+		message = args[0].(string)
+	})
+	mockTestContext.EXPECT().FailNow()
+
+	actor.AttemptsTo(task)
+
+	require.True(t, strings.HasSuffix(message, "\n    at "+stepLocation), message)
+	require.Equal(t, stepLocation, finished.Location)
+	require.Equal(t, events.Failed, finished.Outcome)
+}
+
+func TestTestActorFollowsDebugDecisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	var output bytes.Buffer
+	actor := &testActor{
+		name:        "Debugger",
+		testContext: mockTestContext,
+		ctx:         context.Background(),
+		stepper:     debug.NewStepper(strings.NewReader("s\n\na\n"), &output),
+	}
+
+	var performed []string
+	step := func(name string) core.Activity {
+		return core.Do("#actor "+name, func(actor core.Actor, ctx context.Context) error {
+			performed = append(performed, name)
+			return nil
+		})
+	}
+
+	gomock.InOrder(
+		mockTestContext.EXPECT().Logf("%s", "Skipped activity '#actor logs in': skipped in debug mode"),
+		mockTestContext.EXPECT().Errorf("%s", "Aborted in debug mode before '#actor pays'"),
+		mockTestContext.EXPECT().FailNow(),
+	)
+
+	actor.AttemptsTo(step("logs in"), step("orders"), step("pays"), step("logs out"))
+
+	require.Equal(t, []string{"orders"}, performed)
+}
+
+func TestMustAnswerReturnsTypedAnswer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := &testActor{name: "Asker", testContext: mockTestContext, ctx: context.Background()}
+	orders := core.NewQuestion("the number of orders", func(actor core.Actor, ctx context.Context) (int, error) {
+		return 3, nil
+	})
+
+	require.Equal(t, 3, MustAnswer(actor, orders))
+}
+
+func TestMustAnswerFailsTheTestWhenTheQuestionCannotBeAnswered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+	mockTestContext.EXPECT().Errorf("%s", "failed to answer question 'asks the number of orders': database is down")
+	mockTestContext.EXPECT().FailNow()
+
+	actor := &testActor{name: "Asker", testContext: mockTestContext, ctx: context.Background()}
+	orders := core.NewQuestion("the number of orders", func(actor core.Actor, ctx context.Context) (int, error) {
+		return 0, errors.New("database is down")
+	})
+
+	require.Equal(t, 0, MustAnswer(actor, orders))
+}
+
+func TestTestActorHandlesUnansweredQuestionsOfAssertions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	var outcomes []events.Outcome
+	bus := events.NewBus()
+	bus.Subscribe(events.ListenerFunc(func(event events.Event) {
+		if e, ok := event.(events.ActivityFinished); ok {
+			outcomes = append(outcomes, e.Outcome)
+		}
+	}))
+	actor := &testActor{name: "Checker", testContext: mockTestContext, ctx: context.Background(), bus: bus}
+
+	response := core.NewQuestion("the last response", func(actor core.Actor, ctx context.Context) (string, error) {
+		return "", errors.New("no response available")
+	})
+
+	gomock.InOrder(
+		mockTestContext.EXPECT().Logf("%s", "Skipped activity '#actor ensures that asks the last response equals ok': "+
+			"the question could not be answered: failed to answer question 'asks the last response': no response available"),
+		mockTestContext.EXPECT().Errorf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+			require.Contains(t, args[0], "Non-critical activity error")
+		}),
+		mockTestContext.EXPECT().Errorf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+			require.Contains(t, args[0], "Critical activity error")
+		}),
+		mockTestContext.EXPECT().FailNow(),
+	)
+
+	actor.AttemptsTo(
+		ensure.That(response, expectations.Equals("ok")).OrSkip(),
+		ensure.That(response, expectations.Equals("ok")).OnErrorContinue(),
+		ensure.That(response, expectations.Equals("ok")).OnAnswerError(core.Critical()),
+		ensure.That(response, expectations.Equals("ok")),
+	)
+
+	require.Equal(t, []events.Outcome{events.Skipped, events.Failed, events.Failed}, outcomes)
+}
+
+func TestTestActorStopsAtFailedAssertionsUnlessTheyContinue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := &testActor{name: "Checker", testContext: mockTestContext, ctx: context.Background()}
+	status := core.NewQuestion("the status", func(actor core.Actor, ctx context.Context) (int, error) {
+		return 500, nil
+	})
+
+	gomock.InOrder(
+		mockTestContext.EXPECT().Logf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+			require.Contains(t, args[0], "Ignore activity error '#actor ensures that asks the status equals 204'")
+		}),
+		mockTestContext.EXPECT().Errorf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+			require.Contains(t, args[0], "Non-critical activity error '#actor ensures that asks the status equals 201'")
+		}),
+		mockTestContext.EXPECT().Errorf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+			require.Contains(t, args[0], "Critical activity error '#actor ensures that asks the status equals 200'")
+		}),
+		mockTestContext.EXPECT().FailNow(),
+	)
+
+	actor.AttemptsTo(
+		ensure.That(status, expectations.Equals(204)).AsWarning(),
+		ensure.That(status, expectations.Equals(201)).Continuing(),
+		ensure.That(status, expectations.Equals(200)),
+		ensure.That(status, expectations.Equals(404)),
+	)
+}
+
+func TestEnsureUsesTheDefaultFailureMode(t *testing.T) {
+	status := core.NewQuestion("the status", func(actor core.Actor, ctx context.Context) (int, error) {
+		return 200, nil
+	})
+	require.Equal(t, core.FailFast, ensure.That(status, expectations.Equals(200)).FailureMode())
+
+	ensure.SetDefaultFailureMode(core.NonCritical())
+	defer ensure.SetDefaultFailureMode(core.FailFast)
+
+	require.Equal(t, core.ErrorButContinue, ensure.That(status, expectations.Equals(200)).FailureMode())
+	require.Equal(t, core.FailFast, ensure.That(status, expectations.Equals(200)).WithFailureMode(core.Critical()).FailureMode())
+}
+
+func TestTestActorSkipsTheRestOfTheTestWhenSkipIfHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	var outcomes []events.Outcome
+	bus := events.NewBus()
+	bus.Subscribe(events.ListenerFunc(func(event events.Event) {
+		if e, ok := event.(events.ActivityFinished); ok {
+			outcomes = append(outcomes, e.Outcome)
+		}
+	}))
+	actor := &testActor{name: "Shopper", testContext: mockTestContext, ctx: context.Background(), bus: bus}
+
+	sandboxUnavailable := core.Of("the sandbox is unavailable", func(actor core.Actor, ctx context.Context) (bool, error) {
+		return true, nil
+	})
+	paid := false
+
+	gomock.InOrder(
+		mockTestContext.EXPECT().Logf("%s", "Skipped activity '#actor skips the test if the sandbox is unavailable': payments run only against the sandbox"),
+		mockTestContext.EXPECT().Logf("%s", "Skipped activity '#actor pays': payments run only against the sandbox"),
+		mockTestContext.EXPECT().Skip("payments run only against the sandbox"),
+	)
+
+	actor.AttemptsTo(
+		core.SkipIf(sandboxUnavailable, "payments run only against the sandbox"),
+		core.Do("#actor pays", func(actor core.Actor, ctx context.Context) error {
+			paid = true
+			return nil
+		}),
+	)
+
+	require.False(t, paid)
+	require.Equal(t, []events.Outcome{events.Skipped, events.Skipped}, outcomes)
+}
+
+func TestTestActorTraitsAndAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := testingMocks.NewMockTestContext(ctrl)
+
+	actor := core.Is(&testActor{name: "Ada", testContext: mockTestContext, ctx: context.Background()}, "admin", "auditor")
+	core.With(actor, "tenant", "acme")
+	core.With(actor, "tenant", "globex")
+
+	require.True(t, core.HasTrait(actor, "admin"))
+	require.True(t, core.HasTrait(actor, "auditor"))
+	require.False(t, core.HasTrait(actor, "guest"))
+
+	tenant, ok := core.AttributeOf(actor, "tenant")
+	require.True(t, ok)
+	require.Equal(t, "globex", tenant)
+
+	_, ok = core.AttributeOf(actor, "region")
+	require.False(t, ok)
+}
+
+func TestTraitsLeaveActorsThatCannotCarryThemUnchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	actor := coreMocks.NewMockActor(ctrl)
+
+	require.NotPanics(t, func() {
+		require.Same(t, actor, core.Is(actor, "admin"))
+		require.Same(t, actor, core.With(actor, "tenant", "acme"))
+	})
+	require.False(t, core.HasTrait(actor, "admin"))
+}
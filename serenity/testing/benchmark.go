@@ -0,0 +1,52 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/load"
+)
+
+// NewSerenityBenchmark creates a SerenityTest for a benchmark. It has no reporter, so that
+// reporting neither distorts the measurements nor floods the output with b.N iterations.
+//
+// Example:
+//
+//	func BenchmarkCheckout(b *testing.B) {
+//		test := serenity.NewSerenityBenchmark(b)
+//		actor := test.ActorCalled("Shopper").WhoCan(api.CallAnApiAt(shopURL))
+//
+//		serenity.Benchmark(b, actor, AddToCartAndCheckout())
+//	}
+func NewSerenityBenchmark(b *testing.B) SerenityTest {
+	b.Helper()
+	return NewSerenityTestWithReporter(context.Background(), b, nil)
+}
+
+// Benchmark performs the activities b.N times as the actor, so acceptance tasks can be
+// reused as benchmarks. Besides ns/op it reports allocations and the p50, p95 and p99
+// latency of the iterations as the "p50-ns", "p95-ns" and "p99-ns" metrics. A failing
+// activity fails the benchmark as it would fail a test.
+func Benchmark(b *testing.B, actor core.Actor, activities ...core.Activity) {
+	b.Helper()
+	b.ReportAllocs()
+
+	latencies := make([]time.Duration, 0, b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := time.Now()
+		actor.AttemptsTo(activities...)
+		latencies = append(latencies, time.Since(start))
+	}
+	b.StopTimer()
+
+	slices.Sort(latencies)
+	result := &load.Result{Iterations: len(latencies), Latencies: latencies}
+	for _, percentile := range []float64{50, 95, 99} {
+		b.ReportMetric(float64(result.Percentile(percentile).Nanoseconds()), fmt.Sprintf("p%.0f-ns", percentile))
+	}
+}
@@ -0,0 +1,31 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nchursin/serenity-go/serenity/core"
+)
+
+func TestBenchmarkReportsAllocationsAndLatencyPercentiles(t *testing.T) {
+	performed := 0
+	result := testing.Benchmark(func(b *testing.B) {
+		test := NewSerenityBenchmark(b)
+		actor := test.ActorCalled("Shopper")
+
+		Benchmark(b, actor, core.Do("#actor adds an item to the cart", func(actor core.Actor, ctx context.Context) error {
+			performed++
+			return nil
+		}))
+	})
+
+	require.Positive(t, result.N)
+	require.GreaterOrEqual(t, performed, result.N)
+	require.Contains(t, result.Extra, "p50-ns")
+	require.Contains(t, result.Extra, "p95-ns")
+	require.Contains(t, result.Extra, "p99-ns")
+	require.LessOrEqual(t, result.Extra["p50-ns"], result.Extra["p99-ns"])
+	require.Positive(t, result.MemAllocs)
+}
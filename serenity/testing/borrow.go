@@ -0,0 +1,58 @@
+package testing
+
+import (
+	"reflect"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// borrowedAbility is an ability lent to the test by a pool
+type borrowedAbility struct {
+	ability abilities.Ability
+	release func() error
+}
+
+// Borrow acquires an ability from the pool for the test, waiting while all of them are
+// lent. Instead of being discarded, the ability is returned to the pool when the test
+// shuts down. A failure to acquire fails the test.
+//
+// Example:
+//
+//	actor := test.ActorCalled("Shopper").WhoCan(serenity.Borrow(test, databases))
+func Borrow[T abilities.Ability](test SerenityTest, pool *abilities.Pool[T]) T {
+	ability, err := pool.Acquire(test.Context())
+	if err != nil {
+		test.TestContext().Errorf("%s", masked("Failed to borrow %s: %v", reflect.TypeFor[T](), err))
+		test.TestContext().FailNow()
+		return ability
+	}
+
+	release := func() error { return pool.Release(ability) }
+	if st, ok := test.(*serenityTest); ok {
+		st.mutex.Lock()
+		st.borrowed = append(st.borrowed, borrowedAbility{ability: ability, release: release})
+		st.mutex.Unlock()
+	} else {
+		test.TestContext().Cleanup(func() { _ = release() })
+	}
+	return ability
+}
+
+// isBorrowed reports whether the ability was borrowed from a pool. The caller must hold
+// the mutex.
+func (st *serenityTest) isBorrowed(ability abilities.Ability) bool {
+	for _, borrowed := range st.borrowed {
+		if sameAbility(borrowed.ability, ability) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameAbility reports whether both abilities are the same instance
+func sameAbility(a, b abilities.Ability) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
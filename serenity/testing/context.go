@@ -6,7 +6,7 @@
 // Key Features:
 //
 //   - Automatic error handling through TestContext
-//   - Actor lifecycle management with defer.Shutdown()
+//   - Actor lifecycle management, with Shutdown registered through t.Cleanup
 //   - Integrated reporting capabilities
 //   - Support for multiple actors in single test
 //   - Thread-safe actor management
@@ -80,9 +80,24 @@ type TestContext interface {
 	// Failed returns true if the test has already failed
 	Failed() bool
 
+	// Cleanup registers a function to be called when the test and its subtests complete
 	Cleanup(func())
 
+	// Helper marks the calling function as a test helper, so failures are reported at
+	// the caller's line
 	Helper()
+
+	// Skip logs the arguments and stops the test, marking it as skipped
+	Skip(args ...interface{})
+
+	// Skipf logs a formatted message and stops the test, marking it as skipped
+	Skipf(format string, args ...interface{})
+
+	// Skipped returns true if the test was skipped
+	Skipped() bool
+
+	// Setenv sets an environment variable for the duration of the test
+	Setenv(key, value string)
 }
 
 // Advanced Usage Examples:
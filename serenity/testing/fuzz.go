@@ -0,0 +1,58 @@
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/events"
+)
+
+// FuzzInput lists the types Go fuzzing can generate
+type FuzzInput interface {
+	string | []byte | bool | float32 | float64 |
+		int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64
+}
+
+// FuzzInputAttachment is the name of the attachment holding the input of a fuzzed test
+const FuzzInputAttachment = "fuzz-input"
+
+// Fuzz runs the target for every input of the seed corpus and, with -fuzz, for every
+// input the fuzzer generates. Each input runs as its own SerenityTest, so tasks are
+// parameterized with generated data while failed assertions are reported as in any
+// other test. The input is attached to the test result to reproduce failures.
+//
+// Example:
+//
+//	func FuzzCreateOrder(f *testing.F) {
+//		f.Add(`{"item": "book"}`)
+//
+//		serenity.Fuzz(f, func(test serenity.SerenityTest, body string) {
+//			actor := test.ActorCalled("Attacker").WhoCan(api.CallAnApiAt(shopURL))
+//			actor.AttemptsTo(
+//				api.SendPostRequest("/orders").WithBody(body),
+//				ensure.That(api.LastResponseStatus{}, expectations.Satisfies("is not a server error", func(status int) error {
+//					if status >= 500 {
+//						return fmt.Errorf("got %d", status)
+//					}
+//					return nil
+//				})),
+//			)
+//		})
+//	}
+func Fuzz[T FuzzInput](f *testing.F, target func(test SerenityTest, input T)) {
+	f.Helper()
+	f.Fuzz(func(t *testing.T, input T) {
+		test := NewSerenityTest(t)
+		test.Events().Publish(fuzzInputAttachment(input))
+		target(test, input)
+	})
+}
+
+// fuzzInputAttachment attaches the input to the test, as raw bytes or formatted text
+func fuzzInputAttachment(input any) events.AttachmentAdded {
+	if data, ok := input.([]byte); ok {
+		return events.AttachmentAdded{Name: FuzzInputAttachment, MediaType: "application/octet-stream", Content: data}
+	}
+	return events.AttachmentAdded{Name: FuzzInputAttachment, MediaType: "text/plain", Content: fmt.Appendf(nil, "%v", input)}
+}
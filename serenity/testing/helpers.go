@@ -7,12 +7,11 @@
 package testing
 
 import (
-	"fmt"
-
 	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/core"
 )
 
-// abilityMatchesType checks if two abilities are of the same type.
+// abilityMatchesType checks if an ability matches the requested ability type.
 // This helper function is used internally to match ability types when
 // retrieving specific abilities from an actor's ability collection.
 //
@@ -23,11 +22,11 @@ import (
 //
 // Returns:
 //
-//	true if the abilities are of the same concrete type, false otherwise
+//	true if the ability matches the requested type, false otherwise
 //
-// This function uses type string comparison to determine type equality,
-// which is sufficient for the current ability system where each ability
-// type has a unique implementation.
+// The requested type can be a concrete type or a pointer to an interface, see core.AbilityMatches.
+// Matching by interface allows swapping fake and real ability implementations
+// without changing the activities and questions that use them.
 func abilityMatchesType(ability, abilityType abilities.Ability) bool {
-	return fmt.Sprintf("%T", ability) == fmt.Sprintf("%T", abilityType)
+	return core.AbilityMatches(ability, abilityType)
 }
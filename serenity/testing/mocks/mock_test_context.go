@@ -136,3 +136,62 @@ func (mr *MockTestContextMockRecorder) Name() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockTestContext)(nil).Name))
 }
+
+// Setenv mocks base method.
+func (m *MockTestContext) Setenv(key, value string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Setenv", key, value)
+}
+
+// Setenv indicates an expected call of Setenv.
+func (mr *MockTestContextMockRecorder) Setenv(key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setenv", reflect.TypeOf((*MockTestContext)(nil).Setenv), key, value)
+}
+
+// Skip mocks base method.
+func (m *MockTestContext) Skip(args ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Skip", varargs...)
+}
+
+// Skip indicates an expected call of Skip.
+func (mr *MockTestContextMockRecorder) Skip(args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Skip", reflect.TypeOf((*MockTestContext)(nil).Skip), args...)
+}
+
+// Skipf mocks base method.
+func (m *MockTestContext) Skipf(format string, args ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Skipf", varargs...)
+}
+
+// Skipf indicates an expected call of Skipf.
+func (mr *MockTestContextMockRecorder) Skipf(format any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Skipf", reflect.TypeOf((*MockTestContext)(nil).Skipf), varargs...)
+}
+
+// Skipped mocks base method.
+func (m *MockTestContext) Skipped() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Skipped")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Skipped indicates an expected call of Skipped.
+func (mr *MockTestContextMockRecorder) Skipped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Skipped", reflect.TypeOf((*MockTestContext)(nil).Skipped))
+}
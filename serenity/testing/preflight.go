@@ -0,0 +1,106 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvironmentCheck verifies that a dependency of the suite, such as a database or a
+// service, is available. Checks are identified by name, so each runs once per process.
+type EnvironmentCheck struct {
+	// Name identifies the dependency in the preflight report, e.g. "database"
+	Name string
+	// Verify returns why the dependency is not available, or nil if it is
+	Verify func(ctx context.Context) error
+}
+
+// checkResult is the outcome of an environment check, shared by the tests of the process
+type checkResult struct {
+	once     sync.Once
+	err      error
+	duration time.Duration
+}
+
+// preflight holds the results of the environment checks run by the process, and whether
+// a test already reported the unavailable dependencies
+var preflight = struct {
+	mutex    sync.Mutex
+	results  map[string]*checkResult
+	reported bool
+}{results: make(map[string]*checkResult)}
+
+// RequireEnvironment runs the checks that have not run in the process yet, concurrently, and
+// fails the test if any dependency is unavailable. The first failing test reports every
+// check; later ones name the unavailable dependencies only.
+func (st *serenityTest) RequireEnvironment(checks ...EnvironmentCheck) SerenityTest {
+	st.testCtx.Helper()
+
+	results := make([]*checkResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		results[i] = resultOf(check.Name)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].once.Do(func() {
+				start := time.Now()
+				results[i].err = check.Verify(st.ctx)
+				results[i].duration = time.Since(start)
+			})
+		}()
+	}
+	wg.Wait()
+
+	var unavailable []string
+	for i, check := range checks {
+		if results[i].err != nil {
+			unavailable = append(unavailable, check.Name)
+		}
+	}
+	if len(unavailable) == 0 {
+		return st
+	}
+
+	preflight.mutex.Lock()
+	first := !preflight.reported
+	preflight.reported = true
+	preflight.mutex.Unlock()
+
+	if first {
+		st.testCtx.Errorf("%s", masked("%s", preflightReport(checks, results)))
+	} else {
+		st.testCtx.Errorf("%s", masked("The environment is not ready, unavailable: %s (see the preflight report of the first failed test)", strings.Join(unavailable, ", ")))
+	}
+	st.testCtx.FailNow()
+	return st
+}
+
+// resultOf returns the shared result of the named check
+func resultOf(name string) *checkResult {
+	preflight.mutex.Lock()
+	defer preflight.mutex.Unlock()
+
+	result, ok := preflight.results[name]
+	if !ok {
+		result = &checkResult{}
+		preflight.results[name] = result
+	}
+	return result
+}
+
+// preflightReport lists the outcome of every check
+func preflightReport(checks []EnvironmentCheck, results []*checkResult) string {
+	var report strings.Builder
+	report.WriteString("The environment is not ready:")
+	for i, check := range checks {
+		if err := results[i].err; err != nil {
+			fmt.Fprintf(&report, "\n  ✗ %s: %v (%s)", check.Name, err, results[i].duration.Round(time.Millisecond))
+		} else {
+			fmt.Fprintf(&report, "\n  ✓ %s (%s)", check.Name, results[i].duration.Round(time.Millisecond))
+		}
+	}
+	return report.String()
+}
@@ -0,0 +1,157 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/reporting"
+	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
+)
+
+// ReporterFactory creates the reporter of a test from the run settings. Factories of
+// reporters that collect the whole run return the same reporter for every test.
+type ReporterFactory = reporting.ReporterFactory
+
+func init() {
+	RegisterReporter("console", func(settings config.RunSettings) (reporting.Reporter, error) {
+		return console_reporter.NewConsoleReporter(), nil
+	})
+}
+
+// runReporters holds the reporters of the run settings that summarize the whole run
+var runReporters = struct {
+	reporters []reporting.RunReporter
+	mutex     sync.Mutex
+}{}
+
+// trackRunReporter remembers the reporter if it summarizes the whole run
+func trackRunReporter(reporter reporting.Reporter) {
+	runReporter, ok := reporter.(reporting.RunReporter)
+	if !ok {
+		return
+	}
+	runReporters.mutex.Lock()
+	defer runReporters.mutex.Unlock()
+
+	for _, tracked := range runReporters.reporters {
+		if tracked == runReporter {
+			return
+		}
+	}
+	runReporters.reporters = append(runReporters.reporters, runReporter)
+}
+
+// Main runs the tests and finishes the run, so the reporters of the run settings that
+// summarize the whole run, such as the dashboard, the webhook notifier or the endpoint
+// coverage, can render, send or store their summaries. It returns the exit code of the tests. Call it from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(serenity.Main(m))
+//	}
+func Main(m *testing.M) int {
+	code := m.Run()
+	FinishRun()
+	return code
+}
+
+// FinishRun notifies the reporters of the run settings that summarize the whole run that
+// it has finished. Main calls it after the tests.
+func FinishRun() {
+	runReporters.mutex.Lock()
+	reporters := runReporters.reporters
+	runReporters.reporters = nil
+	runReporters.mutex.Unlock()
+
+	for _, reporter := range reporters {
+		reporter.OnRunFinish()
+	}
+}
+
+// RegisterReporter makes a reporter available to the run settings under the name, typically
+// from the init function of the package defining it. It panics if the name is registered twice.
+// The console and attachments reporters are built in; the reporter packages, such as
+// webhook or history, register theirs when they are imported:
+//
+//	import _ "github.com/nchursin/serenity-go/serenity/reporting/webhook"
+func RegisterReporter(name string, factory ReporterFactory) {
+	reporting.RegisterReporter(name, factory)
+}
+
+// reporterFor creates the reporter of a test from the reporters named in the settings
+func reporterFor(settings config.RunSettings) (reporting.Reporter, error) {
+	reporters := make([]reporting.Reporter, 0, len(settings.Reporters))
+	for _, name := range settings.Reporters {
+		factory, ok := reporting.ReporterFactoryFor(name)
+		if !ok {
+			available := reporting.RegisteredReporters()
+			return nil, fmt.Errorf("unknown reporter '%s' (available: %s; reporter packages register theirs when imported)", name, strings.Join(available, ", "))
+		}
+		reporter, err := factory(settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reporter '%s': %w", name, err)
+		}
+		trackRunReporter(reporter)
+		reporters = append(reporters, reporter)
+	}
+
+	switch len(reporters) {
+	case 0:
+		return nil, nil
+	case 1:
+		return reporters[0], nil
+	default:
+		return reporting.NewMultiReporter(reporters...), nil
+	}
+}
+
+// runSettings returns the settings of the test run, failing the test if they can't be loaded
+func runSettings(t TestContext) config.RunSettings {
+	settings, err := config.Run()
+	if err != nil {
+		t.Errorf("%s", masked("Invalid run settings: %v", err))
+		t.FailNow()
+	}
+	return settings
+}
+
+// newConfiguredTest creates a test reporting to the reporters named in the run settings
+func newConfiguredTest(ctx context.Context, t TestContext) SerenityTest {
+	t.Helper()
+	reporter, err := reporterFor(runSettings(t))
+	if err != nil {
+		t.Errorf("%s", masked("Invalid run settings: %v", err))
+		t.FailNow()
+	}
+	return NewSerenityTestWithReporter(ctx, t, reporter)
+}
+
+// Parallel runs the test in parallel with the others when the run settings ask for it.
+// Tests opt in by calling it first, as they would call t.Parallel; it panics after
+// t.Setenv, which parallel tests can't use.
+//
+// Example:
+//
+//	func TestCheckout(t *testing.T) {
+//		serenity.Parallel(t)
+//		test := serenity.NewSerenityTest(t)
+//		...
+//	}
+func Parallel(t *testing.T) {
+	t.Helper()
+	if runSettings(t).Parallel {
+		t.Parallel()
+	}
+}
+
+// Tagged skips the test unless the tag filter of the run settings allows its tags
+func (st *serenityTest) Tagged(tags ...string) SerenityTest {
+	st.testCtx.Helper()
+	if !st.settings.Tags.Allows(tags...) {
+		st.testCtx.Skipf("Skipped by the tag filter of the run settings: the test is tagged %s", strings.Join(tags, ", "))
+	}
+	return st
+}
@@ -3,13 +3,18 @@ package testing
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/nchursin/serenity-go/serenity/abilities"
+	"github.com/nchursin/serenity-go/serenity/config"
 	"github.com/nchursin/serenity-go/serenity/core"
+	"github.com/nchursin/serenity-go/serenity/debug"
+	"github.com/nchursin/serenity-go/serenity/events"
 	"github.com/nchursin/serenity-go/serenity/reporting"
-	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
+	"github.com/nchursin/serenity-go/serenity/reporting/triage"
 )
 
 // ReporterProvider provides access to reporter adapter
@@ -25,7 +30,8 @@ type ReporterProvider interface {
 //  1. Create test instance with NewSerenityTest() or NewSerenityTestWithReporter()
 //  2. Create actors using ActorCalled()
 //  3. Execute test activities
-//  4. Call Shutdown() to clean up resources (typically via defer)
+//  4. Shutdown() cleans up resources when the test completes; it is registered
+//     through TestContext.Cleanup, so calling it explicitly is optional
 //
 // Thread Safety:
 //
@@ -51,8 +57,9 @@ type SerenityTest interface {
 	ActorCalled(name string) core.Actor
 
 	// Shutdown cleans up resources and finalizes the test.
-	// This method should be called via defer after creating the test instance.
-	// Failure to call Shutdown() may result in resource leaks.
+	// It is registered through TestContext.Cleanup when the test is created, so it runs
+	// even if the test does not call it. Calling it earlier, e.g. via defer, reports the
+	// test before other cleanups run; subsequent calls do nothing.
 	//
 	// Example:
 	//	test := serenity.NewSerenityTest(t)
@@ -60,11 +67,96 @@ type SerenityTest interface {
 	// Side effects:
 	//	- Flushes any pending reports
 	//	- Cleans up actor resources
+	//	- Discards abilities implementing abilities.Discardable
 	//	- Finalizes test metrics
 	Shutdown()
 
 	// GetReporterAdapter returns the test runner adapter for reporting
 	GetReporterAdapter() *reporting.TestRunnerAdapter
+
+	// Events returns the event bus of the test. Actors publish test, activity, answer and
+	// assertion events on it; the reporter is one of its listeners.
+	//
+	// Example:
+	//	test.Events().Subscribe(events.ListenerFunc(func(event events.Event) {
+	//		log.Println(event.Kind())
+	//	}))
+	Events() *events.Bus
+
+	// OutputDir returns the output directory of the run in the run settings, where tests
+	// save files such as reports and downloads instead of creating them ad hoc.
+	//
+	// Example:
+	//	file, err := test.OutputDir().Create("exports/orders.csv")
+	OutputDir() *reporting.OutputDir
+
+	// WithWorkBudget makes all actors of the test honor the given time budget.
+	// Once the budget is exhausted, non-critical activities are skipped and
+	// reported with StatusSkipped; critical activities still run.
+	//
+	// Parameters:
+	//	budget - Budget shared by the test run, nil removes the limit
+	//
+	// Returns:
+	//	The same SerenityTest instance for method chaining
+	WithWorkBudget(budget *core.WorkBudget) SerenityTest
+
+	// CoversRequirement declares that the test covers the requirements, e.g. issue IDs
+	// such as "JIRA-123". Reports list them with the test result, so that coverage
+	// matrices can be built between scenarios and requirements.
+	//
+	// Returns:
+	//	The same SerenityTest instance for method chaining
+	CoversRequirement(requirements ...string) SerenityTest
+
+	// CoversCase links the test to cases of a test-management tool, such as TestRail,
+	// Xray or Zephyr Scale. The "testmanagement" reporter of the run settings uploads
+	// the status of the test to each case when the run finishes.
+	//
+	// Example:
+	//	test := serenity.NewSerenityTest(t).CoversCase("C1234")
+	//
+	// Returns:
+	//	The same SerenityTest instance for method chaining
+	CoversCase(cases ...string) SerenityTest
+
+	// SharedAbility shares the ability between the actors of the test, so an expensive
+	// resource such as a browser or a database pool is created once. Actors use it after
+	// declaring core.WhoCanUseShared; it is discarded after the last of them is done, or on
+	// Shutdown if no actor used it.
+	//
+	// Example:
+	//	test.SharedAbility(db.UseADatabaseAt("postgres", dsn))
+	//	admin := core.WhoCanUseShared(test.ActorCalled("Admin"), (*db.UseADatabase)(nil))
+	//
+	// Returns:
+	//	The same SerenityTest instance for method chaining
+	SharedAbility(ability abilities.Ability) SerenityTest
+
+	// RequireEnvironment verifies that the dependencies of the suite are available before
+	// the scenario runs. Each check runs once per process, so a suite whose database is
+	// down fails every test fast, with one consolidated report of all unavailable
+	// dependencies instead of a connection error per scenario.
+	//
+	// Example:
+	//	test := serenity.NewSerenityTest(t).RequireEnvironment(
+	//		health.Reachable("database", "tcp://localhost:5432"),
+	//		health.Reachable("orders", config.URL("orders")+"/healthz"),
+	//	)
+	//
+	// Returns:
+	//	The same SerenityTest instance for method chaining
+	RequireEnvironment(checks ...EnvironmentCheck) SerenityTest
+
+	// Tagged declares the tags of the test, skipping it unless the tag filter of the run
+	// settings allows them, so a run can select e.g. the smoke tests without code edits.
+	//
+	// Example:
+	//	test := serenity.NewSerenityTest(t).Tagged("smoke", "checkout")
+	//
+	// Returns:
+	//	The same SerenityTest instance for method chaining
+	Tagged(tags ...string) SerenityTest
 }
 
 // Test Lifecycle Examples:
@@ -95,71 +187,75 @@ type SerenityTest interface {
 //		actor.AttemptsTo(api.SendGetRequest("/health"))
 //	}
 
-// testResult implements the TestResult interface
-type testResult struct {
-	name     string
-	status   reporting.Status
-	duration time.Duration
-	err      error
-}
-
-// Name returns the test name
-func (tr *testResult) Name() string {
-	return tr.name
-}
-
-// Status returns the test status
-func (tr *testResult) Status() reporting.Status {
-	return tr.status
-}
-
-// Duration returns the test duration in seconds
-func (tr *testResult) Duration() float64 {
-	return tr.duration.Seconds()
+// serenityTest implements SerenityTest
+type serenityTest struct {
+	testCtx      TestContext
+	ctx          context.Context
+	actors       map[string]core.Actor
+	mutex        sync.RWMutex
+	adapter      *reporting.TestRunnerAdapter
+	startTime    time.Time
+	testName     string
+	budget       *core.WorkBudget
+	bus          *events.Bus
+	shutdown     bool
+	requirements []string
+	cases        []string
+	contexts     []events.Context
+	shared       *sharedAbilities
+	borrowed     []borrowedAbility
+	settings     config.RunSettings
+	triage       *triage.Collector
+	cancel       context.CancelFunc
 }
 
-// Error returns the test error, if any
-func (tr *testResult) Error() error {
-	return tr.err
-}
+// reportedInKey is the context key of the reporting contexts set by ReportedIn
+type reportedInKey struct{}
 
-// serenityTest implements SerenityTest
-type serenityTest struct {
-	testCtx   TestContext
-	ctx       context.Context
-	actors    map[string]core.Actor
-	mutex     sync.RWMutex
-	adapter   *reporting.TestRunnerAdapter
-	startTime time.Time
-	testName  string
-	shutdown  bool
+// ReportedIn returns a context that makes the tests created with it report in the given
+// feature, scenario and example instead of the ones derived from the test name.
+// Gherkin-backed and data-driven runs use it to name the levels explicitly:
+//
+//	ctx := serenity.ReportedIn(context.Background(),
+//		events.Feature("Checkout"), events.Scenario("Pay by card"), events.Example("visa"))
+//	test := serenity.NewSerenityTestWithContext(ctx, t)
+func ReportedIn(ctx context.Context, contexts ...events.Context) context.Context {
+	return context.WithValue(ctx, reportedInKey{}, contexts)
 }
 
-// NewSerenityTest creates a new SerenityTest instance
+// NewSerenityTest creates a new SerenityTest instance reporting to the reporters of the run
+// settings, the console by default
 func NewSerenityTest(t TestContext) SerenityTest {
 	t.Helper()
-	return NewSerenityTestWithReporter(context.Background(), t, console_reporter.NewConsoleReporter())
+	return newConfiguredTest(context.Background(), t)
 }
 
-// NewSerenityTest creates a new SerenityTest instance
+// NewSerenityTestWithContext creates a new SerenityTest instance reporting to the reporters
+// of the run settings, the console by default
 func NewSerenityTestWithContext(ctx context.Context, t TestContext) SerenityTest {
 	t.Helper()
-	return NewSerenityTestWithReporter(ctx, t, console_reporter.NewConsoleReporter())
+	return newConfiguredTest(ctx, t)
 }
 
-// NewSerenityTestWithReporter creates a new SerenityTest instance with a reporter
+// NewSerenityTestWithReporter creates a new SerenityTest instance with a reporter. The
+// other run settings, such as the timeouts, apply as for NewSerenityTest.
 func NewSerenityTestWithReporter(ctx context.Context, t TestContext, reporter reporting.Reporter) SerenityTest {
 	t.Helper()
+	settings := runSettings(t)
+	cancel := context.CancelFunc(func() {})
+	if settings.ScenarioTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, settings.ScenarioTimeout)
+	}
+
 	var adapter *reporting.TestRunnerAdapter
 	if reporter != nil {
 		adapter = reporting.NewTestRunnerAdapter(reporter)
 	}
 
 	testName := t.Name()
-
-	// Notify reporter that test is starting
-	if reporter != nil {
-		reporter.OnTestStart(testName)
+	contexts, ok := ctx.Value(reportedInKey{}).([]events.Context)
+	if !ok {
+		contexts = events.ContextsOf(testName)
 	}
 
 	st := &serenityTest{
@@ -169,8 +265,23 @@ func NewSerenityTestWithReporter(ctx context.Context, t TestContext, reporter re
 		adapter:   adapter,
 		startTime: time.Now(),
 		testName:  testName,
+		contexts:  contexts,
+		shared:    &sharedAbilities{},
+		settings:  settings,
+		cancel:    cancel,
+	}
+	triageSettings, err := triage.SettingsOf(settings)
+	if err != nil {
+		t.Errorf("%s", masked("Invalid run settings: %v", err))
+		t.FailNow()
+	}
+	if triageSettings.Enabled {
+		st.triage = triage.NewCollector(triageSettings.Exchanges)
 	}
 
+	// Notify reporter that test is starting
+	st.eventBus().Publish(events.TestStarted{Test: testName, Contexts: contexts})
+
 	t.Cleanup(func() { t.Helper(); st.Shutdown() })
 	return st
 }
@@ -186,26 +297,51 @@ func (st *serenityTest) ActorCalled(name string) core.Actor {
 	}
 
 	st.mutex.Lock()
-	defer st.mutex.Unlock()
 
 	// Double-check after acquiring write lock
 	if actor, exists := st.actors[name]; exists {
+		st.mutex.Unlock()
 		return actor
 	}
 
-	// Create new actor with test context and reporter
+	// Create new actor with test context and event bus
+	bus := st.eventBus()
 	actor = &testActor{
 		name:        name,
 		abilities:   make([]abilities.Ability, 0),
+		shared:      st.shared,
 		testContext: st.testCtx,
-		reporter:    st.adapter,
+		bus:         bus,
 		ctx:         st.ctx,
+		budget:      st.budget,
+		stepper:     debug.FromEnv(),
+		retries:     st.settings.Retries,
+		timeout:     st.settings.ActivityTimeout,
 	}
 
 	st.actors[name] = actor
+	st.mutex.Unlock()
+
+	bus.Publish(events.ActorStarted{Actor: name})
 	return actor
 }
 
+// eventBus returns the event bus of the test, creating it with the reporter as its first
+// listener, followed by the triage collector, when needed. The caller must hold the mutex or
+// be the only user of the test.
+func (st *serenityTest) eventBus() *events.Bus {
+	if st.bus == nil {
+		st.bus = events.NewBus()
+		if st.adapter != nil && st.adapter.GetReporter() != nil {
+			st.bus.Subscribe(reporting.NewListener(st.adapter.GetReporter()))
+		}
+		if st.triage != nil {
+			st.bus.Subscribe(st.triage)
+		}
+	}
+	return st.bus
+}
+
 // TestContext returns the embedded testing.TB interface.
 // This method provides access to the underlying testing framework.
 func (st *serenityTest) TestContext() TestContext {
@@ -222,38 +358,240 @@ func (st *serenityTest) GetReporterAdapter() *reporting.TestRunnerAdapter {
 	return st.adapter
 }
 
-// Shutdown cleans up resources
-func (st *serenityTest) Shutdown() {
+// Events returns the event bus of the test
+func (st *serenityTest) Events() *events.Bus {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
 
+	return st.eventBus()
+}
+
+// OutputDir returns the output directory of the run in the run settings
+func (st *serenityTest) OutputDir() *reporting.OutputDir {
+	return reporting.OutputDirOf(st.settings)
+}
+
+// WithWorkBudget makes all actors of the test, existing and future ones, honor the budget
+func (st *serenityTest) WithWorkBudget(budget *core.WorkBudget) SerenityTest {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.budget = budget
+	for _, actor := range st.actors {
+		if ta, ok := actor.(*testActor); ok {
+			ta.useWorkBudget(budget)
+		}
+	}
+	return st
+}
+
+// CoversRequirement declares the requirements covered by the test and announces each new one
+func (st *serenityTest) CoversRequirement(requirements ...string) SerenityTest {
+	st.mutex.Lock()
+	var covered []string
+	for _, requirement := range requirements {
+		if !slices.Contains(st.requirements, requirement) {
+			st.requirements = append(st.requirements, requirement)
+			covered = append(covered, requirement)
+		}
+	}
+	bus := st.eventBus()
+	st.mutex.Unlock()
+
+	for _, requirement := range covered {
+		bus.Publish(events.RequirementCovered{Test: st.testName, Requirement: requirement})
+	}
+	return st
+}
+
+// CoversCase links the test to the test-management cases
+func (st *serenityTest) CoversCase(cases ...string) SerenityTest {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	for _, id := range cases {
+		if !slices.Contains(st.cases, id) {
+			st.cases = append(st.cases, id)
+		}
+	}
+	return st
+}
+
+// SharedAbility shares the ability between the actors that declare core.WhoCanUseShared
+func (st *serenityTest) SharedAbility(ability abilities.Ability) SerenityTest {
+	st.shared.add(ability)
+	return st
+}
+
+// Shutdown discards actor abilities, reports the test result and cleans up resources
+func (st *serenityTest) Shutdown() {
+	st.mutex.Lock()
+
 	if st.shutdown {
+		st.mutex.Unlock()
 		return
 	}
 
 	// Create test result
-	duration := time.Since(st.startTime)
-	status := reporting.StatusPassed
-	var testErr error
-
-	if st.testCtx.Failed() {
-		status = reporting.StatusFailed
-		testErr = fmt.Errorf("test failed")
+	attachments := st.attachments()
+	if st.triage != nil && st.testCtx.Failed() {
+		attachments = append(attachments, st.triageBundle()...)
 	}
-
-	result := &testResult{
-		name:     st.testName,
-		status:   status,
-		duration: duration,
-		err:      testErr,
+	st.discardAbilities()
+
+	finished := events.TestFinished{
+		Test:         st.testName,
+		Outcome:      events.Passed,
+		Duration:     time.Since(st.startTime),
+		Requirements: slices.Clone(st.requirements),
+		Cases:        slices.Clone(st.cases),
+		Contexts:     st.contexts,
 	}
 
-	// Notify reporter that test is finished
-	if st.adapter != nil && st.adapter.GetReporter() != nil {
-		st.adapter.GetReporter().OnTestFinish(result)
+	switch {
+	case st.testCtx.Failed():
+		finished.Outcome = events.Failed
+		finished.Err = fmt.Errorf("test failed")
+	case st.testCtx.Skipped():
+		finished.Outcome = events.Skipped
 	}
 
 	// Clear actors map
+	bus := st.eventBus()
 	st.actors = make(map[string]core.Actor)
 	st.shutdown = true
+	st.mutex.Unlock()
+
+	// Notify listeners that test is finished
+	for _, attachment := range attachments {
+		bus.Publish(attachment)
+	}
+	bus.Publish(finished)
+	st.cancel()
+}
+
+// attachments collects the attachments of the Attachable abilities of all actors, in the
+// order of the actors' names, followed by those of the shared abilities. The caller must
+// hold the mutex.
+func (st *serenityTest) attachments() []events.AttachmentAdded {
+	var attachments []events.AttachmentAdded
+	collect := func(actor string, ability abilities.Ability) {
+		if attachable, ok := ability.(abilities.Attachable); ok {
+			for _, attachment := range attachable.Attachments() {
+				attachments = append(attachments, events.AttachmentAdded{
+					Actor:     actor,
+					Name:      attachment.Name,
+					MediaType: attachment.MediaType,
+					Content:   attachment.Content,
+				})
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(st.actors)) {
+		actor := st.actors[name]
+		holder, ok := actor.(core.AbilityHolder)
+		if !ok {
+			continue
+		}
+		own := holder.Abilities()
+		if ta, ok := actor.(*testActor); ok {
+			own = ta.ownAbilities()
+		}
+		for _, ability := range own {
+			collect(actor.Name(), ability)
+		}
+	}
+	if st.shared != nil {
+		for _, ability := range st.shared.all() {
+			collect("", ability)
+		}
+	}
+	return attachments
+}
+
+// triageBundle exports the triage bundle of the failed test as an attachment of the test,
+// before the abilities are discarded. The caller must hold the mutex.
+func (st *serenityTest) triageBundle() []events.AttachmentAdded {
+	actors := make([]core.Actor, 0, len(st.actors))
+	for _, name := range slices.Sorted(maps.Keys(st.actors)) {
+		actors = append(actors, st.actors[name])
+	}
+	bundle := st.triage.Bundle(st.testName, actors, triage.CurrentEnvironment(st.OutputDir().RunID()))
+	content, err := bundle.Marshal()
+	if err != nil {
+		st.testCtx.Errorf("Failed to attach the triage bundle: %v", err)
+		return nil
+	}
+	return []events.AttachmentAdded{{
+		Name:      triage.AttachmentName,
+		MediaType: "application/json",
+		Content:   content,
+	}}
+}
+
+// discardAbilities discards the Discardable abilities of all actors, reporting failures
+// through the test context. Shared abilities are discarded after the actors' own ones,
+// once their last user is done, and borrowed abilities are returned to their pools.
+// The caller must hold the mutex.
+func (st *serenityTest) discardAbilities() {
+	for _, actor := range st.actors {
+		holder, ok := actor.(core.AbilityHolder)
+		if !ok {
+			continue
+		}
+
+		own := holder.Abilities()
+		if ta, ok := actor.(*testActor); ok {
+			own = ta.ownAbilities()
+		}
+		for _, ability := range own {
+			st.discardOf(actor, ability)
+		}
+	}
+
+	for _, actor := range st.actors {
+		if ta, ok := actor.(*testActor); ok {
+			for _, ability := range ta.releaseShared() {
+				st.discardOf(actor, ability)
+			}
+		}
+	}
+	if st.shared != nil {
+		for _, ability := range st.shared.unused() {
+			if st.isBorrowed(ability) {
+				continue
+			}
+			if err := discard(ability); err != nil {
+				st.testCtx.Errorf("Failed to discard shared ability %s: %v", core.AbilityName(ability), err)
+			}
+		}
+	}
+
+	// Borrowed abilities go back to their pools instead of being discarded
+	for _, borrowed := range st.borrowed {
+		if err := borrowed.release(); err != nil {
+			st.testCtx.Errorf("Failed to return ability %s to its pool: %v", core.AbilityName(borrowed.ability), err)
+		}
+	}
+	st.borrowed = nil
+}
+
+// discardOf discards the ability of the actor, reporting a failure through the test context
+func (st *serenityTest) discardOf(actor core.Actor, ability abilities.Ability) {
+	if st.isBorrowed(ability) {
+		return
+	}
+	if err := discard(ability); err != nil {
+		st.testCtx.Errorf("Failed to discard ability %s of actor '%s': %v",
+			core.AbilityName(ability), actor.Name(), err)
+	}
+}
+
+// discard discards the ability if it is Discardable
+func discard(ability abilities.Ability) error {
+	if discardable, ok := ability.(abilities.Discardable); ok {
+		return discardable.Discard()
+	}
+	return nil
 }
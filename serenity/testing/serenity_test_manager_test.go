@@ -2,11 +2,16 @@ package testing
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/nchursin/serenity-go/serenity/config"
+	"github.com/nchursin/serenity-go/serenity/core"
 	"github.com/nchursin/serenity-go/serenity/reporting"
 	"github.com/nchursin/serenity-go/serenity/reporting/console_reporter"
 	reportingMocks "github.com/nchursin/serenity-go/serenity/reporting/mocks"
@@ -56,6 +61,7 @@ func TestSerenityTestLifecycleReporting(t *testing.T) {
 
 	mockTestContext.EXPECT().Name().Return("TestExample")
 	mockTestContext.EXPECT().Failed().Return(false)
+	mockTestContext.EXPECT().Skipped().Return(false)
 	mockTestContext.EXPECT().Helper()
 	mockTestContext.EXPECT().Cleanup(gomock.Any())
 
@@ -92,3 +98,207 @@ func TestSerenityTestLifecycleReportingFailed(t *testing.T) {
 	// Simulate test end
 	test.Shutdown()
 }
+
+// discardableAbility records whether it was discarded
+type discardableAbility struct {
+	discarded bool
+	err       error
+}
+
+func (d *discardableAbility) Discard() error {
+	d.discarded = true
+	return d.err
+}
+
+func TestSerenityTestShutdownDiscardsAbilities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockReporter := reportingMocks.NewMockReporter(ctrl)
+	mockTestContext := mocks.NewMockTestContext(ctrl)
+
+	mockReporter.EXPECT().OnTestStart("DiscardTest")
+	mockReporter.EXPECT().OnTestFinish(gomock.Any())
+
+	mockTestContext.EXPECT().Name().Return("DiscardTest")
+	mockTestContext.EXPECT().Failed().Return(true)
+	mockTestContext.EXPECT().Helper()
+	mockTestContext.EXPECT().Cleanup(gomock.Any())
+	mockTestContext.EXPECT().Errorf("Failed to discard ability %s of actor '%s': %v",
+		"testing.discardableAbility", "Broken", gomock.Any())
+
+	test := NewSerenityTestWithReporter(context.Background(), mockTestContext, mockReporter)
+
+	healthy := &discardableAbility{}
+	broken := &discardableAbility{err: errors.New("connection already closed")}
+	test.ActorCalled("Healthy").WhoCan(healthy)
+	test.ActorCalled("Broken").WhoCan(broken)
+
+	test.Shutdown()
+	test.Shutdown()
+
+	require.True(t, healthy.discarded)
+	require.True(t, broken.discarded)
+}
+
+func TestSerenityTestShutsDownOnCleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockReporter := reportingMocks.NewMockReporter(ctrl)
+	mockTestContext := mocks.NewMockTestContext(ctrl)
+
+	var cleanup func()
+	mockReporter.EXPECT().OnTestStart("ForgetfulTest")
+	mockReporter.EXPECT().OnTestFinish(gomock.Any()).Times(1)
+
+	mockTestContext.EXPECT().Name().Return("ForgetfulTest")
+	mockTestContext.EXPECT().Failed().Return(false)
+	mockTestContext.EXPECT().Skipped().Return(false)
+	mockTestContext.EXPECT().Helper().AnyTimes()
+	mockTestContext.EXPECT().Cleanup(gomock.Any()).Do(func(f func()) { cleanup = f })
+
+	test := NewSerenityTestWithReporter(context.Background(), mockTestContext, mockReporter)
+	ability := &discardableAbility{}
+	test.ActorCalled("Ada").WhoCan(ability)
+
+	// The test never calls Shutdown; the testing framework runs the registered cleanup
+	require.NotNil(t, cleanup)
+	cleanup()
+
+	require.True(t, ability.discarded)
+}
+
+// pool counts how often it is discarded
+type pool struct {
+	discards int
+}
+
+func (p *pool) Discard() error {
+	p.discards++
+	return nil
+}
+
+func TestSerenityTestSharesAbilitiesBetweenActors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockReporter := reportingMocks.NewMockReporter(ctrl)
+	mockTestContext := mocks.NewMockTestContext(ctrl)
+
+	mockReporter.EXPECT().OnTestStart("SharedTest")
+	mockReporter.EXPECT().OnTestFinish(gomock.Any())
+
+	mockTestContext.EXPECT().Name().Return("SharedTest")
+	mockTestContext.EXPECT().Failed().Return(false)
+	mockTestContext.EXPECT().Skipped().Return(false)
+	mockTestContext.EXPECT().Helper()
+	mockTestContext.EXPECT().Cleanup(gomock.Any())
+
+	test := NewSerenityTestWithReporter(context.Background(), mockTestContext, mockReporter)
+	shared := &pool{}
+	unused := &discardableAbility{}
+	test.SharedAbility(shared).SharedAbility(unused)
+
+	admin := core.WhoCanUseShared(test.ActorCalled("Admin"), &pool{})
+	customer := core.WhoCanUseShared(core.WhoCanUseShared(test.ActorCalled("Customer"), &pool{}), &pool{})
+
+	adminPool, err := admin.AbilityTo(&pool{})
+	require.NoError(t, err)
+	customerPool, err := customer.AbilityTo(&pool{})
+	require.NoError(t, err)
+	require.Same(t, shared, adminPool)
+	require.Same(t, shared, customerPool)
+	_, err = admin.AbilityTo(&discardableAbility{})
+	require.Error(t, err)
+
+	test.Shutdown()
+
+	require.Equal(t, 1, shared.discards)
+	require.True(t, unused.discarded)
+}
+
+func TestSerenityTestFailsActorsUsingAbilitiesThatAreNotShared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTestContext := mocks.NewMockTestContext(ctrl)
+
+	mockTestContext.EXPECT().Name().Return("SharedTest")
+	mockTestContext.EXPECT().Helper()
+	mockTestContext.EXPECT().Cleanup(gomock.Any())
+	gomock.InOrder(
+		mockTestContext.EXPECT().Errorf("%s", "Actor 'Admin' cannot use shared testing.pool: the test shares no such ability"),
+		mockTestContext.EXPECT().FailNow(),
+	)
+
+	test := NewSerenityTestWithReporter(context.Background(), mockTestContext, nil)
+	core.WhoCanUseShared(test.ActorCalled("Admin"), &pool{})
+}
+
+func TestSerenityTestRequiresTheEnvironmentOncePerProcess(t *testing.T) {
+	var verified atomic.Int32
+	database := EnvironmentCheck{Name: "preflight database", Verify: func(ctx context.Context) error {
+		verified.Add(1)
+		return errors.New("connection refused")
+	}}
+	queue := EnvironmentCheck{Name: "preflight queue", Verify: func(ctx context.Context) error {
+		verified.Add(1)
+		return nil
+	}}
+
+	newTest := func(name string) (*mocks.MockTestContext, SerenityTest) {
+		ctrl := gomock.NewController(t)
+		mockTestContext := mocks.NewMockTestContext(ctrl)
+		mockTestContext.EXPECT().Name().Return(name)
+		mockTestContext.EXPECT().Helper().AnyTimes()
+		mockTestContext.EXPECT().Cleanup(gomock.Any())
+		return mockTestContext, NewSerenityTestWithReporter(context.Background(), mockTestContext, nil)
+	}
+
+	first, test := newTest("FirstTest")
+	var report string
+	first.EXPECT().Errorf("%s", gomock.Any()).Do(func(format string, args ...interface{}) {
+		report = args[0].(string)
+	})
+	first.EXPECT().FailNow()
+	test.RequireEnvironment(database, queue)
+
+	require.Contains(t, report, "The environment is not ready:")
+	require.Contains(t, report, "✗ preflight database: connection refused")
+	require.Contains(t, report, "✓ preflight queue")
+
+	second, test := newTest("SecondTest")
+	second.EXPECT().Errorf("%s", "The environment is not ready, unavailable: preflight database (see the preflight report of the first failed test)")
+	second.EXPECT().FailNow()
+	test.RequireEnvironment(database, queue)
+
+	require.Equal(t, int32(2), verified.Load(), "each check runs once per process")
+}
+
+func TestSerenityTestAppliesTheRunSettings(t *testing.T) {
+	config.UseRunSettings(config.RunSettings{
+		ActivityTimeout: time.Minute,
+		Retries:         config.RetryPolicy{Attempts: 3},
+		Tags:            config.TagFilter{Exclude: []string{"slow"}},
+	})
+	t.Cleanup(func() { config.UseRunSettings(config.DefaultRunSettings()) })
+
+	t.Run("activities are retried within the activity timeout", func(t *testing.T) {
+		test := NewSerenityTest(t)
+		require.Nil(t, test.GetReporterAdapter(), "no reporters are configured")
+
+		attempts := 0
+		test.ActorCalled("Tester").AttemptsTo(core.Do("#actor tries until the third time", func(actor core.Actor, ctx context.Context) error {
+			attempts++
+			if _, ok := ctx.Deadline(); !ok {
+				return errors.New("the activity has no deadline")
+			}
+			if attempts < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		}))
+		require.Equal(t, 3, attempts)
+	})
+
+	var excluded *testing.T
+	t.Run("excluded tags skip the test", func(t *testing.T) {
+		excluded = t
+		NewSerenityTest(t).Tagged("checkout", "slow")
+		require.Fail(t, "the test should have been skipped")
+	})
+	require.True(t, excluded.Skipped())
+}
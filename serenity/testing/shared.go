@@ -0,0 +1,108 @@
+package testing
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/nchursin/serenity-go/serenity/abilities"
+)
+
+// sharedAbility is an ability shared by the actors of a test, with the number of actors
+// still using it
+type sharedAbility struct {
+	ability abilities.Ability
+	users   int
+	done    bool
+}
+
+// sharedAbilities holds the abilities shared by the actors of a test
+type sharedAbilities struct {
+	entries []*sharedAbility
+	mutex   sync.Mutex
+}
+
+// add registers the ability for sharing
+func (sa *sharedAbilities) add(ability abilities.Ability) {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	sa.entries = append(sa.entries, &sharedAbility{ability: ability})
+}
+
+// use returns the shared abilities matching the types, or all of them if no types are
+// given, and counts the actor as one of their users. It returns the types that match no
+// shared ability as missing.
+func (sa *sharedAbilities) use(using []*sharedAbility, abilityTypes []abilities.Ability) (used []*sharedAbility, missing []abilities.Ability) {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	var matched []*sharedAbility
+	if len(abilityTypes) == 0 {
+		matched = sa.entries
+	}
+	for _, abilityType := range abilityTypes {
+		found := false
+		for _, entry := range sa.entries {
+			if abilityMatchesType(entry.ability, abilityType) {
+				matched = append(matched, entry)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, abilityType)
+		}
+	}
+
+	for _, entry := range matched {
+		if !slices.Contains(using, entry) && !slices.Contains(used, entry) {
+			entry.users++
+			used = append(used, entry)
+		}
+	}
+	return used, missing
+}
+
+// release stops the actor using the shared abilities and returns those it was the last
+// user of
+func (sa *sharedAbilities) release(using []*sharedAbility) []abilities.Ability {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	var unused []abilities.Ability
+	for _, entry := range using {
+		entry.users--
+		if entry.users == 0 && !entry.done {
+			entry.done = true
+			unused = append(unused, entry.ability)
+		}
+	}
+	return unused
+}
+
+// unused returns the shared abilities no actor has used and marks them as done
+func (sa *sharedAbilities) unused() []abilities.Ability {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	var unused []abilities.Ability
+	for _, entry := range sa.entries {
+		if entry.users == 0 && !entry.done {
+			entry.done = true
+			unused = append(unused, entry.ability)
+		}
+	}
+	return unused
+}
+
+// all returns the shared abilities in the order they were shared
+func (sa *sharedAbilities) all() []abilities.Ability {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	all := make([]abilities.Ability, len(sa.entries))
+	for i, entry := range sa.entries {
+		all[i] = entry.ability
+	}
+	return all
+}